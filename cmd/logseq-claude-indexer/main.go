@@ -1,30 +1,116 @@
 package main
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/dyluth/logseq-claude-indexer/internal/config"
+	"github.com/dyluth/logseq-claude-indexer/internal/doctor"
+	"github.com/dyluth/logseq-claude-indexer/internal/embeddings"
+	"github.com/dyluth/logseq-claude-indexer/internal/export"
+	"github.com/dyluth/logseq-claude-indexer/internal/gitlog"
+	"github.com/dyluth/logseq-claude-indexer/internal/gitsnapshot"
+	"github.com/dyluth/logseq-claude-indexer/internal/gitstage"
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/internal/lock"
+	"github.com/dyluth/logseq-claude-indexer/internal/metrics"
+	"github.com/dyluth/logseq-claude-indexer/internal/parallel"
 	"github.com/dyluth/logseq-claude-indexer/internal/parser"
+	"github.com/dyluth/logseq-claude-indexer/internal/scaffold"
 	"github.com/dyluth/logseq-claude-indexer/internal/scanner"
+	"github.com/dyluth/logseq-claude-indexer/internal/scope"
+	"github.com/dyluth/logseq-claude-indexer/internal/selfupdate"
+	"github.com/dyluth/logseq-claude-indexer/internal/spill"
+	"github.com/dyluth/logseq-claude-indexer/internal/watcher"
 	"github.com/dyluth/logseq-claude-indexer/internal/writer"
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
 )
 
 var (
-	repoPath  string
-	outputDir string
-	quiet     bool
-	verbose   bool
-	dryRun    bool
-	version   = "0.1.0"
+	repoPath   string
+	outputDir  string
+	configPath string
+	quiet      bool
+	verbose    bool
+	dryRun     bool
+	applyTags  bool
+	gitAdd     bool
+	version    = "0.1.0"
+
+	somedayTag  string
+	somedayDays int
+	language    string
+	snapshot    string
+	strict      bool
+
+	reminderDays int
+
+	watchStrategy string
+	pollInterval  time.Duration
+	metricsAddr   string
+	lockWait      time.Duration
+
+	diffFrom string
+	diffTo   string
+
+	checkVersion bool
+
+	semantic    bool
+	searchLimit int
+
+	propKey     string
+	propGroupBy string
+	propValue   string
+	propList    bool
+	propJSON    bool
+
+	onlyWriters []string
+	skipWriters []string
+	prune       bool
+
+	excludeAnomalies bool
+
+	exportPath    string
+	filesFrom     string
+	lowMemory     bool
+	scopePatterns []string
+	scopeHops     int
+	profileName   string
+	outputProfile writer.Profile
+
+	initForce       bool
+	initNoHook      bool
+	initPrintConfig bool
+)
+
+// lowMemoryBatch is how many files --low-memory parses before merging the
+// results, and lowMemoryGCPercent how much the heap may grow between collections
+const (
+	lowMemoryBatch     = 100
+	lowMemoryGCPercent = 25
 )
 
+// watchDebounce is how long watch mode waits for further changes before regenerating
+const watchDebounce = 500 * time.Millisecond
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -46,195 +132,1841 @@ var generateCmd = &cobra.Command{
 	RunE:  runGenerate,
 }
 
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Regenerate indexes whenever pages or journals change",
+	Long: `Watch the Logseq repository and regenerate all indexes when markdown files
+in pages/ or journals/ change. Uses OS file notifications where available and
+falls back to polling on WSL and filesystems where notifications fail.`,
+	RunE: runWatch,
+}
+
+var graphDiffCmd = &cobra.Command{
+	Use:   "graph-diff",
+	Short: "Compare the reference graph between two git revisions",
+	Long: `Check out two revisions of the Logseq repository in temporary git worktrees,
+index both, and write graph-diff.md listing new and removed pages, hub page
+changes, and the pages whose reference counts changed most.`,
+	Example: "  logseq-claude-indexer graph-diff --from HEAD~30 --to HEAD",
+	RunE:    runGraphDiff,
+}
+
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search page blocks by keyword, or by meaning with --semantic",
+	Long: `Search the top-level blocks of every page and journal. By default blocks are
+ranked by how many of the query's words they contain. With --semantic, the query
+is embedded by the provider configured under embeddings: in .logseq-indexer.yml
+and blocks are ranked by cosine similarity, using the vectors in
+embeddings.jsonl from the last generate.`,
+	Example: `  logseq-claude-indexer search "deployment rollback"
+  logseq-claude-indexer search --semantic "how did we decide on the auth provider?"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+var queryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Answer ad-hoc questions about the graph",
+}
+
+var queryPropsCmd = &cobra.Command{
+	Use:   "props",
+	Short: "Count or list the values of a block or page property",
+	Long: `Count the values of a key:: value property across every block and page,
+optionally grouped by another property. A block without the group-by property
+takes its page's, and --group-by page groups by the page holding the block.
+Links and tags are counted by name, and lists such as tags:: once per item.`,
+	Example: `  logseq-claude-indexer query props --key status
+  logseq-claude-indexer query props --key status --group-by project
+  logseq-claude-indexer query props --key type --value book --list`,
+	Args: cobra.NoArgs,
+	RunE: runQueryProps,
+}
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <index-file>",
+	Short: "Describe what a generated index file contains",
+	Long: `Print what a generated file is for, what it's built from, its caveats, and
+its sections (or fields, for JSON files), as documented in the index README.
+The file can be a bare name or a path into the output directory; nothing is
+read from it.`,
+	Example: `  logseq-claude-indexer explain missing-pages.md
+  logseq-claude-indexer explain .claude/indexes/backlinks/Phoenix.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return writer.WriteExplanation(os.Stdout, args[0])
+	},
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Show version information",
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Printf("logseq-claude-indexer version %s\n", version)
+		if !checkVersion {
+			return nil
+		}
+
+		release, err := selfupdate.NewClient().Latest(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if selfupdate.IsNewer(version, release.Version) {
+			fmt.Printf("A newer release is available: %s (run `logseq-claude-indexer self-update`)\n", release.Version)
+		} else {
+			fmt.Println("You are running the latest release")
+		}
+		return nil
 	},
 }
 
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the repository, config, hooks, and outputs for setup problems",
+	Long: `Verify that the repository looks like a Logseq graph, config.edn and
+.logseq-indexer.yml parse, a git hook runs the indexer, the output directory is
+writable, and the previous run's outputs are intact. Each problem comes with a
+suggested fix. Exits non-zero if any check fails.`,
+	RunE: runDoctor,
+}
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Set up a Logseq repository for the indexer",
+	Long: `Scaffold everything a new graph needs in one step: an example
+.logseq-indexer.yml, the output directory, a post-commit hook that regenerates
+the indexes, a CLAUDE.md section pointing Claude at them, and Claude command
+files in .claude/commands. Existing files are kept unless --force is given; an
+existing hook gets the generate command appended, and the CLAUDE.md section is
+updated in place.`,
+	Example: `  logseq-claude-indexer init
+  logseq-claude-indexer init --print-config > .logseq-indexer.yml`,
+	Args: cobra.NoArgs,
+	RunE: runInit,
+}
+
+var selfUpdateCmd = &cobra.Command{
+	Use:   "self-update",
+	Short: "Update to the latest GitHub release",
+	Long: `Download the latest release for this platform from GitHub, verify it against
+the release's SHA-256 checksums, and replace the running binary. Installs
+managed by Homebrew or scoop are left to the package manager.`,
+	RunE: runSelfUpdate,
+}
+
 func init() {
 	// Add commands
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(graphDiffCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(selfUpdateCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(searchCmd)
+	rootCmd.AddCommand(queryCmd)
+	rootCmd.AddCommand(explainCmd)
+	queryCmd.AddCommand(queryPropsCmd)
 
-	// Add flags to generate command
-	generateCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
-	generateCmd.Flags().StringVar(&outputDir, "output", ".claude/indexes", "Output directory for index files")
-	generateCmd.Flags().BoolVar(&quiet, "quiet", false, "Suppress output (for git hooks)")
-	generateCmd.Flags().BoolVar(&verbose, "verbose", false, "Show detailed logging")
+	// Generate and watch share the indexing flags
+	for _, cmd := range []*cobra.Command{generateCmd, watchCmd} {
+		cmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+		cmd.Flags().StringVar(&outputDir, "output", "", "Output directory for index files (default: the --profile's directory)")
+		cmd.Flags().StringVar(&profileName, "profile", string(writer.ProfileClaude), profileUsage)
+		cmd.Flags().StringVar(&configPath, "config", "", "Path to config file (default: <repo>/"+config.DefaultFileName+")")
+		cmd.Flags().BoolVar(&quiet, "quiet", false, "Suppress output (for git hooks)")
+		cmd.Flags().BoolVar(&verbose, "verbose", false, "Show detailed logging")
+		cmd.Flags().DurationVar(&lockWait, "lock-wait", 0, "How long to wait for another run writing the output directory (0 exits immediately)")
+		cmd.Flags().StringVar(&somedayTag, "someday-tag", "someday", "Tag that moves open tasks into the someday/maybe backlog (empty to disable)")
+		cmd.Flags().IntVar(&somedayDays, "someday-days", 0, "Move LATER tasks older than N days into the someday/maybe backlog (0 to disable)")
+		cmd.Flags().StringVar(&language, "language", "", "Only index files in this language: en or de (files with too little text to detect are kept)")
+		cmd.Flags().BoolVar(&strict, "strict", false, "Abort if any file fails to read or parse (by default failures are reported in diagnostics.md)")
+		cmd.Flags().BoolVar(&excludeAnomalies, "exclude-anomalies", false, "Leave suspicious logbook entries (see time-tracking-issues.md) out of time totals and the timeline")
+		cmd.Flags().IntVar(&reminderDays, "reminder-days", 7, "Include open tasks due within N days (and overdue ones) in reminders.json")
+		cmd.Flags().StringSliceVar(&onlyWriters, "only", nil, "Only run these writers, e.g. tasks,dashboard (README.md and manifest.json are always written)")
+		cmd.Flags().StringSliceVar(&skipWriters, "skip", nil, "Don't run these writers, e.g. backlinks,graph")
+		cmd.Flags().BoolVar(&prune, "prune", false, "Remove files earlier runs generated that this run didn't, e.g. after disabling a writer (not with --only or --skip)")
+		cmd.Flags().StringSliceVar(&scopePatterns, "scope", nil, "Only index pages matching these paths, e.g. pages/work/**, plus the journal blocks linking them (needs its own --output)")
+		cmd.Flags().IntVar(&scopeHops, "scope-hops", 0, "Widen --scope to pages within N links of a matching page, reporting the size at each distance")
+		cmd.Flags().BoolVar(&lowMemory, "low-memory", false, "Parse files in small batches on one core and keep page words in a temporary file instead of memory (slower; for small servers)")
+		cmd.Flags().StringVar(&snapshot, "snapshot", "", "Once a week, commit the output directory with summary stats: commit, or tag to also tag it (empty to disable)")
+	}
 	generateCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be generated without writing files")
+	generateCmd.Flags().BoolVar(&gitAdd, "git-add", false, "After writing, git add output files whose content changed (for pre-commit hooks)")
+	generateCmd.Flags().StringVar(&exportPath, "export", "", "Index a Logseq graph export (.edn or .json) instead of the markdown files in --repo")
+	generateCmd.Flags().StringVar(&filesFrom, "files-from", "", "Index only the files listed in this file, one path per line, or - for stdin, e.g. from git diff --name-only (needs its own --output)")
+	generateCmd.Flags().BoolVar(&applyTags, "apply-tags", false, "Insert suggested existing tags as tags:: properties on untagged pages (combine with --dry-run to preview)")
+
+	// Add flags to watch command
+	watchCmd.Flags().StringVar(&watchStrategy, "watch-strategy", string(watcher.StrategyAuto), "Change detection: auto, notify (fsnotify), or poll")
+	watchCmd.Flags().DurationVar(&pollInterval, "poll-interval", watcher.DefaultPollInterval, "Polling interval when using the poll strategy")
+	watchCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Serve Prometheus metrics at http://<addr>/metrics, e.g. :9110 (empty to disable)")
+
+	doctorCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+	doctorCmd.Flags().StringVar(&outputDir, "output", "", "Output directory for index files (default: the --profile's directory)")
+	doctorCmd.Flags().StringVar(&profileName, "profile", string(writer.ProfileClaude), profileUsage)
+	doctorCmd.Flags().StringVar(&configPath, "config", "", "Path to config file (default: <repo>/"+config.DefaultFileName+")")
+
+	initCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+	initCmd.Flags().StringVar(&outputDir, "output", "", "Output directory for index files, relative to the repository (default: the --profile's directory)")
+	initCmd.Flags().StringVar(&profileName, "profile", string(writer.ProfileClaude), profileUsage+"; the hook generates with it")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite an existing config and command files")
+	initCmd.Flags().BoolVar(&initNoHook, "no-hook", false, "Don't install the post-commit hook")
+	initCmd.Flags().BoolVar(&initPrintConfig, "print-config", false, "Print the example config to stdout instead of scaffolding")
+
+	searchCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+	searchCmd.Flags().StringVar(&outputDir, "output", "", "Output directory holding embeddings.jsonl (default: the --profile's directory)")
+	searchCmd.Flags().StringVar(&profileName, "profile", string(writer.ProfileClaude), profileUsage)
+	searchCmd.Flags().StringVar(&configPath, "config", "", "Path to config file (default: <repo>/"+config.DefaultFileName+")")
+	searchCmd.Flags().BoolVar(&semantic, "semantic", false, "Rank by embedding similarity (requires embeddings: in the config and a previous generate)")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 10, "Maximum number of results")
+
+	queryPropsCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+	queryPropsCmd.Flags().StringVar(&configPath, "config", "", "Path to config file (default: <repo>/"+config.DefaultFileName+")")
+	queryPropsCmd.Flags().StringVar(&propKey, "key", "", "Property to count, e.g. status (required)")
+	queryPropsCmd.Flags().StringVar(&propGroupBy, "group-by", "", "Property to group by, or page for the page holding each block")
+	queryPropsCmd.Flags().StringVar(&propValue, "value", "", "Only count blocks with this value of --key")
+	queryPropsCmd.Flags().BoolVar(&propList, "list", false, "List the blocks under each value instead of only counting them")
+	queryPropsCmd.Flags().BoolVar(&propJSON, "json", false, "Print the results as JSON")
+	queryPropsCmd.MarkFlagRequired("key")
+
+	versionCmd.Flags().BoolVar(&checkVersion, "check", false, "Also report whether a newer release exists on GitHub")
+
+	// Add flags to graph-diff command
+	graphDiffCmd.Flags().StringVar(&repoPath, "repo", ".", "Path to Logseq repository")
+	graphDiffCmd.Flags().StringVar(&outputDir, "output", "", "Output directory for graph-diff.md (default: the --profile's directory)")
+	graphDiffCmd.Flags().StringVar(&profileName, "profile", string(writer.ProfileClaude), profileUsage)
+	graphDiffCmd.Flags().BoolVar(&quiet, "quiet", false, "Suppress output")
+	graphDiffCmd.Flags().StringVar(&diffFrom, "from", "", "Older revision to compare, e.g. HEAD~30 (required)")
+	graphDiffCmd.Flags().StringVar(&diffTo, "to", "HEAD", "Newer revision to compare")
+	graphDiffCmd.MarkFlagRequired("from")
 }
 
-func runGenerate(cmd *cobra.Command, args []string) error {
-	// Set up logger
+// newLogger creates the command logger, discarding output in quiet mode
+func newLogger() *log.Logger {
 	logger := log.New(os.Stdout, "", 0)
 	if quiet {
 		logger.SetOutput(io.Discard)
 	}
+	return logger
+}
 
-	logger.Printf("Scanning Logseq repository: %s", repoPath)
+func runGenerate(cmd *cobra.Command, args []string) error {
+	if err := applyProfile(cmd); err != nil {
+		return err
+	}
+	_, err := generateIndexes(newLogger())
+	return err
+}
 
-	// Convert to absolute path
-	absRepoPath, err := filepath.Abs(repoPath)
+// profileUsage describes the --profile flag shared by the commands that
+// read or write the output directory
+var profileUsage = "Output conventions for an AI assistant: " + strings.Join(writer.Profiles(), ", ") + " (sets the default --output)"
+
+// applyProfile checks --profile and points --output at the profile's
+// directory unless it was given explicitly
+func applyProfile(cmd *cobra.Command) error {
+	profile, err := writer.ParseProfile(profileName)
 	if err != nil {
-		return fmt.Errorf("invalid repo path: %w", err)
+		return err
 	}
+	outputProfile = profile
+	if !cmd.Flags().Changed("output") {
+		outputDir = profile.OutputDir()
+	}
+	return nil
+}
 
-	// Verify repo path exists
-	if _, err := os.Stat(absRepoPath); os.IsNotExist(err) {
-		return fmt.Errorf("repository path does not exist: %s", absRepoPath)
+func runWatch(cmd *cobra.Command, args []string) error {
+	if err := applyProfile(cmd); err != nil {
+		return err
 	}
+	logger := newLogger()
 
-	// 1. Scan for files
-	if verbose {
-		logger.Println("Step 1: Scanning for markdown files...")
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
 	}
-	sc := scanner.New(absRepoPath)
-	files, err := sc.Scan()
+
+	w, err := watcher.New(absRepoPath, watcher.Strategy(watchStrategy), pollInterval)
 	if err != nil {
-		return fmt.Errorf("scanning files: %w", err)
+		return err
 	}
 
-	logger.Printf("Found %d markdown files", len(files))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if len(files) == 0 {
-		logger.Println("No markdown files found in pages/ or journals/")
-		return nil
+	changes, err := w.Watch(ctx)
+	if err != nil {
+		return fmt.Errorf("starting watcher: %w", err)
 	}
 
-	// 2. Parse all files
-	if verbose {
-		logger.Println("Step 2: Parsing files...")
+	recorder := metrics.NewRecorder()
+	if metricsAddr != "" {
+		if err := serveMetrics(ctx, metricsAddr, recorder, logger); err != nil {
+			return err
+		}
 	}
 
-	var allTasks []models.Task
-	var allRefs []models.PageReference
-	parseErrors := 0
+	// Initial generation so the indexes are fresh before the first change
+	generateAndRecord(logger, recorder)
 
-	for _, file := range files {
-		content, err := os.ReadFile(file.AbsolutePath)
-		if err != nil {
-			if verbose {
-				logger.Printf("Warning: Failed to read %s: %v", file.Path, err)
-			}
-			parseErrors++
-			continue
+	logger.Printf("Watching %s for changes (%s strategy, Ctrl+C to stop)", absRepoPath, w.Name())
+
+	for range changes {
+		// Let bursts of saves (e.g. Logseq writing several files) settle before regenerating
+		time.Sleep(watchDebounce)
+		drainPending(changes)
+
+		generateAndRecord(logger, recorder)
+	}
+
+	return nil
+}
+
+func runDoctor(cmd *cobra.Command, args []string) error {
+	if err := applyProfile(cmd); err != nil {
+		return err
+	}
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
+	}
+	absOutputDir := outputDir
+	if !filepath.IsAbs(outputDir) {
+		absOutputDir = filepath.Join(absRepoPath, outputDir)
+	}
+
+	checks := doctor.Run(doctor.Options{
+		RepoPath:    absRepoPath,
+		OutputDir:   absOutputDir,
+		ConfigPath:  configPath,
+		ToolVersion: version,
+	})
+	for _, c := range checks {
+		fmt.Printf("%s %s: %s\n", c.Status, c.Name, c.Detail)
+		if c.Fix != "" {
+			fmt.Printf("    → %s\n", c.Fix)
 		}
+	}
 
-		// Parse tasks
-		tasks, err := parser.ParseTasks(string(content), file.Path)
-		if err != nil {
-			if verbose {
-				logger.Printf("Warning: Failed to parse tasks in %s: %v", file.Path, err)
-			}
-			parseErrors++
-		} else {
-			allTasks = append(allTasks, tasks...)
+	if doctor.Failed(checks) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("doctor found problems that stop indexing")
+	}
+	return nil
+}
+
+func runInit(cmd *cobra.Command, args []string) error {
+	if err := applyProfile(cmd); err != nil {
+		return err
+	}
+	if initPrintConfig {
+		_, err := os.Stdout.Write(scaffold.ExampleConfig())
+		return err
+	}
+
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
+	}
+	if info, err := os.Stat(absRepoPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("repository not found: %s", absRepoPath)
+	}
+	relOutput := outputDir
+	if filepath.IsAbs(outputDir) {
+		rel, err := filepath.Rel(absRepoPath, outputDir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return fmt.Errorf("--output must be inside the repository for init, got %s", outputDir)
 		}
+		relOutput = rel
+	}
 
-		// Parse references
-		refs, err := parser.ParseReferences(string(content), file.Path)
-		if err != nil {
-			if verbose {
-				logger.Printf("Warning: Failed to parse references in %s: %v", file.Path, err)
-			}
-			parseErrors++
-		} else {
-			allRefs = append(allRefs, refs...)
+	hookProfile := ""
+	if outputProfile != writer.ProfileClaude {
+		hookProfile = string(outputProfile)
+	}
+	steps, err := scaffold.Init(scaffold.Options{
+		RepoPath:  absRepoPath,
+		OutputDir: filepath.Clean(relOutput),
+		Profile:   hookProfile,
+		Force:     initForce,
+		NoHook:    initNoHook,
+	})
+	for _, step := range steps {
+		marker := "✓"
+		if step.Action == scaffold.Skipped {
+			marker = "-"
+		}
+		line := fmt.Sprintf("%s %s %s", marker, step.Action, step.Path)
+		if step.Detail != "" {
+			line += " (" + step.Detail + ")"
 		}
+		fmt.Println(line)
+	}
+	if err != nil {
+		return err
 	}
 
-	logger.Printf("Extracted %d tasks and %d references", len(allTasks), len(allRefs))
+	profileFlag := ""
+	if hookProfile != "" {
+		profileFlag = " --profile " + hookProfile
+	}
+	fmt.Printf("\nNext: run `logseq-claude-indexer generate --repo %s%s --output %s`, then `logseq-claude-indexer doctor%s` to check the setup.\n",
+		repoPath, profileFlag, filepath.ToSlash(relOutput), profileFlag)
+	return nil
+}
 
-	if parseErrors > 0 {
-		logger.Printf("Warning: %d parse errors encountered", parseErrors)
+func runSelfUpdate(cmd *cobra.Command, args []string) error {
+	exePath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating current binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(exePath); err == nil {
+		exePath = resolved
+	}
+	if err := selfupdate.CheckManaged(exePath); err != nil {
+		return err
 	}
 
-	// 3. Build indexes
-	if verbose {
-		logger.Println("Step 3: Building indexes...")
+	client := selfupdate.NewClient()
+	release, err := client.Latest(cmd.Context())
+	if err != nil {
+		return err
+	}
+	if !selfupdate.IsNewer(version, release.Version) {
+		fmt.Printf("Already up to date (version %s)\n", version)
+		return nil
 	}
 
-	taskIndex := indexer.BuildTaskIndex(allTasks)
-	graphIndex := indexer.BuildReferenceGraph(allRefs, files)
-	timelineIndex := indexer.BuildTimelineIndex(allTasks, files)
-	missingPagesIndex := indexer.BuildMissingPagesIndex(graphIndex, 5)
-	timeTrackingIndex := indexer.BuildTimeTrackingIndex(allTasks)
+	fmt.Printf("Downloading %s (current %s)...\n", release.Version, version)
+	binary, err := client.Download(cmd.Context(), release, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return err
+	}
+	if err := selfupdate.Replace(exePath, binary); err != nil {
+		return err
+	}
 
-	if dryRun {
-		logger.Println("\n=== DRY RUN MODE ===")
-		logger.Printf("Would create task index with %d tasks", taskIndex.TotalTasks)
-		logger.Printf("Would create reference graph with %d nodes", len(graphIndex.Nodes))
-		logger.Printf("Would create timeline with %d days", len(timelineIndex.Entries))
-		logger.Printf("Would create missing pages report with %d pages", len(missingPagesIndex.MissingPages))
-		logger.Printf("Would create time tracking report (%.1f%% adoption, %d tracked)",
-			timeTrackingIndex.Statistics.AdoptionRate,
-			timeTrackingIndex.Statistics.TasksWithTracking)
-		return nil
+	fmt.Printf("✓ Updated %s to %s (checksum verified)\n", exePath, release.Version)
+	return nil
+}
+
+func runGraphDiff(cmd *cobra.Command, args []string) error {
+	if err := applyProfile(cmd); err != nil {
+		return err
 	}
+	logger := newLogger()
 
-	// 4. Write output files
-	if verbose {
-		logger.Println("Step 4: Writing index files...")
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
 	}
 
-	// Make output path absolute (relative to repo path)
+	graphs := make([]*indexer.ReferenceGraph, 2)
+	for i, rev := range []string{diffFrom, diffTo} {
+		dir, cleanup, err := gitlog.Checkout(absRepoPath, rev)
+		if err != nil {
+			return err
+		}
+		graphs[i], err = buildReferenceGraph(dir)
+		if cleanupErr := cleanup(); cleanupErr != nil {
+			logger.Printf("Warning: removing worktree for %s: %v", rev, cleanupErr)
+		}
+		if err != nil {
+			return fmt.Errorf("indexing %s: %w", rev, err)
+		}
+		logger.Printf("Indexed %s: %d pages", rev, len(graphs[i].Nodes))
+	}
+
+	diff := indexer.BuildGraphDiff(graphs[0], graphs[1], diffFrom, diffTo)
+
 	absOutputDir := outputDir
 	if !filepath.IsAbs(outputDir) {
 		absOutputDir = filepath.Join(absRepoPath, outputDir)
 	}
+	if err := writer.WriteGraphDiff(diff, absOutputDir); err != nil {
+		return fmt.Errorf("writing graph diff: %w", err)
+	}
+	logger.Printf("✓ Created %s (%d new pages, %d removed, %d reference changes)",
+		filepath.Join(absOutputDir, writer.GraphDiffFileName),
+		len(diff.NewPages), len(diff.RemovedPages), len(diff.ReferenceChanges))
+
+	return nil
+}
+
+// buildReferenceGraph scans a repository checkout and builds just its reference graph
+func buildReferenceGraph(absRepoPath string) (*indexer.ReferenceGraph, error) {
+	files, err := scanner.New(absRepoPath).Scan()
+	if err != nil {
+		return nil, fmt.Errorf("scanning files: %w", err)
+	}
+
+	var allRefs []models.PageReference
+	for _, file := range files {
+		content, err := os.ReadFile(file.AbsolutePath)
+		if err != nil {
+			return nil, err
+		}
+		refs, err := parser.ParseReferences(string(content), file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("parsing references in %s: %w", file.Path, err)
+		}
+		allRefs = append(allRefs, refs...)
+	}
 
-	// Write task index by status
-	if err := writer.WriteTaskIndex(taskIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing task index: %w", err)
+	graph := indexer.BuildReferenceGraph(allRefs, files)
+	var favorites []string
+	if edn, err := os.ReadFile(filepath.Join(absRepoPath, "logseq", "config.edn")); err == nil {
+		favorites = parser.ParseFavorites(string(edn))
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "tasks-by-status.md"))
+	graph.ApplyPinned(favorites, allRefs)
+
+	return graph, nil
+}
 
-	// Write priority index
-	if err := writer.WritePriorityIndex(taskIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing priority index: %w", err)
+// generateAndRecord runs one watch-mode generation, logging failures and recording metrics
+func generateAndRecord(logger *log.Logger, recorder *metrics.Recorder) {
+	start := time.Now()
+	counts, err := generateIndexes(logger)
+	if err != nil {
+		logger.Printf("Error: %v", err)
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "tasks-by-priority.md"))
+	recorder.Record(time.Since(start), counts, err)
+}
 
-	// Write timeline recent
-	if err := writer.WriteTimelineRecent(timelineIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing recent timeline: %w", err)
+// serveMetrics starts the /metrics endpoint in the background until ctx is cancelled
+func serveMetrics(ctx context.Context, addr string, recorder *metrics.Recorder, logger *log.Logger) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("starting metrics server: %w", err)
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "timeline-recent.md"))
 
-	// Write timeline full
-	if err := writer.WriteTimelineFull(timelineIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing full timeline: %w", err)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", recorder)
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go server.Serve(listener)
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	logger.Printf("Serving metrics at http://%s/metrics", listener.Addr())
+	return nil
+}
+
+// drainPending discards change notifications that arrived during the debounce window
+func drainPending(changes <-chan struct{}) {
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+		default:
+			return
+		}
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "timeline-full.md"))
+}
+
+// generateIndexes scans, parses, and writes all indexes once.
+// It returns the manifest counts, or nil when nothing was written (dry run, empty repo).
+func generateIndexes(logger *log.Logger) (map[string]int, error) {
+	logger.Printf("Scanning Logseq repository: %s", repoPath)
 
-	// Write missing pages
-	if err := writer.WriteMissingPages(missingPagesIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing missing pages: %w", err)
+	// Convert to absolute path
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repo path: %w", err)
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "missing-pages.md"))
 
-	// Write time tracking
-	if err := writer.WriteTimeTracking(timeTrackingIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing time tracking: %w", err)
+	// Verify repo path exists
+	if _, err := os.Stat(absRepoPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("repository path does not exist: %s", absRepoPath)
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "time-tracking.md"))
 
-	// Write reference graph
-	if err := writer.WriteReferenceGraph(graphIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing reference graph: %w", err)
+	if language != "" && language != parser.LanguageEnglish && language != parser.LanguageGerman {
+		return nil, fmt.Errorf("unsupported language %q (expected en or de)", language)
+	}
+	if len(scopePatterns) > 0 && filepath.Clean(outputDir) == outputProfile.OutputDir() {
+		return nil, fmt.Errorf("--scope needs its own --output directory so it doesn't replace the full index in %s", outputProfile.OutputDir())
+	}
+	if scopeHops < 0 {
+		return nil, fmt.Errorf("--scope-hops must not be negative")
+	}
+	if scopeHops > 0 && len(scopePatterns) == 0 {
+		return nil, fmt.Errorf("--scope-hops needs --scope")
+	}
+	if filesFrom != "" {
+		if exportPath != "" || len(scopePatterns) > 0 {
+			return nil, fmt.Errorf("--files-from can't be combined with --export or --scope")
+		}
+		if filepath.Clean(outputDir) == outputProfile.OutputDir() {
+			return nil, fmt.Errorf("--files-from needs its own --output directory so it doesn't replace the full index in %s", outputProfile.OutputDir())
+		}
+	}
+	snapshotMode, err := gitsnapshot.ParseMode(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	writers, err := writer.Select(onlyWriters, skipWriters)
+	if err != nil {
+		return nil, err
+	}
+	partial := len(onlyWriters) > 0 || len(skipWriters) > 0
+	if prune && partial {
+		return nil, fmt.Errorf("--prune can't be combined with --only or --skip, which leave other writers' files in place")
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "reference-graph.md"))
 
-	// Write dashboard (aggregated overview)
-	if err := writer.WriteDashboard(taskIndex, graphIndex, timelineIndex, missingPagesIndex, timeTrackingIndex, absOutputDir); err != nil {
-		return fmt.Errorf("writing dashboard: %w", err)
+	// Load optional config
+	cfg, err := config.Load(absRepoPath, configPath)
+	if err != nil {
+		return nil, err
+	}
+	priorities, _ := cfg.Tasks.PriorityLevels() // Validated in config.Load
+	models.SetPriorities(priorities)
+	statuses, _ := cfg.Tasks.StatusOrder() // Validated in config.Load
+	models.SetStatuses(statuses)
+	closed := make([]models.TaskStatus, len(cfg.Tasks.ClosedStatuses))
+	for i, status := range cfg.Tasks.ClosedStatuses {
+		closed[i] = models.TaskStatus(status)
+	}
+	models.SetClosedStatuses(closed)
+	scanner.SetExtensions(cfg.Scanner.Extensions)
+	if err := writer.SetDurationFormat(writer.DurationFormat(cfg.Output.DurationFormat)); err != nil {
+		return nil, err
+	}
+	rounding, _ := cfg.Output.Rounding() // Validated in config.Load
+	if err := writer.SetDurationRounding(rounding); err != nil {
+		return nil, err
+	}
+	if err := writer.SetLocale(writer.Locale(cfg.Output.Locale)); err != nil {
+		return nil, err
+	}
+	if err := writer.SetMinConfidence(cfg.Output.MinConfidence); err != nil {
+		return nil, err
+	}
+	priorityIndex := make(map[models.Priority]int)
+	for priority, limit := range cfg.Tasks.PriorityIndex {
+		priorityIndex[models.Priority(priority)] = limit
+	}
+	if err := writer.SetPriorityIndex(priorityIndex); err != nil {
+		return nil, err
+	}
+	symbols := writer.Symbols{
+		Status:   make(map[models.TaskStatus]string),
+		Priority: make(map[models.Priority]string),
+		NoEmoji:  !cfg.Output.Symbols.EmojiEnabled(),
+	}
+	for status, marker := range cfg.Output.Symbols.Status {
+		symbols.Status[models.TaskStatus(status)] = marker
+	}
+	for priority, marker := range cfg.Output.Symbols.Priority {
+		symbols.Priority[models.Priority(priority)] = marker
+	}
+	if err := writer.SetSymbols(symbols); err != nil {
+		return nil, err
 	}
-	logger.Printf("✓ Created %s", filepath.Join(absOutputDir, "dashboard.md"))
 
-	logger.Println("Index generation complete!")
+	// Time each step for the --verbose breakdown
+	var phases []phaseTiming
+	phaseStart := time.Now()
+	endPhase := func(name string) {
+		now := time.Now()
+		phases = append(phases, phaseTiming{name, now.Sub(phaseStart)})
+		phaseStart = now
+	}
 
-	return nil
+	// 1. Scan for files
+	if verbose {
+		logger.Println("Step 1: Scanning for markdown files...")
+	}
+	scanRoot := absRepoPath
+	if exportPath != "" {
+		if applyTags {
+			return nil, fmt.Errorf("--apply-tags edits page files and can't be used with --export")
+		}
+		scanRoot, err = renderExport(exportPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(scanRoot)
+		logger.Printf("Reading Logseq export: %s", exportPath)
+	}
+	sc := scanner.New(scanRoot)
+	var files []models.File
+	if filesFrom != "" {
+		paths, err := readFileList(filesFrom)
+		if err != nil {
+			return nil, err
+		}
+		var skipped []string
+		files, skipped = sc.Files(paths)
+		if len(skipped) > 0 {
+			logger.Printf("Skipped %d listed files outside pages/ and journals/, not indexed, or missing", len(skipped))
+			if verbose {
+				for _, path := range skipped {
+					logger.Printf("  %s", path)
+				}
+			}
+		}
+	} else if files, err = sc.Scan(); err != nil {
+		return nil, fmt.Errorf("scanning files: %w", err)
+	}
+	if len(scopePatterns) > 0 {
+		scopedRoot, result, err := renderScope(files)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(scopedRoot)
+		if files, err = scanner.New(scopedRoot).Scan(); err != nil {
+			return nil, fmt.Errorf("scanning scoped files: %w", err)
+		}
+		logger.Printf("Scoped to %s: %d pages and %d journals mentioning them",
+			strings.Join(scopePatterns, ", "), result.Pages, result.Journals)
+		if scopeHops > 0 {
+			for distance, size := range result.Reach {
+				logger.Printf("  Hop %d: %d pages, %.1f KB", distance, size.Pages, float64(size.Bytes)/1024)
+			}
+			logger.Printf("  Left out %d pages further away", result.Pruned)
+		}
+	}
+
+	logger.Printf("Found %d markdown files", len(files))
+
+	if len(files) == 0 {
+		logger.Println("No markdown files found in pages/ or journals/")
+		return nil, nil
+	}
+
+	endPhase("Scan")
+
+	// 2. Parse all files
+	if verbose {
+		logger.Println("Step 2: Parsing files...")
+	}
+
+	var allTasks []models.Task
+	var allRefs []models.PageReference
+	var inboxItems []models.InboxItem
+	var diagnostics []models.Diagnostic
+	pageTags := make(map[string][]string)     // Page name -> tags:: property values
+	languages := make(map[string]string)      // Page name -> detected language code
+	journalWordCounts := make(map[string]int) // Journal path -> words written
+	var fileErrors []error
+	indexedFiles := files[:0:0] // Files left after the language filter
+
+	// Content words: page name -> words for keyword extraction, and journal
+	// path -> words for trend detection. --low-memory keeps them on disk.
+	var pageWords, journalWords spill.Store = spill.NewMemory(), spill.NewMemory()
+	workers, batch := 0, len(files)
+	if lowMemory {
+		debug.SetGCPercent(lowMemoryGCPercent)
+		workers, batch = 1, lowMemoryBatch
+		if pageWords, err = spill.NewFile(""); err != nil {
+			return nil, err
+		}
+		defer pageWords.Close()
+		if journalWords, err = spill.NewFile(""); err != nil {
+			return nil, err
+		}
+		defer journalWords.Close()
+	}
+
+	// Parse files in parallel (in batches with --low-memory); a failure in
+	// one file keeps the results of the others
+	parseTimings := make([]fileTiming, len(files))
+	for start := 0; start < len(files); start += batch {
+		end := min(start+batch, len(files))
+		parse := func(file models.File) (parsedFile, error) { return parseFile(file, cfg.Inbox) }
+		for i, result := range parallel.Map(files[start:end], workers, parse) {
+			file := files[start+i]
+			parsed := result.Value
+			parseTimings[start+i] = fileTiming{file.Path, parsed.bytes, result.Duration}
+
+			if result.Err != nil {
+				code := fileErrorCode(result.Err)
+				if verbose {
+					logger.Printf("Warning: %s in %s: %v", code, file.Path, result.Err)
+					var panicErr *parallel.PanicError
+					if errors.As(result.Err, &panicErr) {
+						logger.Printf("%s", panicErr.Stack)
+					}
+				}
+				diagnostics = append(diagnostics, models.Diagnostic{
+					Severity: models.SeverityError,
+					Code:     code,
+					File:     file.Path,
+					Message:  result.Err.Error(),
+				})
+				fileErrors = append(fileErrors, fmt.Errorf("%s: %w", file.Path, result.Err))
+			}
+
+			if parsed.skipped {
+				continue
+			}
+			indexedFiles = append(indexedFiles, file)
+
+			pageName := models.PageName(file.Path)
+			if parsed.language != "" {
+				languages[pageName] = parsed.language
+			}
+			if parsed.read {
+				pageWords.Add(pageName, parsed.words)
+				if file.Type == models.FileTypeJournal {
+					journalWords.Add(file.Path, parsed.words)
+					journalWordCounts[file.Path] = parsed.wordCount
+				}
+			}
+			if len(parsed.tags) > 0 {
+				pageTags[pageName] = parsed.tags
+			}
+			allTasks = append(allTasks, parsed.tasks...)
+			allRefs = append(allRefs, parsed.refs...)
+			inboxItems = append(inboxItems, parsed.inbox...)
+		}
+	}
+
+	logger.Printf("Extracted %d tasks and %d references", len(allTasks), len(allRefs))
+
+	if len(fileErrors) > 0 {
+		if strict {
+			return nil, fmt.Errorf("%d files failed to parse (--strict): %w", len(fileErrors), errors.Join(fileErrors...))
+		}
+		logger.Printf("Warning: %d files failed to parse (see diagnostics.md)", len(fileErrors))
+	}
+	if skipped := len(files) - len(indexedFiles); skipped > 0 {
+		logger.Printf("Skipped %d files not in language %q", skipped, language)
+	}
+	files = indexedFiles
+	endPhase("Parse")
+
+	// 3. Build indexes
+	if verbose {
+		logger.Println("Step 3: Building indexes...")
+	}
+
+	// Park someday/maybe tasks so the task indexes stay focused on actionable work
+	activeTasks, somedayIndex := indexer.SplitSomedayTasks(allTasks, indexer.SomedayOptions{
+		Tag:        somedayTag,
+		LaterAfter: somedayDays,
+	}, time.Now())
+
+	taskIndex := indexer.BuildTaskIndex(activeTasks)
+
+	// Suspicious clock entries are always reported, and optionally kept out of the time totals
+	anomalies := indexer.FindLogbookAnomalies(allTasks, time.Now())
+	timedTasks := allTasks
+	if excludeAnomalies {
+		timedTasks = indexer.ExcludeAnomalies(allTasks, anomalies)
+	}
+	// Accidental clock-in/out noise is counted, not aggregated
+	timedTasks, microSessions := indexer.ExcludeMicroSessions(timedTasks, time.Duration(cfg.TimeTracking.MinEntrySeconds)*time.Second)
+	if len(anomalies) > 0 {
+		logger.Printf("Warning: %d suspicious logbook entries (see %s)", len(anomalies), writer.TimeTrackingIssuesFileName)
+	}
+
+	// Task project references may be left out of the graph or kept as their own edges
+	graphRefs := allRefs
+	projectRefMode := indexer.ProjectRefMode(cfg.Graph.ProjectRefs)
+	var projectRefs []models.PageReference
+	if projectRefMode == indexer.ProjectRefsExclude || projectRefMode == indexer.ProjectRefsSeparate {
+		graphRefs, projectRefs = indexer.SplitProjectRefs(allRefs)
+	}
+	graphIndex := indexer.BuildReferenceGraph(graphRefs, files)
+	if projectRefMode == indexer.ProjectRefsSeparate {
+		graphIndex.ApplyProjectRefs(projectRefs)
+	}
+	graphIndex.ApplyNoiseFilter(graphRefs, cfg.Graph.MinLineChars)
+
+	// Pinned pages from the sidebar favorites and the Contents page
+	var favorites []string
+	if edn, err := os.ReadFile(filepath.Join(absRepoPath, "logseq", "config.edn")); err == nil {
+		favorites = parser.ParseFavorites(string(edn))
+	}
+	graphIndex.ApplyPinned(favorites, allRefs)
+	graphIndex.ApplyLanguages(languages)
+	graphIndex.ApplyKeywords(indexer.BuildKeywordIndexFrom(pageWords.All(), 8))
+	graphIndex.ApplyTaskStats(timedTasks)
+	taskIndex.ApplyCompletionCandidates(allTasks, graphIndex, allRefs, time.Now(), cfg.Tasks.CompleteAfterWeeks)
+	retros := indexer.BuildRetros(allTasks, graphIndex, allRefs, time.Now(), indexer.DefaultRetroWeeks)
+	pageDetailsIndex := indexer.BuildPageDetailsIndex(graphIndex, allRefs)
+	pageDetailsIndex.ApplySessionPatterns(indexer.BuildSessionPatterns(allTasks, 3))
+	pageDetailsIndex.ApplyRelatedJournals(5)
+	pageDetailsIndex.ApplyTaskRollups(graphIndex, allTasks, 10)
+
+	var inlineTags []string
+	for _, task := range allTasks {
+		inlineTags = append(inlineTags, task.Tags...)
+	}
+	tagSuggestionsIndex := indexer.BuildTagSuggestionsIndex(graphIndex, pageTags, inlineTags, 3)
+
+	timelineIndex := indexer.BuildTimelineIndex(timedTasks, files)
+	timelineIndex.ApplyWriting(journalWordCounts, time.Now())
+
+	// Place page tasks on the timeline, falling back to git commit dates
+	history, err := gitlog.FileHistory(absRepoPath)
+	if err != nil {
+		logger.Printf("Warning: %v", err)
+	}
+	pageModified := make(map[string]time.Time, len(history))
+	pageCreated := make(map[string]time.Time, len(history))
+	for path, dates := range history {
+		pageModified[path] = dates.Modified
+		pageCreated[path] = dates.Created
+	}
+	timelineIndex.BackfillPageTasks(allTasks, pageModified)
+
+	// Page creations and edits of 5+ lines, using mtime for files git doesn't
+	// know (bulk mtime days are left out, see ApplyPageActivity)
+	pageEdits := make(map[string][]indexer.PageEdit)
+	for _, file := range files {
+		if file.Type != models.FileTypePage {
+			continue
+		}
+		path := filepath.ToSlash(file.Path)
+		dates, tracked := history[path]
+		if !tracked {
+			pageEdits[path] = []indexer.PageEdit{{Date: file.ModTime, Lines: -1}}
+			continue
+		}
+		for i, commit := range dates.Commits {
+			pageEdits[path] = append(pageEdits[path], indexer.PageEdit{
+				Date:    commit.Date,
+				Lines:   commit.Lines,
+				Created: i == len(dates.Commits)-1, // Oldest commit added the page
+			})
+		}
+	}
+	timelineIndex.ApplyPageActivity(pageEdits, 5)
+
+	// Old pages to revisit, judged by their last edit (mtime outside git)
+	pageTouched := make(map[string]time.Time, len(pageModified))
+	for _, file := range files {
+		path := filepath.ToSlash(file.Path)
+		if modified, tracked := pageModified[path]; tracked {
+			pageTouched[path] = modified
+		} else {
+			pageTouched[path] = file.ModTime
+		}
+	}
+	resurfaceIndex := indexer.BuildResurfaceIndex(graphIndex, pageTouched, allRefs, time.Now(), 5)
+
+	missingPagesIndex := indexer.BuildMissingPagesIndex(graphIndex, 5)
+	knownPeople, _ := cfg.MissingPages.PeoplePatterns() // Validated in config.Load
+	missingPagesIndex.ApplyPersonSignals(allRefs, knownPeople)
+	rulePatterns, _ := cfg.MissingPages.RulePatterns() // Validated in config.Load
+	typeRules := make([]indexer.ClassificationRule, len(cfg.MissingPages.Rules))
+	for i, rule := range cfg.MissingPages.Rules {
+		typeRules[i] = indexer.ClassificationRule{Pattern: rulePatterns[i], Keywords: rule.Keywords, Type: rule.Type}
+	}
+	missingPagesIndex.ApplyClassificationRules(typeRules)
+	missingPagesIndex.ApplySnippets(allRefs)
+	missingPagesIndex.ApplyAliasSuggestions(graphIndex)
+	graphHealthIndex := indexer.BuildGraphHealthIndex(graphIndex, 3)
+	graphHealthIndex.ApplyLinkHealth(graphIndex, 3)
+	graphHealthIndex.ApplyNamespaceHints(graphIndex)
+
+	// Quick-capture items waiting to be filed, when an inbox is configured
+	var inboxIndex *indexer.InboxIndex
+	if cfg.Inbox.Enabled() {
+		inboxIndex = indexer.BuildInboxIndex(inboxItems, graphIndex, cfg.Inbox.Page, strings.TrimPrefix(cfg.Inbox.Tag, "#"), time.Now())
+	}
+
+	// Pages Logseq kept copies of after deleting them, reported but never indexed
+	var deletedPagesIndex *indexer.DeletedPagesIndex
+	if cfg.Scanner.DeletedDays > 0 && exportPath == "" {
+		deleted, err := scanner.New(absRepoPath).ScanDeleted()
+		if err != nil {
+			return nil, fmt.Errorf("scanning deleted pages: %w", err)
+		}
+		if len(scopePatterns) > 0 {
+			sc, err := scope.New(scopePatterns)
+			if err != nil {
+				return nil, err
+			}
+			deleted = sc.Deleted(deleted, files)
+		}
+		deletedPagesIndex = indexer.BuildDeletedPagesIndex(deleted, graphIndex, cfg.Scanner.DeletedDays, time.Now())
+		if n := deletedPagesIndex.Referenced(); n > 0 {
+			logger.Printf("Note: %d recently deleted pages are still linked (see %s)", n, writer.DeletedPagesFileName)
+		}
+	}
+	timeTrackingIndex := indexer.BuildTimeTrackingIndex(timedTasks)
+	timeTrackingIndex.ApplyRecords(timedTasks, time.Now())
+	timeTrackingIndex.ApplyStartDelays(timedTasks, pageCreated)
+	timeTrackingIndex.Anomalies = anomalies
+	timeTrackingIndex.AnomaliesExcluded = excludeAnomalies
+	timeTrackingIndex.MicroSessions = microSessions
+
+	budgets, _ := cfg.TimeTracking.WeeklyBudgets() // Validated in config.Load
+	if len(budgets) > 0 {
+		timeTrackingIndex.ApplyBudgets(budgets, time.Now())
+	}
+	var timeCategories []indexer.TimeCategory
+	for name, category := range cfg.TimeTracking.Categories {
+		timeCategories = append(timeCategories, indexer.TimeCategory{Name: name, Projects: category.Projects, Tags: category.Tags})
+	}
+	timeTrackingIndex.ApplyCategories(timedTasks, timeCategories)
+
+	timeTreeIndex := indexer.BuildTimeTreeIndex(timedTasks, time.Now())
+
+	effortIndex := indexer.BuildEffortIndex(activeTasks)
+	trendsIndex := indexer.BuildTrendsIndexFrom(journalWords.All(), allRefs, time.Now(), 14, 3)
+	if err := errors.Join(pageWords.Err(), journalWords.Err()); err != nil {
+		return nil, err
+	}
+	remindersIndex := indexer.BuildRemindersIndex(allTasks, time.Now(), reminderDays)
+	dailyPlan := indexer.BuildDailyPlan(activeTasks, remindersIndex, time.Now())
+	weekPlan := indexer.BuildWeekPlan(activeTasks, effortIndex, timeTrackingIndex, time.Now())
+
+	diagnostics = append(diagnostics, indexer.CheckJournalDates(files)...)
+	diagnosticsIndex := indexer.BuildDiagnosticsIndex(diagnostics)
+	if warnings := diagnosticsIndex.Count(models.SeverityWarning); warnings > 0 {
+		logger.Printf("Warning: %d diagnostics warnings (see diagnostics.md)", warnings)
+	}
+
+	if applyTags {
+		if err := applyTagSuggestions(tagSuggestionsIndex, absRepoPath, logger); err != nil {
+			return nil, err
+		}
+	}
+
+	if dryRun {
+		logger.Println("\n=== DRY RUN MODE ===")
+		logger.Printf("Would create task index with %d tasks", taskIndex.TotalTasks)
+		logger.Printf("Would create someday backlog with %d tasks", len(somedayIndex.Tasks))
+		logger.Printf("Would create reference graph with %d nodes", len(graphIndex.Nodes))
+		logger.Printf("Would create timeline with %d days", len(timelineIndex.Entries))
+		logger.Printf("Would create missing pages report with %d pages", len(missingPagesIndex.MissingPages))
+		logger.Printf("Would create tag suggestions for %d untagged pages", len(tagSuggestionsIndex.Pages))
+		logger.Printf("Would create time tracking report (%.1f%% adoption, %d tracked)",
+			timeTrackingIndex.Statistics.AdoptionRate,
+			timeTrackingIndex.Statistics.TasksWithTracking)
+		logger.Printf("Would create reminders feed with %d tasks due within %d days", len(remindersIndex.Reminders), reminderDays)
+		logger.Printf("Would create daily planning prompt (%d on the agenda, %d carried over)", len(dailyPlan.Agenda), len(dailyPlan.CarriedOver))
+		logger.Printf("Would create retro prompts for %d finished projects", len(retros))
+		if verbose {
+			endPhase("Build indexes")
+			logTimings(logger, phases, parseTimings)
+		}
+		return nil, nil
+	}
+
+	endPhase("Build indexes")
+
+	// 4. Write output files
+	if verbose {
+		logger.Println("Step 4: Writing index files...")
+	}
+
+	// Make output path absolute (relative to repo path)
+	absOutputDir := outputDir
+	if !filepath.IsAbs(outputDir) {
+		absOutputDir = filepath.Join(absRepoPath, outputDir)
+	}
+
+	// Hold the output lock while writing so a hook run and a watch run can't interleave files
+	outputLock, err := lock.Acquire(absOutputDir, lockWait)
+	if err != nil {
+		return nil, err
+	}
+	defer outputLock.Release()
+
+	// Remember the previous outputs so only real changes get staged
+	var before *gitstage.Snapshot
+	if gitAdd {
+		if before, err = gitstage.Take(absOutputDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Track generated files for the manifest
+	var generated []string
+	created := func(name string) {
+		generated = append(generated, name)
+		logger.Printf("✓ Created %s", filepath.Join(absOutputDir, name))
+	}
+
+	// Run the selected writers in registration order
+	indexes := &writer.Indexes{
+		Tasks:          taskIndex,
+		Someday:        somedayIndex,
+		Timeline:       timelineIndex,
+		MissingPages:   missingPagesIndex,
+		TimeTracking:   timeTrackingIndex,
+		TimeTree:       timeTreeIndex,
+		Reminders:      remindersIndex,
+		DailyPlan:      dailyPlan,
+		WeekPlan:       weekPlan,
+		Retros:         retros,
+		Graph:          graphIndex,
+		GraphHealth:    graphHealthIndex,
+		TagSuggestions: tagSuggestionsIndex,
+		PageDetails:    pageDetailsIndex,
+		Trends:         trendsIndex,
+		Effort:         effortIndex,
+		Diagnostics:    diagnosticsIndex,
+		Resurface:      resurfaceIndex,
+		Inbox:          inboxIndex,
+		DeletedPages:   deletedPagesIndex,
+	}
+
+	// Compare with the previous run for the dashboard's Since Last Run section
+	previousState, err := writer.ReadRunState(absOutputDir)
+	if err != nil {
+		logger.Printf("Warning: ignoring previous %s: %v", writer.RunStateFileName, err)
+	}
+	indexes.Changes = indexer.BuildChanges(previousState, allTasks, graphIndex)
+	diagnosticsIndex.ApplyHistory(previousState)
+	if n := len(diagnosticsIndex.Regressions); n > 0 {
+		logger.Printf("Warning: %d files have more errors than in the last run (see diagnostics.md)", n)
+	}
+	if diagnosticsIndex.ErrorJump {
+		logger.Printf("Warning: errors jumped to %d from an average of %.1f (see diagnostics.md)",
+			diagnosticsIndex.Count(models.SeverityError), diagnosticsIndex.AverageErrors())
+	}
+	writeOpts := writer.Options{OutputDir: absOutputDir, GraphName: filepath.Base(absRepoPath)}
+	for _, w := range writers {
+		names, err := w.Write(context.Background(), indexes, writeOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			created(name)
+		}
+	}
+
+	// Remember this run for the next one's comparison
+	runState := indexer.BuildRunState(allTasks, graphIndex, time.Now())
+	runState.RecordDiagnostics(files, diagnosticsIndex)
+	if err := writer.WriteRunState(runState, absOutputDir); err != nil {
+		return nil, err
+	}
+	created(writer.RunStateFileName)
+
+	// Embed page chunks for semantic search
+	var retained []string // Not rewritten this run, but not stale either
+	if cfg.Embeddings.Enabled() {
+		total, embedded, err := writeEmbeddings(context.Background(), cfg.Embeddings, files, absOutputDir)
+		if err != nil {
+			// Optional enrichment: a provider outage shouldn't block the indexes
+			logger.Printf("Warning: skipping %s: %v", embeddings.FileName, err)
+			retained = append(retained, embeddings.FileName)
+		} else {
+			created(embeddings.FileName)
+			if verbose {
+				logger.Printf("  Embedded %d of %d chunks (the rest were unchanged)", embedded, total)
+			}
+		}
+	}
+
+	// Trim the markdown indexes to the token budget, lowest priority first
+	if cfg.Output.TokenBudget > 0 {
+		results, err := writer.ApplyTokenBudget(absOutputDir, generated, cfg.Output.TokenBudget, cfg.Output.BudgetPriority)
+		if err != nil {
+			return nil, fmt.Errorf("applying token budget: %w", err)
+		}
+		total := 0
+		for _, result := range results {
+			total += result.Tokens
+			if result.Omitted > 0 {
+				logger.Printf("✓ Trimmed %s to ~%d tokens (omitted sections: %d)", result.File, result.Tokens, result.Omitted)
+			}
+		}
+		if verbose {
+			logger.Printf("  Markdown indexes: ~%d of %d budgeted tokens", total, cfg.Output.TokenBudget)
+		}
+	}
+
+	// Write manifest last so it only lists files that were written successfully
+	manifest := &writer.Manifest{
+		ToolVersion: version,
+		GeneratedAt: time.Now().UTC(),
+		Counts: map[string]int{
+			"files":                 len(files),
+			"tasks":                 len(allTasks),
+			"references":            len(allRefs),
+			"pages":                 len(graphIndex.Nodes),
+			"errors":                diagnosticsIndex.Count(models.SeverityError),
+			"warnings":              diagnosticsIndex.Count(models.SeverityWarning),
+			"journal_date_warnings": countCodes(diagnosticsIndex, indexer.CodeInvalidJournalDate, indexer.CodeDateLikePage, indexer.CodeDuplicateDate),
+		},
+	}
+
+	// Point Cursor at the indexes with a project rule it loads on demand
+	if outputProfile == writer.ProfileCursor {
+		relDir, err := filepath.Rel(absRepoPath, absOutputDir)
+		if err != nil {
+			relDir = absOutputDir
+		}
+		if err := writer.WriteCursorRule(generated, relDir, absOutputDir); err != nil {
+			return nil, fmt.Errorf("writing Cursor rule: %w", err)
+		}
+		created(writer.CursorRuleFileName)
+	}
+
+	// Document the outputs for collaborators, then list the README in the manifest too
+	manifest.Files = generated
+	if err := writer.WriteIndexReadme(manifest, readmeOptions(cfg, absRepoPath), absOutputDir); err != nil {
+		return nil, fmt.Errorf("writing index README: %w", err)
+	}
+	created(writer.IndexReadmeFileName)
+	manifest.Files = generated
+
+	// Find files earlier runs generated that this one didn't, so they don't
+	// pass for current indexes
+	previous, _ := writer.ReadManifest(absOutputDir) // nil on the first run
+	produced := append(append(append([]string{}, generated...), writer.ManifestFileName), retained...)
+	stale := writer.StaleFiles(previous, produced, partial, absOutputDir)
+	if prune {
+		if err := writer.PruneFiles(stale, absOutputDir); err != nil {
+			return nil, fmt.Errorf("pruning stale outputs: %w", err)
+		}
+		for _, name := range stale {
+			logger.Printf("✓ Removed stale %s", filepath.Join(absOutputDir, name))
+		}
+	} else if len(stale) > 0 {
+		manifest.Stale = stale
+		logger.Printf("Note: earlier runs generated files this run didn't (run with --prune to remove them): %s", strings.Join(stale, ", "))
+	}
+
+	// Tell scripts parsing the indexes when a file's sections or fields change shape
+	manifest.Formats = writer.Formats(append(generated, writer.ManifestFileName))
+	if previous != nil {
+		manifest.FormatChanges = writer.CompareFormats(previous.Formats, manifest.Formats)
+		if len(manifest.FormatChanges) > 0 {
+			manifest.FormatChangesSince = previous.ToolVersion
+			logger.Printf("Note: index format changed since version %s (see format_changes in %s):", previous.ToolVersion, writer.ManifestFileName)
+			for _, change := range manifest.FormatChanges {
+				logger.Printf("  %s", change)
+			}
+		}
+	}
+
+	if err := writer.WriteManifest(manifest, absOutputDir); err != nil {
+		return nil, fmt.Errorf("writing manifest: %w", err)
+	}
+	created(writer.ManifestFileName)
+
+	if gitAdd {
+		changes, err := before.Changes()
+		if err != nil {
+			return nil, err
+		}
+		if err := gitstage.Stage(absOutputDir, changes); err != nil {
+			return nil, fmt.Errorf("staging outputs: %w", err)
+		}
+		logger.Printf("✓ Staged %d changed and %d removed index files", len(changes.Modified), len(changes.Deleted))
+	}
+
+	if snapshotMode != gitsnapshot.ModeOff {
+		result, err := gitsnapshot.Take(absOutputDir, snapshotMode, time.Now(), manifest.Counts)
+		if err != nil {
+			return nil, fmt.Errorf("taking weekly snapshot: %w", err)
+		}
+		switch {
+		case result.Existing:
+			logger.Printf("Weekly snapshot for %s already taken", result.Week)
+		case result.Commit != "":
+			logger.Printf("✓ Committed weekly snapshot %s (%s)", result.Week, result.Commit)
+		case result.Unchanged && result.Tag == "":
+			logger.Printf("Indexes unchanged since the last commit; no snapshot for %s yet", result.Week)
+		}
+		if result.Tag != "" {
+			logger.Printf("✓ Tagged weekly snapshot %s", result.Tag)
+		}
+	}
+
+	if verbose {
+		endPhase("Write")
+		logTimings(logger, phases, parseTimings)
+	}
+
+	logger.Println("Index generation complete!")
+
+	return manifest.Counts, nil
+}
+
+// applyTagSuggestions inserts suggested existing tags into untagged pages and
+// drops those pages from index, so tag-suggestions.md doesn't list them again.
+// New-tag ideas are never applied. In dry-run mode the changes are only logged.
+func applyTagSuggestions(index *indexer.TagSuggestionsIndex, absRepoPath string, logger *log.Logger) error {
+	applied := 0
+	var untagged []*indexer.PageTagSuggestions // Pages left for tag-suggestions.md
+	for _, page := range index.Pages {
+		tags := page.ExistingTags()
+		if len(tags) == 0 {
+			untagged = append(untagged, page)
+			continue
+		}
+
+		if dryRun {
+			logger.Printf("Would add tags:: %s to %s", strings.Join(tags, ", "), page.FilePath)
+			applied++
+			continue
+		}
+
+		path := filepath.Join(absRepoPath, page.FilePath)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", page.FilePath, err)
+		}
+		if err := os.WriteFile(path, []byte(parser.InsertPageTags(string(content), tags)), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", page.FilePath, err)
+		}
+		if verbose {
+			logger.Printf("Added tags:: %s to %s", strings.Join(tags, ", "), page.FilePath)
+		}
+		applied++
+	}
+
+	if dryRun {
+		logger.Printf("Would tag %d pages", applied)
+		return nil
+	}
+	// The tagged pages are no longer untagged, but the rest of this run was
+	// built from the files as they were before
+	index.Pages = untagged
+	index.TaggedPages += applied
+	logger.Printf("Tagged %d pages", applied)
+	if applied > 0 {
+		logger.Println("The other indexes will reflect the new tags on the next run")
+	}
+	return nil
+}
+
+// Diagnostic codes for files that failed to parse
+const (
+	codeReadFailed  = "read-failed"
+	codeParseFailed = "parse-failed"
+	codeParsePanic  = "parse-panic"
+)
+
+// phaseTiming is how long one generation step took
+type phaseTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// fileTiming is how long one file took to parse
+type fileTiming struct {
+	path     string
+	bytes    int
+	duration time.Duration
+}
+
+// maxSlowFiles is how many of the slowest files to parse --verbose lists
+const maxSlowFiles = 10
+
+// logTimings logs the time spent in each step and the slowest files to
+// parse, so pathological files (huge generated pages) can be excluded
+func logTimings(logger *log.Logger, phases []phaseTiming, files []fileTiming) {
+	var total, parsing time.Duration
+	for _, phase := range phases {
+		total += phase.duration
+	}
+	for _, file := range files {
+		parsing += file.duration
+	}
+
+	logger.Println("Timing:")
+	for _, phase := range phases {
+		if phase.name == "Parse" {
+			logger.Printf("  %-14s %v (%v of worker time)", phase.name, phase.duration.Round(time.Millisecond), parsing.Round(time.Millisecond))
+			continue
+		}
+		logger.Printf("  %-14s %v", phase.name, phase.duration.Round(time.Millisecond))
+	}
+	logger.Printf("  %-14s %v", "Total", total.Round(time.Millisecond))
+
+	slowest := append([]fileTiming(nil), files...)
+	sort.Slice(slowest, func(i, j int) bool {
+		return slowest[i].duration > slowest[j].duration
+	})
+	logger.Println("Slowest files to parse:")
+	for _, file := range slowest[:min(len(slowest), maxSlowFiles)] {
+		logger.Printf("  %10v  %s (%d KB)", file.duration.Round(10*time.Microsecond), file.path, (file.bytes+1023)/1024)
+	}
+}
+
+// renderExport renders a Logseq graph export as journals/ and pages/ markdown
+// files in a temporary directory, which the caller removes
+func renderExport(path string) (string, error) {
+	pages, err := export.Load(path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp("", "logseq-export-")
+	if err != nil {
+		return "", fmt.Errorf("creating export directory: %w", err)
+	}
+	if err := export.Render(pages, dir, info.ModTime()); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("rendering export: %w", err)
+	}
+	return dir, nil
+}
+
+// readFileList reads the --files-from list: one path per line, from stdin
+// when source is "-". Blank lines are ignored.
+func readFileList(source string) ([]string, error) {
+	var r io.Reader = os.Stdin
+	if source != "-" {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("reading file list: %w", err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var paths []string
+	lines := bufio.NewScanner(r)
+	for lines.Scan() {
+		if path := strings.TrimSpace(lines.Text()); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	if err := lines.Err(); err != nil {
+		return nil, fmt.Errorf("reading file list: %w", err)
+	}
+	return paths, nil
+}
+
+// renderScope copies the files in --scope, and the journal blocks linking
+// them, to a temporary directory, which the caller removes
+func renderScope(files []models.File) (string, scope.Result, error) {
+	sc, err := scope.New(scopePatterns)
+	if err != nil {
+		return "", scope.Result{}, err
+	}
+	sc.Hops = scopeHops
+
+	dir, err := os.MkdirTemp("", "logseq-scope-")
+	if err != nil {
+		return "", scope.Result{}, fmt.Errorf("creating scope directory: %w", err)
+	}
+	result, err := sc.Render(files, dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", scope.Result{}, fmt.Errorf("rendering scope: %w", err)
+	}
+	return dir, result, nil
+}
+
+// parsedFile holds everything extracted from one markdown file
+type parsedFile struct {
+	bytes     int    // Size of the file's content
+	read      bool   // The file's content was read
+	skipped   bool   // Filtered out by --language
+	language  string // Detected language code, "" if unknown
+	tasks     []models.Task
+	refs      []models.PageReference
+	words     []string // Content words for keyword extraction
+	wordCount int      // Words written, for journal writing statistics
+	tags      []string // tags:: property values
+	inbox     []models.InboxItem
+}
+
+// parseFile reads and parses one file. Results parsed before an error are still returned.
+func parseFile(file models.File, inbox config.InboxConfig) (parsedFile, error) {
+	var parsed parsedFile
+
+	content, err := os.ReadFile(file.AbsolutePath)
+	if err != nil {
+		return parsed, err
+	}
+	text := string(content)
+	parsed.read = true
+	parsed.bytes = len(content)
+
+	// Detect language, skipping files in other languages when filtering
+	parsed.language = parser.DetectLanguage(text)
+	if language != "" && parsed.language != "" && parsed.language != language {
+		parsed.skipped = true
+		return parsed, nil
+	}
+
+	parsed.words = parser.ExtractWords(text)
+	parsed.wordCount = parser.CountWords(text)
+	parsed.tags = parser.ParsePageTags(text)
+
+	if inbox.Enabled() {
+		name := strings.ReplaceAll(models.PageName(file.Path), "___", "/")
+		wholePage := file.Type == models.FileTypePage && inbox.Page != "" && strings.EqualFold(name, inbox.Page)
+		parsed.inbox = parser.ParseInboxItems(text, file.Path, wholePage, strings.TrimPrefix(inbox.Tag, "#"))
+	}
+
+	tasks, taskErr := parser.ParseTasks(text, file.Path)
+	if taskErr != nil {
+		taskErr = fmt.Errorf("parsing tasks: %w", taskErr)
+	}
+	parsed.tasks = tasks
+
+	refs, refErr := parser.ParseReferences(text, file.Path)
+	if refErr != nil {
+		refErr = fmt.Errorf("parsing references: %w", refErr)
+	}
+	parsed.refs = refs
+
+	return parsed, errors.Join(taskErr, refErr)
+}
+
+// writeEmbeddings chunks the indexed files, embeds chunks that changed since
+// the last run, and writes embeddings.jsonl. It returns the chunk count and
+// how many were newly embedded.
+func writeEmbeddings(ctx context.Context, cfg config.EmbeddingsConfig, files []models.File, absOutputDir string) (int, int, error) {
+	provider, err := embeddings.NewProvider(embeddings.Options{
+		Command: cfg.Command,
+		URL:     cfg.URL,
+		Model:   cfg.Model,
+		Headers: cfg.Headers,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	chunks, err := chunkFiles(files)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Unreadable previous output just means everything is embedded again
+	previous, _ := embeddings.ReadJSONL(absOutputDir)
+
+	records, embedded, err := embeddings.Embed(ctx, provider, cfg.Model, chunks, previous, cfg.BatchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if err := embeddings.WriteJSONL(records, absOutputDir); err != nil {
+		return 0, 0, err
+	}
+	return len(records), embedded, nil
+}
+
+// chunkFiles splits files into their top-level blocks
+func chunkFiles(files []models.File) ([]embeddings.Chunk, error) {
+	var chunks []embeddings.Chunk
+	for _, file := range files {
+		content, err := os.ReadFile(file.AbsolutePath)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, embeddings.ChunkPage(string(content), file.Path)...)
+	}
+	return chunks, nil
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	// Past argument parsing, errors are about the repo or provider, not usage
+	cmd.SilenceUsage = true
+	query := strings.Join(args, " ")
+	if err := applyProfile(cmd); err != nil {
+		return err
+	}
+
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
+	}
+	absOutputDir := outputDir
+	if !filepath.IsAbs(absOutputDir) {
+		absOutputDir = filepath.Join(absRepoPath, outputDir)
+	}
+
+	cfg, err := config.Load(absRepoPath, configPath)
+	if err != nil {
+		return err
+	}
+	scanner.SetExtensions(cfg.Scanner.Extensions)
+
+	var results []embeddings.Result
+	if semantic {
+		if !cfg.Embeddings.Enabled() {
+			return fmt.Errorf("--semantic needs an embedding command or url under embeddings: in %s", config.DefaultFileName)
+		}
+		records, err := embeddings.ReadJSONL(absOutputDir)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return fmt.Errorf("no %s in %s; run generate first", embeddings.FileName, absOutputDir)
+		}
+
+		provider, err := embeddings.NewProvider(embeddings.Options{
+			Command: cfg.Embeddings.Command,
+			URL:     cfg.Embeddings.URL,
+			Model:   cfg.Embeddings.Model,
+			Headers: cfg.Embeddings.Headers,
+		})
+		if err != nil {
+			return err
+		}
+		vectors, err := provider.Embed(cmd.Context(), []string{query})
+		if err != nil {
+			return err
+		}
+		if len(vectors) != 1 {
+			return fmt.Errorf("provider returned %d vectors for the query", len(vectors))
+		}
+		results = embeddings.Search(records, vectors[0], searchLimit)
+	} else {
+		files, err := scanner.New(absRepoPath).Scan()
+		if err != nil {
+			return fmt.Errorf("scanning files: %w", err)
+		}
+		chunks, err := chunkFiles(files)
+		if err != nil {
+			return err
+		}
+		records := make([]embeddings.Record, len(chunks))
+		for i, c := range chunks {
+			records[i] = embeddings.Record{Page: c.Page, File: c.File, Line: c.Line, Text: c.Text}
+		}
+		results = embeddings.KeywordSearch(records, query, searchLimit)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No matches")
+		return nil
+	}
+	for i, result := range results {
+		firstLine, _, _ := strings.Cut(result.Text, "\n")
+		fmt.Printf("%d. [[%s]] %s:%d (%.2f)\n   %s\n", i+1, result.Page, result.File, result.Line, result.Score, parser.ExtractContext(firstLine, 120))
+	}
+	return nil
+}
+
+func runQueryProps(cmd *cobra.Command, args []string) error {
+	// Past argument parsing, errors are about the repo, not usage
+	cmd.SilenceUsage = true
+
+	absRepoPath, err := filepath.Abs(repoPath)
+	if err != nil {
+		return fmt.Errorf("invalid repo path: %w", err)
+	}
+	cfg, err := config.Load(absRepoPath, configPath)
+	if err != nil {
+		return err
+	}
+	scanner.SetExtensions(cfg.Scanner.Extensions)
+
+	files, err := scanner.New(absRepoPath).Scan()
+	if err != nil {
+		return fmt.Errorf("scanning files: %w", err)
+	}
+	var blocks []models.PropertyBlock
+	for _, file := range files {
+		content, err := os.ReadFile(file.AbsolutePath)
+		if err != nil {
+			return err
+		}
+		blocks = append(blocks, parser.ParseProperties(string(content), file.Path)...)
+	}
+
+	groups := indexer.QueryProperties(blocks, indexer.PropertyQuery{Key: propKey, GroupBy: propGroupBy, Value: propValue})
+	if propJSON {
+		return writer.WritePropertyQueryJSON(os.Stdout, groups, propList)
+	}
+	if len(groups) == 0 {
+		fmt.Printf("No blocks have %s::\n", propKey)
+		return nil
+	}
+	for _, group := range groups {
+		indent := ""
+		if propGroupBy != "" {
+			fmt.Printf("%s: %s (%d)\n", propGroupBy, group.Group, group.Count())
+			indent = "  "
+		}
+		for _, value := range group.Values {
+			fmt.Printf("%s%s: %d\n", indent, value.Value, len(value.Blocks))
+			if !propList {
+				continue
+			}
+			for _, block := range value.Blocks {
+				text := block.Text
+				if block.Page {
+					text = "(page properties)"
+				}
+				fmt.Printf("%s  %s:%d %s\n", indent, block.SourceFile, block.LineNumber, parser.ExtractContext(text, 120))
+			}
+		}
+	}
+	return nil
+}
+
+// fileErrorCode returns the diagnostic code for a parseFile error
+func fileErrorCode(err error) string {
+	var panicErr *parallel.PanicError
+	var pathErr *fs.PathError
+	switch {
+	case errors.As(err, &panicErr):
+		return codeParsePanic
+	case errors.As(err, &pathErr):
+		return codeReadFailed
+	default:
+		return codeParseFailed
+	}
+}
+
+// readmeOptions lists the settings that shaped this run's indexes
+func readmeOptions(cfg *config.Config, absRepoPath string) []writer.ReadmeOption {
+	configFile := "none (defaults)"
+	if configPath != "" {
+		configFile = configPath
+	} else if _, err := os.Stat(filepath.Join(absRepoPath, config.DefaultFileName)); err == nil {
+		configFile = config.DefaultFileName
+	}
+
+	disabledOr := func(enabled bool, value string) string {
+		if !enabled {
+			return "disabled"
+		}
+		return value
+	}
+
+	var priorities []string
+	for _, p := range models.AllPriorities() {
+		if p != models.PriorityNone {
+			priorities = append(priorities, string(p))
+		}
+	}
+
+	durationFormat := cfg.Output.DurationFormat
+	if durationFormat == "" {
+		durationFormat = string(writer.DurationShort)
+	}
+
+	locale := cfg.Output.Locale
+	if locale == "" {
+		locale = string(writer.LocaleEnglish)
+	}
+
+	projectRefs := cfg.Graph.ProjectRefs
+	if projectRefs == "" {
+		projectRefs = string(indexer.ProjectRefsCount)
+	}
+
+	budgetPriority := cfg.Output.BudgetPriority
+	if len(budgetPriority) == 0 {
+		budgetPriority = writer.DefaultBudgetPriority
+	}
+	budget := fmt.Sprintf("%d tokens (priority: %s)", cfg.Output.TokenBudget, strings.Join(budgetPriority, " > "))
+
+	symbols := "default"
+	if s := cfg.Output.Symbols; len(s.Status) > 0 || len(s.Priority) > 0 || !s.EmojiEnabled() {
+		symbols = fmt.Sprintf("%d status and %d priority markers customized", len(s.Status), len(s.Priority))
+		if !s.EmojiEnabled() {
+			symbols += ", no emoji"
+		}
+	}
+
+	embeddingProvider := "command"
+	if cfg.Embeddings.URL != "" {
+		embeddingProvider = cfg.Embeddings.URL
+	}
+	if cfg.Embeddings.Model != "" {
+		embeddingProvider += " (" + cfg.Embeddings.Model + ")"
+	}
+
+	input := "markdown files"
+	if exportPath != "" {
+		input = "Logseq export " + exportPath
+	}
+	if len(scopePatterns) > 0 {
+		input += ", scoped to " + strings.Join(scopePatterns, ", ")
+		if scopeHops > 0 {
+			input += fmt.Sprintf(", pages up to %d hop(s) away", scopeHops)
+		}
+		input += " and journal blocks linking them"
+	}
+	if filesFrom == "-" {
+		input = "markdown files listed on stdin"
+	} else if filesFrom != "" {
+		input = "markdown files listed in " + filesFrom
+	}
+
+	staleOutputs := "kept, listed in manifest.json"
+	if prune {
+		staleOutputs = "removed (--prune)"
+	}
+
+	anomalies := "included in time totals"
+	if excludeAnomalies {
+		anomalies = "excluded from time totals"
+	}
+
+	var inboxSources []string
+	if cfg.Inbox.Page != "" {
+		inboxSources = append(inboxSources, "[["+cfg.Inbox.Page+"]]")
+	}
+	if cfg.Inbox.Tag != "" {
+		inboxSources = append(inboxSources, "#"+strings.TrimPrefix(cfg.Inbox.Tag, "#"))
+	}
+	inbox := strings.Join(inboxSources, " and ")
+
+	priorityIndex := "[#A]"
+	for _, p := range models.Priorities() {
+		limit, listed := cfg.Tasks.PriorityIndex[string(p)]
+		if !listed {
+			continue
+		}
+		if p != models.PriorityHigh {
+			priorityIndex += ", [#" + string(p) + "]"
+		}
+		if limit > 0 {
+			priorityIndex += fmt.Sprintf(" (up to %d)", limit)
+		}
+	}
+
+	return []writer.ReadmeOption{
+		{Name: "Config file", Value: configFile},
+		{Name: "Input", Value: input},
+		{Name: "Profile", Value: string(outputProfile)},
+		{Name: "Language filter", Value: disabledOr(language != "", language)},
+		{Name: "Someday tag", Value: disabledOr(somedayTag != "", "#"+somedayTag)},
+		{Name: "Someday after", Value: disabledOr(somedayDays > 0, fmt.Sprintf("LATER tasks older than %d days", somedayDays))},
+		{Name: "Reminder window", Value: fmt.Sprintf("%d days", reminderDays)},
+		{Name: "Logbook anomalies", Value: anomalies},
+		{Name: "Stale outputs", Value: staleOutputs},
+		{Name: "Priorities", Value: strings.Join(priorities, ", ")},
+		{Name: "Priority index", Value: priorityIndex},
+		{Name: "Duration format", Value: durationFormat},
+		{Name: "Duration rounding", Value: disabledOr(cfg.Output.DurationRounding != "", "nearest "+cfg.Output.DurationRounding)},
+		{Name: "Report language", Value: locale},
+		{Name: "Task project references", Value: projectRefs},
+		{Name: "Noise filter", Value: disabledOr(cfg.Graph.MinLineChars > 0, fmt.Sprintf("lines under %d characters", cfg.Graph.MinLineChars))},
+		{Name: "Token budget", Value: disabledOr(cfg.Output.TokenBudget > 0, budget)},
+		{Name: "Minimum confidence", Value: disabledOr(cfg.Output.MinConfidence > 0, fmt.Sprintf("%g (weaker guesses hidden from markdown)", cfg.Output.MinConfidence))},
+		{Name: "Weekly budgets", Value: disabledOr(len(cfg.TimeTracking.Budgets) > 0, fmt.Sprintf("%d projects", len(cfg.TimeTracking.Budgets)))},
+		{Name: "Time categories", Value: disabledOr(len(cfg.TimeTracking.Categories) > 0, fmt.Sprintf("%d categories", len(cfg.TimeTracking.Categories)))},
+		{Name: "Minimum clock entry", Value: disabledOr(cfg.TimeTracking.MinEntrySeconds > 0, fmt.Sprintf("%ds (shorter entries counted as micro-sessions)", cfg.TimeTracking.MinEntrySeconds))},
+		{Name: "Inbox", Value: disabledOr(cfg.Inbox.Enabled(), inbox)},
+		{Name: "Deleted pages", Value: disabledOr(cfg.Scanner.DeletedDays > 0 && exportPath == "", fmt.Sprintf("deleted in the last %d days", cfg.Scanner.DeletedDays))},
+		{Name: "Missing page rules", Value: disabledOr(len(cfg.MissingPages.Rules) > 0, fmt.Sprintf("%d rules", len(cfg.MissingPages.Rules)))},
+		{Name: "Embeddings", Value: disabledOr(cfg.Embeddings.Enabled(), embeddingProvider)},
+		{Name: "Symbols", Value: symbols},
+	}
+}
+
+// countCodes counts diagnostics matching any of the given codes
+func countCodes(index *indexer.DiagnosticsIndex, codes ...string) int {
+	count := 0
+	for _, d := range index.Diagnostics {
+		for _, code := range codes {
+			if d.Code == code {
+				count++
+				break
+			}
+		}
+	}
+	return count
 }
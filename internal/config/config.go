@@ -0,0 +1,512 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFileName is the config file looked up in the repository root
+const DefaultFileName = ".logseq-indexer.yml"
+
+// Config holds user settings loaded from .logseq-indexer.yml
+// Every field is optional; a missing file yields the zero-value defaults.
+type Config struct {
+	TimeTracking TimeTrackingConfig `yaml:"time_tracking"`
+	MissingPages MissingPagesConfig `yaml:"missing_pages"`
+	Tasks        TasksConfig        `yaml:"tasks"`
+	Output       OutputConfig       `yaml:"output"`
+	Scanner      ScannerConfig      `yaml:"scanner"`
+	Graph        GraphConfig        `yaml:"graph"`
+	Embeddings   EmbeddingsConfig   `yaml:"embeddings"`
+	Inbox        InboxConfig        `yaml:"inbox"`
+}
+
+// InboxConfig enables quick-capture inbox processing: the top-level blocks of
+// Page and blocks tagged with Tag anywhere are unprocessed items until moved
+// or marked DONE
+type InboxConfig struct {
+	// Page is the inbox page's name, e.g. "Inbox" for pages/Inbox.md
+	Page string `yaml:"page"`
+
+	// Tag marks inbox blocks on any page or journal, e.g. "inbox" for #inbox
+	Tag string `yaml:"tag"`
+}
+
+// Enabled reports whether an inbox page or tag is configured
+func (i InboxConfig) Enabled() bool {
+	return i.Page != "" || i.Tag != ""
+}
+
+// EmbeddingsConfig configures the optional embeddings stage, which sends page
+// chunks to a provider of your choice and writes the vectors to
+// embeddings.jsonl for `search --semantic`. Set Command or URL, not both.
+type EmbeddingsConfig struct {
+	// Command is a shell command that reads {"model", "input": [texts]} on
+	// stdin and writes {"embeddings": [[...]]} on stdout
+	Command string `yaml:"command"`
+
+	// URL is an HTTP endpoint taking the same request as a POST body, e.g. an
+	// OpenAI-compatible /v1/embeddings or Ollama's /api/embed
+	URL string `yaml:"url"`
+
+	// Model is passed to the provider as "model"
+	Model string `yaml:"model"`
+
+	// Headers are sent with HTTP requests; values expand $VARIABLES, so keys
+	// can stay in the environment, e.g. Authorization: "Bearer $OPENAI_API_KEY"
+	Headers map[string]string `yaml:"headers"`
+
+	// BatchSize is how many chunks are sent per request (default 32)
+	BatchSize int `yaml:"batch_size"`
+}
+
+// Enabled reports whether an embedding provider is configured
+func (e EmbeddingsConfig) Enabled() bool {
+	return e.Command != "" || e.URL != ""
+}
+
+// GraphConfig configures the reference graph
+type GraphConfig struct {
+	// ProjectRefs sets how the first [[page]] on a task line, its project,
+	// counts: count (an ordinary reference, default), exclude (left out of
+	// the graph), or separate (a project edge outside hub rankings)
+	ProjectRefs string `yaml:"project_refs"`
+
+	// MinLineChars leaves references on lines with fewer letters and digits
+	// than this, besides links, tags, and property names, out of reference
+	// counts and hub rankings (default 0: off). Breadcrumbs and
+	// "related:: [[A]] [[B]]" lines have none.
+	MinLineChars int `yaml:"min_line_chars"`
+}
+
+// ScannerConfig configures which files are indexed
+type ScannerConfig struct {
+	// Extensions lists the file extensions to index (default: .md, .markdown,
+	// .mdx). Files with a non-markdown extension such as .txt are only
+	// indexed if they contain Logseq-style "- " bullets.
+	Extensions []string `yaml:"extensions"`
+
+	// DeletedDays reports pages deleted in the last this many days, from the
+	// copies Logseq keeps in logseq/.recycle and logseq/bak, in
+	// recently-deleted.md (0, the default, disables it). The copies are never
+	// indexed with the graph.
+	DeletedDays int `yaml:"deleted_days"`
+}
+
+// OutputConfig configures how index files are rendered
+type OutputConfig struct {
+	// DurationFormat is the markdown duration style: short ("2h 30m", default),
+	// decimal ("2.5h"), clock ("2:30"), or iso8601 ("PT2H30M").
+	// JSON outputs always use seconds plus ISO 8601.
+	DurationFormat string `yaml:"duration_format"`
+
+	// DurationRounding rounds markdown durations to the nearest multiple of
+	// a Go duration such as "5m" or "15m" (default: no rounding). JSON
+	// outputs keep the raw values.
+	DurationRounding string `yaml:"duration_rounding"`
+
+	// Locale is the language of markdown headings, labels, and dates:
+	// en (default) or de. File names and JSON outputs are unaffected.
+	Locale string `yaml:"locale"`
+
+	// TokenBudget caps the estimated tokens of the markdown indexes combined
+	// (0, the default, means no cap). Lower-priority indexes lose their
+	// trailing sections first.
+	TokenBudget int `yaml:"token_budget"`
+
+	// BudgetPriority orders the index groups that share TokenBudget, most
+	// important first: dashboard, tasks, timeline, graph, time, diagnostics.
+	// Unlisted groups follow in that default order.
+	BudgetPriority []string `yaml:"budget_priority"`
+
+	// MinConfidence hides heuristic guesses scoring below it (0 to 1) from
+	// markdown outputs: missing page types below it are listed as
+	// unclassified, and possibly complete projects below it are left out.
+	// classifications.json keeps every guess with its score (default: 0,
+	// show everything).
+	MinConfidence float64 `yaml:"min_confidence"`
+
+	// Symbols replaces the status and priority markers and emoji in markdown
+	// outputs, for tools that don't display some emoji
+	Symbols SymbolsConfig `yaml:"symbols"`
+}
+
+// SymbolsConfig configures the markers markdown outputs use
+type SymbolsConfig struct {
+	// Emoji can be set to false to drop emoji from headings and use text
+	// indicators such as "[over]" instead (default: true)
+	Emoji *bool `yaml:"emoji"`
+
+	// Status maps a task status (NOW, LATER, TODO, DOING, DONE) to the marker
+	// shown instead of "[NOW]" etc.; an empty marker hides it
+	Status map[string]string `yaml:"status"`
+
+	// Priority maps a priority letter to the marker shown instead of "[#A]"
+	// etc.; an empty marker hides it
+	Priority map[string]string `yaml:"priority"`
+}
+
+// EmojiEnabled reports whether emoji should be used (the default)
+func (s SymbolsConfig) EmojiEnabled() bool {
+	return s.Emoji == nil || *s.Emoji
+}
+
+// TasksConfig configures how tasks are parsed and grouped
+type TasksConfig struct {
+	// Priorities lists the priority letters in use, highest first, for graphs
+	// that extend Logseq's default A, B, C (e.g. [A, B, C, D, E])
+	Priorities []string `yaml:"priorities"`
+
+	// Statuses orders task statuses in every report, and may add custom
+	// markers such as WAITING (default: NOW, DOING, TODO, LATER, DONE)
+	Statuses []string `yaml:"statuses"`
+
+	// ClosedStatuses lists statuses that finish a task like DONE, such as
+	// CANCELED, so they aren't counted as open work. Each must be one of the
+	// statuses (default: [DONE]; DONE is always closed)
+	ClosedStatuses []string `yaml:"closed_statuses"`
+
+	// CompleteAfterWeeks is how many quiet weeks make a project whose tasks
+	// are all DONE a "possibly complete" candidate on the dashboard (default: 4)
+	CompleteAfterWeeks int `yaml:"complete_after_weeks"`
+
+	// PriorityIndex adds priorities to tasks-by-priority.md, mapped to how
+	// many of their tasks to list (0 for all), e.g. B: 20. [#A] is always
+	// listed; giving it a cap limits it too (default: [#A] only, uncapped)
+	PriorityIndex map[string]int `yaml:"priority_index"`
+}
+
+// TimeTrackingConfig configures the time tracking report
+type TimeTrackingConfig struct {
+	// Budgets maps a project (first page reference on a task) to a weekly
+	// time budget written as a Go duration, e.g. "Project Phoenix": "10h"
+	Budgets map[string]string `yaml:"budgets"`
+
+	// Categories group projects and tags into higher-level buckets such as
+	// Meetings, Focus, or Admin, e.g. "Meetings": {tags: [meeting]}
+	Categories map[string]TimeCategoryConfig `yaml:"categories"`
+
+	// MinEntrySeconds leaves clock entries shorter than this out of the time
+	// aggregates, counting them as micro-sessions instead (default: 0, keep all)
+	MinEntrySeconds int `yaml:"min_entry_seconds"`
+}
+
+// TimeCategoryConfig lists what counts towards one time category
+type TimeCategoryConfig struct {
+	// Projects are first page references on a task, e.g. "Standup"
+	Projects []string `yaml:"projects"`
+
+	// Tags are #tags on a task, without the leading #
+	Tags []string `yaml:"tags"`
+}
+
+// MissingPagesConfig configures the missing pages report
+type MissingPagesConfig struct {
+	// People lists regular expressions for page names that are always people,
+	// e.g. "^(Alice|Bob) " or "\\(contractor\\)$"
+	People []string `yaml:"people"`
+
+	// Rules override the built-in type detection; the first matching rule wins
+	Rules []TypeRule `yaml:"rules"`
+}
+
+// TypeRule assigns a type to missing pages whose name matches Match (a
+// regular expression) or contains any of Keywords (ignoring case). Type may
+// be person, project, date, concept, or a custom type such as "paper".
+type TypeRule struct {
+	Match    string   `yaml:"match"`
+	Keywords []string `yaml:"keywords"`
+	Type     string   `yaml:"type"`
+}
+
+// Load reads the config file at path. If path is empty, DefaultFileName in
+// repoPath is used and a missing file is not an error.
+func Load(repoPath, path string) (*Config, error) {
+	explicit := path != ""
+	if !explicit {
+		path = filepath.Join(repoPath, DefaultFileName)
+	}
+
+	cfg := &Config{}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !explicit {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing config %s: %w", path, err)
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// validate checks values that can't be expressed in the YAML types alone
+func (c *Config) validate() error {
+	if _, err := c.TimeTracking.WeeklyBudgets(); err != nil {
+		return err
+	}
+	if err := c.TimeTracking.validateCategories(); err != nil {
+		return err
+	}
+	if _, err := c.MissingPages.PeoplePatterns(); err != nil {
+		return err
+	}
+	if _, err := c.MissingPages.RulePatterns(); err != nil {
+		return err
+	}
+	if _, err := c.Tasks.PriorityLevels(); err != nil {
+		return err
+	}
+	order, err := c.Tasks.StatusOrder()
+	if err != nil {
+		return err
+	}
+	for _, status := range c.Tasks.ClosedStatuses {
+		if !slices.Contains(order, models.TaskStatus(status)) {
+			return fmt.Errorf("tasks.closed_statuses: %q isn't one of tasks.statuses", status)
+		}
+	}
+	for status := range c.Output.Symbols.Status {
+		if !slices.Contains(order, models.TaskStatus(status)) {
+			return fmt.Errorf("output.symbols.status: unknown status %q", status)
+		}
+	}
+	levels, _ := c.Tasks.PriorityLevels() // Validated above
+	for priority := range c.Output.Symbols.Priority {
+		if !slices.Contains(levels, models.Priority(priority)) {
+			return fmt.Errorf("output.symbols.priority: unknown priority %q", priority)
+		}
+	}
+	for priority, limit := range c.Tasks.PriorityIndex {
+		if !slices.Contains(levels, models.Priority(priority)) {
+			return fmt.Errorf("tasks.priority_index: unknown priority %q", priority)
+		}
+		if limit < 0 {
+			return fmt.Errorf("tasks.priority_index: cap for %s must not be negative, got %d", priority, limit)
+		}
+	}
+	if c.Graph.MinLineChars < 0 {
+		return fmt.Errorf("graph.min_line_chars: must not be negative, got %d", c.Graph.MinLineChars)
+	}
+	if strings.ContainsAny(strings.TrimPrefix(c.Inbox.Tag, "#"), " \t#") {
+		return fmt.Errorf("inbox.tag: %q must be a single tag", c.Inbox.Tag)
+	}
+	if c.TimeTracking.MinEntrySeconds < 0 {
+		return fmt.Errorf("time_tracking.min_entry_seconds: must not be negative, got %d", c.TimeTracking.MinEntrySeconds)
+	}
+	if c.Tasks.CompleteAfterWeeks < 0 {
+		return fmt.Errorf("tasks.complete_after_weeks: must not be negative, got %d", c.Tasks.CompleteAfterWeeks)
+	}
+	switch c.Output.DurationFormat {
+	case "", "short", "decimal", "clock", "iso8601":
+	default:
+		return fmt.Errorf("output.duration_format: unknown format %q (expected short, decimal, clock, or iso8601)", c.Output.DurationFormat)
+	}
+	if _, err := c.Output.Rounding(); err != nil {
+		return err
+	}
+	switch c.Output.Locale {
+	case "", "en", "de":
+	default:
+		return fmt.Errorf("output.locale: unknown locale %q (expected en or de)", c.Output.Locale)
+	}
+	if c.Scanner.DeletedDays < 0 {
+		return fmt.Errorf("scanner.deleted_days: must not be negative, got %d", c.Scanner.DeletedDays)
+	}
+	for _, ext := range c.Scanner.Extensions {
+		if strings.Trim(strings.TrimSpace(ext), ".") == "" {
+			return fmt.Errorf("scanner.extensions: empty extension %q", ext)
+		}
+	}
+	switch c.Graph.ProjectRefs {
+	case "", "count", "exclude", "separate":
+	default:
+		return fmt.Errorf("graph.project_refs: unknown mode %q (expected count, exclude, or separate)", c.Graph.ProjectRefs)
+	}
+	if c.Embeddings.Command != "" && c.Embeddings.URL != "" {
+		return fmt.Errorf("embeddings: set either command or url, not both")
+	}
+	if c.Embeddings.URL != "" && !strings.HasPrefix(c.Embeddings.URL, "http://") && !strings.HasPrefix(c.Embeddings.URL, "https://") {
+		return fmt.Errorf("embeddings.url: %q is not an http(s) URL", c.Embeddings.URL)
+	}
+	if c.Embeddings.BatchSize < 0 {
+		return fmt.Errorf("embeddings.batch_size: must not be negative, got %d", c.Embeddings.BatchSize)
+	}
+	if c.Output.TokenBudget < 0 {
+		return fmt.Errorf("output.token_budget: must not be negative, got %d", c.Output.TokenBudget)
+	}
+	for _, group := range c.Output.BudgetPriority {
+		switch group {
+		case "dashboard", "tasks", "timeline", "graph", "time", "diagnostics":
+		default:
+			return fmt.Errorf("output.budget_priority: unknown group %q (expected dashboard, tasks, timeline, graph, time, or diagnostics)", group)
+		}
+	}
+	if c.Output.MinConfidence < 0 || c.Output.MinConfidence > 1 {
+		return fmt.Errorf("output.min_confidence: must be between 0 and 1, got %g", c.Output.MinConfidence)
+	}
+	return nil
+}
+
+// Rounding returns the configured duration rounding unit, zero if unset
+func (o OutputConfig) Rounding() (time.Duration, error) {
+	if o.DurationRounding == "" {
+		return 0, nil
+	}
+	unit, err := time.ParseDuration(o.DurationRounding)
+	if err != nil {
+		return 0, fmt.Errorf("output.duration_rounding: %w", err)
+	}
+	if unit <= 0 || unit%time.Minute != 0 {
+		return 0, fmt.Errorf("output.duration_rounding: %q is not a positive whole number of minutes", o.DurationRounding)
+	}
+	return unit, nil
+}
+
+// WeeklyBudgets returns the configured per-project weekly budgets as durations
+func (t TimeTrackingConfig) WeeklyBudgets() (map[string]time.Duration, error) {
+	budgets := make(map[string]time.Duration, len(t.Budgets))
+	for project, value := range t.Budgets {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("time_tracking.budgets[%q]: %w", project, err)
+		}
+		if d <= 0 {
+			return nil, fmt.Errorf("time_tracking.budgets[%q]: budget must be positive", project)
+		}
+		budgets[project] = d
+	}
+	return budgets, nil
+}
+
+// validateCategories checks each category matches something and no project
+// or tag is claimed by two categories
+func (t TimeTrackingConfig) validateCategories() error {
+	projects := make(map[string]string)
+	tags := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(t.Categories)) {
+		category := t.Categories[name]
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("time_tracking.categories: category name is empty")
+		}
+		if len(category.Projects) == 0 && len(category.Tags) == 0 {
+			return fmt.Errorf("time_tracking.categories[%q]: list at least one project or tag", name)
+		}
+		for _, project := range category.Projects {
+			key := strings.ToLower(project)
+			if other, taken := projects[key]; taken {
+				return fmt.Errorf("time_tracking.categories[%q]: project %q is already in %q", name, project, other)
+			}
+			projects[key] = name
+		}
+		for _, tag := range category.Tags {
+			key := strings.ToLower(strings.TrimPrefix(tag, "#"))
+			if other, taken := tags[key]; taken {
+				return fmt.Errorf("time_tracking.categories[%q]: tag %q is already in %q", name, tag, other)
+			}
+			tags[key] = name
+		}
+	}
+	return nil
+}
+
+// PeoplePatterns compiles the configured known-people patterns
+func (m MissingPagesConfig) PeoplePatterns() ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, 0, len(m.People))
+	for i, expr := range m.People {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("missing_pages.people[%d]: %w", i, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// RulePatterns validates the type rules and compiles their Match
+// expressions, returning one pattern per rule (nil if it has no Match)
+func (m MissingPagesConfig) RulePatterns() ([]*regexp.Regexp, error) {
+	patterns := make([]*regexp.Regexp, len(m.Rules))
+	for i, rule := range m.Rules {
+		if strings.TrimSpace(rule.Type) == "" {
+			return nil, fmt.Errorf("missing_pages.rules[%d]: type is required", i)
+		}
+		if rule.Match == "" && len(rule.Keywords) == 0 {
+			return nil, fmt.Errorf("missing_pages.rules[%d]: set match or keywords", i)
+		}
+		if rule.Match != "" {
+			re, err := regexp.Compile(rule.Match)
+			if err != nil {
+				return nil, fmt.Errorf("missing_pages.rules[%d]: %w", i, err)
+			}
+			patterns[i] = re
+		}
+	}
+	return patterns, nil
+}
+
+// statusMarkerRegex matches a status marker: uppercase letters, digits, - and _
+var statusMarkerRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
+
+// StatusOrder returns the configured status order, or models.DefaultStatuses
+// if none is set. The built-in statuses must all be listed.
+func (t TasksConfig) StatusOrder() ([]models.TaskStatus, error) {
+	if len(t.Statuses) == 0 {
+		return models.DefaultStatuses, nil
+	}
+
+	order := make([]models.TaskStatus, 0, len(t.Statuses))
+	for _, s := range t.Statuses {
+		if !statusMarkerRegex.MatchString(s) {
+			return nil, fmt.Errorf("tasks.statuses: %q must be an uppercase marker such as WAITING", s)
+		}
+		if slices.Contains(order, models.TaskStatus(s)) {
+			return nil, fmt.Errorf("tasks.statuses: %q listed twice", s)
+		}
+		order = append(order, models.TaskStatus(s))
+	}
+	for _, builtin := range models.DefaultStatuses {
+		if !slices.Contains(order, builtin) {
+			return nil, fmt.Errorf("tasks.statuses: missing built-in status %s", builtin)
+		}
+	}
+	return order, nil
+}
+
+// PriorityLevels returns the configured priorities, or models.DefaultPriorities if none are set
+func (t TasksConfig) PriorityLevels() ([]models.Priority, error) {
+	if len(t.Priorities) == 0 {
+		return models.DefaultPriorities, nil
+	}
+
+	seen := make(map[string]bool)
+	levels := make([]models.Priority, 0, len(t.Priorities))
+	for _, p := range t.Priorities {
+		if len(p) != 1 || p[0] < 'A' || p[0] > 'Z' {
+			return nil, fmt.Errorf("tasks.priorities: %q must be a single uppercase letter", p)
+		}
+		if seen[p] {
+			return nil, fmt.Errorf("tasks.priorities: %q listed twice", p)
+		}
+		seen[p] = true
+		levels = append(levels, models.Priority(p))
+	}
+	return levels, nil
+}
@@ -0,0 +1,426 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_MissingDefaultFile(t *testing.T) {
+	cfg, err := Load(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.TimeTracking.Budgets) != 0 {
+		t.Errorf("Expected no budgets, got %v", cfg.TimeTracking.Budgets)
+	}
+}
+
+func TestLoad_MissingExplicitFile(t *testing.T) {
+	if _, err := Load(t.TempDir(), "/nonexistent/config.yml"); err == nil {
+		t.Error("Expected error for missing explicit config file")
+	}
+}
+
+func TestLoad_Budgets(t *testing.T) {
+	repo := t.TempDir()
+	content := `time_tracking:
+  budgets:
+    Project Phoenix: 10h
+    Admin: 2h30m
+`
+	if err := os.WriteFile(filepath.Join(repo, DefaultFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(repo, "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	budgets, err := cfg.TimeTracking.WeeklyBudgets()
+	if err != nil {
+		t.Fatalf("WeeklyBudgets failed: %v", err)
+	}
+	if budgets["Project Phoenix"] != 10*time.Hour {
+		t.Errorf("Expected 10h for Project Phoenix, got %v", budgets["Project Phoenix"])
+	}
+	if budgets["Admin"] != 150*time.Minute {
+		t.Errorf("Expected 2h30m for Admin, got %v", budgets["Admin"])
+	}
+}
+
+func TestLoad_InvalidBudget(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "time_tracking:\n  budgets:\n    Phoenix: ten hours\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for invalid budget duration")
+	}
+}
+
+func TestLoad_Categories(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "time_tracking:\n  categories:\n    Meetings:\n      projects: [Standup]\n      tags: [meeting]\n    Admin:\n      tags: [email]\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	meetings := cfg.TimeTracking.Categories["Meetings"]
+	if len(meetings.Projects) != 1 || meetings.Projects[0] != "Standup" || len(meetings.Tags) != 1 || meetings.Tags[0] != "meeting" {
+		t.Errorf("Unexpected Meetings category: %+v", meetings)
+	}
+}
+
+func TestLoad_InvalidClosedStatuses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "tasks:\n  closed_statuses: [CANCELED]\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for a closed status missing from tasks.statuses")
+	}
+}
+
+func TestLoad_InvalidCategories(t *testing.T) {
+	for name, content := range map[string]string{
+		"empty category": "time_tracking:\n  categories:\n    Meetings: {}\n",
+		"shared project": "time_tracking:\n  categories:\n    Meetings:\n      projects: [Standup]\n    Admin:\n      projects: [standup]\n",
+		"shared tag":     "time_tracking:\n  categories:\n    Meetings:\n      tags: [call]\n    Admin:\n      tags: ['#call']\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "custom.yml")
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := Load("", path); err == nil {
+				t.Error("Expected error for invalid categories")
+			}
+		})
+	}
+}
+
+func TestLoad_InvalidMinEntrySeconds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("time_tracking:\n  min_entry_seconds: -5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for negative min_entry_seconds")
+	}
+}
+
+func TestLoad_PeoplePatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "missing_pages:\n  people:\n    - '^Dr '\n    - '\\(contractor\\)$'\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	patterns, err := cfg.MissingPages.PeoplePatterns()
+	if err != nil {
+		t.Fatalf("PeoplePatterns failed: %v", err)
+	}
+	if len(patterns) != 2 || !patterns[1].MatchString("Sam Lee (contractor)") {
+		t.Errorf("Unexpected patterns: %v", patterns)
+	}
+}
+
+func TestLoad_InvalidPeoplePattern(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("missing_pages:\n  people: ['(unclosed']\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for invalid people pattern")
+	}
+}
+
+func TestLoad_TypeRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "missing_pages:\n  rules:\n    - match: '\\(\\d{4}\\)$'\n      type: paper\n    - keywords: [GmbH, Inc]\n      type: customer\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	patterns, err := cfg.MissingPages.RulePatterns()
+	if err != nil {
+		t.Fatalf("RulePatterns failed: %v", err)
+	}
+	if len(patterns) != 2 || !patterns[0].MatchString("Attention Is All You Need (2017)") || patterns[1] != nil {
+		t.Errorf("Unexpected patterns: %v", patterns)
+	}
+	if cfg.MissingPages.Rules[1].Type != "customer" || len(cfg.MissingPages.Rules[1].Keywords) != 2 {
+		t.Errorf("Unexpected rule: %+v", cfg.MissingPages.Rules[1])
+	}
+}
+
+func TestLoad_InvalidTypeRules(t *testing.T) {
+	for _, rules := range []string{
+		"[{match: '(unclosed', type: paper}]",
+		"[{match: 'x'}]",
+		"[{type: paper}]",
+	} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte("missing_pages:\n  rules: "+rules+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for rules %s", rules)
+		}
+	}
+}
+
+func TestLoad_Priorities(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("tasks:\n  priorities: [A, B, C, D, E]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	levels, err := cfg.Tasks.PriorityLevels()
+	if err != nil {
+		t.Fatalf("PriorityLevels failed: %v", err)
+	}
+	if len(levels) != 5 || levels[4] != "E" {
+		t.Errorf("Expected A-E, got %v", levels)
+	}
+}
+
+func TestLoad_InvalidPriorities(t *testing.T) {
+	for _, value := range []string{"[A, b]", "[A, AB]", "[A, B, A]"} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte("tasks:\n  priorities: "+value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for priorities %s", value)
+		}
+	}
+}
+
+func TestLoad_Statuses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	content := "tasks:\n  statuses: [NOW, DOING, WAITING, TODO, LATER, DONE]\noutput:\n  symbols:\n    status:\n      WAITING: \"[w]\"\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load("", path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	order, err := cfg.Tasks.StatusOrder()
+	if err != nil {
+		t.Fatalf("StatusOrder failed: %v", err)
+	}
+	if len(order) != 6 || order[2] != "WAITING" {
+		t.Errorf("Expected WAITING third of 6, got %v", order)
+	}
+}
+
+func TestLoad_InvalidStatuses(t *testing.T) {
+	for _, value := range []string{"[NOW, DOING, TODO, LATER]", "[NOW, DOING, TODO, LATER, DONE, waiting]", "[NOW, DOING, TODO, LATER, DONE, NOW]"} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte("tasks:\n  statuses: "+value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for statuses %s", value)
+		}
+	}
+}
+
+func TestLoad_Symbols(t *testing.T) {
+	dir := t.TempDir()
+	content := "output:\n  symbols:\n    emoji: false\n    status:\n      NOW: \"[!]\"\n    priority:\n      A: \"\"\n"
+	if err := os.WriteFile(filepath.Join(dir, DefaultFileName), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir, "")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Output.Symbols.EmojiEnabled() {
+		t.Error("Expected emoji to be disabled")
+	}
+	if cfg.Output.Symbols.Status["NOW"] != "[!]" {
+		t.Errorf("Expected NOW marker, got %q", cfg.Output.Symbols.Status["NOW"])
+	}
+	if marker, ok := cfg.Output.Symbols.Priority["A"]; !ok || marker != "" {
+		t.Errorf("Expected an empty A marker, got %q (set: %v)", marker, ok)
+	}
+
+	if !(SymbolsConfig{}).EmojiEnabled() {
+		t.Error("Expected emoji to be enabled by default")
+	}
+}
+
+func TestLoad_InvalidSymbols(t *testing.T) {
+	for _, content := range []string{
+		"output:\n  symbols:\n    status:\n      WAITING: \"[w]\"\n",
+		"output:\n  symbols:\n    priority:\n      D: \"!\"\n",
+	} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_InvalidPriorityIndex(t *testing.T) {
+	for _, content := range []string{
+		"tasks:\n  priority_index:\n    D: 10\n",
+		"tasks:\n  priority_index:\n    B: -1\n",
+	} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_InvalidCompleteAfterWeeks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("tasks:\n  complete_after_weeks: -2\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for negative complete_after_weeks")
+	}
+}
+
+func TestLoad_InvalidMinLineChars(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("graph:\n  min_line_chars: -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for negative min_line_chars")
+	}
+}
+
+func TestLoad_InvalidDeletedDays(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("scanner:\n  deleted_days: -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for negative deleted_days")
+	}
+}
+
+func TestLoad_InvalidDurationFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("output:\n  duration_format: fortnights\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for unknown duration format")
+	}
+}
+
+func TestLoad_InvalidDurationRounding(t *testing.T) {
+	for _, value := range []string{"fortnight", "-15m", "90s"} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte("output:\n  duration_rounding: "+value+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for duration_rounding %s", value)
+		}
+	}
+}
+
+func TestLoad_InvalidEmbeddings(t *testing.T) {
+	for _, content := range []string{
+		"embeddings:\n  command: embed\n  url: http://localhost:11434/api/embed\n",
+		"embeddings:\n  url: localhost:11434\n",
+		"embeddings:\n  command: embed\n  batch_size: -1\n",
+	} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_InvalidLocale(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("output:\n  locale: klingon\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for unknown locale")
+	}
+}
+
+func TestLoad_InvalidTokenBudget(t *testing.T) {
+	for _, content := range []string{
+		"output:\n  token_budget: -1\n",
+		"output:\n  budget_priority: [dashboard, calendar]\n",
+	} {
+		path := filepath.Join(t.TempDir(), "custom.yml")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load("", path); err == nil {
+			t.Errorf("Expected error for %q", content)
+		}
+	}
+}
+
+func TestLoad_InvalidInboxTag(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("inbox:\n  tag: \"to file\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for an inbox tag with a space")
+	}
+}
+
+func TestLoad_InvalidMinConfidence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom.yml")
+	if err := os.WriteFile(path, []byte("output:\n  min_confidence: 1.5\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load("", path); err == nil {
+		t.Error("Expected error for min_confidence above 1")
+	}
+}
@@ -0,0 +1,316 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/config"
+	"github.com/dyluth/logseq-claude-indexer/internal/lock"
+	"github.com/dyluth/logseq-claude-indexer/internal/parser"
+	"github.com/dyluth/logseq-claude-indexer/internal/scanner"
+	"github.com/dyluth/logseq-claude-indexer/internal/writer"
+)
+
+// Status is the outcome of a single check
+type Status int
+
+const (
+	StatusOK Status = iota
+	StatusWarn
+	StatusFail
+)
+
+// String returns the marker printed for a status
+func (s Status) String() string {
+	switch s {
+	case StatusOK:
+		return "✓"
+	case StatusWarn:
+		return "!"
+	default:
+		return "✗"
+	}
+}
+
+// Check is the result of one environment check
+type Check struct {
+	Name   string
+	Status Status
+	Detail string // What was found
+	Fix    string // Suggested fix, empty when nothing needs doing
+}
+
+// Options locates the repository and outputs being checked
+type Options struct {
+	RepoPath    string // Absolute path to the Logseq repository
+	OutputDir   string // Absolute path to the index output directory
+	ConfigPath  string // Explicit --config path, or "" for the default
+	ToolVersion string // Version of the running binary
+}
+
+// hookBinary is the command git hooks are expected to run
+const hookBinary = "logseq-claude-indexer"
+
+// Run performs every check in order. Checks that depend on the repository
+// existing are skipped when it doesn't.
+func Run(opts Options) []Check {
+	graph := checkGraph(opts.RepoPath)
+	checks := []Check{graph}
+	if graph.Status == StatusFail {
+		return checks
+	}
+
+	checks = append(checks,
+		checkConfigEDN(opts.RepoPath),
+		checkIndexerConfig(opts.RepoPath, opts.ConfigPath),
+		checkHook(opts.RepoPath),
+		checkBinaryOnPath(),
+		checkOutputDir(opts.OutputDir),
+		checkLock(opts.OutputDir),
+		checkManifest(opts.OutputDir, opts.ToolVersion),
+	)
+	return checks
+}
+
+// Failed reports whether any check failed
+func Failed(checks []Check) bool {
+	for _, c := range checks {
+		if c.Status == StatusFail {
+			return true
+		}
+	}
+	return false
+}
+
+// checkGraph verifies the repository looks like a Logseq graph
+func checkGraph(repoPath string) Check {
+	c := Check{Name: "Logseq graph"}
+
+	if info, err := os.Stat(repoPath); err != nil || !info.IsDir() {
+		c.Status = StatusFail
+		c.Detail = fmt.Sprintf("%s is not a directory", repoPath)
+		c.Fix = "Pass the graph's root folder with --repo"
+		return c
+	}
+
+	pages := countMarkdown(filepath.Join(repoPath, "pages"))
+	journals := countMarkdown(filepath.Join(repoPath, "journals"))
+	if pages < 0 && journals < 0 {
+		c.Status = StatusFail
+		c.Detail = "no pages/ or journals/ directory"
+		c.Fix = "Pass the folder containing pages/ and journals/ with --repo (not a subfolder of it)"
+		return c
+	}
+
+	c.Detail = fmt.Sprintf("%d pages, %d journals", max(pages, 0), max(journals, 0))
+	if _, err := os.Stat(filepath.Join(repoPath, "logseq")); err != nil {
+		c.Status = StatusWarn
+		c.Detail += "; no logseq/ directory"
+		c.Fix = "Open the folder in Logseq once to create logseq/config.edn (favorites won't be pinned until then)"
+	}
+	return c
+}
+
+// countMarkdown counts markdown files directly in dir, or returns -1 if dir doesn't exist
+func countMarkdown(dir string) int {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return -1
+	}
+	count := 0
+	for _, entry := range entries {
+		if !entry.IsDir() && scanner.HasIndexedExtension(entry.Name()) {
+			count++
+		}
+	}
+	return count
+}
+
+// checkConfigEDN verifies logseq/config.edn is structurally valid
+func checkConfigEDN(repoPath string) Check {
+	c := Check{Name: "config.edn"}
+	path := filepath.Join(repoPath, "logseq", "config.edn")
+
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		c.Detail = "not present (optional)"
+		return c
+	}
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = fmt.Sprintf("Check the permissions of %s", path)
+		return c
+	}
+
+	if err := parser.ValidateEDN(string(content)); err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = fmt.Sprintf("Fix the bracket or quote in %s; Logseq won't load it either", path)
+		return c
+	}
+
+	c.Detail = fmt.Sprintf("%d favorites", len(parser.ParseFavorites(string(content))))
+	return c
+}
+
+// checkIndexerConfig verifies the optional .logseq-indexer.yml loads
+func checkIndexerConfig(repoPath, configPath string) Check {
+	c := Check{Name: "Indexer config"}
+
+	path := configPath
+	if path == "" {
+		path = filepath.Join(repoPath, config.DefaultFileName)
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			c.Detail = config.DefaultFileName + " not present (defaults in use)"
+			return c
+		}
+	}
+
+	if _, err := config.Load(repoPath, configPath); err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = fmt.Sprintf("Correct %s (see the Configuration section of the README)", path)
+		return c
+	}
+	c.Detail = path
+	return c
+}
+
+// checkHook looks for a git hook that runs the indexer
+func checkHook(repoPath string) Check {
+	c := Check{Name: "Git hook"}
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		c.Status = StatusWarn
+		c.Detail = "not a git repository (or git is not installed)"
+		c.Fix = "Run `git init` to use commit hooks, or keep indexes fresh with `logseq-claude-indexer watch`"
+		return c
+	}
+	hooksDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(repoPath, hooksDir)
+	}
+
+	for _, hook := range []string{"post-commit", "pre-commit"} {
+		path := filepath.Join(hooksDir, hook)
+		content, err := os.ReadFile(path)
+		if err != nil || !strings.Contains(string(content), hookBinary) {
+			continue
+		}
+
+		c.Detail = hook + " runs " + hookBinary
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0111 == 0 {
+			c.Status = StatusWarn
+			c.Detail += ", but isn't executable"
+			c.Fix = fmt.Sprintf("chmod +x %s", path)
+		}
+		return c
+	}
+
+	c.Status = StatusWarn
+	c.Detail = "no hook runs " + hookBinary
+	c.Fix = "Run `logseq-claude-indexer init` to install a post-commit hook (see Git Hook Integration in the README)"
+	return c
+}
+
+// checkBinaryOnPath verifies hooks will be able to find the indexer
+func checkBinaryOnPath() Check {
+	c := Check{Name: "Binary on PATH"}
+	path, err := exec.LookPath(hookBinary)
+	if err != nil {
+		c.Status = StatusWarn
+		c.Detail = hookBinary + " not found on PATH"
+		c.Fix = "Run `make install-user` and add ~/.local/bin to PATH, or use an absolute path in your hook"
+		return c
+	}
+	c.Detail = path
+	return c
+}
+
+// checkOutputDir verifies the output directory can be created and written
+func checkOutputDir(outputDir string) Check {
+	c := Check{Name: "Output directory"}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		c.Status = StatusFail
+		c.Detail = err.Error()
+		c.Fix = "Choose a writable location with --output"
+		return c
+	}
+	f, err := os.CreateTemp(outputDir, ".doctor-*")
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = fmt.Sprintf("%s is not writable: %v", outputDir, err)
+		c.Fix = fmt.Sprintf("Fix the permissions of %s or choose another --output", outputDir)
+		return c
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	c.Detail = outputDir + " is writable"
+	return c
+}
+
+// checkLock looks for a lock file left behind by a crashed run
+func checkLock(outputDir string) Check {
+	c := Check{Name: "Lock file"}
+	held, stale := lock.Check(outputDir)
+	switch {
+	case stale:
+		c.Status = StatusWarn
+		c.Detail = "stale lock left by a crashed run"
+		c.Fix = fmt.Sprintf("rm %s (the next run also removes it automatically)", filepath.Join(outputDir, lock.FileName))
+	case held:
+		c.Detail = "another run is generating indexes right now"
+	default:
+		c.Detail = "none"
+	}
+	return c
+}
+
+// checkManifest verifies the previous run's outputs are intact
+func checkManifest(outputDir, toolVersion string) Check {
+	c := Check{Name: "Previous output"}
+
+	manifest, err := writer.ReadManifest(outputDir)
+	if os.IsNotExist(err) {
+		c.Status = StatusWarn
+		c.Detail = "no indexes generated yet"
+		c.Fix = "Run `logseq-claude-indexer generate`"
+		return c
+	}
+	if err != nil {
+		c.Status = StatusFail
+		c.Detail = fmt.Sprintf("%s is corrupt: %v", writer.ManifestFileName, err)
+		c.Fix = "Run `logseq-claude-indexer generate` to rebuild it"
+		return c
+	}
+
+	var missing []string
+	for _, name := range manifest.Files {
+		if _, err := os.Stat(filepath.Join(outputDir, name)); err != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		c.Status = StatusWarn
+		c.Detail = fmt.Sprintf("%d files listed in %s are missing (%s)", len(missing), writer.ManifestFileName, strings.Join(missing, ", "))
+		c.Fix = "Run `logseq-claude-indexer generate` to restore them"
+		return c
+	}
+
+	c.Detail = fmt.Sprintf("%d files from %s", len(manifest.Files), manifest.GeneratedAt.Local().Format("2006-01-02 15:04"))
+	if manifest.ToolVersion != toolVersion {
+		c.Status = StatusWarn
+		c.Detail += fmt.Sprintf(", generated by version %s", manifest.ToolVersion)
+		c.Fix = "Run `logseq-claude-indexer generate` so the indexes match this version"
+	}
+	return c
+}
@@ -0,0 +1,112 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/writer"
+)
+
+// byName indexes checks by name
+func byName(checks []Check) map[string]Check {
+	result := make(map[string]Check)
+	for _, c := range checks {
+		result[c.Name] = c
+	}
+	return result
+}
+
+func TestRun_NotAGraph(t *testing.T) {
+	dir := t.TempDir()
+	checks := Run(Options{RepoPath: dir, OutputDir: filepath.Join(dir, "out")})
+
+	if len(checks) != 1 || checks[0].Status != StatusFail || checks[0].Fix == "" {
+		t.Fatalf("Expected a single failing graph check with a fix, got %+v", checks)
+	}
+	if !Failed(checks) {
+		t.Error("Expected Failed to report the failure")
+	}
+}
+
+func TestRun_HealthyGraph(t *testing.T) {
+	repo := t.TempDir()
+	write(t, repo, "pages/A.md", "- note")
+	write(t, repo, "journals/2025_11_06.md", "- entry")
+	write(t, repo, "logseq/config.edn", `{:favorites ["a"]}`)
+	out := filepath.Join(repo, ".claude", "indexes")
+
+	if err := writer.WriteManifest(&writer.Manifest{
+		ToolVersion: "1.0.0",
+		GeneratedAt: time.Now(),
+		Files:       []string{"dashboard.md"},
+	}, out); err != nil {
+		t.Fatal(err)
+	}
+	write(t, out, "dashboard.md", "# Dashboard")
+
+	checks := byName(Run(Options{RepoPath: repo, OutputDir: out, ToolVersion: "1.0.0"}))
+
+	for _, name := range []string{"Logseq graph", "config.edn", "Indexer config", "Output directory", "Lock file", "Previous output"} {
+		if checks[name].Status != StatusOK {
+			t.Errorf("%s: expected OK, got %+v", name, checks[name])
+		}
+	}
+	if got := checks["Logseq graph"].Detail; got != "1 pages, 1 journals" {
+		t.Errorf("Unexpected graph detail %q", got)
+	}
+	if got := checks["config.edn"].Detail; got != "1 favorites" {
+		t.Errorf("Unexpected config.edn detail %q", got)
+	}
+}
+
+func TestRun_Problems(t *testing.T) {
+	repo := t.TempDir()
+	write(t, repo, "pages/A.md", "- note")
+	write(t, repo, "logseq/config.edn", "{:favorites [\"a\"}\n")
+	write(t, repo, ".logseq-indexer.yml", "output:\n  duration_format: fortnights\n")
+	out := filepath.Join(repo, "out")
+
+	if err := writer.WriteManifest(&writer.Manifest{ToolVersion: "0.9.0", Files: []string{"gone.md"}}, out); err != nil {
+		t.Fatal(err)
+	}
+
+	checks := byName(Run(Options{RepoPath: repo, OutputDir: out, ToolVersion: "1.0.0"}))
+
+	if c := checks["config.edn"]; c.Status != StatusFail || !strings.Contains(c.Detail, "line 1") {
+		t.Errorf("Expected config.edn failure with a line number, got %+v", c)
+	}
+	if c := checks["Indexer config"]; c.Status != StatusFail {
+		t.Errorf("Expected invalid indexer config to fail, got %+v", c)
+	}
+	if c := checks["Previous output"]; c.Status != StatusWarn || !strings.Contains(c.Detail, "gone.md") {
+		t.Errorf("Expected missing output files to be reported, got %+v", c)
+	}
+	for _, c := range checks {
+		if c.Status != StatusOK && c.Fix == "" {
+			t.Errorf("%s: expected a suggested fix, got %+v", c.Name, c)
+		}
+	}
+}
+
+func TestCheckManifest_Corrupt(t *testing.T) {
+	out := t.TempDir()
+	write(t, out, writer.ManifestFileName, "{not json")
+
+	if c := checkManifest(out, "1.0.0"); c.Status != StatusFail {
+		t.Errorf("Expected corrupt manifest to fail, got %+v", c)
+	}
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
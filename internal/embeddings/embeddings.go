@@ -0,0 +1,242 @@
+package embeddings
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// FileName is the JSONL export of page chunks and their vectors
+const FileName = "embeddings.jsonl"
+
+// DefaultBatchSize is how many chunks are sent to the provider per call
+const DefaultBatchSize = 32
+
+// maxChunkRunes caps a chunk's text; longer blocks are truncated
+const maxChunkRunes = 2000
+
+// Chunk is a top-level block of a page with its children
+type Chunk struct {
+	Page string
+	File string
+	Line int // First line of the block (1-indexed)
+	Text string
+}
+
+// input is the text sent to the provider: the page name gives short blocks context
+func (c Chunk) input() string {
+	return c.Page + ": " + c.Text
+}
+
+// Record is one line of embeddings.jsonl
+type Record struct {
+	Page   string    `json:"page"`
+	File   string    `json:"file"`
+	Line   int       `json:"line"`
+	Text   string    `json:"text"`
+	Hash   string    `json:"hash"` // Of the model and embedded input, to reuse vectors between runs
+	Vector []float64 `json:"vector"`
+}
+
+// ChunkPage splits a page into its top-level blocks. Lines before the first
+// bullet (page properties or a preamble) form a chunk of their own.
+func ChunkPage(content, filePath string) []Chunk {
+	page := models.PageName(filePath)
+	var chunks []Chunk
+	var lines []string
+	start := 1
+
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(lines, "\n"))
+		if runes := []rune(text); len(runes) > maxChunkRunes {
+			text = string(runes[:maxChunkRunes])
+		}
+		if len([]rune(text)) >= 3 {
+			chunks = append(chunks, Chunk{Page: page, File: filePath, Line: start, Text: text})
+		}
+		lines = nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, "- ") || line == "-" {
+			flush()
+			start = i + 1
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return chunks
+}
+
+// Embed returns a record per chunk. Vectors from previous records with the
+// same hash are reused; the rest are requested from the provider in batches.
+// It also returns how many chunks were newly embedded.
+func Embed(ctx context.Context, provider Provider, model string, chunks []Chunk, previous []Record, batchSize int) ([]Record, int, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	cached := make(map[string][]float64, len(previous))
+	for _, r := range previous {
+		cached[r.Hash] = r.Vector
+	}
+
+	records := make([]Record, len(chunks))
+	var pending []int // Indexes of chunks without a cached vector
+	for i, chunk := range chunks {
+		hash := inputHash(model, chunk.input())
+		records[i] = Record{Page: chunk.Page, File: chunk.File, Line: chunk.Line, Text: chunk.Text, Hash: hash}
+		if vector, ok := cached[hash]; ok {
+			records[i].Vector = vector
+		} else {
+			pending = append(pending, i)
+		}
+	}
+
+	for start := 0; start < len(pending); start += batchSize {
+		batch := pending[start:min(start+batchSize, len(pending))]
+		inputs := make([]string, len(batch))
+		for j, i := range batch {
+			inputs[j] = chunks[i].input()
+		}
+
+		vectors, err := provider.Embed(ctx, inputs)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(vectors) != len(inputs) {
+			return nil, 0, fmt.Errorf("provider returned %d vectors for %d inputs", len(vectors), len(inputs))
+		}
+		for j, i := range batch {
+			records[i].Vector = vectors[j]
+		}
+	}
+
+	return records, len(pending), nil
+}
+
+// inputHash identifies an embedded input, so a changed model re-embeds everything
+func inputHash(model, input string) string {
+	sum := sha256.Sum256([]byte(model + "\x00" + input))
+	return hex.EncodeToString(sum[:8])
+}
+
+// WriteJSONL writes records to embeddings.jsonl in outputDir, one per line
+func WriteJSONL(records []Record, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, FileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("encoding %s:%d: %w", r.File, r.Line, err)
+		}
+	}
+	return w.Flush()
+}
+
+// ReadJSONL reads embeddings.jsonl from outputDir. A missing file yields no records.
+func ReadJSONL(outputDir string) ([]Record, error) {
+	f, err := os.Open(filepath.Join(outputDir, FileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var r Record
+		if err := dec.Decode(&r); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", FileName, err)
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// Result is a record ranked against a query
+type Result struct {
+	Record
+	Score float64
+}
+
+// Search ranks records by cosine similarity to the query vector, best first
+func Search(records []Record, query []float64, limit int) []Result {
+	results := make([]Result, 0, len(records))
+	for _, r := range records {
+		results = append(results, Result{Record: r, Score: Cosine(r.Vector, query)})
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// KeywordSearch ranks records by how many distinct query words their text
+// contains, then by total occurrences. Records matching no word are dropped.
+func KeywordSearch(records []Record, query string, limit int) []Result {
+	words := strings.Fields(strings.ToLower(query))
+	var results []Result
+	for _, r := range records {
+		text := strings.ToLower(r.Text)
+		matched, occurrences := 0, 0
+		for _, word := range words {
+			if n := strings.Count(text, word); n > 0 {
+				matched++
+				occurrences += n
+			}
+		}
+		if matched > 0 {
+			// Distinct words dominate; occurrences only break ties
+			score := float64(matched) + float64(occurrences)/float64(occurrences+1)/2
+			results = append(results, Result{Record: r, Score: score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// Cosine returns the cosine similarity of two vectors, 0 if their lengths
+// differ or either is zero
+func Cosine(a, b []float64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
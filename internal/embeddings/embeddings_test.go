@@ -0,0 +1,219 @@
+package embeddings
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeProvider embeds each text as [len(text), 1] and counts the texts it saw
+type fakeProvider struct {
+	calls int
+	texts int
+}
+
+func (f *fakeProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	f.calls++
+	f.texts += len(texts)
+	vectors := make([][]float64, len(texts))
+	for i, text := range texts {
+		vectors[i] = []float64{float64(len(text)), 1}
+	}
+	return vectors, nil
+}
+
+func TestChunkPage(t *testing.T) {
+	content := "tags:: project\n\n- First block\n  - child\n- Second block\n-\n"
+	chunks := ChunkPage(content, "pages/Project Phoenix.md")
+
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks (properties and two blocks; the empty block skipped), got %d: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Text != "tags:: project" || chunks[0].Line != 1 {
+		t.Errorf("Unexpected preamble chunk: %+v", chunks[0])
+	}
+	if chunks[1].Text != "- First block\n  - child" || chunks[1].Line != 3 {
+		t.Errorf("Expected children to stay with their block, got %+v", chunks[1])
+	}
+	if chunks[2].Page != "Project Phoenix" || chunks[2].Line != 5 {
+		t.Errorf("Unexpected page or line: %+v", chunks[2])
+	}
+}
+
+func TestEmbed_ReusesUnchangedVectors(t *testing.T) {
+	chunks := []Chunk{
+		{Page: "A", File: "pages/A.md", Line: 1, Text: "- one"},
+		{Page: "A", File: "pages/A.md", Line: 2, Text: "- two"},
+		{Page: "B", File: "pages/B.md", Line: 1, Text: "- three"},
+	}
+	provider := &fakeProvider{}
+
+	records, embedded, err := Embed(context.Background(), provider, "m1", chunks, nil, 2)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if embedded != 3 || provider.calls != 2 {
+		t.Errorf("Expected 3 chunks in 2 batches, got %d in %d", embedded, provider.calls)
+	}
+
+	// One edited block: only it is embedded again
+	chunks[1].Text = "- two, edited"
+	provider = &fakeProvider{}
+	records, embedded, err = Embed(context.Background(), provider, "m1", chunks, records, 2)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if embedded != 1 || provider.texts != 1 {
+		t.Errorf("Expected only the edited chunk to be embedded, got %d", embedded)
+	}
+	if records[1].Vector[0] != float64(len("A: - two, edited")) {
+		t.Errorf("Expected a fresh vector for the edited chunk, got %v", records[1].Vector)
+	}
+
+	// A different model invalidates every vector
+	_, embedded, err = Embed(context.Background(), &fakeProvider{}, "m2", chunks, records, 2)
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if embedded != 3 {
+		t.Errorf("Expected a model change to re-embed everything, got %d", embedded)
+	}
+}
+
+func TestJSONL_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+
+	records, err := ReadJSONL(dir)
+	if err != nil || records != nil {
+		t.Fatalf("Expected no records and no error for a missing file, got %v, %v", records, err)
+	}
+
+	want := []Record{{Page: "A", File: "pages/A.md", Line: 3, Text: "- one", Hash: "abc", Vector: []float64{0.5, -1}}}
+	if err := WriteJSONL(want, dir); err != nil {
+		t.Fatalf("WriteJSONL failed: %v", err)
+	}
+	got, err := ReadJSONL(dir)
+	if err != nil {
+		t.Fatalf("ReadJSONL failed: %v", err)
+	}
+	if len(got) != 1 || got[0].Line != 3 || got[0].Vector[1] != -1 {
+		t.Errorf("Round trip changed the records: %+v", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	records := []Record{
+		{Page: "Far", Vector: []float64{0, 1}},
+		{Page: "Near", Vector: []float64{1, 0.1}},
+		{Page: "Broken", Vector: []float64{1}},
+	}
+
+	results := Search(records, []float64{1, 0}, 2)
+	if len(results) != 2 || results[0].Page != "Near" || results[1].Page != "Far" {
+		t.Fatalf("Unexpected ranking: %+v", results)
+	}
+	if math.Abs(Cosine([]float64{1, 2}, []float64{2, 4})-1) > 1e-9 {
+		t.Error("Expected parallel vectors to have similarity 1")
+	}
+	if Cosine([]float64{0, 0}, []float64{1, 1}) != 0 {
+		t.Error("Expected a zero vector to have similarity 0")
+	}
+}
+
+func TestKeywordSearch(t *testing.T) {
+	records := []Record{
+		{Page: "One word", Text: "rollback rollback rollback"},
+		{Page: "Both words", Text: "The Rollback plan"},
+		{Page: "Neither", Text: "nothing here"},
+	}
+
+	results := KeywordSearch(records, "rollback plan", 0)
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 matches, got %+v", results)
+	}
+	if results[0].Page != "Both words" {
+		t.Errorf("Expected matching more words to outrank repetition, got %s first", results[0].Page)
+	}
+}
+
+func TestHTTPProvider(t *testing.T) {
+	t.Setenv("EMBED_TEST_KEY", "secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Expected the header to expand from the environment, got %q", got)
+		}
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Bad request body: %v", err)
+		}
+		if req.Model != "test-model" || len(req.Input) != 2 {
+			t.Errorf("Unexpected request: %+v", req)
+		}
+		// OpenAI's reply shape
+		w.Write([]byte(`{"data":[{"embedding":[1,0]},{"embedding":[0,1]}]}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(Options{URL: server.URL, Model: "test-model", Headers: map[string]string{"Authorization": "Bearer $EMBED_TEST_KEY"}})
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+	vectors, err := provider.Embed(context.Background(), []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vectors) != 2 || vectors[1][1] != 1 {
+		t.Errorf("Unexpected vectors: %v", vectors)
+	}
+}
+
+func TestHTTPProvider_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider, _ := NewProvider(Options{URL: server.URL})
+	if _, err := provider.Embed(context.Background(), []string{"a"}); err == nil || !strings.Contains(err.Error(), "model not found") {
+		t.Errorf("Expected the endpoint's error to be reported, got %v", err)
+	}
+}
+
+func TestCommandProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+
+	// Ollama's reply shape; the request on stdin is discarded
+	provider, err := NewProvider(Options{Command: `cat > /dev/null; echo '{"embeddings":[[0.25,0.75]]}'`})
+	if err != nil {
+		t.Fatalf("NewProvider failed: %v", err)
+	}
+	vectors, err := provider.Embed(context.Background(), []string{"a"})
+	if err != nil {
+		t.Fatalf("Embed failed: %v", err)
+	}
+	if len(vectors) != 1 || vectors[0][1] != 0.75 {
+		t.Errorf("Unexpected vectors: %v", vectors)
+	}
+
+	failing, _ := NewProvider(Options{Command: "echo boom >&2; exit 1"})
+	if _, err := failing.Embed(context.Background(), []string{"a"}); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("Expected stderr in the error, got %v", err)
+	}
+}
+
+func TestNewProvider_RequiresOneSource(t *testing.T) {
+	if _, err := NewProvider(Options{}); err == nil {
+		t.Error("Expected an error with no command or URL")
+	}
+	if _, err := NewProvider(Options{Command: "x", URL: "http://x"}); err == nil {
+		t.Error("Expected an error with both a command and a URL")
+	}
+}
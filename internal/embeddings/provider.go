@@ -0,0 +1,154 @@
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Provider turns texts into vectors, one per text in the same order
+type Provider interface {
+	Embed(ctx context.Context, texts []string) ([][]float64, error)
+}
+
+// Options selects and configures a provider. Exactly one of Command and URL is set.
+type Options struct {
+	Command string            // Shell command reading a request on stdin and writing a response on stdout
+	URL     string            // HTTP endpoint receiving the request as a POST body
+	Model   string            // Sent as "model" when set
+	Headers map[string]string // HTTP headers; values expand $VARIABLES from the environment
+}
+
+// request is the JSON sent to a provider. It's the shape OpenAI-compatible
+// and Ollama embedding endpoints accept.
+type request struct {
+	Model string   `json:"model,omitempty"`
+	Input []string `json:"input"`
+}
+
+// response accepts both common reply shapes: {"embeddings": [[...]]} and
+// OpenAI's {"data": [{"embedding": [...]}]}
+type response struct {
+	Embeddings [][]float64 `json:"embeddings"`
+	Data       []struct {
+		Embedding []float64 `json:"embedding"`
+	} `json:"data"`
+}
+
+// vectors returns the response's vectors in input order
+func (r response) vectors() [][]float64 {
+	if len(r.Embeddings) > 0 {
+		return r.Embeddings
+	}
+	vectors := make([][]float64, len(r.Data))
+	for i, d := range r.Data {
+		vectors[i] = d.Embedding
+	}
+	return vectors
+}
+
+// NewProvider creates the provider the options describe
+func NewProvider(opts Options) (Provider, error) {
+	switch {
+	case opts.Command != "" && opts.URL != "":
+		return nil, errors.New("set either an embedding command or a URL, not both")
+	case opts.Command != "":
+		return &CommandProvider{Command: opts.Command, Model: opts.Model}, nil
+	case opts.URL != "":
+		return &HTTPProvider{
+			HTTP:    &http.Client{Timeout: 60 * time.Second},
+			URL:     opts.URL,
+			Model:   opts.Model,
+			Headers: opts.Headers,
+		}, nil
+	}
+	return nil, errors.New("no embedding command or URL configured")
+}
+
+// CommandProvider runs a shell command per batch
+type CommandProvider struct {
+	Command string
+	Model   string
+}
+
+// Embed implements Provider
+func (p *CommandProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(request{Model: p.Model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", p.Command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", p.Command)
+	}
+	cmd.Stdin = bytes.NewReader(body)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running embedding command: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return decodeResponse(out)
+}
+
+// HTTPProvider POSTs each batch to an endpoint
+type HTTPProvider struct {
+	HTTP    *http.Client
+	URL     string
+	Model   string
+	Headers map[string]string
+}
+
+// Embed implements Provider
+func (p *HTTPProvider) Embed(ctx context.Context, texts []string) ([][]float64, error) {
+	body, err := json.Marshal(request{Model: p.Model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range p.Headers {
+		req.Header.Set(name, os.ExpandEnv(value))
+	}
+
+	resp, err := p.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling embedding endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading embedding response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embedding endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(out)))
+	}
+	return decodeResponse(out)
+}
+
+// decodeResponse parses a provider's reply
+func decodeResponse(out []byte) ([][]float64, error) {
+	var r response
+	if err := json.Unmarshal(out, &r); err != nil {
+		return nil, fmt.Errorf("decoding embedding response: %w", err)
+	}
+	return r.vectors(), nil
+}
@@ -0,0 +1,274 @@
+package export
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// decodeEDN parses a single EDN value into the same shapes encoding/json
+// produces: maps become map[string]any, vectors, lists, and sets []any,
+// numbers float64, and keywords, symbols, and characters strings. Map keys
+// that are keywords lose their colon and namespace (:block/content is
+// "content"). Tagged literals such as #uuid "..." decode to their value.
+func decodeEDN(data []byte) (any, error) {
+	d := &ednDecoder{data: string(data)}
+	value, err := d.value()
+	if err != nil {
+		return nil, err
+	}
+	d.skipSpace()
+	if d.pos < len(d.data) {
+		return nil, d.errorf("unexpected %q after value", d.data[d.pos])
+	}
+	return value, nil
+}
+
+// ednDecoder reads EDN values from data, tracking the position for errors
+type ednDecoder struct {
+	data string
+	pos  int
+}
+
+func (d *ednDecoder) errorf(format string, args ...any) error {
+	line := 1 + strings.Count(d.data[:d.pos], "\n")
+	return fmt.Errorf("EDN line %d: %s", line, fmt.Sprintf(format, args...))
+}
+
+// skipSpace skips whitespace, commas, ; comments, and #_ discarded values
+func (d *ednDecoder) skipSpace() {
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch {
+		case c == ',' || c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			d.pos++
+		case c == ';':
+			for d.pos < len(d.data) && d.data[d.pos] != '\n' {
+				d.pos++
+			}
+		case strings.HasPrefix(d.data[d.pos:], "#_"):
+			d.pos += 2
+			if _, err := d.value(); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// value reads the next value
+func (d *ednDecoder) value() (any, error) {
+	d.skipSpace()
+	if d.pos >= len(d.data) {
+		return nil, d.errorf("unexpected end of input")
+	}
+
+	switch c := d.data[d.pos]; {
+	case c == '{':
+		d.pos++
+		return d.mapValue()
+	case c == '[':
+		d.pos++
+		return d.sequence(']')
+	case c == '(':
+		d.pos++
+		return d.sequence(')')
+	case c == '"':
+		return d.stringValue()
+	case c == '\\':
+		return d.character()
+	case c == '#':
+		d.pos++
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unexpected end of input after #")
+		}
+		if d.data[d.pos] == '{' {
+			d.pos++
+			return d.sequence('}')
+		}
+		// Tagged literal: the tag is dropped and the value kept
+		if _, err := d.token(); err != nil {
+			return nil, err
+		}
+		return d.value()
+	case c == ']' || c == ')' || c == '}':
+		return nil, d.errorf("unexpected %q", c)
+	}
+
+	tok, err := d.token()
+	if err != nil {
+		return nil, err
+	}
+	return atom(tok), nil
+}
+
+// mapValue reads key/value pairs up to the closing brace
+func (d *ednDecoder) mapValue() (any, error) {
+	m := make(map[string]any)
+	for {
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated map")
+		}
+		if d.data[d.pos] == '}' {
+			d.pos++
+			return m, nil
+		}
+
+		key, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(key)] = value
+	}
+}
+
+// sequence reads values up to the closing delimiter
+func (d *ednDecoder) sequence(end byte) ([]any, error) {
+	items := []any{}
+	for {
+		d.skipSpace()
+		if d.pos >= len(d.data) {
+			return nil, d.errorf("unterminated collection, expected %q", end)
+		}
+		if d.data[d.pos] == end {
+			d.pos++
+			return items, nil
+		}
+
+		item, err := d.value()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+}
+
+// stringValue reads a double-quoted string with escapes
+func (d *ednDecoder) stringValue() (string, error) {
+	var b strings.Builder
+	d.pos++ // Opening quote
+	for d.pos < len(d.data) {
+		c := d.data[d.pos]
+		switch c {
+		case '"':
+			d.pos++
+			return b.String(), nil
+		case '\\':
+			d.pos++
+			if d.pos >= len(d.data) {
+				return "", d.errorf("unterminated string")
+			}
+			switch e := d.data[d.pos]; e {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case 'u':
+				if d.pos+5 > len(d.data) {
+					return "", d.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(d.data[d.pos+1:d.pos+5], 16, 32)
+				if err != nil {
+					return "", d.errorf("invalid unicode escape")
+				}
+				b.WriteRune(rune(r))
+				d.pos += 4
+			default:
+				b.WriteByte(e) // \" \\ and anything else stand for themselves
+			}
+			d.pos++
+		default:
+			b.WriteByte(c)
+			d.pos++
+		}
+	}
+	return "", d.errorf("unterminated string")
+}
+
+// character reads a character literal such as \a or \newline
+func (d *ednDecoder) character() (string, error) {
+	d.pos++ // Backslash
+	if d.pos >= len(d.data) {
+		return "", d.errorf("unterminated character")
+	}
+	_, size := utf8.DecodeRuneInString(d.data[d.pos:])
+	start := d.pos
+	d.pos += size
+	for d.pos < len(d.data) && !isDelimiter(d.data[d.pos]) {
+		d.pos++
+	}
+
+	switch name := d.data[start:d.pos]; name {
+	case "newline":
+		return "\n", nil
+	case "space":
+		return " ", nil
+	case "tab":
+		return "\t", nil
+	case "return":
+		return "\r", nil
+	default:
+		return name, nil
+	}
+}
+
+// token reads a keyword, symbol, or number up to the next delimiter
+func (d *ednDecoder) token() (string, error) {
+	start := d.pos
+	for d.pos < len(d.data) && !isDelimiter(d.data[d.pos]) {
+		d.pos++
+	}
+	if d.pos == start {
+		if d.pos >= len(d.data) {
+			return "", d.errorf("unexpected end of input")
+		}
+		return "", d.errorf("unexpected %q", d.data[d.pos])
+	}
+	return d.data[start:d.pos], nil
+}
+
+// isDelimiter reports whether c ends a token
+func isDelimiter(c byte) bool {
+	return c < utf8.RuneSelf && (unicode.IsSpace(rune(c)) || strings.IndexByte(`,;"()[]{}`, c) >= 0)
+}
+
+// atom converts a token to nil, a bool, a number, or a string
+func atom(tok string) any {
+	switch tok {
+	case "nil":
+		return nil
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if n, err := strconv.ParseFloat(strings.TrimRight(tok, "NM"), 64); err == nil {
+		return n
+	}
+	return tok
+}
+
+// mapKey turns a decoded key into a string: :block/content becomes "content"
+func mapKey(key any) string {
+	s, ok := key.(string)
+	if !ok {
+		return fmt.Sprint(key)
+	}
+	if strings.HasPrefix(s, ":") {
+		s = s[1:]
+		if i := strings.LastIndex(s, "/"); i >= 0 {
+			s = s[i+1:]
+		}
+	}
+	return s
+}
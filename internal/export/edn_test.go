@@ -0,0 +1,69 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeEDN(t *testing.T) {
+	input := `{:version 1 ; a comment
+	 :blocks ({:block/id #uuid "6551e3c2-0000-4000-8000-000000000000"
+	           :block/page-name "Projects/App"
+	           :block/properties {:tags [:work "app"], :rating 4.5}
+	           :block/children [{:block/content "TODO Ship \"v2\"\nSCHEDULED: <2025-11-10 Mon>"
+	                             :block/collapsed? false
+	                             #_ :ignored #_ "value"
+	                             :block/children []}]}
+	          #{nil \a})}`
+
+	got, err := decodeEDN([]byte(input))
+	if err != nil {
+		t.Fatalf("decodeEDN failed: %v", err)
+	}
+
+	want := map[string]any{
+		"version": float64(1),
+		"blocks": []any{
+			map[string]any{
+				"id":         "6551e3c2-0000-4000-8000-000000000000",
+				"page-name":  "Projects/App",
+				"properties": map[string]any{"tags": []any{":work", "app"}, "rating": 4.5},
+				"children": []any{
+					map[string]any{
+						"content":    "TODO Ship \"v2\"\nSCHEDULED: <2025-11-10 Mon>",
+						"collapsed?": false,
+						"children":   []any{},
+					},
+				},
+			},
+			[]any{nil, "a"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %#v, got %#v", want, got)
+	}
+}
+
+func TestDecodeEDN_Errors(t *testing.T) {
+	for _, input := range []string{`{:a 1`, `[1 2`, `"open`, `{:a 1} extra`, `)`, `#`, `[1 #`, `{:a #`, `#uuid`, `# `} {
+		if _, err := decodeEDN([]byte(input)); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func FuzzDecodeEDN(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		`{:blocks [{:block/content "TODO Write report" :block/uuid #uuid "6543a1b2-0000-0000-0000-000000000000"}]}`,
+		`[1 2.5 -3 nil true false \a \newline :kw sym "s\u00e9"]`,
+		`#{1 2} (a b) ; comment`,
+		`#`, `[1 #`, `{:a #`, `"\u12`,
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		// Errors are fine; panics and hangs are not
+		decodeEDN([]byte(input))
+	})
+}
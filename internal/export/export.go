@@ -0,0 +1,264 @@
+// Package export reads Logseq graph exports (Export graph → EDN or JSON, and
+// the database version's exports) and renders them as a journals/ and pages/
+// markdown tree, so an export is indexed exactly like a file-based graph.
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/parser"
+)
+
+// Page is one page of an export
+type Page struct {
+	Name       string
+	Journal    bool
+	Date       time.Time // Journal date, zero for pages
+	Updated    time.Time // Last update recorded in the export, zero if unknown
+	Properties map[string]string
+	Blocks     []Block
+}
+
+// Block is one block of a page, with its nested blocks
+type Block struct {
+	Content    string
+	Properties map[string]string // Only those not already written in Content
+	Children   []Block
+}
+
+// Load reads a Logseq export. Files ending in .edn are read as EDN, anything
+// else as JSON.
+func Load(path string) ([]Page, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root any
+	if strings.EqualFold(filepath.Ext(path), ".edn") {
+		root, err = decodeEDN(data)
+	} else {
+		err = json.Unmarshal(data, &root)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading export %s: %w", path, err)
+	}
+
+	// {:version 1 :blocks [...]}, or a bare list of pages
+	items, ok := root.([]any)
+	if m, isMap := root.(map[string]any); isMap {
+		items, ok = m["blocks"].([]any)
+	}
+	if !ok {
+		return nil, fmt.Errorf("reading export %s: no pages found (expected a \"blocks\" list)", path)
+	}
+
+	var pages []Page
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		if page, ok := toPage(m); ok {
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
+
+// toPage maps an exported page, skipping entries without a name
+func toPage(m map[string]any) (Page, bool) {
+	name := firstString(m, "original-name", "page-name", "title", "name")
+	if name == "" {
+		return Page{}, false
+	}
+
+	page := Page{Name: name, Properties: properties(m["properties"])}
+	if day, ok := m["journal-day"].(float64); ok {
+		// The database version records journal days as yyyymmdd
+		if date, err := time.Parse("20060102", strconv.Itoa(int(day))); err == nil {
+			page.Journal, page.Date = true, date
+		}
+	} else if date, ok := parser.ParseDate(name); ok {
+		page.Journal, page.Date = true, date
+	}
+	if ms, ok := m["updated-at"].(float64); ok && ms > 0 {
+		page.Updated = time.UnixMilli(int64(ms))
+	}
+
+	for _, child := range list(m["children"]) {
+		c, ok := child.(map[string]any)
+		if !ok {
+			continue
+		}
+		// Page properties live in a pre-block, already read from the page itself
+		if preBlock, _ := c["pre-block?"].(bool); preBlock && len(page.Properties) > 0 {
+			continue
+		}
+		page.Blocks = append(page.Blocks, toBlock(c))
+	}
+	return page, true
+}
+
+// toBlock maps an exported block and its children
+func toBlock(m map[string]any) Block {
+	block := Block{Content: firstString(m, "content", "title")}
+	for key, value := range properties(m["properties"]) {
+		if strings.Contains(block.Content, key+"::") {
+			continue
+		}
+		if block.Properties == nil {
+			block.Properties = make(map[string]string)
+		}
+		block.Properties[key] = value
+	}
+	for _, child := range list(m["children"]) {
+		if c, ok := child.(map[string]any); ok {
+			block.Children = append(block.Children, toBlock(c))
+		}
+	}
+	return block
+}
+
+// FileName returns the path a page is rendered to, relative to the graph
+// root: journals/2025_11_06.md, or pages/Projects___App.md for "Projects/App"
+func (p Page) FileName() string {
+	if p.Journal {
+		return filepath.Join("journals", p.Date.Format("2006_01_02")+".md")
+	}
+	name := strings.ReplaceAll(p.Name, "/", "___")
+	name = strings.NewReplacer(`\`, "_", ":", "_", "*", "_", "?", "_", `"`, "_", "<", "_", ">", "_", "|", "_").Replace(name)
+	return filepath.Join("pages", name+".md")
+}
+
+// Markdown renders the page as Logseq markdown: page properties, then one
+// "- " bullet per block, children indented by a tab
+func (p Page) Markdown() string {
+	var b strings.Builder
+	for _, key := range sortedKeys(p.Properties) {
+		fmt.Fprintf(&b, "%s:: %s\n", key, p.Properties[key])
+	}
+	for _, block := range p.Blocks {
+		writeBlock(&b, block, 0)
+	}
+	return b.String()
+}
+
+// writeBlock writes a block bullet, its continuation lines, and its children
+func writeBlock(b *strings.Builder, block Block, depth int) {
+	indent := strings.Repeat("\t", depth)
+	lines := strings.Split(strings.TrimRight(block.Content, "\n"), "\n")
+	fmt.Fprintf(b, "%s- %s\n", indent, lines[0])
+	for _, line := range lines[1:] {
+		fmt.Fprintf(b, "%s  %s\n", indent, line)
+	}
+	for _, key := range sortedKeys(block.Properties) {
+		fmt.Fprintf(b, "%s  %s:: %s\n", indent, key, block.Properties[key])
+	}
+	for _, child := range block.Children {
+		writeBlock(b, child, depth+1)
+	}
+}
+
+// Render writes each page as a markdown file under dir (see Page.FileName),
+// dated by its last update, or modTime when the export doesn't record one.
+// Pages mapping to the same file are merged in export order.
+func Render(pages []Page, dir string, modTime time.Time) error {
+	contents := make(map[string]string)
+	dates := make(map[string]time.Time)
+	var order []string
+	for _, page := range pages {
+		name := page.FileName()
+		if _, seen := contents[name]; !seen {
+			order = append(order, name)
+		}
+		contents[name] += page.Markdown()
+		if page.Updated.After(dates[name]) {
+			dates[name] = page.Updated
+		}
+	}
+
+	for _, name := range order {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("creating directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(contents[name]), 0644); err != nil {
+			return fmt.Errorf("writing %s: %w", name, err)
+		}
+		date := dates[name]
+		if date.IsZero() {
+			date = modTime
+		}
+		if err := os.Chtimes(path, date, date); err != nil {
+			return fmt.Errorf("dating %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// properties converts an exported property map to strings, with lists
+// joined by commas (tags:: a, b)
+func properties(value any) map[string]string {
+	m, ok := value.(map[string]any)
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	props := make(map[string]string, len(m))
+	for key, v := range m {
+		props[key] = propertyValue(v)
+	}
+	return props
+}
+
+// propertyValue formats one property value
+func propertyValue(v any) string {
+	switch v := v.(type) {
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = propertyValue(item)
+		}
+		return strings.Join(parts, ", ")
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case nil:
+		return ""
+	case string:
+		return strings.TrimPrefix(v, ":")
+	}
+	return fmt.Sprint(v)
+}
+
+// firstString returns the first of keys holding a non-empty string
+func firstString(m map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := m[key].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// list returns value as a list, or nil
+func list(value any) []any {
+	items, _ := value.([]any)
+	return items
+}
+
+// sortedKeys returns a property map's keys in order, for stable output
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
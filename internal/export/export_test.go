@@ -0,0 +1,116 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoad_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.json")
+	data := `{"version": 1, "blocks": [
+		{"page-name": "nov 10th, 2025", "children": [
+			{"content": "DONE Review [[Projects/App]]", "children": [
+				{"content": "notes\nsecond line", "properties": {"owner": "sam"}}
+			]}
+		]},
+		{"original-name": "Projects/App", "page-name": "projects/app", "properties": {"tags": ["work", "app"]},
+		 "updated-at": 1762732800000, "children": [
+			{"content": "tags:: work, app", "pre-block?": true},
+			{"content": "TODO Ship it\nowner:: sam", "properties": {"owner": "sam"}}
+		]}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("Expected 2 pages, got %d", len(pages))
+	}
+
+	journal, page := pages[0], pages[1]
+	if !journal.Journal || journal.FileName() != filepath.Join("journals", "2025_11_10.md") {
+		t.Errorf("Expected a journal for Nov 10th, got %+v (%s)", journal, journal.FileName())
+	}
+	if want := "- DONE Review [[Projects/App]]\n\t- notes\n\t  second line\n\t  owner:: sam\n"; journal.Markdown() != want {
+		t.Errorf("Expected journal markdown %q, got %q", want, journal.Markdown())
+	}
+
+	if page.Journal || page.FileName() != filepath.Join("pages", "Projects___App.md") {
+		t.Errorf("Expected page Projects/App, got %+v (%s)", page, page.FileName())
+	}
+	if want := "tags:: work, app\n- TODO Ship it\n  owner:: sam\n"; page.Markdown() != want {
+		t.Errorf("Expected page markdown %q, got %q", want, page.Markdown())
+	}
+	if !page.Updated.Equal(time.UnixMilli(1762732800000)) {
+		t.Errorf("Expected updated-at to be read, got %s", page.Updated)
+	}
+}
+
+func TestLoad_EDNDatabaseVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.edn")
+	data := `{:blocks [{:block/title "Nov 6th, 2025" :block/journal-day 20251106
+	                   :block/children [{:block/title "NOW Write [[Report]]"}]}
+	                  {:block/title "Report" :block/children []}
+	                  {:block/uuid #uuid "6551e3c2-0000-4000-8000-000000000000"}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pages, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("Expected 2 named pages, got %d", len(pages))
+	}
+	if !pages[0].Journal || !pages[0].Date.Equal(time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected a journal for 2025-11-06, got %+v", pages[0])
+	}
+	if pages[0].Markdown() != "- NOW Write [[Report]]\n" {
+		t.Errorf("Expected block titles as content, got %q", pages[0].Markdown())
+	}
+}
+
+func TestLoad_NoPages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "graph.json")
+	if err := os.WriteFile(path, []byte(`{"version": 1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for an export without pages")
+	}
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	exported := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	pages := []Page{
+		{Name: "Alpha", Blocks: []Block{{Content: "first"}}},
+		{Name: "Nov 10th, 2025", Journal: true, Date: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), Blocks: []Block{{Content: "TODO Call"}}},
+	}
+
+	if err := Render(pages, dir, exported); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "journals", "2025_11_10.md"))
+	if err != nil {
+		t.Fatalf("Expected journal file: %v", err)
+	}
+	if string(content) != "- TODO Call\n" {
+		t.Errorf("Expected journal content, got %q", content)
+	}
+	info, err := os.Stat(filepath.Join(dir, "pages", "Alpha.md"))
+	if err != nil {
+		t.Fatalf("Expected page file: %v", err)
+	}
+	if !info.ModTime().Equal(exported) {
+		t.Errorf("Expected the export time as mtime, got %s", info.ModTime())
+	}
+}
@@ -0,0 +1,111 @@
+package gitlog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/scanner"
+)
+
+// FileDates records when a file first and last appeared in git history
+type FileDates struct {
+	Created  time.Time // Author date of the first commit touching the file
+	Modified time.Time // Author date of the most recent commit touching the file
+	Commits  []Commit  // Every commit touching the file, newest first
+}
+
+// Commit is one change to a file
+type Commit struct {
+	Date  time.Time
+	Lines int // Lines added plus lines deleted
+}
+
+// commitMarker prefixes commit date lines in the log output (--format=%x00...)
+// so they can't be confused with file names
+const commitMarker = "\x00"
+
+// FileHistory reads the commit dates of every markdown file in repoPath's git
+// history, keyed by path relative to repoPath (forward slashes). If repoPath
+// isn't inside a git repository (or git isn't installed) an empty map is returned.
+func FileHistory(repoPath string) (map[string]FileDates, error) {
+	history := make(map[string]FileDates)
+
+	if _, err := exec.LookPath("git"); err != nil {
+		return history, nil
+	}
+	check := exec.Command("git", "rev-parse", "--is-inside-work-tree")
+	check.Dir = repoPath
+	if err := check.Run(); err != nil {
+		return history, nil
+	}
+
+	// core.quotePath=false keeps non-ASCII names (Maßnahmen.md) unescaped
+	args := []string{"-c", "core.quotePath=false", "log", "--format=%x00%aI", "--numstat", "--relative", "--no-renames", "--"}
+	for _, ext := range scanner.Extensions() {
+		args = append(args, "*"+ext)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// A repository without commits has no history yet
+		if strings.Contains(stderr.String(), "does not have any commits") {
+			return history, nil
+		}
+		return nil, fmt.Errorf("reading git history: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseLog(out), nil
+}
+
+// parseLog parses `git log --format=<marker>%aI --numstat` output, newest commit first
+func parseLog(out []byte) map[string]FileDates {
+	history := make(map[string]FileDates)
+	var current time.Time
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, commitMarker) {
+			current, _ = time.Parse(time.RFC3339, strings.TrimPrefix(line, commitMarker))
+			continue
+		}
+		if line == "" || current.IsZero() {
+			continue
+		}
+
+		// numstat lines: "<added>\t<deleted>\t<path>" ("-" for binary files)
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		added, _ := strconv.Atoi(fields[0])
+		deleted, _ := strconv.Atoi(fields[1])
+
+		// Names with quotes, tabs, or newlines are still C-quoted
+		path := fields[2]
+		if strings.HasPrefix(path, `"`) {
+			if unquoted, err := strconv.Unquote(path); err == nil {
+				path = unquoted
+			}
+		}
+		path = filepath.ToSlash(path)
+		dates, seen := history[path]
+		if !seen {
+			dates.Modified = current // Newest commit comes first
+		}
+		dates.Created = current // Keeps moving back to the oldest commit
+		dates.Commits = append(dates.Commits, Commit{Date: current, Lines: added + deleted})
+		history[path] = dates
+	}
+
+	return history
+}
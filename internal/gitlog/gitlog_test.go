@@ -0,0 +1,147 @@
+package gitlog
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseLog(t *testing.T) {
+	out := []byte("\x002025-11-06T10:00:00Z\n\n3\t1\tpages/A.md\n1\t0\tjournals/2025_11_06.md\n" +
+		"\x002025-11-01T09:00:00Z\n\n10\t0\tpages/A.md\n2\t0\tpages/B.md\n")
+
+	history := parseLog(out)
+
+	a := history["pages/A.md"]
+	if !a.Created.Equal(time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected A created Nov 1, got %v", a.Created)
+	}
+	if !a.Modified.Equal(time.Date(2025, 11, 6, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected A modified Nov 6, got %v", a.Modified)
+	}
+	if len(a.Commits) != 2 || a.Commits[0].Lines != 4 || a.Commits[1].Lines != 10 {
+		t.Errorf("Unexpected commits for A: %+v", a.Commits)
+	}
+	if b := history["pages/B.md"]; !b.Created.Equal(b.Modified) {
+		t.Errorf("Expected single-commit file to have equal dates, got %+v", b)
+	}
+	if len(history) != 3 {
+		t.Errorf("Expected 3 files, got %d", len(history))
+	}
+}
+
+func TestParseLog_NonASCII(t *testing.T) {
+	out := []byte("\x002025-11-06T10:00:00Z\n\n1\t0\tpages/Maßnahmen.md\n2\t0\t\"pages/\\303\\234bersicht.md\"\n")
+
+	history := parseLog(out)
+
+	for _, path := range []string{"pages/Maßnahmen.md", "pages/Übersicht.md"} {
+		if _, ok := history[path]; !ok {
+			t.Errorf("Expected %s in history, got %v", path, history)
+		}
+	}
+}
+
+func TestFileHistory_NotARepo(t *testing.T) {
+	history, err := FileHistory(t.TempDir())
+	if err != nil {
+		t.Fatalf("FileHistory failed: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("Expected empty history, got %v", history)
+	}
+}
+
+func TestFileHistory(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	run := func(env []string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	commit := func(date string) {
+		env := []string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date}
+		run(env, "add", "-A")
+		run(env, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "update")
+	}
+
+	run(nil, "init", "--quiet")
+	if history, err := FileHistory(repo); err != nil || len(history) != 0 {
+		t.Fatalf("Expected empty history before first commit, got %v, %v", history, err)
+	}
+
+	os.MkdirAll(filepath.Join(repo, "pages"), 0755)
+	os.WriteFile(filepath.Join(repo, "pages", "A.md"), []byte("v1"), 0644)
+	commit("2025-11-01T09:00:00Z")
+	os.WriteFile(filepath.Join(repo, "pages", "A.md"), []byte("v2"), 0644)
+	os.WriteFile(filepath.Join(repo, "pages", "Maßnahmen.md"), []byte("v1"), 0644)
+	commit("2025-11-06T10:00:00Z")
+
+	history, err := FileHistory(repo)
+	if err != nil {
+		t.Fatalf("FileHistory failed: %v", err)
+	}
+	a := history["pages/A.md"]
+	if a.Created.Day() != 1 || a.Modified.Day() != 6 {
+		t.Errorf("Unexpected dates for pages/A.md: %+v", a)
+	}
+	if m, ok := history["pages/Maßnahmen.md"]; !ok || m.Created.Day() != 6 {
+		t.Errorf("Expected pages/Maßnahmen.md created Nov 6, got %+v (history %v)", m, history)
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+
+	graph := filepath.Join(repo, "notes")
+	os.MkdirAll(filepath.Join(graph, "pages"), 0755)
+	run("init", "--quiet")
+	os.WriteFile(filepath.Join(graph, "pages", "A.md"), []byte("v1"), 0644)
+	run("add", "-A")
+	run("commit", "--quiet", "-m", "first")
+	os.WriteFile(filepath.Join(graph, "pages", "A.md"), []byte("v2"), 0644)
+	run("commit", "--quiet", "-am", "second")
+
+	// The checkout maps the graph subdirectory into the worktree
+	dir, cleanup, err := Checkout(graph, "HEAD~1")
+	if err != nil {
+		t.Fatalf("Checkout failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "pages", "A.md"))
+	if err != nil || string(content) != "v1" {
+		t.Errorf("Expected v1 at HEAD~1, got %q (%v)", content, err)
+	}
+
+	if err := cleanup(); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Expected worktree to be removed, got %v", err)
+	}
+
+	if _, _, err := Checkout(graph, "no-such-rev"); err == nil {
+		t.Error("Expected error for unknown revision")
+	}
+}
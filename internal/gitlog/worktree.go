@@ -0,0 +1,50 @@
+package gitlog
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Checkout checks out rev into a temporary worktree and returns the directory
+// corresponding to repoPath inside it (repoPath may be a subdirectory of the
+// repository). Call cleanup to remove the worktree.
+func Checkout(repoPath, rev string) (dir string, cleanup func() error, err error) {
+	commit, err := git(repoPath, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return "", nil, fmt.Errorf("unknown revision %q", rev)
+	}
+	prefix, err := git(repoPath, "rev-parse", "--show-prefix")
+	if err != nil {
+		return "", nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "logseq-indexer-worktree-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("creating worktree directory: %w", err)
+	}
+	if _, err := git(repoPath, "worktree", "add", "--detach", "--quiet", tmp, commit); err != nil {
+		os.RemoveAll(tmp)
+		return "", nil, err
+	}
+
+	cleanup = func() error {
+		_, err := git(repoPath, "worktree", "remove", "--force", tmp)
+		os.RemoveAll(tmp)
+		return err
+	}
+	return filepath.Join(tmp, filepath.FromSlash(prefix)), cleanup, nil
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
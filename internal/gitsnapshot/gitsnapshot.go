@@ -0,0 +1,146 @@
+package gitsnapshot
+
+import (
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/lock"
+)
+
+// Mode selects how weekly snapshots are recorded
+type Mode string
+
+const (
+	ModeOff    Mode = ""       // No snapshots
+	ModeCommit Mode = "commit" // Commit the output directory once a week
+	ModeTag    Mode = "tag"    // Commit as above, then tag the snapshot
+)
+
+// ParseMode validates a --snapshot value
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(value); mode {
+	case ModeOff, ModeCommit, ModeTag:
+		return mode, nil
+	default:
+		return ModeOff, fmt.Errorf("unknown snapshot mode %q (use commit or tag)", value)
+	}
+}
+
+// subjectPrefix starts every snapshot commit subject, followed by the ISO week
+const subjectPrefix = "Index snapshot "
+
+// tagPrefix starts every snapshot tag name, followed by the ISO week
+const tagPrefix = "index-snapshot-"
+
+// Result describes what a snapshot run did
+type Result struct {
+	Week      string // ISO week, e.g. "2025-W45"
+	Commit    string // Short hash of the snapshot commit ("" if none was made)
+	Tag       string // Tag created ("" if none)
+	Existing  bool   // This week's snapshot had already been taken
+	Unchanged bool   // The output directory matched the last commit, so nothing was committed
+}
+
+// Week returns the ISO week identifier for t, e.g. "2025-W45"
+func Week(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// Message builds the standardized snapshot commit message: the week in the
+// subject, then one "name: value" line per summary count
+func Message(week string, counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s\n", subjectPrefix, week)
+	if len(names) > 0 {
+		b.WriteString("\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %d\n", name, counts[name])
+	}
+	return b.String()
+}
+
+// Take records this week's snapshot of dir, unless one already exists.
+// The commit contains only dir; other staged changes are left staged.
+// Hooks are skipped so a post-commit hook doesn't regenerate the indexes mid-run.
+func Take(dir string, mode Mode, now time.Time, counts map[string]int) (Result, error) {
+	result := Result{Week: Week(now)}
+	if mode == ModeOff {
+		return result, nil
+	}
+
+	existing, err := existingSnapshot(dir, mode, result.Week)
+	if err != nil {
+		return result, err
+	}
+	if existing {
+		result.Existing = true
+		return result, nil
+	}
+
+	if _, err := git(dir, "add", "--all", "--", ".", ":(exclude)"+lock.FileName); err != nil {
+		return result, err
+	}
+	if _, err := git(dir, "diff", "--cached", "--quiet", "--", "."); err == nil {
+		result.Unchanged = true
+	} else {
+		if _, err := git(dir, "-c", "core.hooksPath=/dev/null", "commit", "--quiet", "-m", Message(result.Week, counts), "--", "."); err != nil {
+			return result, err
+		}
+		if result.Commit, err = git(dir, "rev-parse", "--short", "HEAD"); err != nil {
+			return result, err
+		}
+	}
+
+	// Tag the new commit, or the existing one holding the unchanged indexes
+	if mode == ModeTag {
+		result.Tag = tagPrefix + result.Week
+		if _, err := git(dir, "tag", "--annotate", "--message", Message(result.Week, counts), result.Tag); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// existingSnapshot reports whether this week's snapshot tag (tag mode) or
+// commit (commit mode) already exists
+func existingSnapshot(dir string, mode Mode, week string) (bool, error) {
+	if mode == ModeTag {
+		out, err := git(dir, "tag", "--list", tagPrefix+week)
+		return out != "", err
+	}
+
+	// An unborn branch has no history to search
+	if _, err := git(dir, "rev-parse", "--verify", "--quiet", "HEAD"); err != nil {
+		return false, nil
+	}
+	out, err := git(dir, "log", "-1", "--format=%h", "--grep=^"+subjectPrefix+week+"$")
+	return out != "", err
+}
+
+// git runs a git command in dir and returns its trimmed output, including the
+// output in any error
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		subcommand := args[0]
+		if subcommand == "-c" {
+			subcommand = args[2]
+		}
+		return "", fmt.Errorf("git %s: %w: %s", subcommand, err, strings.TrimSpace(string(out)))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
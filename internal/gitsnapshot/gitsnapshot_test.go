@@ -0,0 +1,175 @@
+package gitsnapshot
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/lock"
+)
+
+func TestWeek(t *testing.T) {
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC), "2025-W45"},
+		{time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC), "2025-W01"}, // ISO year differs from calendar year
+		{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), "2025-W01"},
+	}
+
+	for _, tt := range tests {
+		if got := Week(tt.date); got != tt.want {
+			t.Errorf("Week(%s) = %q, want %q", tt.date.Format("2006-01-02"), got, tt.want)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	got := Message("2025-W45", map[string]int{"tasks": 40, "files": 12})
+	want := "Index snapshot 2025-W45\n\nfiles: 12\ntasks: 40\n"
+	if got != want {
+		t.Errorf("Message = %q, want %q", got, want)
+	}
+}
+
+func TestParseMode(t *testing.T) {
+	for _, value := range []string{"", "commit", "tag"} {
+		if _, err := ParseMode(value); err != nil {
+			t.Errorf("ParseMode(%q) failed: %v", value, err)
+		}
+	}
+	if _, err := ParseMode("daily"); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func TestTake_Commit(t *testing.T) {
+	repo, out := setupRepo(t)
+	write(t, repo, "pages/Notes.md", "- notes\n")
+	gitCmd(t, repo, "add", "pages/Notes.md") // Unrelated staged change
+	write(t, out, "dashboard.md", "# Dashboard\n")
+	write(t, out, lock.FileName, "123\n")
+
+	now := time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC)
+	result, err := Take(out, ModeCommit, now, map[string]int{"tasks": 3})
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+	if result.Commit == "" || result.Existing || result.Unchanged {
+		t.Fatalf("Expected a new snapshot commit, got %+v", result)
+	}
+
+	committed := gitCmd(t, repo, "show", "--name-only", "--format=%s", "HEAD")
+	if !strings.HasPrefix(committed, "Index snapshot 2025-W45") {
+		t.Errorf("Unexpected subject: %q", committed)
+	}
+	if !strings.Contains(committed, "indexes/dashboard.md") {
+		t.Errorf("Expected dashboard in snapshot, got %q", committed)
+	}
+	if strings.Contains(committed, lock.FileName) || strings.Contains(committed, "pages/Notes.md") {
+		t.Errorf("Snapshot should contain only index files, got %q", committed)
+	}
+	if staged := gitCmd(t, repo, "diff", "--cached", "--name-only"); strings.TrimSpace(staged) != "pages/Notes.md" {
+		t.Errorf("Expected unrelated change to stay staged, got %q", staged)
+	}
+
+	// A second run in the same week does nothing
+	write(t, out, "dashboard.md", "# Dashboard v2\n")
+	result, err = Take(out, ModeCommit, now.Add(48*time.Hour), nil)
+	if err != nil {
+		t.Fatalf("Second Take failed: %v", err)
+	}
+	if !result.Existing || result.Commit != "" {
+		t.Errorf("Expected existing snapshot to be detected, got %+v", result)
+	}
+
+	// Next week snapshots again
+	result, err = Take(out, ModeCommit, now.AddDate(0, 0, 7), nil)
+	if err != nil {
+		t.Fatalf("Next-week Take failed: %v", err)
+	}
+	if result.Week != "2025-W46" || result.Commit == "" {
+		t.Errorf("Expected a 2025-W46 snapshot, got %+v", result)
+	}
+}
+
+func TestTake_TagUnchanged(t *testing.T) {
+	repo, out := setupRepo(t)
+	write(t, out, "dashboard.md", "# Dashboard\n")
+	gitCmd(t, repo, "add", ".")
+	gitCmd(t, repo, "commit", "--quiet", "-m", "initial")
+
+	now := time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC)
+	result, err := Take(out, ModeTag, now, map[string]int{"tasks": 3})
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+	if !result.Unchanged || result.Tag != "index-snapshot-2025-W45" {
+		t.Fatalf("Expected unchanged indexes to be tagged at HEAD, got %+v", result)
+	}
+	if tags := gitCmd(t, repo, "tag", "--points-at", "HEAD"); strings.TrimSpace(tags) != result.Tag {
+		t.Errorf("Expected tag on HEAD, got %q", tags)
+	}
+
+	result, err = Take(out, ModeTag, now, nil)
+	if err != nil {
+		t.Fatalf("Second Take failed: %v", err)
+	}
+	if !result.Existing {
+		t.Errorf("Expected existing tag to be detected, got %+v", result)
+	}
+}
+
+func TestTake_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("GIT_CEILING_DIRECTORIES", os.TempDir())
+
+	out := t.TempDir()
+	if _, err := Take(out, ModeCommit, time.Now(), nil); err == nil {
+		t.Error("Expected error outside a git repository")
+	}
+}
+
+// setupRepo creates a git repository with an indexes/ output directory
+func setupRepo(t *testing.T) (repo, out string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	for _, key := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		t.Setenv(key+"_NAME", "Test")
+		t.Setenv(key+"_EMAIL", "test@example.com")
+	}
+
+	repo = t.TempDir()
+	gitCmd(t, repo, "init", "--quiet")
+	return repo, filepath.Join(repo, "indexes")
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func gitCmd(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return string(out)
+}
@@ -0,0 +1,134 @@
+package gitstage
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// timestampRegex matches generation timestamps, which change on every run
+// without the index content changing. Localized reports label them in their
+// own language, so any one-word label followed by just a timestamp counts.
+var timestampRegex = regexp.MustCompile(`(?m)^(?:\*\*Generated\*\*|Generated): .*$` +
+	`|^(?:\*\*\pL+\*\*|\pL+): \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d(?:Z|[+-]\d\d:\d\d)$` +
+	`|"generated_at": "[^"]*"`)
+
+// Snapshot is the content of every file in an output directory before a run
+type Snapshot struct {
+	dir   string
+	files map[string][]byte // Path relative to dir -> content
+}
+
+// Changes lists output files that differ from a snapshot, relative to the output directory
+type Changes struct {
+	Modified []string // New or changed files
+	Deleted  []string // Files removed since the snapshot
+}
+
+// Empty reports whether nothing changed
+func (c Changes) Empty() bool {
+	return len(c.Modified) == 0 && len(c.Deleted) == 0
+}
+
+// Take records the current content of dir; a missing directory gives an empty snapshot
+func Take(dir string) (*Snapshot, error) {
+	s := &Snapshot{dir: dir, files: make(map[string][]byte)}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == dir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		s.files[rel] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting %s: %w", dir, err)
+	}
+
+	return s, nil
+}
+
+// Changes compares the directory's current content with the snapshot.
+// Files whose only difference is a generation timestamp are restored to their
+// previous content, so they don't show up as modified in git.
+func (s *Snapshot) Changes() (Changes, error) {
+	current, err := Take(s.dir)
+	if err != nil {
+		return Changes{}, err
+	}
+
+	var changes Changes
+	for rel, content := range current.files {
+		previous, existed := s.files[rel]
+		if existed && bytes.Equal(previous, content) {
+			continue
+		}
+		if existed && bytes.Equal(stripTimestamps(previous), stripTimestamps(content)) {
+			if err := os.WriteFile(filepath.Join(s.dir, rel), previous, 0644); err != nil {
+				return Changes{}, fmt.Errorf("restoring %s: %w", rel, err)
+			}
+			continue
+		}
+		changes.Modified = append(changes.Modified, rel)
+	}
+	for rel := range s.files {
+		if _, exists := current.files[rel]; !exists {
+			changes.Deleted = append(changes.Deleted, rel)
+		}
+	}
+
+	sort.Strings(changes.Modified)
+	sort.Strings(changes.Deleted)
+	return changes, nil
+}
+
+// stripTimestamps blanks out generation timestamps for comparison
+func stripTimestamps(content []byte) []byte {
+	return timestampRegex.ReplaceAll(content, nil)
+}
+
+// Stage runs git add for modified files and unstages deleted ones, from the
+// repository containing dir. Paths in changes are relative to dir.
+func Stage(dir string, changes Changes) error {
+	if len(changes.Modified) > 0 {
+		args := append([]string{"add", "--"}, changes.Modified...)
+		if err := runGit(dir, args...); err != nil {
+			return err
+		}
+	}
+	if len(changes.Deleted) > 0 {
+		// --ignore-unmatch: deleted files may never have been committed
+		args := append([]string{"rm", "--cached", "--quiet", "--ignore-unmatch", "--"}, changes.Deleted...)
+		if err := runGit(dir, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runGit runs a git command in dir, including its output in any error
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
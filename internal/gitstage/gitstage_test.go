@@ -0,0 +1,136 @@
+package gitstage
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/internal/writer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestChanges(t *testing.T) {
+	dir := t.TempDir()
+	write(t, dir, "same.md", "# Same\n\nGenerated: 2025-01-01T00:00:00Z\n\nbody\n")
+	write(t, dir, "changed.md", "old\n")
+	write(t, dir, "removed.md", "gone soon\n")
+	write(t, dir, "german.md", "# Wissens-Dashboard\n\n**Erstellt**: 2025-01-01T00:00:00Z\n")
+
+	snapshot, err := Take(dir)
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+
+	write(t, dir, "same.md", "# Same\n\nGenerated: 2025-02-02T00:00:00Z\n\nbody\n")
+	write(t, dir, "changed.md", "new\n")
+	write(t, dir, "german.md", "# Wissens-Dashboard\n\n**Erstellt**: 2025-02-02T00:00:00Z\n")
+	write(t, dir, "sub/added.md", "hello\n")
+	os.Remove(filepath.Join(dir, "removed.md"))
+
+	changes, err := snapshot.Changes()
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+
+	wantModified := []string{"changed.md", filepath.Join("sub", "added.md")}
+	if !reflect.DeepEqual(changes.Modified, wantModified) {
+		t.Errorf("Modified = %v, want %v", changes.Modified, wantModified)
+	}
+	if !reflect.DeepEqual(changes.Deleted, []string{"removed.md"}) {
+		t.Errorf("Deleted = %v, want [removed.md]", changes.Deleted)
+	}
+
+	// Timestamp-only changes are reverted
+	content, _ := os.ReadFile(filepath.Join(dir, "same.md"))
+	if !strings.Contains(string(content), "2025-01-01") {
+		t.Errorf("Expected timestamp-only change to be restored, got %q", content)
+	}
+}
+
+func TestChanges_RunStateRerun(t *testing.T) {
+	dir := t.TempDir()
+	diagnostics := indexer.BuildDiagnosticsIndex([]models.Diagnostic{{Severity: models.SeverityError, Code: "parse-failed", File: "pages/A.md"}})
+	generate := func(previous *indexer.RunState, now time.Time) *indexer.RunState {
+		diagnostics.ApplyHistory(previous)
+		state := &indexer.RunState{GeneratedAt: now, Pages: []string{"a"}}
+		state.RecordDiagnostics([]models.File{{Path: "pages/A.md"}}, diagnostics)
+		if err := writer.WriteRunState(state, dir); err != nil {
+			t.Fatal(err)
+		}
+		return state
+	}
+
+	first := generate(nil, time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC))
+	snapshot, err := Take(dir)
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+
+	// Regenerating unchanged notes stages nothing
+	generate(first, first.GeneratedAt.Add(time.Hour))
+	changes, err := snapshot.Changes()
+	if err != nil {
+		t.Fatalf("Changes failed: %v", err)
+	}
+	if !changes.Empty() {
+		t.Errorf("Expected nothing to stage, got %+v", changes)
+	}
+}
+
+func TestTake_MissingDir(t *testing.T) {
+	snapshot, err := Take(filepath.Join(t.TempDir(), "missing"))
+	if err != nil {
+		t.Fatalf("Take failed: %v", err)
+	}
+	if len(snapshot.files) != 0 {
+		t.Errorf("Expected empty snapshot, got %d files", len(snapshot.files))
+	}
+}
+
+func TestStage(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	git(t, repo, "init", "--quiet")
+	out := filepath.Join(repo, "indexes")
+	write(t, out, "a.md", "a\n")
+	write(t, out, "b.md", "b\n")
+
+	if err := Stage(out, Changes{Modified: []string{"a.md"}, Deleted: []string{"never-tracked.md"}}); err != nil {
+		t.Fatalf("Stage failed: %v", err)
+	}
+
+	staged := git(t, repo, "diff", "--cached", "--name-only")
+	if strings.TrimSpace(staged) != "indexes/a.md" {
+		t.Errorf("Expected only indexes/a.md staged, got %q", staged)
+	}
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return string(out)
+}
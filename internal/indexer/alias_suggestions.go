@@ -0,0 +1,205 @@
+package indexer
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// AliasSuggestion proposes alias:: entries for an existing page whose name
+// missing pages look like variants of
+type AliasSuggestion struct {
+	Page     string // Existing page
+	FilePath string
+	Variants []AliasVariant // Most referenced first
+}
+
+// AliasVariant is a missing page that looks like another name for an existing one
+type AliasVariant struct {
+	Name           string
+	ReferenceCount int
+	Reason         string // "same words", "plural", or "typo"
+}
+
+// References returns the references the aliases would resolve
+func (s AliasSuggestion) References() int {
+	total := 0
+	for _, v := range s.Variants {
+		total += v.ReferenceCount
+	}
+	return total
+}
+
+// ApplyAliasSuggestions finds missing pages that look like variants of an
+// existing page: the same words in another order or with other punctuation,
+// singular against plural, or a typo (edit distance 1, or 2 for names of 10+
+// characters). Adding them as alias:: on the existing page resolves their
+// links instead of creating near-duplicate pages. Names differing in their
+// digits ("Sprint 12", "Sprint 13") and dates are never matched.
+func (mi *MissingPagesIndex) ApplyAliasSuggestions(graph *ReferenceGraph) {
+	mi.AliasSuggestions = nil
+
+	var existing []aliasCandidate
+	for name, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) || journalTitleRegex.MatchString(name) {
+			continue
+		}
+		existing = append(existing, newAliasCandidate(name, node))
+	}
+	sort.Slice(existing, func(i, j int) bool { return existing[i].name < existing[j].name })
+
+	byPage := make(map[string]*AliasSuggestion)
+	aliasOf := make(map[string]string) // Missing page -> existing page
+	for name, node := range graph.Nodes {
+		if node.FilePath != "" || node.ReferenceCount == 0 || journalTitleRegex.MatchString(name) {
+			continue
+		}
+		variant := newAliasCandidate(name, node)
+
+		// The closest existing page wins; ties go to the first name alphabetically
+		var best *aliasCandidate
+		bestRank, bestReason := 0, ""
+		for i := range existing {
+			rank, reason := aliasMatch(variant, existing[i])
+			if reason != "" && (best == nil || rank < bestRank) {
+				best, bestRank, bestReason = &existing[i], rank, reason
+			}
+		}
+		if best == nil {
+			continue
+		}
+
+		suggestion := byPage[best.name]
+		if suggestion == nil {
+			suggestion = &AliasSuggestion{Page: best.name, FilePath: best.node.FilePath}
+			byPage[best.name] = suggestion
+		}
+		suggestion.Variants = append(suggestion.Variants, AliasVariant{
+			Name:           name,
+			ReferenceCount: node.ReferenceCount,
+			Reason:         bestReason,
+		})
+		aliasOf[name] = best.name
+	}
+
+	for _, suggestion := range byPage {
+		sort.Slice(suggestion.Variants, func(i, j int) bool {
+			a, b := suggestion.Variants[i], suggestion.Variants[j]
+			if a.ReferenceCount != b.ReferenceCount {
+				return a.ReferenceCount > b.ReferenceCount
+			}
+			return a.Name < b.Name
+		})
+		mi.AliasSuggestions = append(mi.AliasSuggestions, *suggestion)
+	}
+	for i := range mi.MissingPages {
+		mi.MissingPages[i].AliasOf = aliasOf[mi.MissingPages[i].Name]
+	}
+	sort.Slice(mi.AliasSuggestions, func(i, j int) bool {
+		a, b := mi.AliasSuggestions[i], mi.AliasSuggestions[j]
+		if len(a.Variants) != len(b.Variants) {
+			return len(a.Variants) > len(b.Variants)
+		}
+		if a.References() != b.References() {
+			return a.References() > b.References()
+		}
+		return a.Page < b.Page
+	})
+}
+
+// aliasCandidate is a page name prepared for comparison
+type aliasCandidate struct {
+	name     string
+	node     *GraphNode
+	norm     string // Lowercase words separated by single spaces
+	words    string // norm's words sorted
+	singular string // words with plural "s" endings dropped
+	digits   string
+	length   int // Runes in norm
+}
+
+// newAliasCandidate normalizes a page name: "Project-Phoenix" and
+// "project phoenix" compare equal
+func newAliasCandidate(name string, node *GraphNode) aliasCandidate {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	c := aliasCandidate{name: name, node: node, norm: strings.Join(fields, " ")}
+
+	sorted := slices.Sorted(slices.Values(fields))
+	c.words = strings.Join(sorted, " ")
+	for i, w := range sorted {
+		if len(w) > 3 {
+			sorted[i] = strings.TrimSuffix(w, "s")
+		}
+	}
+	slices.Sort(sorted)
+	c.singular = strings.Join(sorted, " ")
+	c.digits = digitsOf(c.norm)
+	c.length = len([]rune(c.norm))
+	return c
+}
+
+// aliasMatch compares a missing page with an existing one, returning why it
+// looks like a variant ("" if it doesn't) and a rank, lower meaning closer
+func aliasMatch(variant, page aliasCandidate) (int, string) {
+	// Logseq already resolves links differing only in case
+	if variant.digits != page.digits || variant.norm == "" || strings.EqualFold(variant.name, page.name) {
+		return 0, ""
+	}
+	if variant.words == page.words {
+		return 0, "same words"
+	}
+	if variant.singular == page.singular {
+		return 1, "plural"
+	}
+
+	// Short names are too easily one edit apart ("Bob", "Rob")
+	maxDistance := 0
+	switch shorter := min(variant.length, page.length); {
+	case shorter >= 10:
+		maxDistance = 2
+	case shorter >= 5:
+		maxDistance = 1
+	}
+	if maxDistance == 0 || abs(variant.length-page.length) > maxDistance {
+		return 0, ""
+	}
+	if d := editDistance(variant.norm, page.norm); d > 0 && d <= maxDistance {
+		return 1 + d, "typo"
+	}
+	return 0, ""
+}
+
+// digitsOf returns the digits of s in order
+func digitsOf(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// editDistance is the Levenshtein distance between a and b in runes
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
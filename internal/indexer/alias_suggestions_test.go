@@ -0,0 +1,53 @@
+package indexer
+
+import "testing"
+
+func TestApplyAliasSuggestions(t *testing.T) {
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Project Phoenix": {FilePath: "pages/Project Phoenix.md"},
+		"Kubernetes":      {FilePath: "pages/Kubernetes.md"},
+		"Sprint 12":       {FilePath: "pages/Sprint 12.md"},
+		"Bob":             {FilePath: "pages/Bob.md"},
+		"Nov 6th, 2025":   {FilePath: "journals/2025_11_06.md"},
+
+		"Phoenix Project": {ReferenceCount: 4},
+		"project-phoenix": {ReferenceCount: 6},
+		"Kubernets":       {ReferenceCount: 2}, // Typo
+		"Kubernetes Pods": {ReferenceCount: 9}, // A different page
+		"Sprint 13":       {ReferenceCount: 3}, // Different number
+		"Rob":             {ReferenceCount: 3}, // Too short to call a typo
+		"kubernetes":      {ReferenceCount: 1}, // Same page to Logseq
+		"Nov 7th, 2025":   {ReferenceCount: 1},
+	}}
+	index := &MissingPagesIndex{MissingPages: []MissingPage{{Name: "project-phoenix"}, {Name: "Kubernetes Pods"}}}
+
+	index.ApplyAliasSuggestions(graph)
+
+	if len(index.AliasSuggestions) != 2 {
+		t.Fatalf("Expected 2 alias suggestions, got %+v", index.AliasSuggestions)
+	}
+
+	phoenix := index.AliasSuggestions[0]
+	if phoenix.Page != "Project Phoenix" || len(phoenix.Variants) != 2 || phoenix.References() != 10 {
+		t.Fatalf("Expected Project Phoenix with 2 variants first, got %+v", phoenix)
+	}
+	if v := phoenix.Variants[0]; v.Name != "project-phoenix" || v.Reason != "same words" {
+		t.Errorf("Expected most referenced variant first, got %+v", v)
+	}
+
+	kubernetes := index.AliasSuggestions[1]
+	if kubernetes.Page != "Kubernetes" || len(kubernetes.Variants) != 1 || kubernetes.Variants[0].Reason != "typo" {
+		t.Errorf("Expected Kubernets as a typo of Kubernetes, got %+v", kubernetes)
+	}
+
+	if index.MissingPages[0].AliasOf != "Project Phoenix" || index.MissingPages[1].AliasOf != "" {
+		t.Errorf("Expected only project-phoenix marked as an alias, got %+v", index.MissingPages)
+	}
+}
+
+func TestAliasMatch_Plural(t *testing.T) {
+	_, reason := aliasMatch(newAliasCandidate("Design Reviews", nil), newAliasCandidate("Design Review", nil))
+	if reason != "plural" {
+		t.Errorf("Expected plural match, got %q", reason)
+	}
+}
@@ -0,0 +1,153 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// RunState is what a generation remembers so the next one can report what changed
+type RunState struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	OpenTasks   []string  `json:"open_tasks"` // Task.ID of each task not DONE
+	DoneTasks   []string  `json:"done_tasks"` // Task.ID of each DONE task
+	Pages       []string  `json:"pages"`      // Pages with a file, excluding journals
+
+	// Set by RecordDiagnostics
+	Files              []string         `json:"files,omitempty"`               // Every scanned file
+	FileErrors         map[string]int   `json:"file_errors,omitempty"`         // Error diagnostics per file
+	DiagnosticsHistory []DiagnosticsRun `json:"diagnostics_history,omitempty"` // The last DiagnosticsHistoryRuns runs, oldest first
+}
+
+// Changes summarizes the graph's changes since the previous generation
+type Changes struct {
+	Since          time.Time // When the previous generation ran
+	TasksAdded     []models.Task
+	TasksCompleted []models.Task
+	NewPages       []string
+	TimeLogged     time.Duration // Logbook time clocked after Since
+}
+
+// legacyTaskKey is how run states written before tasks were keyed by
+// Task.ID identified a task: its file plus its description
+func legacyTaskKey(task models.Task) string {
+	return task.SourceFile + "\x00" + task.Description
+}
+
+// hasLegacyTaskKeys reports whether a run state predates Task.ID keys
+func (s *RunState) hasLegacyTaskKeys() bool {
+	for _, keys := range [][]string{s.OpenTasks, s.DoneTasks} {
+		for _, key := range keys {
+			if strings.Contains(key, "\x00") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// BuildRunState records the tasks and pages of this generation
+func BuildRunState(tasks []models.Task, graph *ReferenceGraph, now time.Time) *RunState {
+	state := &RunState{GeneratedAt: now}
+	for _, task := range tasks {
+		if models.IsClosed(task.Status) {
+			state.DoneTasks = append(state.DoneTasks, task.ID())
+		} else {
+			state.OpenTasks = append(state.OpenTasks, task.ID())
+		}
+	}
+	for name, node := range graph.Nodes {
+		if node.FilePath != "" && !isJournalNode(node) {
+			state.Pages = append(state.Pages, name)
+		}
+	}
+	sort.Strings(state.OpenTasks)
+	sort.Strings(state.DoneTasks)
+	sort.Strings(state.Pages)
+	return state
+}
+
+// RecordDiagnostics adds the scanned files and this run's errors to the
+// state, with this run appended to the diagnostics history ApplyHistory loaded.
+// A run with the same counts as the last one isn't appended, so regenerating
+// unchanged notes leaves run-state.json as it was.
+func (s *RunState) RecordDiagnostics(files []models.File, diagnostics *DiagnosticsIndex) {
+	s.Files = nil
+	for _, file := range files {
+		s.Files = append(s.Files, file.Path)
+	}
+	sort.Strings(s.Files)
+
+	s.FileErrors = diagnostics.FileErrors()
+	run := DiagnosticsRun{
+		GeneratedAt: s.GeneratedAt,
+		Errors:      diagnostics.Count(models.SeverityError),
+		Warnings:    diagnostics.Count(models.SeverityWarning),
+	}
+	history := append([]DiagnosticsRun(nil), diagnostics.History...)
+	if n := len(history); n == 0 || history[n-1].Errors != run.Errors || history[n-1].Warnings != run.Warnings {
+		history = append(history, run)
+	}
+	s.DiagnosticsHistory = history[max(0, len(history)-DiagnosticsHistoryRuns):]
+}
+
+// BuildChanges compares this generation with the previous run's state.
+// It returns nil when there is no previous state, i.e. on the first run.
+// Tasks are matched by Task.ID, so rewording a task with an id:: property
+// isn't reported as a new task; a state from before IDs is matched by file
+// and description instead, once, until this run's state replaces it.
+func BuildChanges(previous *RunState, tasks []models.Task, graph *ReferenceGraph) *Changes {
+	if previous == nil {
+		return nil
+	}
+
+	changes := &Changes{Since: previous.GeneratedAt}
+	open := toSet(previous.OpenTasks)
+	done := toSet(previous.DoneTasks)
+	legacy := previous.hasLegacyTaskKeys()
+	for _, task := range tasks {
+		key := task.ID()
+		if legacy {
+			key = legacyTaskKey(task)
+		}
+		if !open[key] && !done[key] {
+			changes.TasksAdded = append(changes.TasksAdded, task)
+		}
+		if task.Status == models.StatusDONE && !done[key] {
+			changes.TasksCompleted = append(changes.TasksCompleted, task)
+		}
+
+		// Only the part of each clock entry after the previous run counts
+		for _, entry := range task.Logbook {
+			if !entry.End.After(previous.GeneratedAt) {
+				continue
+			}
+			if entry.Start.Before(previous.GeneratedAt) {
+				changes.TimeLogged += entry.End.Sub(previous.GeneratedAt)
+			} else {
+				changes.TimeLogged += entry.Duration
+			}
+		}
+	}
+
+	pages := toSet(previous.Pages)
+	for name, node := range graph.Nodes {
+		if node.FilePath != "" && !isJournalNode(node) && !pages[name] {
+			changes.NewPages = append(changes.NewPages, name)
+		}
+	}
+	sort.Strings(changes.NewPages)
+
+	return changes
+}
+
+// toSet turns a list of strings into a lookup set
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
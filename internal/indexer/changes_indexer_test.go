@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildChanges(t *testing.T) {
+	lastRun := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	oldGraph := BuildReferenceGraph(nil, []models.File{
+		{Path: "pages/Alpha.md", Type: models.FileTypePage},
+	})
+	before := []models.Task{
+		{Status: models.StatusTODO, Description: "Write spec", SourceFile: "pages/Alpha.md", LineNumber: 1},
+		{Status: models.StatusDONE, Description: "Kickoff", SourceFile: "pages/Alpha.md", LineNumber: 2},
+	}
+	previous := BuildRunState(before, oldGraph, lastRun)
+
+	graph := BuildReferenceGraph(nil, []models.File{
+		{Path: "pages/Alpha.md", Type: models.FileTypePage},
+		{Path: "pages/Beta.md", Type: models.FileTypePage},
+		{Path: "journals/2025_11_11.md", Type: models.FileTypeJournal},
+	})
+	after := []models.Task{
+		{Status: models.StatusDONE, Description: "Write spec", SourceFile: "pages/Alpha.md", LineNumber: 3, Logbook: []models.LogbookEntry{
+			// Straddles the last run: only the hour after it counts
+			{Start: lastRun.Add(-time.Hour), End: lastRun.Add(time.Hour), Duration: 2 * time.Hour},
+			{Start: lastRun.Add(-3 * time.Hour), End: lastRun.Add(-2 * time.Hour), Duration: time.Hour},
+		}},
+		{Status: models.StatusDONE, Description: "Kickoff", SourceFile: "pages/Alpha.md", LineNumber: 4},
+		{Status: models.StatusTODO, Description: "Review draft", SourceFile: "journals/2025_11_11.md", LineNumber: 1, Logbook: []models.LogbookEntry{
+			{Start: lastRun.Add(24 * time.Hour), End: lastRun.Add(24*time.Hour + 30*time.Minute), Duration: 30 * time.Minute},
+		}},
+	}
+
+	changes := BuildChanges(previous, after, graph)
+
+	if !changes.Since.Equal(lastRun) {
+		t.Errorf("Since = %v, want %v", changes.Since, lastRun)
+	}
+	if len(changes.TasksAdded) != 1 || changes.TasksAdded[0].Description != "Review draft" {
+		t.Errorf("TasksAdded = %+v, want only Review draft", changes.TasksAdded)
+	}
+	if len(changes.TasksCompleted) != 1 || changes.TasksCompleted[0].Description != "Write spec" {
+		t.Errorf("TasksCompleted = %+v, want only Write spec (moved lines don't count)", changes.TasksCompleted)
+	}
+	if len(changes.NewPages) != 1 || changes.NewPages[0] != "Beta" {
+		t.Errorf("NewPages = %v, want [Beta] (journals excluded)", changes.NewPages)
+	}
+	if changes.TimeLogged != 90*time.Minute {
+		t.Errorf("TimeLogged = %v, want 1h30m", changes.TimeLogged)
+	}
+}
+
+func TestBuildChanges_BlockID(t *testing.T) {
+	graph := BuildReferenceGraph(nil, nil)
+	before := []models.Task{
+		{Status: models.StatusTODO, Description: "Write spec", SourceFile: "pages/Alpha.md", Properties: map[string]string{"id": "6731a2b0-1c2d-4e5f-8a9b-0c1d2e3f4a5b"}},
+	}
+	previous := BuildRunState(before, graph, time.Now())
+
+	// Reworded, but the block id:: is the same task
+	after := []models.Task{
+		{Status: models.StatusTODO, Description: "Write the spec for Alpha", SourceFile: "pages/Alpha.md", Properties: map[string]string{"id": "6731a2b0-1c2d-4e5f-8a9b-0c1d2e3f4a5b"}},
+	}
+	if changes := BuildChanges(previous, after, graph); len(changes.TasksAdded) != 0 {
+		t.Errorf("TasksAdded = %+v, want none for a reworded task with an id::", changes.TasksAdded)
+	}
+}
+
+func TestBuildChanges_LegacyRunState(t *testing.T) {
+	graph := BuildReferenceGraph(nil, nil)
+	// Keyed by file and description, as run states were before Task.ID
+	previous := &RunState{
+		OpenTasks: []string{"pages/Alpha.md\x00Write spec"},
+		DoneTasks: []string{"pages/Alpha.md\x00Kickoff"},
+	}
+	tasks := []models.Task{
+		{Status: models.StatusDONE, Description: "Write spec", SourceFile: "pages/Alpha.md", Properties: map[string]string{"id": "6731a2b0-1c2d-4e5f-8a9b-0c1d2e3f4a5b"}},
+		{Status: models.StatusDONE, Description: "Kickoff", SourceFile: "pages/Alpha.md"},
+	}
+
+	changes := BuildChanges(previous, tasks, graph)
+	if len(changes.TasksAdded) != 0 {
+		t.Errorf("TasksAdded = %+v, want none after migrating the old keys", changes.TasksAdded)
+	}
+	if len(changes.TasksCompleted) != 1 || changes.TasksCompleted[0].Description != "Write spec" {
+		t.Errorf("TasksCompleted = %+v, want only Write spec", changes.TasksCompleted)
+	}
+
+	// The next run's state uses IDs
+	state := BuildRunState(tasks, graph, time.Now())
+	if state.hasLegacyTaskKeys() || !slices.Contains(state.DoneTasks, "6731a2b0-1c2d-4e5f-8a9b-0c1d2e3f4a5b") {
+		t.Errorf("Expected IDs in the new run state, got %q", state.DoneTasks)
+	}
+}
+
+func TestBuildChanges_FirstRun(t *testing.T) {
+	if changes := BuildChanges(nil, nil, BuildReferenceGraph(nil, nil)); changes != nil {
+		t.Errorf("Expected no changes without a previous run, got %+v", changes)
+	}
+}
@@ -0,0 +1,45 @@
+package indexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ClassificationRule maps missing page names to a type, e.g. everything
+// matching "^Customer: " to "customer". A rule matches if its pattern
+// matches the name or the name contains any keyword (case-insensitively).
+type ClassificationRule struct {
+	Pattern  *regexp.Regexp // nil to match on keywords only
+	Keywords []string
+	Type     string // A built-in type (person, project, date, concept) or a custom one; lowercased
+}
+
+// matches reports whether the rule applies to pageName
+func (r ClassificationRule) matches(pageName string) bool {
+	if r.Pattern != nil && r.Pattern.MatchString(pageName) {
+		return true
+	}
+	lower := strings.ToLower(pageName)
+	for _, keyword := range r.Keywords {
+		if keyword != "" && strings.Contains(lower, strings.ToLower(keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyClassificationRules overrides each missing page's type with the first
+// matching rule's. Pages no rule matches keep their heuristic type, so this
+// runs after ApplyPersonSignals.
+func (mi *MissingPagesIndex) ApplyClassificationRules(rules []ClassificationRule) {
+	for i := range mi.MissingPages {
+		page := &mi.MissingPages[i]
+		for _, rule := range rules {
+			if rule.matches(page.Name) {
+				page.PageType = strings.ToLower(strings.TrimSpace(rule.Type))
+				page.TypeConfidence = ConfidenceCertain
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package indexer
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestApplyClassificationRules(t *testing.T) {
+	index := &MissingPagesIndex{
+		MissingPages: []MissingPage{
+			{Name: "Attention Is All You Need (2017)", PageType: "concept"},
+			{Name: "Acme Corp", PageType: "person"},
+			{Name: "Sprint Retro Notes", PageType: "project"},
+			{Name: "Alice Johnson", PageType: "person"},
+		},
+	}
+	rules := []ClassificationRule{
+		{Pattern: regexp.MustCompile(`\(\d{4}\)$`), Type: "paper"},
+		{Keywords: []string{"corp", "GmbH"}, Type: "customer"},
+		{Keywords: []string{"retro"}, Type: "concept"},
+		{Keywords: []string{"acme"}, Type: "project"}, // Shadowed by the customer rule
+	}
+
+	index.ApplyClassificationRules(rules)
+
+	want := map[string]string{
+		"Attention Is All You Need (2017)": "paper",
+		"Acme Corp":                        "customer",
+		"Sprint Retro Notes":               "concept",
+		"Alice Johnson":                    "person",
+	}
+	for _, page := range index.MissingPages {
+		if page.PageType != want[page.Name] {
+			t.Errorf("%s: expected %s, got %s", page.Name, want[page.Name], page.PageType)
+		}
+	}
+	if index.MissingPages[0].TypeConfidence != ConfidenceCertain || index.MissingPages[3].TypeConfidence != 0 {
+		t.Errorf("Expected only rule matches to become certain, got %+v", index.MissingPages)
+	}
+}
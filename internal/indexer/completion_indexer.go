@@ -0,0 +1,140 @@
+package indexer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// DefaultCompleteAfterWeeks is how long a project with only DONE tasks must
+// be quiet before it's suggested as complete
+const DefaultCompleteAfterWeeks = 4
+
+// CompletionCandidate is a project page that looks finished: every task
+// referencing it is DONE and nothing has happened on it for a while
+type CompletionCandidate struct {
+	Page         string
+	FilePath     string
+	DoneTasks    int
+	LastActivity time.Time // Latest task completion, logbook entry, or journal mention
+	QuietWeeks   int       // Whole weeks since LastActivity
+	Confidence   float64   // From 0 to 1: more DONE tasks and a longer quiet spell make it likelier the project is finished
+}
+
+// ApplyCompletionCandidates flags project pages whose referencing tasks are
+// all DONE and whose last activity is more than afterWeeks weeks before now,
+// so they can be archived or given a retro. Pages without any dated activity
+// are skipped, since their age can't be told. A single DONE task just past
+// the cutoff is a weak signal (ConfidenceMixed); each further task and each
+// further afterWeeks of quiet add 0.1, up to ConfidenceHigh. Candidates are
+// sorted by last activity, oldest first.
+func (ti *TaskIndex) ApplyCompletionCandidates(tasks []models.Task, graph *ReferenceGraph, refs []models.PageReference, now time.Time, afterWeeks int) {
+	if afterWeeks <= 0 {
+		afterWeeks = DefaultCompleteAfterWeeks
+	}
+	cutoff := now.AddDate(0, 0, -7*afterWeeks)
+
+	ti.CompletionCandidates = nil
+	for page, project := range finishedProjects(tasks, graph, refs) {
+		last := project.LastActivity
+		if last.IsZero() || !last.Before(cutoff) {
+			continue
+		}
+		quietWeeks := int(now.Sub(last).Hours() / (24 * 7))
+		ti.CompletionCandidates = append(ti.CompletionCandidates, CompletionCandidate{
+			Page:         page,
+			FilePath:     graph.Nodes[page].FilePath,
+			DoneTasks:    len(project.Tasks),
+			LastActivity: last,
+			QuietWeeks:   quietWeeks,
+			Confidence: ConfidenceMixed + 0.1*float64(min(len(project.Tasks)-1, 2)) +
+				0.1*float64(min(quietWeeks/afterWeeks-1, 2)),
+		})
+	}
+
+	sort.Slice(ti.CompletionCandidates, func(i, j int) bool {
+		a, b := ti.CompletionCandidates[i], ti.CompletionCandidates[j]
+		if !a.LastActivity.Equal(b.LastActivity) {
+			return a.LastActivity.Before(b.LastActivity)
+		}
+		return a.Page < b.Page
+	})
+}
+
+// finishedProject is an existing page whose referencing tasks are all DONE
+type finishedProject struct {
+	Tasks        []models.Task          // DONE tasks referencing the page
+	JournalRefs  []models.PageReference // References to the page from journals
+	LastActivity time.Time              // Latest task completion, logbook entry, or journal mention; zero if none is dated
+}
+
+// finishedProjects returns the existing, non-journal pages that are the
+// project (first page reference) of at least one task and where every task
+// referencing them is DONE, keyed by page name
+func finishedProjects(tasks []models.Task, graph *ReferenceGraph, refs []models.PageReference) map[string]*finishedProject {
+	isProjectPage := func(name string) bool {
+		node, exists := graph.Nodes[name]
+		return exists && node.FilePath != "" && !isJournalNode(node)
+	}
+
+	projects := make(map[string]*finishedProject)
+	open := make(map[string]bool)
+	isProject := make(map[string]bool)
+	touch := func(project *finishedProject, t time.Time) {
+		if t.After(project.LastActivity) {
+			project.LastActivity = t
+		}
+	}
+
+	for _, task := range tasks {
+		if len(task.PageRefs) > 0 {
+			isProject[task.PageRefs[0]] = true
+		}
+		seen := make(map[string]bool)
+		for _, page := range task.PageRefs {
+			if seen[page] || !isProjectPage(page) {
+				continue
+			}
+			seen[page] = true
+
+			if !models.IsClosed(task.Status) {
+				open[page] = true
+				continue
+			}
+			project, exists := projects[page]
+			if !exists {
+				project = &finishedProject{}
+				projects[page] = project
+			}
+			project.Tasks = append(project.Tasks, task)
+			if completed, ok := completionDate(task); ok {
+				touch(project, completed)
+			}
+			for _, entry := range task.Logbook {
+				touch(project, entry.End)
+			}
+		}
+	}
+	for page := range projects {
+		if open[page] || !isProject[page] {
+			delete(projects, page)
+		}
+	}
+
+	// Journal mentions count as activity, e.g. notes from a follow-up meeting
+	for _, ref := range refs {
+		project, exists := projects[ref.TargetPage]
+		if !exists || !strings.HasPrefix(filepath.ToSlash(ref.SourceFile), "journals/") {
+			continue
+		}
+		if date, err := extractDateFromJournalPath(ref.SourceFile); err == nil {
+			project.JournalRefs = append(project.JournalRefs, ref)
+			touch(project, date)
+		}
+	}
+
+	return projects
+}
@@ -0,0 +1,62 @@
+package indexer
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyCompletionCandidates(t *testing.T) {
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Finished":   {PageName: "Finished", FilePath: "pages/Finished.md"},
+		"Active":     {PageName: "Active", FilePath: "pages/Active.md"},
+		"Recent":     {PageName: "Recent", FilePath: "pages/Recent.md"},
+		"Mentioned":  {PageName: "Mentioned", FilePath: "pages/Mentioned.md"},
+		"Undated":    {PageName: "Undated", FilePath: "pages/Undated.md"},
+		"Missing":    {PageName: "Missing"},
+		"2025_10_01": {PageName: "2025_10_01", FilePath: "journals/2025_10_01.md"},
+	}}
+
+	tasks := []models.Task{
+		{Status: models.StatusDONE, PageRefs: []string{"Finished"}, CompletedAt: time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDONE, PageRefs: []string{"Finished", "Finished"}, SourceFile: "journals/2025_09_15.md"},
+		{Status: models.StatusDONE, PageRefs: []string{"Active"}, CompletedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusLATER, PageRefs: []string{"Active"}},
+		{Status: models.StatusDONE, PageRefs: []string{"Recent"}, Logbook: []models.LogbookEntry{{End: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)}}},
+		{Status: models.StatusDONE, PageRefs: []string{"Mentioned"}, CompletedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDONE, PageRefs: []string{"Undated"}, SourceFile: "pages/Undated.md"},
+		{Status: models.StatusDONE, PageRefs: []string{"Missing"}, CompletedAt: time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	refs := []models.PageReference{
+		{SourceFile: "journals/2025_11_25.md", SourcePage: "2025_11_25", TargetPage: "Mentioned"},
+		{SourceFile: "pages/Other.md", SourcePage: "Other", TargetPage: "Finished"},
+	}
+
+	index := &TaskIndex{}
+	index.ApplyCompletionCandidates(tasks, graph, refs, now, 4)
+
+	if len(index.CompletionCandidates) != 1 {
+		t.Fatalf("Expected only Finished to be a candidate, got %+v", index.CompletionCandidates)
+	}
+	c := index.CompletionCandidates[0]
+	if c.Page != "Finished" || c.DoneTasks != 2 || c.FilePath != "pages/Finished.md" {
+		t.Errorf("Unexpected candidate: %+v", c)
+	}
+	if !c.LastActivity.Equal(time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)) || c.QuietWeeks != 8 {
+		t.Errorf("Expected last activity on 2025-10-01, 8 weeks ago, got %s (%d weeks)", c.LastActivity, c.QuietWeeks)
+	}
+	if math.Abs(c.Confidence-0.7) > 1e-9 {
+		t.Errorf("Expected confidence 0.7 for 2 tasks quiet twice the threshold, got %.2f", c.Confidence)
+	}
+
+	// A shorter threshold lets the recently finished project through
+	index.ApplyCompletionCandidates(tasks, graph, refs, now, 1)
+	if len(index.CompletionCandidates) != 2 || index.CompletionCandidates[1].Page != "Recent" {
+		t.Errorf("Expected Finished then Recent, got %+v", index.CompletionCandidates)
+	} else if recent := index.CompletionCandidates[1]; recent.Confidence != ConfidenceMixed {
+		t.Errorf("Expected a single task just past the threshold to be a weak guess, got %.2f", recent.Confidence)
+	}
+}
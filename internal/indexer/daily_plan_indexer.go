@@ -0,0 +1,76 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// DailyPlan gathers what a day's planning conversation needs: today's
+// agenda, unfinished work from earlier days, and the top priorities.
+// Each task appears in the first list it qualifies for only.
+type DailyPlan struct {
+	Date        time.Time
+	Agenda      []Reminder    // Due today or overdue, most overdue first
+	CarriedOver []CarriedTask // NOW/DOING tasks from earlier days and open tasks from yesterday, newest first
+	Priorities  []models.Task // Other open tasks with the highest priority level
+	Upcoming    []Reminder    // Due after today, soonest first
+}
+
+// CarriedTask is an unfinished task from an earlier journal day
+type CarriedTask struct {
+	Task    models.Task
+	AgeDays int // Days since the task's journal date
+}
+
+// BuildDailyPlan assembles the plan for now's date from open tasks and the
+// reminders feed
+func BuildDailyPlan(tasks []models.Task, reminders *RemindersIndex, now time.Time) *DailyPlan {
+	plan := &DailyPlan{Date: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)}
+
+	listed := make(map[string]bool)
+	key := func(task models.Task) string {
+		return fmt.Sprintf("%s:%d", task.SourceFile, task.LineNumber)
+	}
+
+	for _, r := range reminders.Reminders {
+		if r.DaysUntil <= 0 {
+			plan.Agenda = append(plan.Agenda, r)
+			listed[key(r.Task)] = true
+		}
+	}
+
+	for _, task := range tasks {
+		if models.IsClosed(task.Status) || listed[key(task)] {
+			continue
+		}
+		age := taskAgeDays(task, now)
+		inProgress := task.Status == models.StatusNOW || task.Status == models.StatusDOING
+		if (inProgress && age >= 1) || age == 1 {
+			plan.CarriedOver = append(plan.CarriedOver, CarriedTask{Task: task, AgeDays: age})
+			listed[key(task)] = true
+		}
+	}
+	sort.SliceStable(plan.CarriedOver, func(i, j int) bool {
+		return plan.CarriedOver[i].AgeDays < plan.CarriedOver[j].AgeDays
+	})
+
+	if levels := models.Priorities(); len(levels) > 0 {
+		for _, task := range tasks {
+			if !models.IsClosed(task.Status) && task.Priority == levels[0] && !listed[key(task)] {
+				plan.Priorities = append(plan.Priorities, task)
+				listed[key(task)] = true
+			}
+		}
+	}
+
+	for _, r := range reminders.Reminders {
+		if r.DaysUntil > 0 && !listed[key(r.Task)] {
+			plan.Upcoming = append(plan.Upcoming, r)
+		}
+	}
+
+	return plan
+}
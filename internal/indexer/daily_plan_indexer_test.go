@@ -0,0 +1,41 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildDailyPlan(t *testing.T) {
+	now := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "File taxes", SourceFile: "pages/Admin.md", LineNumber: 1, Deadline: time.Date(2025, 11, 8, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDOING, Description: "Refactor parser", SourceFile: "journals/2025_11_03.md", LineNumber: 2, Priority: models.PriorityHigh},
+		{Status: models.StatusTODO, Description: "Reply to Sam", SourceFile: "journals/2025_11_09.md", LineNumber: 3},
+		{Status: models.StatusTODO, Description: "Old idea", SourceFile: "journals/2025_10_01.md", LineNumber: 4},
+		{Status: models.StatusTODO, Description: "Budget review", SourceFile: "pages/Finance.md", LineNumber: 5, Priority: models.PriorityHigh},
+		{Status: models.StatusDONE, Description: "Finished", SourceFile: "journals/2025_11_09.md", LineNumber: 6, Priority: models.PriorityHigh},
+		{Status: models.StatusLATER, Description: "Book dentist", SourceFile: "pages/Health.md", LineNumber: 7, Scheduled: time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusNOW, Description: "Started today", SourceFile: "journals/2025_11_10.md", LineNumber: 8},
+	}
+	reminders := BuildRemindersIndex(tasks, now, 7)
+
+	plan := BuildDailyPlan(tasks, reminders, now)
+
+	if len(plan.Agenda) != 1 || plan.Agenda[0].Task.Description != "File taxes" {
+		t.Errorf("Expected the overdue task on the agenda, got %+v", plan.Agenda)
+	}
+	if len(plan.CarriedOver) != 2 || plan.CarriedOver[0].Task.Description != "Reply to Sam" || plan.CarriedOver[1].Task.Description != "Refactor parser" {
+		t.Fatalf("Expected yesterday's task then the week-old DOING task, got %+v", plan.CarriedOver)
+	}
+	if plan.CarriedOver[1].AgeDays != 7 {
+		t.Errorf("Expected age 7, got %d", plan.CarriedOver[1].AgeDays)
+	}
+	if len(plan.Priorities) != 1 || plan.Priorities[0].Description != "Budget review" {
+		t.Errorf("Expected only the unlisted open [#A] task as a priority, got %+v", plan.Priorities)
+	}
+	if len(plan.Upcoming) != 1 || plan.Upcoming[0].Task.Description != "Book dentist" {
+		t.Errorf("Expected the scheduled task as upcoming, got %+v", plan.Upcoming)
+	}
+}
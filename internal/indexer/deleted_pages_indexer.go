@@ -0,0 +1,103 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// DeletedPage is a page no longer in pages/ that Logseq kept a copy of
+type DeletedPage struct {
+	Name           string
+	Copy           string    // Newest copy, relative to the repository
+	Copies         int       // Copies kept of the page
+	DeletedAt      time.Time // Modification time of the newest copy
+	ReferencedFrom []string  // Pages still linking it, sorted
+}
+
+// DeletedPagesIndex lists recently deleted pages, kept apart from the graph
+type DeletedPagesIndex struct {
+	GeneratedAt time.Time
+	Days        int           // How far back deletions are reported
+	Pages       []DeletedPage // Still referenced first (most references first), then newest first
+}
+
+// Referenced returns how many deleted pages are still linked from the graph
+func (d *DeletedPagesIndex) Referenced() int {
+	count := 0
+	for _, page := range d.Pages {
+		if len(page.ReferencedFrom) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// BuildDeletedPagesIndex groups Logseq's copies of deleted pages by page,
+// keeping those whose newest copy is at most days old. Pages that exist in
+// the graph again are skipped: their copies are backups of overwritten
+// versions, not deletions. References are matched case-insensitively, as
+// Logseq does.
+func BuildDeletedPagesIndex(deleted []models.DeletedFile, graph *ReferenceGraph, days int, now time.Time) *DeletedPagesIndex {
+	index := &DeletedPagesIndex{GeneratedAt: now, Days: days}
+	cutoff := startOfDay(now).AddDate(0, 0, -days)
+
+	existing := make(map[string]bool)
+	referencedFrom := make(map[string][]string) // Lowercase name -> pages linking it
+	for key, node := range graph.Nodes {
+		name := strings.ToLower(namespacedName(key))
+		if node.FilePath != "" {
+			existing[name] = true
+			continue
+		}
+		referencedFrom[name] = append(referencedFrom[name], node.InboundRefs...)
+	}
+
+	pages := make(map[string]*DeletedPage)
+	for _, file := range deleted {
+		name := namespacedName(file.Page)
+		key := strings.ToLower(name)
+		if existing[key] {
+			continue
+		}
+		page, ok := pages[key]
+		if !ok {
+			page = &DeletedPage{Name: name}
+			pages[key] = page
+		}
+		page.Copies++
+		if file.ModTime.After(page.DeletedAt) {
+			page.Copy, page.DeletedAt = file.Path, file.ModTime
+		}
+	}
+
+	for key, page := range pages {
+		if page.DeletedAt.Before(cutoff) {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, source := range referencedFrom[key] {
+			if !seen[source] {
+				seen[source] = true
+				page.ReferencedFrom = append(page.ReferencedFrom, source)
+			}
+		}
+		sort.Strings(page.ReferencedFrom)
+		index.Pages = append(index.Pages, *page)
+	}
+
+	sort.Slice(index.Pages, func(i, j int) bool {
+		a, b := index.Pages[i], index.Pages[j]
+		if len(a.ReferencedFrom) != len(b.ReferencedFrom) {
+			return len(a.ReferencedFrom) > len(b.ReferencedFrom)
+		}
+		if !a.DeletedAt.Equal(b.DeletedAt) {
+			return a.DeletedAt.After(b.DeletedAt)
+		}
+		return a.Name < b.Name
+	})
+
+	return index
+}
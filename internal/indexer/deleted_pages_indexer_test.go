@@ -0,0 +1,56 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildDeletedPagesIndex(t *testing.T) {
+	now := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Phoenix":      {PageName: "Phoenix", FilePath: "pages/Phoenix.md"},
+		"Hiring":       {PageName: "Hiring", FilePath: "pages/Hiring.md"},
+		"Project":      {PageName: "Project", FilePath: "pages/Project.md"},
+		"old idea":     {PageName: "old idea", InboundRefs: []string{"Phoenix", "Hiring"}},
+		"Old Idea":     {PageName: "Old Idea", InboundRefs: []string{"Phoenix"}},
+		"Work/Roadmap": {PageName: "Work/Roadmap", InboundRefs: []string{"Hiring"}},
+	}}
+	copyOf := func(page, path string, modTime time.Time) models.DeletedFile {
+		return models.DeletedFile{File: models.File{Path: path, ModTime: modTime}, Page: page}
+	}
+	deleted := []models.DeletedFile{
+		copyOf("Old Idea", "logseq/.recycle/pages_Old Idea.md", now.AddDate(0, 0, -3)),
+		copyOf("Old Idea", "logseq/bak/pages/Old Idea/2025-11-01.md", now.AddDate(0, 0, -9)),
+		copyOf("Work___Roadmap", "logseq/bak/pages/Work___Roadmap/2025-11-09.md", now.AddDate(0, 0, -1)),
+		copyOf("Scratch", "logseq/.recycle/pages_Scratch.md", now.AddDate(0, 0, -2)),
+		copyOf("Project", "logseq/bak/pages/Project/2025-11-09.md", now.AddDate(0, 0, -1)), // Overwritten, not deleted
+		copyOf("Ancient", "logseq/.recycle/pages_Ancient.md", now.AddDate(0, 0, -60)),
+	}
+
+	index := BuildDeletedPagesIndex(deleted, graph, 30, now)
+
+	var names []string
+	for _, page := range index.Pages {
+		names = append(names, page.Name)
+	}
+	if want := []string{"Old Idea", "Work/Roadmap", "Scratch"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("Expected %v, got %v", want, names)
+	}
+	if index.Referenced() != 2 {
+		t.Errorf("Expected 2 pages still linked, got %d", index.Referenced())
+	}
+
+	oldIdea := index.Pages[0]
+	if oldIdea.Copies != 2 || oldIdea.Copy != "logseq/.recycle/pages_Old Idea.md" {
+		t.Errorf("Expected the newest of 2 copies, got %+v", oldIdea)
+	}
+	if !reflect.DeepEqual(oldIdea.ReferencedFrom, []string{"Hiring", "Phoenix"}) {
+		t.Errorf("Expected links under either casing, sorted and deduplicated, got %v", oldIdea.ReferencedFrom)
+	}
+	if index.Pages[2].ReferencedFrom != nil {
+		t.Errorf("Expected Scratch to be unlinked, got %v", index.Pages[2].ReferencedFrom)
+	}
+}
@@ -0,0 +1,205 @@
+package indexer
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// Diagnostic codes for journal date problems
+const (
+	CodeInvalidJournalDate = "invalid-journal-date"
+	CodeDateLikePage       = "date-like-page"
+	CodeDuplicateDate      = "duplicate-journal-date"
+)
+
+// dateLikeRegex matches file names shaped like a journal date (2025_11_06 or 2025-11-06)
+var dateLikeRegex = regexp.MustCompile(`^\d{4}[_-]\d{1,2}[_-]\d{1,2}$`)
+
+// DiagnosticsHistoryRuns is how many runs' diagnostics counts run-state.json
+// keeps, counting only runs whose counts changed
+const DiagnosticsHistoryRuns = 20
+
+// ErrorJumpMin is the fewest extra errors that count as a jump, so one
+// stray error after a clean history isn't flagged
+const ErrorJumpMin = 3
+
+// DiagnosticsIndex collects structured warnings and errors from a run
+type DiagnosticsIndex struct {
+	GeneratedAt time.Time
+	Diagnostics []models.Diagnostic // Sorted by severity, code, then file
+
+	// Set by ApplyHistory from the previous run's state
+	History     []DiagnosticsRun        // Earlier runs, oldest first
+	Regressions []DiagnosticsRegression // Sorted by file
+	ErrorJump   bool                    // Errors at least doubled against the History average
+}
+
+// DiagnosticsRun counts one run's diagnostics
+type DiagnosticsRun struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Errors      int       `json:"errors"`
+	Warnings    int       `json:"warnings"`
+}
+
+// DiagnosticsRegression is a file with more errors than in the previous run
+type DiagnosticsRegression struct {
+	File   string
+	Before int // 0 when the file was clean
+	After  int
+}
+
+// BuildDiagnosticsIndex sorts the collected diagnostics for reporting
+func BuildDiagnosticsIndex(diagnostics []models.Diagnostic) *DiagnosticsIndex {
+	sorted := append([]models.Diagnostic{}, diagnostics...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if a.Severity != b.Severity {
+			return a.Severity == models.SeverityError
+		}
+		if a.Code != b.Code {
+			return a.Code < b.Code
+		}
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+
+	return &DiagnosticsIndex{
+		GeneratedAt: time.Now(),
+		Diagnostics: sorted,
+	}
+}
+
+// Count returns the number of diagnostics with the given severity
+func (di *DiagnosticsIndex) Count(severity models.Severity) int {
+	count := 0
+	for _, d := range di.Diagnostics {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// FileErrors counts the error diagnostics of each file
+func (di *DiagnosticsIndex) FileErrors() map[string]int {
+	errors := make(map[string]int)
+	for _, d := range di.Diagnostics {
+		if d.Severity == models.SeverityError && d.File != "" {
+			errors[d.File]++
+		}
+	}
+	return errors
+}
+
+// ApplyHistory compares this run's errors with the previous run's state:
+// files that were scanned cleanly last time and now fail, files with more
+// errors than before, and whether the error count jumped against the
+// average of the remembered runs. A sudden change usually means a Logseq
+// upgrade or a bad sync changed the files' syntax. previous may be nil.
+func (di *DiagnosticsIndex) ApplyHistory(previous *RunState) {
+	di.History, di.Regressions, di.ErrorJump = nil, nil, false
+	if previous == nil {
+		return
+	}
+	di.History = previous.DiagnosticsHistory
+
+	scanned := toSet(previous.Files)
+	for file, after := range di.FileErrors() {
+		before := previous.FileErrors[file]
+		if after > before && (before > 0 || scanned[file]) {
+			di.Regressions = append(di.Regressions, DiagnosticsRegression{File: file, Before: before, After: after})
+		}
+	}
+	sort.Slice(di.Regressions, func(i, j int) bool { return di.Regressions[i].File < di.Regressions[j].File })
+
+	if len(di.History) > 0 {
+		average := di.AverageErrors()
+		errors := float64(di.Count(models.SeverityError))
+		di.ErrorJump = errors >= 2*average && errors-average >= ErrorJumpMin
+	}
+}
+
+// AverageErrors returns the average error count of the History runs
+func (di *DiagnosticsIndex) AverageErrors() float64 {
+	if len(di.History) == 0 {
+		return 0
+	}
+	total := 0
+	for _, run := range di.History {
+		total += run.Errors
+	}
+	return float64(total) / float64(len(di.History))
+}
+
+// CheckJournalDates reports journal files whose names aren't valid dates,
+// pages named like dates, and dates claimed by more than one file.
+// Without these warnings such files silently drop out of the timeline.
+func CheckJournalDates(files []models.File) []models.Diagnostic {
+	var diagnostics []models.Diagnostic
+	claimed := make(map[string][]string) // Date -> files parsing to it
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file.Path), filepath.Ext(file.Path))
+		date, err := extractDateFromJournalPath(file.Path)
+
+		switch file.Type {
+		case models.FileTypeJournal:
+			if err != nil {
+				diagnostics = append(diagnostics, models.Diagnostic{
+					Severity: models.SeverityWarning,
+					Code:     CodeInvalidJournalDate,
+					File:     file.Path,
+					Message:  fmt.Sprintf("journal name %q is not a valid date (expected YYYY_MM_DD); excluded from timeline", name),
+				})
+				continue
+			}
+		case models.FileTypePage:
+			if !dateLikeRegex.MatchString(name) {
+				continue
+			}
+			msg := fmt.Sprintf("page %q is named like a journal date; it is treated as a page, not a journal", name)
+			if err != nil {
+				msg = fmt.Sprintf("page %q is named like a journal date but is not a valid date", name)
+			}
+			diagnostics = append(diagnostics, models.Diagnostic{
+				Severity: models.SeverityWarning,
+				Code:     CodeDateLikePage,
+				File:     file.Path,
+				Message:  msg,
+			})
+			if err != nil {
+				continue
+			}
+		}
+
+		if err == nil {
+			key := date.Format("2006-01-02")
+			claimed[key] = append(claimed[key], file.Path)
+		}
+	}
+
+	for date, paths := range claimed {
+		if len(paths) < 2 {
+			continue
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			diagnostics = append(diagnostics, models.Diagnostic{
+				Severity: models.SeverityWarning,
+				Code:     CodeDuplicateDate,
+				File:     path,
+				Message:  fmt.Sprintf("date %s is claimed by %d files: %s", date, len(paths), strings.Join(paths, ", ")),
+			})
+		}
+	}
+
+	return diagnostics
+}
@@ -0,0 +1,147 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestCheckJournalDates(t *testing.T) {
+	files := []models.File{
+		{Path: "journals/2025_11_06.md", Type: models.FileTypeJournal},
+		{Path: "journals/2025_13_40.md", Type: models.FileTypeJournal},
+		{Path: "journals/Meeting notes.md", Type: models.FileTypeJournal},
+		{Path: "pages/2025_11_06.md", Type: models.FileTypePage},
+		{Path: "pages/2025-02-30.md", Type: models.FileTypePage},
+		{Path: "pages/Project.md", Type: models.FileTypePage},
+	}
+
+	diagnostics := CheckJournalDates(files)
+
+	counts := make(map[string]int)
+	for _, d := range diagnostics {
+		counts[d.Code]++
+		if d.Severity != models.SeverityWarning {
+			t.Errorf("Expected warning severity, got %s", d.Severity)
+		}
+	}
+
+	if counts[CodeInvalidJournalDate] != 2 {
+		t.Errorf("Expected 2 invalid journal dates, got %d", counts[CodeInvalidJournalDate])
+	}
+	if counts[CodeDateLikePage] != 2 {
+		t.Errorf("Expected 2 date-like pages, got %d", counts[CodeDateLikePage])
+	}
+	// journals/2025_11_06.md and pages/2025_11_06.md both claim Nov 6
+	if counts[CodeDuplicateDate] != 2 {
+		t.Errorf("Expected 2 duplicate-date warnings, got %d", counts[CodeDuplicateDate])
+	}
+}
+
+func TestBuildDiagnosticsIndex_Sorting(t *testing.T) {
+	index := BuildDiagnosticsIndex([]models.Diagnostic{
+		{Severity: models.SeverityWarning, Code: "b", File: "z.md"},
+		{Severity: models.SeverityWarning, Code: "a", File: "y.md"},
+		{Severity: models.SeverityError, Code: "c", File: "x.md"},
+	})
+
+	if index.Diagnostics[0].Severity != models.SeverityError {
+		t.Error("Expected errors first")
+	}
+	if index.Diagnostics[1].Code != "a" {
+		t.Errorf("Expected warnings sorted by code, got %s", index.Diagnostics[1].Code)
+	}
+	if index.Count(models.SeverityWarning) != 2 || index.Count(models.SeverityError) != 1 {
+		t.Error("Unexpected severity counts")
+	}
+}
+
+func TestDiagnosticsHistory(t *testing.T) {
+	fail := func(file string) models.Diagnostic {
+		return models.Diagnostic{Severity: models.SeverityError, Code: "parse-failed", File: file}
+	}
+	files := []models.File{{Path: "journals/2025_11_03.md"}, {Path: "pages/Broken.md"}, {Path: "pages/Clean.md"}}
+
+	// First run: one broken page, nothing to compare with
+	first := BuildDiagnosticsIndex([]models.Diagnostic{fail("pages/Broken.md")})
+	first.ApplyHistory(nil)
+	previous := &RunState{GeneratedAt: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)}
+	previous.RecordDiagnostics(files, first)
+	if len(previous.DiagnosticsHistory) != 1 || previous.DiagnosticsHistory[0].Errors != 1 || previous.FileErrors["pages/Broken.md"] != 1 {
+		t.Fatalf("Expected the first run recorded, got %+v", previous)
+	}
+
+	// Second run: the page breaks further, a clean file and a new one start failing
+	second := BuildDiagnosticsIndex([]models.Diagnostic{
+		fail("pages/Broken.md"), fail("pages/Broken.md"),
+		fail("pages/Clean.md"),
+		fail("pages/New.md"),
+		{Severity: models.SeverityWarning, Code: CodeDateLikePage, File: "journals/2025_11_03.md"},
+	})
+	second.ApplyHistory(previous)
+
+	want := []DiagnosticsRegression{
+		{File: "pages/Broken.md", Before: 1, After: 2},
+		{File: "pages/Clean.md", Before: 0, After: 1},
+	}
+	if len(second.Regressions) != len(want) {
+		t.Fatalf("Expected %d regressions, got %+v", len(want), second.Regressions)
+	}
+	for i, w := range want {
+		if second.Regressions[i] != w {
+			t.Errorf("Regression %d: expected %+v, got %+v", i, w, second.Regressions[i])
+		}
+	}
+	// 4 errors against an average of 1
+	if !second.ErrorJump {
+		t.Error("Expected an error jump")
+	}
+
+	current := &RunState{GeneratedAt: time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC)}
+	current.RecordDiagnostics(files, second)
+	if len(current.DiagnosticsHistory) != 2 || current.DiagnosticsHistory[1].Errors != 4 || current.DiagnosticsHistory[1].Warnings != 1 {
+		t.Errorf("Expected the history to grow, got %+v", current.DiagnosticsHistory)
+	}
+}
+
+func TestDiagnosticsHistory_Capped(t *testing.T) {
+	previous := &RunState{}
+	for i := 0; i < DiagnosticsHistoryRuns; i++ {
+		previous.DiagnosticsHistory = append(previous.DiagnosticsHistory, DiagnosticsRun{Errors: 1})
+	}
+	index := BuildDiagnosticsIndex([]models.Diagnostic{{Severity: models.SeverityError, Code: "parse-failed", File: "pages/A.md"}})
+	index.ApplyHistory(previous)
+	if index.ErrorJump {
+		t.Error("Expected no jump for a steady error count")
+	}
+
+	state := &RunState{}
+	state.RecordDiagnostics(nil, index)
+	if len(state.DiagnosticsHistory) != DiagnosticsHistoryRuns {
+		t.Errorf("Expected the history capped at %d runs, got %d", DiagnosticsHistoryRuns, len(state.DiagnosticsHistory))
+	}
+}
+
+func TestRecordDiagnostics_Unchanged(t *testing.T) {
+	index := BuildDiagnosticsIndex([]models.Diagnostic{{Severity: models.SeverityError, Code: "parse-failed", File: "pages/A.md"}})
+	first := &RunState{GeneratedAt: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)}
+	first.RecordDiagnostics(nil, index)
+
+	// Regenerating the same notes keeps the history as it was
+	index.ApplyHistory(first)
+	second := &RunState{GeneratedAt: first.GeneratedAt.Add(time.Hour)}
+	second.RecordDiagnostics(nil, index)
+	if !reflect.DeepEqual(second.DiagnosticsHistory, first.DiagnosticsHistory) {
+		t.Errorf("Expected the history unchanged, got %+v", second.DiagnosticsHistory)
+	}
+
+	index = BuildDiagnosticsIndex(nil)
+	index.ApplyHistory(second)
+	third := &RunState{GeneratedAt: second.GeneratedAt.Add(time.Hour)}
+	third.RecordDiagnostics(nil, index)
+	if len(third.DiagnosticsHistory) != 2 || third.DiagnosticsHistory[1].Errors != 0 {
+		t.Errorf("Expected the fixed run appended, got %+v", third.DiagnosticsHistory)
+	}
+}
@@ -0,0 +1,193 @@
+package indexer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// QuickWinLimit is the longest a task can take to count as a quick win
+const QuickWinLimit = 30 * time.Minute
+
+// EffortClass is a rough size for an open task
+type EffortClass string
+
+const (
+	EffortQuick EffortClass = "quick" // Under QuickWinLimit
+	EffortDeep  EffortClass = "deep"  // Needs a focused block of time
+)
+
+// Where an effort classification came from, most reliable first
+const (
+	BasisEstimate  = "estimate"  // estimate:: or effort:: property
+	BasisLogged    = "logged"    // Time already logged on the task exceeds QuickWinLimit
+	BasisHistory   = "history"   // Median logged time of similar completed tasks
+	BasisHeuristic = "heuristic" // Wording of the description
+)
+
+// EffortEstimate is the classification of one open task
+type EffortEstimate struct {
+	Task     models.Task
+	Class    EffortClass
+	Duration time.Duration // Expected time, zero for heuristic classifications
+	Basis    string        // One of the Basis constants
+}
+
+// EffortIndex splits open tasks into quick wins and deep work.
+// Tasks with no estimate, history, or telling wording are left out.
+type EffortIndex struct {
+	GeneratedAt time.Time
+	QuickWins   []EffortEstimate // Highest priority first, then shortest
+	DeepWork    []EffortEstimate // Highest priority first, then longest
+}
+
+// quickVerbs and deepVerbs are description openings that usually signal task size
+var (
+	quickVerbs = []string{"reply", "respond", "email", "call", "ping", "text", "message", "send", "forward",
+		"book", "schedule", "pay", "renew", "cancel", "order", "sign", "file", "submit", "confirm", "check",
+		"remind", "rsvp", "share", "fix typo"}
+	deepVerbs = []string{"design", "write", "draft", "implement", "build", "research", "investigate", "refactor",
+		"migrate", "plan", "prepare", "analyze", "analyse", "architect", "learn", "study", "rewrite", "develop", "create"}
+)
+
+// effortNoiseRegex strips links, tags, and punctuation before matching descriptions
+var effortNoiseRegex = regexp.MustCompile(`\[\[[^\]]*\]\]|#\S+|@\S+|[^\p{L}\p{N}\s]`)
+
+// BuildEffortIndex classifies open, undelegated tasks. The sources are tried
+// in order: an explicit estimate, time already logged beyond QuickWinLimit,
+// the median logged time of completed tasks with similar descriptions, and
+// finally the description's opening verb.
+func BuildEffortIndex(tasks []models.Task) *EffortIndex {
+	index := &EffortIndex{GeneratedAt: time.Now()}
+
+	// Completed tasks with logged time are the history similar tasks are sized by
+	type completed struct {
+		words    map[string]bool
+		duration time.Duration
+	}
+	var history []completed
+	for _, task := range tasks {
+		if task.Status == models.StatusDONE && task.TotalDuration() > 0 {
+			history = append(history, completed{effortWords(task.Description), task.TotalDuration()})
+		}
+	}
+
+	for _, task := range tasks {
+		if models.IsClosed(task.Status) || task.DelegatedTo != "" {
+			continue
+		}
+
+		estimate := EffortEstimate{Task: task}
+		switch {
+		case task.Estimate > 0:
+			estimate.Duration = task.Estimate
+			estimate.Basis = BasisEstimate
+		case task.TotalDuration() >= QuickWinLimit:
+			estimate.Duration = task.TotalDuration()
+			estimate.Basis = BasisLogged
+		default:
+			words := effortWords(task.Description)
+			var similar []time.Duration
+			for _, done := range history {
+				if jaccard(words, done.words) >= 0.5 {
+					similar = append(similar, done.duration)
+				}
+			}
+			if len(similar) > 0 {
+				estimate.Duration = median(similar)
+				estimate.Basis = BasisHistory
+			} else if class, ok := classifyByWording(task.Description); ok {
+				estimate.Class = class
+				estimate.Basis = BasisHeuristic
+			} else {
+				continue
+			}
+		}
+
+		if estimate.Class == "" {
+			estimate.Class = EffortDeep
+			if estimate.Duration < QuickWinLimit {
+				estimate.Class = EffortQuick
+			}
+		}
+
+		if estimate.Class == EffortQuick {
+			index.QuickWins = append(index.QuickWins, estimate)
+		} else {
+			index.DeepWork = append(index.DeepWork, estimate)
+		}
+	}
+
+	sortEstimates(index.QuickWins, false)
+	sortEstimates(index.DeepWork, true)
+
+	return index
+}
+
+// sortEstimates orders by priority, then by duration (known durations first)
+func sortEstimates(estimates []EffortEstimate, longestFirst bool) {
+	sort.SliceStable(estimates, func(i, j int) bool {
+		a, b := estimates[i], estimates[j]
+		if ra, rb := priorityRank(a.Task.Priority), priorityRank(b.Task.Priority); ra != rb {
+			return ra < rb
+		}
+		if (a.Duration == 0) != (b.Duration == 0) {
+			return a.Duration != 0
+		}
+		if longestFirst {
+			return a.Duration > b.Duration
+		}
+		return a.Duration < b.Duration
+	})
+}
+
+// classifyByWording sizes a task by its opening verb, e.g. "Reply to Sam" or "Design the API"
+func classifyByWording(description string) (EffortClass, bool) {
+	text := strings.ToLower(strings.Join(strings.Fields(effortNoiseRegex.ReplaceAllString(description, " ")), " "))
+	for _, verb := range quickVerbs {
+		if text == verb || strings.HasPrefix(text, verb+" ") {
+			return EffortQuick, true
+		}
+	}
+	for _, verb := range deepVerbs {
+		if text == verb || strings.HasPrefix(text, verb+" ") {
+			return EffortDeep, true
+		}
+	}
+	return "", false
+}
+
+// effortWords returns the distinctive words of a description for similarity matching
+func effortWords(description string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(effortNoiseRegex.ReplaceAllString(description, " "))) {
+		if len([]rune(word)) >= 4 { // Skips most stop words
+			words[word] = true
+		}
+	}
+	return words
+}
+
+// jaccard returns the overlap between two word sets, 0 when either is empty
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for word := range a {
+		if b[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// median returns the middle duration (the lower middle for even counts)
+func median(durations []time.Duration) time.Duration {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)/2]
+}
@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildEffortIndex(t *testing.T) {
+	logged := func(d time.Duration) []models.LogbookEntry {
+		return []models.LogbookEntry{{Duration: d}}
+	}
+
+	tasks := []models.Task{
+		// History: completed tasks with logged time
+		{Status: models.StatusDONE, Description: "Update [[Phoenix]] dependency versions", Logbook: logged(15 * time.Minute)},
+		{Status: models.StatusDONE, Description: "Update Phoenix dependency versions again", Logbook: logged(25 * time.Minute)},
+		{Status: models.StatusDONE, Description: "Quarterly budget review", Logbook: logged(3 * time.Hour)},
+
+		{Status: models.StatusTODO, Description: "Tidy desk", Estimate: 5 * time.Minute},
+		{Status: models.StatusTODO, Description: "Quick look at logs", Estimate: 2 * time.Hour},
+		{Status: models.StatusNOW, Description: "Something started", Logbook: logged(45 * time.Minute)},
+		{Status: models.StatusTODO, Description: "Update phoenix dependency versions", Priority: models.PriorityHigh},
+		{Status: models.StatusLATER, Description: "Quarterly budget review for Q1"},
+		{Status: models.StatusTODO, Description: "Reply to [[Sam]] about the offsite"},
+		{Status: models.StatusTODO, Description: "Design the #api schema"},
+		{Status: models.StatusTODO, Description: "Mystery task"},                  // No signal
+		{Status: models.StatusTODO, Description: "Email Bob", DelegatedTo: "Bob"}, // Waiting on someone else
+	}
+
+	index := BuildEffortIndex(tasks)
+
+	wantQuick := []struct {
+		desc  string
+		basis string
+		d     time.Duration
+	}{
+		{"Update phoenix dependency versions", BasisHistory, 15 * time.Minute}, // Priority A first
+		{"Tidy desk", BasisEstimate, 5 * time.Minute},
+		{"Reply to [[Sam]] about the offsite", BasisHeuristic, 0},
+	}
+	if len(index.QuickWins) != len(wantQuick) {
+		t.Fatalf("Expected %d quick wins, got %+v", len(wantQuick), index.QuickWins)
+	}
+	for i, want := range wantQuick {
+		got := index.QuickWins[i]
+		if got.Task.Description != want.desc || got.Basis != want.basis || got.Duration != want.d || got.Class != EffortQuick {
+			t.Errorf("Quick win %d: expected %+v, got %s/%s/%v", i, want, got.Task.Description, got.Basis, got.Duration)
+		}
+	}
+
+	wantDeep := []string{"Quarterly budget review for Q1", "Quick look at logs", "Something started", "Design the #api schema"}
+	if len(index.DeepWork) != len(wantDeep) {
+		t.Fatalf("Expected %d deep work tasks, got %+v", len(wantDeep), index.DeepWork)
+	}
+	for i, desc := range wantDeep {
+		if index.DeepWork[i].Task.Description != desc {
+			t.Errorf("Deep work %d: expected %q, got %q", i, desc, index.DeepWork[i].Task.Description)
+		}
+	}
+	if basis := index.DeepWork[2].Basis; basis != BasisLogged {
+		t.Errorf("Expected logged basis for a task already past the limit, got %s", basis)
+	}
+}
+
+func TestClassifyByWording(t *testing.T) {
+	tests := []struct {
+		desc  string
+		class EffortClass
+		ok    bool
+	}{
+		{"Call the dentist", EffortQuick, true},
+		{"[[Phoenix]] - implement auth", EffortDeep, true},
+		{"Fix typo in README", EffortQuick, true},
+		{"Checkpoint review", "", false}, // "check" must be a whole word
+		{"Mystery", "", false},
+	}
+
+	for _, tt := range tests {
+		class, ok := classifyByWording(tt.desc)
+		if class != tt.class || ok != tt.ok {
+			t.Errorf("classifyByWording(%q) = %q, %v; want %q, %v", tt.desc, class, ok, tt.class, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+)
+
+// GraphDiff compares the reference graph at two git revisions
+type GraphDiff struct {
+	GeneratedAt      time.Time
+	From             string // Older revision, as given by the user
+	To               string // Newer revision
+	NewPages         []string
+	RemovedPages     []string
+	NewJournals      int      // Journal files added (listed only as a count)
+	NewHubPages      []string // Hub pages at To that weren't hubs at From
+	DroppedHubPages  []string // Hub pages at From that aren't hubs at To
+	ReferenceChanges []ReferenceChange
+}
+
+// ReferenceChange is a change in how many pages reference a page
+type ReferenceChange struct {
+	Page   string
+	Before int // Unique referencing pages at From
+	After  int // Unique referencing pages at To
+}
+
+// Delta returns the change in referencing pages
+func (rc ReferenceChange) Delta() int {
+	return rc.After - rc.Before
+}
+
+// BuildGraphDiff compares two reference graphs. Pages are matched by name;
+// journals are counted rather than listed so daily notes don't drown out new pages.
+func BuildGraphDiff(from, to *ReferenceGraph, fromRev, toRev string) *GraphDiff {
+	diff := &GraphDiff{
+		GeneratedAt: time.Now(),
+		From:        fromRev,
+		To:          toRev,
+	}
+
+	for pageName, node := range to.Nodes {
+		if node.FilePath == "" || hasFile(from, pageName) {
+			continue
+		}
+		if isJournalNode(node) {
+			diff.NewJournals++
+		} else {
+			diff.NewPages = append(diff.NewPages, pageName)
+		}
+	}
+	for pageName, node := range from.Nodes {
+		if node.FilePath != "" && !isJournalNode(node) && !hasFile(to, pageName) {
+			diff.RemovedPages = append(diff.RemovedPages, pageName)
+		}
+	}
+	sort.Strings(diff.NewPages)
+	sort.Strings(diff.RemovedPages)
+
+	diff.NewHubPages = missingFrom(to.HubPages, from.HubPages)
+	diff.DroppedHubPages = missingFrom(from.HubPages, to.HubPages)
+
+	// Reference count changes across every page in either graph
+	pages := make(map[string]bool)
+	for pageName := range from.Nodes {
+		pages[pageName] = true
+	}
+	for pageName := range to.Nodes {
+		pages[pageName] = true
+	}
+	for pageName := range pages {
+		change := ReferenceChange{Page: pageName}
+		if node, exists := from.Nodes[pageName]; exists {
+			change.Before = node.ReferenceCount
+		}
+		if node, exists := to.Nodes[pageName]; exists {
+			change.After = node.ReferenceCount
+		}
+		if change.Delta() != 0 {
+			diff.ReferenceChanges = append(diff.ReferenceChanges, change)
+		}
+	}
+
+	// Biggest changes first, growth before decline at equal size
+	sort.Slice(diff.ReferenceChanges, func(i, j int) bool {
+		a, b := diff.ReferenceChanges[i], diff.ReferenceChanges[j]
+		if abs(a.Delta()) != abs(b.Delta()) {
+			return abs(a.Delta()) > abs(b.Delta())
+		}
+		if a.Delta() != b.Delta() {
+			return a.Delta() > b.Delta()
+		}
+		return a.Page < b.Page
+	})
+
+	return diff
+}
+
+// hasFile checks if a page exists as a file in the graph
+func hasFile(graph *ReferenceGraph, pageName string) bool {
+	node, exists := graph.Nodes[pageName]
+	return exists && node.FilePath != ""
+}
+
+// missingFrom returns the items of list that aren't in other, keeping list's order
+func missingFrom(list, other []string) []string {
+	var result []string
+	for _, item := range list {
+		if !contains(other, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildGraphDiff(t *testing.T) {
+	ref := func(source, target string) models.PageReference {
+		return models.PageReference{SourcePage: source, TargetPage: target}
+	}
+
+	from := BuildReferenceGraph(
+		[]models.PageReference{ref("Alpha", "Beta"), ref("Old", "Beta"), ref("2025_11_01", "Alpha")},
+		[]models.File{
+			{Path: "pages/Alpha.md"},
+			{Path: "pages/Beta.md"},
+			{Path: "pages/Old.md"},
+			{Path: "journals/2025_11_01.md"},
+		},
+	)
+	to := BuildReferenceGraph(
+		[]models.PageReference{
+			ref("Alpha", "Gamma"), ref("Beta", "Gamma"), ref("2025_11_02", "Gamma"),
+			ref("2025_11_01", "Alpha"), ref("Alpha", "Beta"),
+		},
+		[]models.File{
+			{Path: "pages/Alpha.md"},
+			{Path: "pages/Beta.md"},
+			{Path: "pages/Gamma.md"},
+			{Path: "journals/2025_11_01.md"},
+			{Path: "journals/2025_11_02.md"},
+		},
+	)
+
+	diff := BuildGraphDiff(from, to, "HEAD~30", "HEAD")
+
+	if !reflect.DeepEqual(diff.NewPages, []string{"Gamma"}) {
+		t.Errorf("NewPages = %v, want [Gamma]", diff.NewPages)
+	}
+	if !reflect.DeepEqual(diff.RemovedPages, []string{"Old"}) {
+		t.Errorf("RemovedPages = %v, want [Old]", diff.RemovedPages)
+	}
+	if diff.NewJournals != 1 {
+		t.Errorf("Expected 1 new journal, got %d", diff.NewJournals)
+	}
+	if !reflect.DeepEqual(diff.NewHubPages, []string{"Gamma"}) {
+		t.Errorf("NewHubPages = %v, want [Gamma]", diff.NewHubPages)
+	}
+	if len(diff.DroppedHubPages) != 0 {
+		t.Errorf("Expected no dropped hubs, got %v", diff.DroppedHubPages)
+	}
+
+	want := []ReferenceChange{
+		{Page: "Gamma", Before: 0, After: 3},
+		{Page: "Beta", Before: 2, After: 1},
+	}
+	if !reflect.DeepEqual(diff.ReferenceChanges, want) {
+		t.Errorf("ReferenceChanges = %+v, want %+v", diff.ReferenceChanges, want)
+	}
+}
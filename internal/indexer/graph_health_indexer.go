@@ -0,0 +1,142 @@
+package indexer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LinkBackSuggestion is a one-way relationship worth making bidirectional
+type LinkBackSuggestion struct {
+	From   string // Page that links heavily
+	To     string // Page that never links back
+	Weight int    // Times From references To
+}
+
+// PageLinkHealth scores how many of a page's outbound links lead somewhere
+type PageLinkHealth struct {
+	Page       string
+	FilePath   string
+	Outbound   int      // Distinct pages linked
+	Unresolved []string // Linked pages that don't exist, sorted
+}
+
+// Score returns the fraction of outbound links that resolve to existing pages
+func (h PageLinkHealth) Score() float64 {
+	if h.Outbound == 0 {
+		return 1
+	}
+	return float64(h.Outbound-len(h.Unresolved)) / float64(h.Outbound)
+}
+
+// GraphHealthIndex collects navigability problems in the reference graph
+type GraphHealthIndex struct {
+	GeneratedAt         time.Time
+	LinkBackThreshold   int                  // Minimum references before suggesting a link back
+	LinkBackSuggestions []LinkBackSuggestion // Sorted by weight descending
+	MinOutboundLinks    int                  // Minimum distinct links before a page is scored (see ApplyLinkHealth)
+	LinkHealth          []PageLinkHealth     // Pages with unresolved links, worst score first
+	NamespaceHints      []NamespaceHint      // Fragmented namespaces (see ApplyNamespaceHints)
+}
+
+// BuildGraphHealthIndex analyses the reference graph for structural issues.
+// Link-back suggestions are made when an existing page references another
+// existing page at least linkBackThreshold times without being linked back.
+// Journal pages are never suggested as link-back targets or sources.
+func BuildGraphHealthIndex(graph *ReferenceGraph, linkBackThreshold int) *GraphHealthIndex {
+	index := &GraphHealthIndex{
+		GeneratedAt:       time.Now(),
+		LinkBackThreshold: linkBackThreshold,
+	}
+
+	for from, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+
+		for to, weight := range node.OutboundWeights {
+			if weight < linkBackThreshold || to == from {
+				continue
+			}
+			target, exists := graph.Nodes[to]
+			if !exists || target.FilePath == "" || isJournalNode(target) {
+				continue
+			}
+			if target.OutboundWeights[from] > 0 {
+				continue
+			}
+			index.LinkBackSuggestions = append(index.LinkBackSuggestions, LinkBackSuggestion{
+				From:   from,
+				To:     to,
+				Weight: weight,
+			})
+		}
+	}
+
+	sort.Slice(index.LinkBackSuggestions, func(i, j int) bool {
+		a, b := index.LinkBackSuggestions[i], index.LinkBackSuggestions[j]
+		if a.Weight != b.Weight {
+			return a.Weight > b.Weight
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+
+	return index
+}
+
+// journalTitleRegex matches Logseq's default journal page title, e.g. "Nov 6th, 2025"
+var journalTitleRegex = regexp.MustCompile(`^[A-Z][a-z]{2} \d{1,2}(st|nd|rd|th), \d{4}$`)
+
+// isJournalNode checks if a graph node is backed by a journal file
+func isJournalNode(node *GraphNode) bool {
+	return strings.HasPrefix(filepath.ToSlash(node.FilePath), "journals/")
+}
+
+// ApplyLinkHealth scores existing pages by the fraction of their outbound
+// links that resolve to existing pages, keeping those with at least one
+// unresolved link. Pages linking fewer than minOutbound distinct pages are
+// skipped so a single stub link doesn't dominate, as are journals, whose
+// links to pages not yet written are the normal Logseq workflow. Links to
+// dates ("Nov 6th, 2025") open journals and aren't scored.
+func (index *GraphHealthIndex) ApplyLinkHealth(graph *ReferenceGraph, minOutbound int) {
+	index.MinOutboundLinks = minOutbound
+	index.LinkHealth = nil
+
+	for name, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+
+		health := PageLinkHealth{Page: name, FilePath: node.FilePath}
+		for _, target := range node.OutboundRefs {
+			if target == name || journalTitleRegex.MatchString(target) {
+				continue
+			}
+			health.Outbound++
+			if t, exists := graph.Nodes[target]; !exists || t.FilePath == "" {
+				health.Unresolved = append(health.Unresolved, target)
+			}
+		}
+		if health.Outbound < minOutbound || len(health.Unresolved) == 0 {
+			continue
+		}
+		sort.Strings(health.Unresolved)
+		index.LinkHealth = append(index.LinkHealth, health)
+	}
+
+	sort.Slice(index.LinkHealth, func(i, j int) bool {
+		a, b := index.LinkHealth[i], index.LinkHealth[j]
+		if a.Score() != b.Score() {
+			return a.Score() < b.Score()
+		}
+		if len(a.Unresolved) != len(b.Unresolved) {
+			return len(a.Unresolved) > len(b.Unresolved)
+		}
+		return a.Page < b.Page
+	})
+}
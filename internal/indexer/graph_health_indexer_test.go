@@ -0,0 +1,135 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildGraphHealthIndex_LinkBack(t *testing.T) {
+	var refs []models.PageReference
+	repeat := func(source, target string, n int) {
+		for i := 0; i < n; i++ {
+			refs = append(refs, models.PageReference{SourcePage: source, TargetPage: target})
+		}
+	}
+	repeat("Project", "Person", 4)  // One-way, above threshold
+	repeat("Project", "Tool", 2)    // Below threshold
+	repeat("Team", "Process", 3)    // Linked back
+	repeat("Process", "Team", 1)    //
+	repeat("Project", "Missing", 5) // Target has no file
+	repeat("2025_11_01", "Team", 6) // Journal source
+
+	files := []models.File{
+		{Path: "pages/Project.md"},
+		{Path: "pages/Person.md"},
+		{Path: "pages/Tool.md"},
+		{Path: "pages/Team.md"},
+		{Path: "pages/Process.md"},
+		{Path: "journals/2025_11_01.md", Type: models.FileTypeJournal},
+	}
+
+	index := BuildGraphHealthIndex(BuildReferenceGraph(refs, files), 3)
+
+	if len(index.LinkBackSuggestions) != 1 {
+		t.Fatalf("Expected 1 suggestion, got %v", index.LinkBackSuggestions)
+	}
+	s := index.LinkBackSuggestions[0]
+	if s.From != "Project" || s.To != "Person" || s.Weight != 4 {
+		t.Errorf("Unexpected suggestion %+v", s)
+	}
+}
+
+func TestApplyLinkHealth(t *testing.T) {
+	var refs []models.PageReference
+	link := func(source string, targets ...string) {
+		for _, target := range targets {
+			refs = append(refs, models.PageReference{SourcePage: source, TargetPage: target})
+		}
+	}
+	link("Project", "Person", "Stub A", "Stub B", "Stub A")     // 1 of 3 resolves
+	link("Team", "Person", "Project", "Team", "Stub C", "Tool") // 3 of 4 resolve; self-link ignored
+	link("Tool", "Stub A", "Stub B")                            // Too few links to score
+	link("Person", "Project", "Team", "Tool", "Nov 6th, 2025")  // All resolve; date links open journals
+	link("2025_11_01", "Stub A", "Stub B", "Stub C")            // Journal
+
+	files := []models.File{
+		{Path: "pages/Project.md"},
+		{Path: "pages/Person.md"},
+		{Path: "pages/Team.md"},
+		{Path: "pages/Tool.md"},
+		{Path: "journals/2025_11_01.md", Type: models.FileTypeJournal},
+	}
+
+	graph := BuildReferenceGraph(refs, files)
+	index := BuildGraphHealthIndex(graph, 3)
+	index.ApplyLinkHealth(graph, 3)
+
+	if len(index.LinkHealth) != 2 {
+		t.Fatalf("Expected 2 scored pages, got %+v", index.LinkHealth)
+	}
+
+	worst := index.LinkHealth[0]
+	if worst.Page != "Project" || worst.Outbound != 3 || len(worst.Unresolved) != 2 {
+		t.Errorf("Unexpected worst page %+v", worst)
+	}
+	if score := worst.Score(); score < 0.33 || score > 0.34 {
+		t.Errorf("Expected score 1/3, got %f", score)
+	}
+	if worst.Unresolved[0] != "Stub A" || worst.Unresolved[1] != "Stub B" {
+		t.Errorf("Expected sorted unresolved links, got %v", worst.Unresolved)
+	}
+
+	team := index.LinkHealth[1]
+	if team.Page != "Team" || team.Outbound != 4 || team.Score() != 0.75 {
+		t.Errorf("Unexpected second page %+v (score %f)", team, team.Score())
+	}
+}
+
+func TestApplyNamespaceHints(t *testing.T) {
+	files := []models.File{
+		{Path: "pages/Projects___Web.md"},
+		{Path: "pages/Projects___Mobile.md"},
+		{Path: "pages/Projects___Mobile___Release.md"}, // Projects/Mobile exists, with one page below
+		{Path: "pages/Areas___Health.md"},              // Only page in Areas
+		{Path: "pages/Tools___Editor.md"},
+		{Path: "pages/Editor.md"}, // Flattening Tools/Editor would collide
+		{Path: "pages/People.md"},
+		{Path: "pages/People%2FAlice.md"},
+		{Path: "pages/People%2FBob.md"}, // Has its parent page: fine
+		{Path: "journals/2025_11_01.md", Type: models.FileTypeJournal},
+	}
+	// A link to the missing parent doesn't make it exist
+	refs := []models.PageReference{{SourcePage: "2025_11_01", TargetPage: "Projects"}}
+
+	graph := BuildReferenceGraph(refs, files)
+	index := BuildGraphHealthIndex(graph, 3)
+	index.ApplyNamespaceHints(graph)
+
+	got := make(map[string]NamespaceHint)
+	for _, h := range index.NamespaceHints {
+		got[h.Namespace] = h
+	}
+	if len(got) != 4 {
+		t.Fatalf("Expected 4 hints, got %+v", index.NamespaceHints)
+	}
+	if index.NamespaceHints[0].Namespace != "Projects" {
+		t.Errorf("Expected create hints first, got %+v", index.NamespaceHints)
+	}
+
+	if h := got["Projects"]; h.Action != NamespaceCreate || h.ParentExists || len(h.Pages) != 3 {
+		t.Errorf("Unexpected Projects hint %+v", h)
+	}
+	if h := got["Projects/Mobile"]; h.Action != NamespaceMerge || h.Target != "Projects/Mobile" {
+		t.Errorf("Expected merge into parent, got %+v", h)
+	}
+	if h := got["Areas"]; h.Action != NamespaceFlatten || h.Target != "Health" {
+		t.Errorf("Expected flatten to Health, got %+v", h)
+	}
+	if h := got["Tools"]; h.Action != NamespaceMerge || h.Target != "Editor" {
+		t.Errorf("Expected merge into Editor, got %+v", h)
+	}
+	if _, ok := got["People"]; ok {
+		t.Error("People has several pages and a parent page, expected no hint")
+	}
+}
@@ -2,6 +2,7 @@ package indexer
 
 import (
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
@@ -11,7 +12,8 @@ import (
 type ReferenceGraph struct {
 	GeneratedAt time.Time
 	Nodes       map[string]*GraphNode // Page name -> Node
-	HubPages    []string              // Most referenced pages (sorted by ref count)
+	HubPages    []string              // Most referenced pages (sorted by ref count, pinned first)
+	Pinned      []string              // Pages pinned via config.edn favorites or the Contents page
 }
 
 // GraphNode represents a page in the reference graph
@@ -21,6 +23,72 @@ type GraphNode struct {
 	OutboundRefs   []string // Pages this page references
 	InboundRefs    []string // Pages that reference this page
 	ReferenceCount int      // Total inbound references (for ranking)
+	Pinned         bool     // Explicitly pinned by the user (favorites/Contents)
+	Keywords       []string // Top TF-IDF keywords (see ApplyKeywords)
+	Language       string   // Detected language code, "" if unknown (see ApplyLanguages)
+
+	// Edge weights: how many times each linked page is referenced (every
+	// occurrence counts, unlike the deduplicated ref lists above)
+	OutboundWeights map[string]int // Target page -> occurrences in this page
+	InboundWeights  map[string]int // Source page -> occurrences pointing here
+	InboundWeight   int            // Sum of InboundWeights
+
+	// Counts before ApplyNoiseFilter; equal to the ones above without it
+	RawReferenceCount int
+	RawInboundWeight  int
+
+	// Task project references kept apart from the counts above (see ApplyProjectRefs)
+	ProjectWeights map[string]int // Source page -> tasks there with this page as their project
+	ProjectTasks   int            // Sum of ProjectWeights
+
+	// Tasks linking this page and the time logged on them (see ApplyTaskStats)
+	TaskCount  int
+	TimeLogged time.Duration
+}
+
+// ProjectRefMode controls how task project references (the first [[page]]
+// on a task line) count towards the reference graph
+type ProjectRefMode string
+
+const (
+	ProjectRefsCount    ProjectRefMode = "count"    // Ordinary references (default)
+	ProjectRefsExclude  ProjectRefMode = "exclude"  // Left out of the graph
+	ProjectRefsSeparate ProjectRefMode = "separate" // Project edges, outside reference counts and hub rankings
+)
+
+// SplitProjectRefs separates task project references from the rest, so
+// project-tagging conventions don't dominate hub rankings
+func SplitProjectRefs(refs []models.PageReference) (other, project []models.PageReference) {
+	for _, ref := range refs {
+		if ref.Project {
+			project = append(project, ref)
+		} else {
+			other = append(other, ref)
+		}
+	}
+	return other, project
+}
+
+// ApplyProjectRefs records project references (see SplitProjectRefs) as a
+// separate edge type. They don't change reference counts or hub pages.
+func (rg *ReferenceGraph) ApplyProjectRefs(refs []models.PageReference) {
+	for _, ref := range refs {
+		if _, exists := rg.Nodes[ref.TargetPage]; !exists {
+			rg.Nodes[ref.TargetPage] = newGraphNode(ref.TargetPage, "") // No file yet
+		}
+		target := rg.Nodes[ref.TargetPage]
+		target.ProjectWeights[ref.SourcePage]++
+		target.ProjectTasks++
+	}
+}
+
+// EdgeWeight returns how many times source references target
+func (rg *ReferenceGraph) EdgeWeight(source, target string) int {
+	node, exists := rg.Nodes[source]
+	if !exists {
+		return 0
+	}
+	return node.OutboundWeights[target]
 }
 
 // BuildReferenceGraph creates a ReferenceGraph from page references and files
@@ -33,12 +101,7 @@ func BuildReferenceGraph(refs []models.PageReference, files []models.File) *Refe
 	// Create nodes for all files
 	for _, file := range files {
 		pageName := extractPageNameFromPath(file.Path)
-		graph.Nodes[pageName] = &GraphNode{
-			PageName:     pageName,
-			FilePath:     file.Path,
-			OutboundRefs: []string{},
-			InboundRefs:  []string{},
-		}
+		graph.Nodes[pageName] = newGraphNode(pageName, file.Path)
 	}
 
 	// Add references
@@ -49,24 +112,24 @@ func BuildReferenceGraph(refs []models.PageReference, files []models.File) *Refe
 			if !contains(node.OutboundRefs, ref.TargetPage) {
 				node.OutboundRefs = append(node.OutboundRefs, ref.TargetPage)
 			}
+			node.OutboundWeights[ref.TargetPage]++
 		}
 
 		// Add inbound reference (even if target page doesn't exist yet)
 		// This handles references to pages that haven't been created
 		if _, exists := graph.Nodes[ref.TargetPage]; !exists {
-			graph.Nodes[ref.TargetPage] = &GraphNode{
-				PageName:     ref.TargetPage,
-				FilePath:     "", // No file yet
-				OutboundRefs: []string{},
-				InboundRefs:  []string{},
-			}
+			graph.Nodes[ref.TargetPage] = newGraphNode(ref.TargetPage, "") // No file yet
 		}
 
 		targetNode := graph.Nodes[ref.TargetPage]
 		if !contains(targetNode.InboundRefs, ref.SourcePage) {
 			targetNode.InboundRefs = append(targetNode.InboundRefs, ref.SourcePage)
 			targetNode.ReferenceCount++
+			targetNode.RawReferenceCount++
 		}
+		targetNode.InboundWeights[ref.SourcePage]++
+		targetNode.InboundWeight++
+		targetNode.RawInboundWeight++
 	}
 
 	// Identify hub pages
@@ -75,24 +138,48 @@ func BuildReferenceGraph(refs []models.PageReference, files []models.File) *Refe
 	return graph
 }
 
+// newGraphNode creates an empty node; filePath is empty for pages without a file
+func newGraphNode(pageName, filePath string) *GraphNode {
+	return &GraphNode{
+		PageName:        pageName,
+		FilePath:        filePath,
+		OutboundRefs:    []string{},
+		InboundRefs:     []string{},
+		OutboundWeights: make(map[string]int),
+		InboundWeights:  make(map[string]int),
+		ProjectWeights:  make(map[string]int),
+	}
+}
+
 // findHubPages returns the top N most referenced pages
 func findHubPages(nodes map[string]*GraphNode, topN int) []string {
 	// Create sorted list of nodes by reference count
 	type nodeCount struct {
 		pageName string
-		count    int
+		weight   int // Total inbound occurrences
+		count    int // Unique referencing pages
 	}
 
 	var counts []nodeCount
 	for pageName, node := range nodes {
-		if node.ReferenceCount > 0 { // Only include pages with references
-			counts = append(counts, nodeCount{pageName, node.ReferenceCount})
+		if node.ReferenceCount > 0 || node.Pinned { // Only include pages with references (or pinned)
+			counts = append(counts, nodeCount{pageName, node.InboundWeight, node.ReferenceCount})
 		}
 	}
 
-	// Sort by count descending
+	// Sort pinned pages first, then by edge weight, then by unique referrers
 	sort.Slice(counts, func(i, j int) bool {
-		return counts[i].count > counts[j].count
+		pi, pj := nodes[counts[i].pageName].Pinned, nodes[counts[j].pageName].Pinned
+		if pi != pj {
+			return pi
+		}
+		if counts[i].weight != counts[j].weight {
+			return counts[i].weight > counts[j].weight
+		}
+		if counts[i].count != counts[j].count {
+			return counts[i].count > counts[j].count
+		}
+		return counts[i].pageName < counts[j].pageName
 	})
 
 	// Take top N
@@ -109,30 +196,98 @@ func findHubPages(nodes map[string]*GraphNode, topN int) []string {
 	return result
 }
 
-// extractPageNameFromPath converts a file path to page name
-func extractPageNameFromPath(filePath string) string {
-	// Use the same logic as parser
-	// This would be better imported from parser, but to avoid circular deps
-	// we duplicate it here (or we could move it to a shared utils package)
-	// For now, we'll use the basename logic
+// contentsPage is the Logseq page whose links make up the sidebar Contents
+const contentsPage = "contents"
 
-	// Find the last slash
-	lastSlash := -1
-	for i := len(filePath) - 1; i >= 0; i-- {
-		if filePath[i] == '/' || filePath[i] == '\\' {
-			lastSlash = i
-			break
+// ApplyPinned marks pages pinned through config.edn favorites or links on the
+// Contents page, and re-ranks hub pages so pinned pages come first.
+// Names are matched case-insensitively since Logseq stores favorites lowercased.
+func (rg *ReferenceGraph) ApplyPinned(favorites []string, refs []models.PageReference) {
+	candidates := append([]string{}, favorites...)
+	for _, ref := range refs {
+		if strings.EqualFold(ref.SourcePage, contentsPage) {
+			candidates = append(candidates, ref.TargetPage)
 		}
 	}
 
-	basename := filePath[lastSlash+1:]
+	lookup := make(map[string]string, len(rg.Nodes))
+	for pageName := range rg.Nodes {
+		lookup[strings.ToLower(pageName)] = pageName
+	}
 
-	// Remove .md extension
-	if len(basename) > 3 && basename[len(basename)-3:] == ".md" {
-		return basename[:len(basename)-3]
+	rg.Pinned = nil
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		pageName, exists := lookup[strings.ToLower(candidate)]
+		if !exists || seen[pageName] {
+			continue
+		}
+		seen[pageName] = true
+		rg.Nodes[pageName].Pinned = true
+		rg.Pinned = append(rg.Pinned, pageName)
 	}
 
-	return basename
+	rg.HubPages = findHubPages(rg.Nodes, 10)
+}
+
+// ApplyTaskStats counts, for each page, the tasks that link it and the time
+// logged on them. A task linking several pages counts towards each.
+func (rg *ReferenceGraph) ApplyTaskStats(tasks []models.Task) {
+	for _, node := range rg.Nodes {
+		node.TaskCount = 0
+		node.TimeLogged = 0
+	}
+	for _, task := range tasks {
+		seen := make(map[string]bool, len(task.PageRefs))
+		for _, page := range task.PageRefs {
+			node, exists := rg.Nodes[page]
+			if !exists || seen[page] {
+				continue
+			}
+			seen[page] = true
+			node.TaskCount++
+			node.TimeLogged += task.TotalDuration()
+		}
+	}
+}
+
+// ApplyKeywords attaches each page's top keywords to its graph node
+func (rg *ReferenceGraph) ApplyKeywords(keywords *KeywordIndex) {
+	for pageName, node := range rg.Nodes {
+		node.Keywords = keywords.Terms(pageName)
+	}
+}
+
+// ApplyLanguages records each page's detected language (page name -> language code).
+// Pages without a file take the most common language of the pages referencing them.
+func (rg *ReferenceGraph) ApplyLanguages(languages map[string]string) {
+	for pageName, node := range rg.Nodes {
+		node.Language = languages[pageName]
+	}
+
+	for _, node := range rg.Nodes {
+		if node.FilePath != "" {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, source := range node.InboundRefs {
+			if lang := languages[source]; lang != "" {
+				counts[lang]++
+			}
+		}
+		best := 0
+		for lang, count := range counts {
+			if count > best || (count == best && lang < node.Language) {
+				node.Language = lang
+				best = count
+			}
+		}
+	}
+}
+
+// extractPageNameFromPath converts a file path to page name
+func extractPageNameFromPath(filePath string) string {
+	return models.PageName(filePath)
 }
 
 // contains checks if a string slice contains a value
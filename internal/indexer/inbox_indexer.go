@@ -0,0 +1,163 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// maxInboxDestinations caps the destinations suggested per inbox item
+const maxInboxDestinations = 3
+
+// InboxEntry is an unprocessed inbox item with where it might go
+type InboxEntry struct {
+	Item         models.InboxItem
+	Captured     time.Time // Journal date, or a date the item links; zero if unknown
+	AgeDays      int       // Days since Captured, -1 if unknown
+	Destinations []InboxDestination
+}
+
+// InboxDestination is a page an inbox item could be moved or linked to
+type InboxDestination struct {
+	Page    string
+	Matches []string // Words the item shares with the page's name or keywords; nil when the item already links it
+}
+
+// InboxIndex lists the quick-capture items waiting to be processed
+type InboxIndex struct {
+	GeneratedAt time.Time
+	Page        string       // Inbox page, "" if none is configured
+	Tag         string       // Inbox tag without the #, "" if none is configured
+	Items       []InboxEntry // Oldest first; undated items follow in file order
+}
+
+// inboxCandidate is an existing project page prepared for keyword matching
+type inboxCandidate struct {
+	name     string
+	nameWord map[string]bool
+	keyword  map[string]bool
+}
+
+// BuildInboxIndex dates each inbox item and suggests destinations: existing
+// pages the item already links, then projects (pages linked from tasks)
+// sharing words with the item, a word in the page name counting double a
+// keyword. A project needs a score of 2 to be suggested.
+func BuildInboxIndex(items []models.InboxItem, graph *ReferenceGraph, page, tag string, now time.Time) *InboxIndex {
+	index := &InboxIndex{GeneratedAt: now, Page: page, Tag: tag}
+
+	existing := make(map[string]string) // Lowercase name -> name, for existing pages
+	var candidates []inboxCandidate
+	for key, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+		name := namespacedName(key)
+		if strings.EqualFold(name, page) {
+			continue
+		}
+		existing[strings.ToLower(name)] = name
+		if node.TaskCount == 0 && node.ProjectTasks == 0 {
+			continue
+		}
+		c := inboxCandidate{name: name, nameWord: make(map[string]bool), keyword: make(map[string]bool)}
+		for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if len(word) >= 3 {
+				c.nameWord[word] = true
+			}
+		}
+		for _, keyword := range node.Keywords {
+			c.keyword[strings.ToLower(keyword)] = true
+		}
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].name < candidates[j].name })
+
+	today := startOfDay(now)
+	for _, item := range items {
+		entry := InboxEntry{Item: item, Captured: item.Date, AgeDays: -1}
+		if date, err := extractDateFromJournalPath(item.SourceFile); err == nil && isJournalPath(item.SourceFile) {
+			entry.Captured = date
+		}
+		if !entry.Captured.IsZero() {
+			entry.AgeDays = max(0, int(today.Sub(startOfDay(entry.Captured)).Hours()/24))
+		}
+
+		linked := make(map[string]bool)
+		for _, link := range item.Links {
+			if name, ok := existing[strings.ToLower(namespacedName(link))]; ok && !linked[name] {
+				linked[name] = true
+				entry.Destinations = append(entry.Destinations, InboxDestination{Page: name})
+			}
+		}
+
+		type scored struct {
+			name    string
+			score   int
+			matches []string
+		}
+		var matches []scored
+		for _, c := range candidates {
+			if linked[c.name] {
+				continue
+			}
+			s := scored{name: c.name}
+			seen := make(map[string]bool)
+			for _, word := range item.Words {
+				if seen[word] {
+					continue
+				}
+				seen[word] = true
+				switch {
+				case c.nameWord[word]:
+					s.score += 2
+				case c.keyword[word]:
+					s.score++
+				default:
+					continue
+				}
+				s.matches = append(s.matches, word)
+			}
+			if s.score >= 2 {
+				matches = append(matches, s)
+			}
+		}
+		sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+		for _, m := range matches {
+			entry.Destinations = append(entry.Destinations, InboxDestination{Page: m.name, Matches: m.matches})
+		}
+		if len(entry.Destinations) > maxInboxDestinations {
+			entry.Destinations = entry.Destinations[:maxInboxDestinations]
+		}
+
+		index.Items = append(index.Items, entry)
+	}
+
+	sort.SliceStable(index.Items, func(i, j int) bool {
+		a, b := index.Items[i], index.Items[j]
+		if a.Captured.IsZero() != b.Captured.IsZero() {
+			return !a.Captured.IsZero()
+		}
+		if !a.Captured.Equal(b.Captured) {
+			return a.Captured.Before(b.Captured)
+		}
+		if a.Item.SourceFile != b.Item.SourceFile {
+			return a.Item.SourceFile < b.Item.SourceFile
+		}
+		return a.Item.LineNumber < b.Item.LineNumber
+	})
+
+	return index
+}
+
+// Oldest returns the age of the oldest dated item, or -1 if none is dated
+func (index *InboxIndex) Oldest() int {
+	if len(index.Items) == 0 {
+		return -1
+	}
+	return index.Items[0].AgeDays
+}
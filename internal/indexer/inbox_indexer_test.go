@@ -0,0 +1,51 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildInboxIndex(t *testing.T) {
+	now := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Phoenix":    {PageName: "Phoenix", FilePath: "pages/Phoenix.md", TaskCount: 3, Keywords: []string{"deploy", "pipeline"}},
+		"Kubernetes": {PageName: "Kubernetes", FilePath: "pages/Kubernetes.md"},
+		"Hiring":     {PageName: "Hiring", FilePath: "pages/Hiring.md", TaskCount: 1, Keywords: []string{"pipeline"}},
+		"Inbox":      {PageName: "Inbox", FilePath: "pages/Inbox.md", TaskCount: 1},
+		"Missing":    {PageName: "Missing"},
+	}}
+	items := []models.InboxItem{
+		{SourceFile: "pages/Inbox.md", LineNumber: 1, Text: "Book dentist", Words: []string{"book", "dentist"}},
+		{SourceFile: "pages/Inbox.md", LineNumber: 3, Text: "Read about operators", Links: []string{"kubernetes", "Missing"},
+			Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)},
+		{SourceFile: "journals/2025_11_01.md", LineNumber: 2, Text: "Speed up the deploy pipeline",
+			Words: []string{"speed", "deploy", "pipeline"}},
+	}
+
+	index := BuildInboxIndex(items, graph, "Inbox", "inbox", now)
+
+	if len(index.Items) != 3 {
+		t.Fatalf("Expected 3 items, got %d", len(index.Items))
+	}
+	if index.Oldest() != 9 {
+		t.Errorf("Expected the oldest item to be 9 days old, got %d", index.Oldest())
+	}
+
+	journal := index.Items[0]
+	if journal.Item.LineNumber != 2 || len(journal.Destinations) != 1 || journal.Destinations[0].Page != "Phoenix" {
+		t.Errorf("Expected the journal item first with Phoenix suggested, got %+v", journal)
+	}
+
+	linked := index.Items[1]
+	if linked.AgeDays != 7 || len(linked.Destinations) != 1 ||
+		linked.Destinations[0].Page != "Kubernetes" || linked.Destinations[0].Matches != nil {
+		t.Errorf("Expected the linked page as the only destination, got %+v", linked)
+	}
+
+	undated := index.Items[2]
+	if undated.AgeDays != -1 || len(undated.Destinations) != 0 {
+		t.Errorf("Expected an undated item without destinations last, got %+v", undated)
+	}
+}
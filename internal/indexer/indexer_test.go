@@ -235,6 +235,64 @@ func TestBuildReferenceGraph_NonExistentTargets(t *testing.T) {
 	}
 }
 
+func TestApplyProjectRefs(t *testing.T) {
+	files := []models.File{
+		{Path: "pages/Page A.md", Type: models.FileTypePage},
+		{Path: "pages/Phoenix.md", Type: models.FileTypePage},
+	}
+	refs := []models.PageReference{
+		{SourcePage: "Page A", TargetPage: "Phoenix", Project: true},
+		{SourcePage: "Page A", TargetPage: "Phoenix", Project: true},
+		{SourcePage: "Page A", TargetPage: "Design"},
+	}
+
+	other, project := SplitProjectRefs(refs)
+	if len(other) != 1 || len(project) != 2 {
+		t.Fatalf("Expected 1 other and 2 project refs, got %d and %d", len(other), len(project))
+	}
+
+	graph := BuildReferenceGraph(other, files)
+	graph.ApplyProjectRefs(project)
+
+	phoenix := graph.Nodes["Phoenix"]
+	if phoenix.ReferenceCount != 0 || phoenix.InboundWeight != 0 {
+		t.Errorf("Expected project refs outside reference counts, got %d/%d", phoenix.ReferenceCount, phoenix.InboundWeight)
+	}
+	if phoenix.ProjectTasks != 2 || phoenix.ProjectWeights["Page A"] != 2 {
+		t.Errorf("Expected 2 project tasks from Page A, got %d (%v)", phoenix.ProjectTasks, phoenix.ProjectWeights)
+	}
+	for _, hub := range graph.HubPages {
+		if hub == "Phoenix" {
+			t.Error("Expected Phoenix to stay out of the hub pages")
+		}
+	}
+}
+
+func TestApplyTaskStats(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "Journal", TargetPage: "Alpha"},
+		{SourcePage: "Journal", TargetPage: "Beta"},
+	}
+	graph := BuildReferenceGraph(refs, nil)
+
+	hour := models.LogbookEntry{Duration: time.Hour}
+	graph.ApplyTaskStats([]models.Task{
+		{Description: "Both", PageRefs: []string{"Alpha", "Beta", "Alpha"}, Logbook: []models.LogbookEntry{hour}},
+		{Description: "Alpha only", PageRefs: []string{"Alpha"}, Logbook: []models.LogbookEntry{hour, hour}},
+		{Description: "Unknown page", PageRefs: []string{"Gamma"}},
+	})
+
+	if alpha := graph.Nodes["Alpha"]; alpha.TaskCount != 2 || alpha.TimeLogged != 3*time.Hour {
+		t.Errorf("Alpha = %d tasks, %v; want 2 tasks, 3h", alpha.TaskCount, alpha.TimeLogged)
+	}
+	if beta := graph.Nodes["Beta"]; beta.TaskCount != 1 || beta.TimeLogged != time.Hour {
+		t.Errorf("Beta = %d tasks, %v; want 1 task, 1h", beta.TaskCount, beta.TimeLogged)
+	}
+	if _, exists := graph.Nodes["Gamma"]; exists {
+		t.Error("ApplyTaskStats should not add nodes")
+	}
+}
+
 func TestGetOrphanPages(t *testing.T) {
 	files := []models.File{
 		{Path: "pages/Connected.md"},
@@ -288,3 +346,158 @@ func TestExtractPageNameFromPath(t *testing.T) {
 		}
 	}
 }
+
+func TestApplyPinned(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "2025_11_01", TargetPage: "Busy Page"},
+		{SourcePage: "2025_11_02", TargetPage: "Busy Page"},
+		{SourcePage: "2025_11_03", TargetPage: "Busy Page"},
+		{SourcePage: "2025_11_01", TargetPage: "Quiet Page"},
+		{SourcePage: "contents", TargetPage: "Quiet Page"},
+	}
+	files := []models.File{
+		{Path: "pages/Busy Page.md"},
+		{Path: "pages/Quiet Page.md"},
+		{Path: "pages/Favorite Page.md"},
+		{Path: "pages/contents.md"},
+	}
+
+	graph := BuildReferenceGraph(refs, files)
+	graph.ApplyPinned([]string{"favorite page", "does not exist", "QUIET PAGE"}, refs)
+
+	if len(graph.Pinned) != 2 {
+		t.Fatalf("Expected 2 pinned pages, got %v", graph.Pinned)
+	}
+	if graph.Pinned[0] != "Favorite Page" || graph.Pinned[1] != "Quiet Page" {
+		t.Errorf("Expected favorites resolved to page names in order, got %v", graph.Pinned)
+	}
+	if !graph.Nodes["Quiet Page"].Pinned || graph.Nodes["Busy Page"].Pinned {
+		t.Error("Pinned flag not set correctly on nodes")
+	}
+
+	// Pinned pages rank ahead of more-referenced pages
+	if graph.HubPages[0] != "Quiet Page" || graph.HubPages[1] != "Favorite Page" {
+		t.Errorf("Expected pinned pages first in hubs, got %v", graph.HubPages)
+	}
+	if graph.HubPages[2] != "Busy Page" {
+		t.Errorf("Expected Busy Page after pinned pages, got %v", graph.HubPages)
+	}
+}
+
+func TestBuildTaskIndex_WaitingOn(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Send contract", DelegatedTo: "Mike", SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Review PR", DelegatedTo: "Sarah", SourceFile: "journals/2020_01_05.md"},
+		{Status: models.StatusLATER, Description: "Update docs", DelegatedTo: "Sarah", SourceFile: "journals/2020_01_02.md"},
+		{Status: models.StatusDONE, Description: "Already done", DelegatedTo: "Mike", SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Mine"},
+	}
+
+	index := BuildTaskIndex(tasks)
+
+	if len(index.WaitingOn) != 2 {
+		t.Fatalf("Expected 2 delegation groups, got %d", len(index.WaitingOn))
+	}
+
+	// Person with most tasks first, oldest task first within a group
+	sarah := index.WaitingOn[0]
+	if sarah.Person != "Sarah" || len(sarah.Tasks) != 2 {
+		t.Fatalf("Expected Sarah with 2 tasks first, got %s with %d", sarah.Person, len(sarah.Tasks))
+	}
+	if sarah.Tasks[0].Task.Description != "Update docs" {
+		t.Errorf("Expected oldest task first, got %q", sarah.Tasks[0].Task.Description)
+	}
+	if sarah.Tasks[0].AgeDays <= sarah.Tasks[1].AgeDays {
+		t.Errorf("Expected descending ages, got %d then %d", sarah.Tasks[0].AgeDays, sarah.Tasks[1].AgeDays)
+	}
+
+	// DONE tasks are not waiting on anyone
+	if mike := index.WaitingOn[1]; len(mike.Tasks) != 1 {
+		t.Errorf("Expected 1 open task for Mike, got %d", len(mike.Tasks))
+	}
+}
+
+func TestBuildTaskIndex_Orphans(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Call the bank", SourceFile: "journals/2020_01_05.md"},
+		{Status: models.StatusLATER, Description: "Fix the shelf", SourceFile: "journals/2020_01_02.md"},
+		{Status: models.StatusDONE, Description: "Done already", SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Linked", PageRefs: []string{"Phoenix"}, SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Tagged", Tags: []string{"home"}, SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Delegated", DelegatedTo: "Mike", SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Scheduled", Scheduled: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "Due", Deadline: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC), SourceFile: "journals/2020_01_01.md"},
+		{Status: models.StatusTODO, Description: "On a page", SourceFile: "pages/Inbox.md"},
+	}
+
+	index := BuildTaskIndex(tasks)
+
+	if len(index.Orphans) != 2 {
+		t.Fatalf("Expected 2 orphan tasks, got %+v", index.Orphans)
+	}
+	// Oldest first
+	if index.Orphans[0].Task.Description != "Fix the shelf" || index.Orphans[1].Task.Description != "Call the bank" {
+		t.Errorf("Expected oldest orphan first, got %q then %q",
+			index.Orphans[0].Task.Description, index.Orphans[1].Task.Description)
+	}
+	if index.Orphans[0].AgeDays-index.Orphans[1].AgeDays != 3 {
+		t.Errorf("Expected ages 3 days apart, got %d and %d", index.Orphans[0].AgeDays, index.Orphans[1].AgeDays)
+	}
+}
+
+func TestBuildReferenceGraph_EdgeWeights(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "A", TargetPage: "Strong"},
+		{SourcePage: "A", TargetPage: "Strong"},
+		{SourcePage: "A", TargetPage: "Strong"},
+		{SourcePage: "A", TargetPage: "Weak"},
+		{SourcePage: "B", TargetPage: "Weak"},
+	}
+	files := []models.File{
+		{Path: "pages/A.md"},
+		{Path: "pages/B.md"},
+	}
+
+	graph := BuildReferenceGraph(refs, files)
+
+	if w := graph.EdgeWeight("A", "Strong"); w != 3 {
+		t.Errorf("Expected A->Strong weight 3, got %d", w)
+	}
+	if w := graph.EdgeWeight("B", "Strong"); w != 0 {
+		t.Errorf("Expected B->Strong weight 0, got %d", w)
+	}
+
+	strong := graph.Nodes["Strong"]
+	if strong.ReferenceCount != 1 || strong.InboundWeight != 3 || strong.InboundWeights["A"] != 3 {
+		t.Errorf("Unexpected Strong node counts: refs=%d weight=%d", strong.ReferenceCount, strong.InboundWeight)
+	}
+
+	// Weight outranks unique referrer count
+	if graph.HubPages[0] != "Strong" {
+		t.Errorf("Expected Strong as top hub by weight, got %v", graph.HubPages)
+	}
+}
+
+func TestApplyLanguages(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "Notizen", TargetPage: "Datenbank Migration"},
+		{SourcePage: "Planung", TargetPage: "Datenbank Migration"},
+		{SourcePage: "Notes", TargetPage: "Datenbank Migration"},
+	}
+	files := []models.File{
+		{Path: "pages/Notizen.md"},
+		{Path: "pages/Planung.md"},
+		{Path: "pages/Notes.md"},
+	}
+
+	graph := BuildReferenceGraph(refs, files)
+	graph.ApplyLanguages(map[string]string{"Notizen": "de", "Planung": "de", "Notes": "en"})
+
+	if lang := graph.Nodes["Notes"].Language; lang != "en" {
+		t.Errorf("Expected Notes to be en, got %q", lang)
+	}
+	// Missing pages take the majority language of their referrers
+	if lang := graph.Nodes["Datenbank Migration"].Language; lang != "de" {
+		t.Errorf("Expected missing page to be de, got %q", lang)
+	}
+}
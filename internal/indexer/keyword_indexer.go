@@ -0,0 +1,92 @@
+package indexer
+
+import (
+	"iter"
+	"maps"
+	"math"
+	"sort"
+)
+
+// Keyword is a term that characterises a page
+type Keyword struct {
+	Term  string
+	Score float64 // TF-IDF score
+}
+
+// KeywordIndex holds the top keywords for each page
+type KeywordIndex struct {
+	ByPage map[string][]Keyword // Page name -> keywords, highest score first
+}
+
+// BuildKeywordIndex computes the topN TF-IDF keywords per page.
+// words maps page name to the page's content words (see parser.ExtractWords).
+// Terms appearing in every page score zero and are never selected.
+func BuildKeywordIndex(words map[string][]string, topN int) *KeywordIndex {
+	return BuildKeywordIndexFrom(maps.All(words), topN)
+}
+
+// BuildKeywordIndexFrom is BuildKeywordIndex reading pages from a sequence,
+// which is read twice: once for document frequencies, then one page at a
+// time for its keywords, so only one page's words are held at once
+func BuildKeywordIndexFrom(words iter.Seq2[string, []string], topN int) *KeywordIndex {
+	index := &KeywordIndex{ByPage: make(map[string][]Keyword)}
+
+	// Document frequency: how many pages use each term
+	docFreq := make(map[string]int)
+	totalDocs := 0.0
+	for _, pageWords := range words {
+		totalDocs++
+		seen := make(map[string]bool)
+		for _, w := range pageWords {
+			if !seen[w] {
+				seen[w] = true
+				docFreq[w]++
+			}
+		}
+	}
+
+	for page, pageWords := range words {
+		total := float64(len(pageWords))
+		if total == 0 {
+			continue
+		}
+		tf := make(map[string]int)
+		for _, w := range pageWords {
+			tf[w]++
+		}
+
+		var keywords []Keyword
+		for term, count := range tf {
+			idf := math.Log(totalDocs / float64(docFreq[term]))
+			score := float64(count) / total * idf
+			if score > 0 {
+				keywords = append(keywords, Keyword{Term: term, Score: score})
+			}
+		}
+
+		sort.Slice(keywords, func(i, j int) bool {
+			if keywords[i].Score != keywords[j].Score {
+				return keywords[i].Score > keywords[j].Score
+			}
+			return keywords[i].Term < keywords[j].Term
+		})
+
+		if len(keywords) > topN {
+			keywords = keywords[:topN]
+		}
+		if len(keywords) > 0 {
+			index.ByPage[page] = keywords
+		}
+	}
+
+	return index
+}
+
+// Terms returns just the keyword terms for a page
+func (ki *KeywordIndex) Terms(page string) []string {
+	var terms []string
+	for _, k := range ki.ByPage[page] {
+		terms = append(terms, k.Term)
+	}
+	return terms
+}
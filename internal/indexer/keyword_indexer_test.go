@@ -0,0 +1,93 @@
+package indexer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildKeywordIndex(t *testing.T) {
+	words := map[string][]string{
+		"Phoenix": {"budget", "budget", "kubernetes", "team"},
+		"Mobile":  {"flutter", "team", "release"},
+		"Admin":   {"expenses", "team"},
+	}
+
+	index := BuildKeywordIndex(words, 2)
+
+	phoenix := index.Terms("Phoenix")
+	if len(phoenix) != 2 {
+		t.Fatalf("Expected 2 keywords for Phoenix, got %v", phoenix)
+	}
+	if phoenix[0] != "budget" {
+		t.Errorf("Expected 'budget' to rank first, got %v", phoenix)
+	}
+
+	// "team" appears on every page so carries no signal
+	for page := range words {
+		for _, term := range index.Terms(page) {
+			if term == "team" {
+				t.Errorf("Expected 'team' to be excluded from %s keywords", page)
+			}
+		}
+	}
+}
+
+func TestBuildKeywordIndexFrom(t *testing.T) {
+	words := map[string][]string{
+		"Phoenix": {"budget", "budget", "kubernetes", "team"},
+		"Mobile":  {"flutter", "team", "release"},
+		"Empty":   nil,
+	}
+
+	// A sequence read one page at a time gives the same keywords as the map
+	passes := 0
+	seq := func(yield func(string, []string) bool) {
+		passes++
+		for _, page := range []string{"Phoenix", "Mobile", "Empty"} {
+			if !yield(page, words[page]) {
+				return
+			}
+		}
+	}
+
+	got, want := BuildKeywordIndexFrom(seq, 3), BuildKeywordIndex(words, 3)
+	if !reflect.DeepEqual(got.ByPage, want.ByPage) {
+		t.Errorf("Expected %v, got %v", want.ByPage, got.ByPage)
+	}
+	if passes != 2 {
+		t.Errorf("Expected the sequence to be read twice, got %d", passes)
+	}
+}
+
+func TestBuildPageDetailsIndex(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "2025_11_01", TargetPage: "Phoenix", SourceFile: "journals/2025_11_01.md", LineNumber: 3},
+		{SourcePage: "2025_11_03", TargetPage: "Phoenix", SourceFile: "journals/2025_11_03.md", LineNumber: 1},
+		{SourcePage: "Phoenix", TargetPage: "Phoenix", SourceFile: "pages/Phoenix.md", LineNumber: 1},
+		{SourcePage: "Phoenix", TargetPage: "Ghost", SourceFile: "pages/Phoenix.md", LineNumber: 2},
+	}
+	files := []models.File{
+		{Path: "pages/Phoenix.md", Type: models.FileTypePage},
+		{Path: "journals/2025_11_01.md", Type: models.FileTypeJournal},
+		{Path: "journals/2025_11_03.md", Type: models.FileTypeJournal},
+	}
+	graph := BuildReferenceGraph(refs, files)
+
+	index := BuildPageDetailsIndex(graph, refs)
+
+	if len(index.Pages) != 1 {
+		t.Fatalf("Expected only the existing non-journal page, got %d pages", len(index.Pages))
+	}
+	page := index.Pages[0]
+	if page.Name != "Phoenix" {
+		t.Errorf("Expected Phoenix, got %s", page.Name)
+	}
+	if len(page.Backlinks) != 2 {
+		t.Fatalf("Expected 2 backlinks (self-reference excluded), got %d", len(page.Backlinks))
+	}
+	if page.Backlinks[0].SourcePage != "2025_11_03" {
+		t.Errorf("Expected newest journal first, got %s", page.Backlinks[0].SourcePage)
+	}
+}
@@ -3,20 +3,34 @@ package indexer
 import (
 	"sort"
 	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
 )
 
 // MissingPage represents a page that is referenced but doesn't exist
 type MissingPage struct {
 	Name           string
 	ReferenceCount int
-	PageType       string // person, date, project, concept
+	PageType       string  // person, date, project, concept, or a custom type from a ClassificationRule
+	TypeConfidence float64 // How sure the PageType guess is, from 0 to 1 (see Confidence* constants)
+	Language       string  // Inferred from referencing pages, "" if unknown
 	ReferencedFrom []string
+	Snippets       []SourceSnippet // One per ReferencedFrom page that has context, in the same order
+	AliasOf        string          // Existing page this looks like another name for (see ApplyAliasSuggestions)
+}
+
+// SourceSnippet is how one referencing page talks about a missing page
+type SourceSnippet struct {
+	Page    string
+	Line    int
+	Context string
 }
 
 // MissingPagesIndex contains pages that should be created
 type MissingPagesIndex struct {
-	MissingPages []MissingPage
-	Threshold    int // Minimum references to be included (5)
+	MissingPages     []MissingPage
+	Threshold        int               // Minimum references to be included (5)
+	AliasSuggestions []AliasSuggestion // Missing pages that look like another name for an existing page (see ApplyAliasSuggestions)
 }
 
 // BuildMissingPagesIndex identifies high-value pages to create from the reference graph
@@ -53,69 +67,170 @@ func BuildMissingPagesIndex(graph *ReferenceGraph, threshold int) *MissingPagesI
 			referencedFrom = referencedFrom[:10]
 		}
 
+		pageType, confidence := classifyPageTypeConfidence(pageName, node.Language)
 		missingPage := MissingPage{
 			Name:           pageName,
 			ReferenceCount: node.ReferenceCount,
-			PageType:       classifyPageType(pageName),
+			PageType:       pageType,
+			TypeConfidence: confidence,
+			Language:       node.Language,
 			ReferencedFrom: referencedFrom,
 		}
 
 		index.MissingPages = append(index.MissingPages, missingPage)
 	}
 
-	// Sort by reference count descending
+	// Sort by reference count descending, then name so output is stable between runs
 	sort.Slice(index.MissingPages, func(i, j int) bool {
-		return index.MissingPages[i].ReferenceCount > index.MissingPages[j].ReferenceCount
+		if index.MissingPages[i].ReferenceCount != index.MissingPages[j].ReferenceCount {
+			return index.MissingPages[i].ReferenceCount > index.MissingPages[j].ReferenceCount
+		}
+		return index.MissingPages[i].Name < index.MissingPages[j].Name
 	})
 
 	return index
 }
 
-// classifyPageType determines the likely type of a page based on its name
-func classifyPageType(pageName string) string {
+// ApplySnippets attaches one context snippet per referencing page to each
+// missing page, taken from that page's first reference with context, so the
+// report shows what the page should be about rather than just where it's linked.
+func (mi *MissingPagesIndex) ApplySnippets(refs []models.PageReference) {
+	first := make(map[[2]string]models.PageReference) // (target, source) -> earliest reference with context
+	for _, ref := range refs {
+		if strings.TrimSpace(ref.Context) == "" {
+			continue
+		}
+		key := [2]string{ref.TargetPage, ref.SourcePage}
+		if existing, ok := first[key]; !ok || ref.LineNumber < existing.LineNumber {
+			first[key] = ref
+		}
+	}
+
+	for i := range mi.MissingPages {
+		page := &mi.MissingPages[i]
+		page.Snippets = nil
+		for _, source := range page.ReferencedFrom {
+			if ref, ok := first[[2]string{page.Name, source}]; ok {
+				page.Snippets = append(page.Snippets, SourceSnippet{Page: source, Line: ref.LineNumber, Context: ref.Context})
+			}
+		}
+	}
+}
+
+// classifyKeywords holds the page-name hints for one language
+type classifyKeywords struct {
+	date    []string // Month names and ordinal date fragments
+	project []string
+	tech    []string // Technical/concept words that override name detection
+}
+
+// languageKeywords maps language codes to their classification hints.
+// English hints always apply, since technical vocabulary is shared.
+var languageKeywords = map[string]classifyKeywords{
+	"en": {
+		date:    []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec", "th,", "st,", "nd,", "rd,"},
+		project: []string{"Sprint", "Project", "Phase", "Release", "Milestone"},
+		tech:    []string{"API", "Service", "Architecture", "System", "Design", "Framework", "Database", "Server", "Team", "Stack", "Platform"},
+	},
+	"de": {
+		date:    []string{"Januar", "Februar", "März", "Mai", "Juni", "Juli", "Okt", "Dez"},
+		project: []string{"Projekt", "Meilenstein"},
+		tech:    []string{"Dienst", "Architektur", "Datenbank", "Plattform", "Schnittstelle", "Entwicklung"},
+	},
+}
+
+// germanNounSuffixes mark capitalised German nouns, which would otherwise look like names
+var germanNounSuffixes = []string{"ung", "heit", "keit", "schaft", "tion", "ität", "nis", "tum", "ment", "lernen", "ismus"}
+
+// Confidence of heuristic classifications, from a rule or unambiguous
+// marker down to a fallback taken because nothing else matched
+const (
+	ConfidenceCertain  = 1.0 // Configured rule, known person, or honorific
+	ConfidenceHigh     = 0.9 // Unambiguous name pattern, e.g. a month name
+	ConfidenceMedium   = 0.8 // Keyword hint, e.g. "Project" or "API"
+	ConfidenceLow      = 0.6 // Capitalised words read as a name
+	ConfidenceFallback = 0.3 // No signal at all
+)
+
+// classifyPageType determines the likely type of a page based on its name.
+// language is the page's detected language code ("" if unknown); it adds
+// that language's keywords and adjusts the name heuristic.
+func classifyPageType(pageName, language string) string {
+	pageType, _ := classifyPageTypeConfidence(pageName, language)
+	return pageType
+}
+
+// classifyPageTypeConfidence is classifyPageType, also returning how
+// confident the guess is
+func classifyPageTypeConfidence(pageName, language string) (string, float64) {
+	hints := []classifyKeywords{languageKeywords["en"]}
+	if extra, exists := languageKeywords[language]; exists && language != "en" {
+		hints = append(hints, extra)
+	}
+
 	// Person: "FirstName LastName - Title" pattern
 	if strings.Contains(pageName, " - ") {
 		// Check if it's a person pattern: "Name - Role"
 		parts := strings.Split(pageName, " - ")
 		if len(parts) == 2 && looksLikeName(parts[0]) {
-			return "person"
+			return "person", ConfidenceHigh
 		}
-		return "concept"
+		return "concept", ConfidenceMedium
 	}
 
 	// Date: Contains month names or date patterns
-	dateKeywords := []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec", "th,", "st,", "nd,", "rd,"}
-	for _, keyword := range dateKeywords {
-		if strings.Contains(pageName, keyword) {
-			return "date"
+	for _, h := range hints {
+		for _, keyword := range h.date {
+			if strings.Contains(pageName, keyword) {
+				return "date", ConfidenceHigh
+			}
 		}
 	}
 
 	// Project: Contains project-related keywords
-	projectKeywords := []string{"Sprint", "Project", "Phase", "Release", "Milestone"}
-	for _, keyword := range projectKeywords {
-		if strings.Contains(pageName, keyword) {
-			return "project"
+	for _, h := range hints {
+		for _, keyword := range h.project {
+			if strings.Contains(pageName, keyword) {
+				return "project", ConfidenceMedium
+			}
 		}
 	}
 
 	// Technical/concept keywords that override name detection
-	techKeywords := []string{"API", "Service", "Architecture", "System", "Design", "Framework", "Database", "Server", "Team", "Stack", "Platform"}
-	for _, keyword := range techKeywords {
-		if strings.Contains(pageName, keyword) {
-			return "concept"
+	for _, h := range hints {
+		for _, keyword := range h.tech {
+			if strings.Contains(pageName, keyword) {
+				return "concept", ConfidenceMedium
+			}
 		}
 	}
 
+	// German capitalises all nouns, so rule out common noun endings first
+	if language == "de" && hasGermanNounSuffix(pageName) {
+		return "concept", ConfidenceMedium
+	}
+
 	// Person: Capitalized words pattern (likely a name)
 	// Only if exactly 2-3 words (typical name pattern)
 	words := strings.Fields(pageName)
 	if len(words) >= 2 && len(words) <= 3 && looksLikeName(pageName) {
-		return "person"
+		return "person", ConfidenceLow
 	}
 
 	// Default
-	return "concept"
+	return "concept", ConfidenceFallback
+}
+
+// hasGermanNounSuffix checks if any word in s ends like a German noun
+func hasGermanNounSuffix(s string) bool {
+	for _, word := range strings.Fields(strings.ToLower(s)) {
+		for _, suffix := range germanNounSuffixes {
+			if strings.HasSuffix(word, suffix) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // looksLikeName checks if a string looks like a person's name
@@ -140,13 +140,60 @@ func TestClassifyPageType(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := classifyPageType(tt.pageName)
+		result := classifyPageType(tt.pageName, "")
 		if result != tt.expectedType {
 			t.Errorf("classifyPageType(%q) = %q, expected %q", tt.pageName, result, tt.expectedType)
 		}
 	}
 }
 
+func TestClassifyPageTypeConfidence(t *testing.T) {
+	tests := []struct {
+		pageName   string
+		wantType   string
+		confidence float64
+	}{
+		{"John Smith - Engineer", "person", ConfidenceHigh},
+		{"Nov 15th, 2025", "date", ConfidenceHigh},
+		{"Project Phoenix", "project", ConfidenceMedium},
+		{"API Design", "concept", ConfidenceMedium},
+		{"Alice Johnson", "person", ConfidenceLow},
+		{"GraphQL", "concept", ConfidenceFallback},
+	}
+
+	for _, tt := range tests {
+		pageType, confidence := classifyPageTypeConfidence(tt.pageName, "")
+		if pageType != tt.wantType || confidence != tt.confidence {
+			t.Errorf("classifyPageTypeConfidence(%q) = %q, %.1f, expected %q, %.1f", tt.pageName, pageType, confidence, tt.wantType, tt.confidence)
+		}
+	}
+}
+
+func TestClassifyPageType_German(t *testing.T) {
+	tests := []struct {
+		pageName     string
+		expectedType string
+	}{
+		{"Maschinelles Lernen", "concept"},
+		{"Digitale Transformation", "concept"},
+		{"Projekt Phoenix", "project"},
+		{"15. März 2025", "date"},
+		{"Anna Schmidt", "person"},
+	}
+
+	for _, tt := range tests {
+		result := classifyPageType(tt.pageName, "de")
+		if result != tt.expectedType {
+			t.Errorf("classifyPageType(%q, de) = %q, expected %q", tt.pageName, result, tt.expectedType)
+		}
+	}
+
+	// Without the language hint German concepts look like names
+	if result := classifyPageType("Maschinelles Lernen", ""); result != "person" {
+		t.Errorf("Expected English heuristics to treat 'Maschinelles Lernen' as a person, got %q", result)
+	}
+}
+
 func TestLooksLikeName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -245,3 +292,48 @@ func TestMissingPagesIndex_Sorting(t *testing.T) {
 		}
 	}
 }
+
+func TestApplySnippets(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "Standup", TargetPage: "Kafka", LineNumber: 9, Context: "- move billing events onto [[Kafka]]"},
+		{SourcePage: "Standup", TargetPage: "Kafka", LineNumber: 3, Context: "- [[Kafka]] lag spiked again"},
+		{SourcePage: "Architecture", TargetPage: "Kafka", LineNumber: 1, Context: ""},
+		{SourcePage: "Architecture", TargetPage: "Kafka", LineNumber: 4, Context: "- replaces RabbitMQ, see [[Kafka]]"},
+		{SourcePage: "Notes", TargetPage: "Kafka", LineNumber: 2},
+		{SourcePage: "Ideas", TargetPage: "Kafka", LineNumber: 1, Context: "- [[Kafka]] streams for search?"},
+		{SourcePage: "Ideas", TargetPage: "Kafka", LineNumber: 5, Context: "- [[Kafka]] connect"},
+	}
+	index := BuildMissingPagesIndex(BuildReferenceGraph(refs, nil), 3)
+	if len(index.MissingPages) != 1 {
+		t.Fatalf("Expected 1 missing page, got %d", len(index.MissingPages))
+	}
+
+	index.ApplySnippets(refs)
+	page := index.MissingPages[0]
+
+	want := map[string]string{
+		"Standup":      "- [[Kafka]] lag spiked again",
+		"Architecture": "- replaces RabbitMQ, see [[Kafka]]",
+		"Ideas":        "- [[Kafka]] streams for search?",
+	}
+	if len(page.Snippets) != len(want) {
+		t.Fatalf("Expected %d snippets (none for Notes), got %+v", len(want), page.Snippets)
+	}
+	for i, snippet := range page.Snippets {
+		if want[snippet.Page] != snippet.Context {
+			t.Errorf("Snippet for %s = %q, want %q", snippet.Page, snippet.Context, want[snippet.Page])
+		}
+		if i > 0 && indexOf(page.ReferencedFrom, snippet.Page) < indexOf(page.ReferencedFrom, page.Snippets[i-1].Page) {
+			t.Errorf("Snippets should follow ReferencedFrom order: %+v vs %v", page.Snippets, page.ReferencedFrom)
+		}
+	}
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
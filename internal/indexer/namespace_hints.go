@@ -0,0 +1,98 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+)
+
+// NamespaceHint suggests a cleanup for a fragmented namespace
+type NamespaceHint struct {
+	Namespace    string   // "Projects", or "Projects/Web" for nested namespaces
+	Pages        []string // Existing pages under the namespace, sorted
+	ParentExists bool     // Whether the namespace has a page of its own
+	Action       string   // "create", "flatten", or "merge"
+	Target       string   // Page name to flatten to, or page to merge into
+}
+
+// Namespace cleanup actions, in report order
+const (
+	NamespaceCreate  = "create"  // Several pages but no parent page: create it
+	NamespaceFlatten = "flatten" // A single page: drop the namespace
+	NamespaceMerge   = "merge"   // A single page whose flattened name exists: merge them
+)
+
+// ApplyNamespaceHints looks for fragmented namespaces among existing pages.
+// A namespace holding a single page is worth flattening (or merging into its
+// parent page when that exists), and one holding several pages without a
+// page of its own lacks an overview to navigate from. Namespaces are derived
+// from file names, so pages/Projects___App.md is "Projects/App".
+func (index *GraphHealthIndex) ApplyNamespaceHints(graph *ReferenceGraph) {
+	index.NamespaceHints = nil
+
+	existing := make(map[string]bool) // Lowercase names of existing pages
+	var names []string
+	for key, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+		name := namespacedName(key)
+		existing[strings.ToLower(name)] = true
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	namespaces := make(map[string]*NamespaceHint) // Keyed by lowercase namespace
+	var order []string
+	for _, name := range names {
+		for i := range len(name) {
+			if name[i] != '/' || i == 0 {
+				continue
+			}
+			key := strings.ToLower(name[:i])
+			hint := namespaces[key]
+			if hint == nil {
+				hint = &NamespaceHint{Namespace: name[:i], ParentExists: existing[key]}
+				namespaces[key] = hint
+				order = append(order, key)
+			}
+			hint.Pages = append(hint.Pages, name)
+		}
+	}
+
+	for _, key := range order {
+		hint := namespaces[key]
+		switch {
+		case len(hint.Pages) == 1 && hint.ParentExists:
+			hint.Action, hint.Target = NamespaceMerge, hint.Namespace
+		case len(hint.Pages) == 1:
+			hint.Target = hint.Pages[0][strings.LastIndex(hint.Pages[0], "/")+1:]
+			hint.Action = NamespaceFlatten
+			if existing[strings.ToLower(hint.Target)] {
+				hint.Action = NamespaceMerge
+			}
+		case !hint.ParentExists:
+			hint.Action = NamespaceCreate
+		default:
+			continue
+		}
+		index.NamespaceHints = append(index.NamespaceHints, *hint)
+	}
+
+	sort.SliceStable(index.NamespaceHints, func(i, j int) bool {
+		a, b := index.NamespaceHints[i], index.NamespaceHints[j]
+		if (a.Action == NamespaceCreate) != (b.Action == NamespaceCreate) {
+			return a.Action == NamespaceCreate
+		}
+		if len(a.Pages) != len(b.Pages) {
+			return len(a.Pages) > len(b.Pages)
+		}
+		return a.Namespace < b.Namespace
+	})
+}
+
+// namespacedName writes a page file's name with "/" namespace separators, as
+// in links: "Projects___App" and "Projects%2FApp" become "Projects/App"
+func namespacedName(name string) string {
+	name = strings.ReplaceAll(name, "___", "/")
+	return strings.ReplaceAll(strings.ReplaceAll(name, "%2F", "/"), "%2f", "/")
+}
@@ -0,0 +1,66 @@
+package indexer
+
+import (
+	"regexp"
+	"unicode"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// navigationRegex strips what a purely navigational line is made of: links,
+// tags, property names, and task markers
+var navigationRegex = regexp.MustCompile(`^(?:[-*]\s+)?(?:[\w-]+::)?|#?\[\[[^\]]*\]\]|#\S+|\b(?:NOW|LATER|TODO|DOING|DONE)\b`)
+
+// ApplyNoiseFilter leaves references on noise lines out of ReferenceCount,
+// InboundWeight, and the hub rankings. A line is noise if it has fewer than
+// minChars letters and digits besides its links, tags, and property name,
+// e.g. breadcrumbs ("[[A]] > [[B]]") or "related:: [[A]] [[B]] [[C]]" farms.
+// Edges and the Raw* counts are unchanged. minChars <= 0 disables the filter.
+func (rg *ReferenceGraph) ApplyNoiseFilter(refs []models.PageReference, minChars int) {
+	if minChars <= 0 {
+		return
+	}
+
+	// Occurrences on noise lines, per target and source
+	noise := make(map[string]map[string]int)
+	for _, ref := range refs {
+		if !isNoiseLine(ref.Context, minChars) {
+			continue
+		}
+		if noise[ref.TargetPage] == nil {
+			noise[ref.TargetPage] = make(map[string]int)
+		}
+		noise[ref.TargetPage][ref.SourcePage]++
+	}
+
+	for pageName, sources := range noise {
+		node, exists := rg.Nodes[pageName]
+		if !exists {
+			continue
+		}
+		for source, count := range sources {
+			node.InboundWeight -= count
+			// A source only counts as a referrer if it has a meaningful reference left
+			if count >= node.InboundWeights[source] {
+				node.ReferenceCount--
+			}
+		}
+	}
+
+	rg.HubPages = findHubPages(rg.Nodes, 10)
+}
+
+// isNoiseLine reports whether a reference's line has fewer than minChars
+// letters and digits of its own
+func isNoiseLine(line string, minChars int) bool {
+	chars := 0
+	for _, r := range navigationRegex.ReplaceAllString(line, "") {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			chars++
+			if chars >= minChars {
+				return false
+			}
+		}
+	}
+	return true
+}
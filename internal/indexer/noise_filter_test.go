@@ -0,0 +1,62 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestIsNoiseLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"- [[Home]] > [[Projects]] > [[Phoenix]]", true},
+		{"related:: [[A]] [[B]] [[C]]", true},
+		{"- tags:: #[[multi word]], #work", true},
+		{"- TODO [[Phoenix]]", true},
+		{"- Reviewed the rollout plan with [[Phoenix]] team", false},
+		{"- [[Alice]] approved", false},
+	}
+
+	for _, tt := range tests {
+		if got := isNoiseLine(tt.line, 8); got != tt.want {
+			t.Errorf("isNoiseLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestApplyNoiseFilter(t *testing.T) {
+	refs := []models.PageReference{
+		// Index links to Hub three times from a link farm only
+		{SourcePage: "Index", TargetPage: "Hub", Context: "related:: [[Hub]] [[Topic]]"},
+		{SourcePage: "Index2", TargetPage: "Hub", Context: "related:: [[Hub]] [[Topic]]"},
+		{SourcePage: "Index3", TargetPage: "Hub", Context: "related:: [[Hub]] [[Topic]]"},
+		// Notes links to Topic from both a farm and a real sentence
+		{SourcePage: "Notes", TargetPage: "Topic", Context: "- Wrote up how [[Topic]] affects the launch"},
+		{SourcePage: "Notes", TargetPage: "Topic", Context: "- [[Topic]]"},
+	}
+	graph := BuildReferenceGraph(refs, nil)
+	if graph.HubPages[0] != "Hub" || graph.Nodes["Hub"].ReferenceCount != 3 {
+		t.Fatalf("Unexpected unfiltered graph: hubs %v", graph.HubPages)
+	}
+
+	graph.ApplyNoiseFilter(refs, 8)
+
+	hub, topic := graph.Nodes["Hub"], graph.Nodes["Topic"]
+	if hub.ReferenceCount != 0 || hub.InboundWeight != 0 {
+		t.Errorf("Expected Hub's link-farm references to be filtered, got %d refs, %d weight", hub.ReferenceCount, hub.InboundWeight)
+	}
+	if hub.RawReferenceCount != 3 || hub.RawInboundWeight != 3 {
+		t.Errorf("Expected raw counts to be kept, got %d refs, %d weight", hub.RawReferenceCount, hub.RawInboundWeight)
+	}
+	if topic.ReferenceCount != 1 || topic.InboundWeight != 1 || topic.RawInboundWeight != 2 {
+		t.Errorf("Expected Notes to stay a referrer of Topic, got %d refs, %d weight", topic.ReferenceCount, topic.InboundWeight)
+	}
+	if len(graph.HubPages) != 1 || graph.HubPages[0] != "Topic" {
+		t.Errorf("Expected only Topic to rank as a hub, got %v", graph.HubPages)
+	}
+	if len(hub.InboundRefs) != 3 {
+		t.Errorf("Expected edges to be kept, got %v", hub.InboundRefs)
+	}
+}
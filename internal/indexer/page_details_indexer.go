@@ -0,0 +1,71 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// PageDetail collects everything known about a single page for its backlinks file
+type PageDetail struct {
+	Name      string
+	FilePath  string
+	Keywords  []string               // Top TF-IDF keywords
+	Language  string                 // Detected language code, "" if unknown
+	Sessions  *SessionPattern        // When the project is usually worked on, nil without enough logbook data
+	Backlinks []models.PageReference // Every reference to this page, grouped by source
+
+	RecentJournals []JournalMention // Most recent journal days referencing the page, newest first
+	JournalDays    int              // Journal days referencing the page in total
+
+	Tasks         *TaskRollup  // Tasks on or linking the page, nil if none (see ApplyTaskRollups)
+	NeighborTasks []TaskRollup // One-hop neighbours with open tasks, most open first
+}
+
+// PageDetailsIndex holds per-page details for existing, non-journal pages
+type PageDetailsIndex struct {
+	GeneratedAt time.Time
+	Pages       []*PageDetail // Sorted by name
+}
+
+// BuildPageDetailsIndex assembles per-page details from the graph and references
+func BuildPageDetailsIndex(graph *ReferenceGraph, refs []models.PageReference) *PageDetailsIndex {
+	index := &PageDetailsIndex{GeneratedAt: time.Now()}
+
+	details := make(map[string]*PageDetail)
+	for name, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+		details[name] = &PageDetail{
+			Name:     name,
+			FilePath: node.FilePath,
+			Keywords: node.Keywords,
+			Language: node.Language,
+		}
+	}
+
+	for _, ref := range refs {
+		if detail, exists := details[ref.TargetPage]; exists && ref.SourcePage != ref.TargetPage {
+			detail.Backlinks = append(detail.Backlinks, ref)
+		}
+	}
+
+	for _, detail := range details {
+		sort.SliceStable(detail.Backlinks, func(i, j int) bool {
+			a, b := detail.Backlinks[i], detail.Backlinks[j]
+			if a.SourcePage != b.SourcePage {
+				return a.SourcePage > b.SourcePage // Newest journals first
+			}
+			return a.LineNumber < b.LineNumber
+		})
+		index.Pages = append(index.Pages, detail)
+	}
+
+	sort.Slice(index.Pages, func(i, j int) bool {
+		return index.Pages[i].Name < index.Pages[j].Name
+	})
+
+	return index
+}
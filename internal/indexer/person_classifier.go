@@ -0,0 +1,114 @@
+package indexer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// personThreshold is the score at which a page is classified as a person
+const personThreshold = 2
+
+// honorifics mark a page name as a person outright
+var honorifics = []string{"Dr.", "Dr ", "Prof.", "Prof ", "Mr.", "Mrs.", "Ms.", "Mx.", "Sir ", "Herr ", "Frau "}
+
+// personContextRegex matches phrases in a referencing line that suggest the
+// linked page is a person ("met with [[X]]", "1:1 [[X]]", "call with [[X]]")
+var personContextRegex = regexp.MustCompile(`(?i)\b(met with|meeting with|call with|chat with|catch-?up with|talked to|spoke (?:to|with)|asked|pinged?|emailed|1[:\-]1|one[- ]on[- ]one|waiting on)\b`)
+
+// conceptSuffixes are word endings typical of concepts rather than surnames
+var conceptSuffixes = []string{"ing", "tion", "sion", "ology", "ics", "ment", "ness", "ism", "ity", "ware"}
+
+// ConfidenceMixed is the confidence of a classification whose signals disagree
+const ConfidenceMixed = 0.5
+
+// ApplyPersonSignals re-scores each missing page's person/concept classification
+// using honorifics, context phrases from referencing lines, and known-people
+// patterns (e.g. from config). Date and project classifications are left alone.
+// Each signal beyond the threshold raises a person's confidence; a concept
+// with some person signals, or a name overruled by concept words, drops to
+// ConfidenceMixed.
+func (mi *MissingPagesIndex) ApplyPersonSignals(refs []models.PageReference, knownPeople []*regexp.Regexp) {
+	contexts := make(map[string][]string)
+	for _, ref := range refs {
+		contexts[ref.TargetPage] = append(contexts[ref.TargetPage], ref.Context)
+	}
+
+	for i := range mi.MissingPages {
+		page := &mi.MissingPages[i]
+		if page.PageType != "person" && page.PageType != "concept" {
+			continue
+		}
+		wasPerson := page.PageType == "person"
+		if isKnownPerson(page.Name, knownPeople) {
+			page.PageType, page.TypeConfidence = "person", ConfidenceCertain
+			continue
+		}
+
+		score := personScore(page.Name, wasPerson, contexts[page.Name], knownPeople)
+		switch {
+		case score >= personThreshold:
+			confidence := min(ConfidenceHigh, ConfidenceLow+0.1*float64(score-personThreshold))
+			if !wasPerson || confidence > page.TypeConfidence {
+				page.TypeConfidence = confidence
+			}
+			page.PageType = "person"
+		case wasPerson || score > 0:
+			page.PageType, page.TypeConfidence = "concept", min(page.TypeConfidence, ConfidenceMixed)
+		}
+	}
+}
+
+// isKnownPerson reports whether a page is a person without weighing signals:
+// it matches a known-people pattern or starts with an honorific
+func isKnownPerson(pageName string, knownPeople []*regexp.Regexp) bool {
+	for _, pattern := range knownPeople {
+		if pattern.MatchString(pageName) {
+			return true
+		}
+	}
+	for _, h := range honorifics {
+		if strings.HasPrefix(pageName, h) {
+			return true
+		}
+	}
+	return false
+}
+
+// personScore combines the signals that a page names a person.
+// nameLooksPersonal is the result of the name-only heuristic.
+func personScore(pageName string, nameLooksPersonal bool, contexts []string, knownPeople []*regexp.Regexp) int {
+	if isKnownPerson(pageName, knownPeople) {
+		return personThreshold
+	}
+
+	score := 0
+	if nameLooksPersonal {
+		score += personThreshold
+	}
+
+	// Concept-like words ("Machine Learning", "Data Engineering") count against
+	for _, word := range strings.Fields(strings.ToLower(pageName)) {
+		for _, suffix := range conceptSuffixes {
+			if strings.HasSuffix(word, suffix) && len(word) > len(suffix)+2 {
+				score -= 2
+				break
+			}
+		}
+	}
+
+	// Each referencing line that talks about the page like a person (capped)
+	contextHits := 0
+	for _, ctx := range contexts {
+		if personContextRegex.MatchString(ctx) {
+			contextHits++
+		}
+	}
+	if contextHits > 2 {
+		contextHits = 2
+	}
+	score += contextHits
+
+	return score
+}
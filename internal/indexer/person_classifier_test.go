@@ -0,0 +1,96 @@
+package indexer
+
+import (
+	"math"
+	"regexp"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyPersonSignals(t *testing.T) {
+	index := &MissingPagesIndex{
+		MissingPages: []MissingPage{
+			{Name: "Machine Learning", PageType: classifyPageType("Machine Learning", "")},
+			{Name: "Alice Johnson", PageType: classifyPageType("Alice Johnson", "")},
+			{Name: "Dr. Okafor", PageType: classifyPageType("Dr. Okafor", "")},
+			{Name: "Kim", PageType: classifyPageType("Kim", "")},
+			{Name: "Acme Corp", PageType: classifyPageType("Acme Corp", "")},
+			{Name: "Sprint 23", PageType: "project"},
+		},
+	}
+	refs := []models.PageReference{
+		{TargetPage: "Alice Johnson", Context: "Met with [[Alice Johnson]] about hiring"},
+		{TargetPage: "Kim", Context: "1:1 with [[Kim]]"},
+		{TargetPage: "Kim", Context: "Asked [[Kim]] for the numbers"},
+		{TargetPage: "Machine Learning", Context: "Reading about [[Machine Learning]]"},
+	}
+	known := []*regexp.Regexp{regexp.MustCompile(`^Acme `)}
+
+	index.ApplyPersonSignals(refs, known)
+
+	want := map[string]string{
+		"Machine Learning": "concept",
+		"Alice Johnson":    "person",
+		"Dr. Okafor":       "person",
+		"Kim":              "person",
+		"Acme Corp":        "person",
+		"Sprint 23":        "project",
+	}
+	for _, page := range index.MissingPages {
+		if page.PageType != want[page.Name] {
+			t.Errorf("%s: expected %s, got %s", page.Name, want[page.Name], page.PageType)
+		}
+	}
+}
+
+func TestApplyPersonSignals_Confidence(t *testing.T) {
+	var pages []MissingPage
+	for _, name := range []string{"Machine Learning", "Alice Johnson", "Dr. Okafor", "Kim"} {
+		pageType, confidence := classifyPageTypeConfidence(name, "")
+		pages = append(pages, MissingPage{Name: name, PageType: pageType, TypeConfidence: confidence})
+	}
+	index := &MissingPagesIndex{MissingPages: pages}
+	refs := []models.PageReference{
+		{TargetPage: "Alice Johnson", Context: "Met with [[Alice Johnson]] about hiring"},
+		{TargetPage: "Kim", Context: "1:1 with [[Kim]]"},
+		{TargetPage: "Kim", Context: "Asked [[Kim]] for the numbers"},
+	}
+
+	index.ApplyPersonSignals(refs, nil)
+
+	want := map[string]float64{
+		"Machine Learning": ConfidenceMixed,     // Looks like a name, but "Learning" is a concept word
+		"Alice Johnson":    ConfidenceLow + 0.1, // Name plus one context
+		"Dr. Okafor":       ConfidenceCertain,
+		"Kim":              ConfidenceLow, // Contexts only
+	}
+	for _, page := range index.MissingPages {
+		if math.Abs(page.TypeConfidence-want[page.Name]) > 1e-9 {
+			t.Errorf("%s: expected confidence %.2f, got %.2f", page.Name, want[page.Name], page.TypeConfidence)
+		}
+	}
+}
+
+func TestPersonScore(t *testing.T) {
+	tests := []struct {
+		name              string
+		nameLooksPersonal bool
+		contexts          []string
+		wantPerson        bool
+	}{
+		{"Alice Johnson", true, nil, true},
+		{"Machine Learning", true, nil, false},
+		{"Machine Learning", true, []string{"Met with [[Machine Learning]]"}, false},
+		{"Prof. Ng", false, nil, true},
+		{"Kim", false, []string{"1:1 [[Kim]]"}, false},
+		{"Kim", false, []string{"1:1 [[Kim]]", "Call with [[Kim]]"}, true},
+	}
+
+	for _, tt := range tests {
+		score := personScore(tt.name, tt.nameLooksPersonal, tt.contexts, nil)
+		if (score >= personThreshold) != tt.wantPerson {
+			t.Errorf("personScore(%q, %v, %v) = %d, want person=%v", tt.name, tt.nameLooksPersonal, tt.contexts, score, tt.wantPerson)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/parser"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// NoGroup labels blocks without a value for the group-by property
+const NoGroup = "(none)"
+
+// PropertyQuery selects blocks by a property and groups them by another
+type PropertyQuery struct {
+	Key     string // Property to aggregate, e.g. "status"
+	GroupBy string // Property to group by, "page" for the block's page, or "" for one group
+	Value   string // Only count this value of Key (case-insensitive), "" for all
+}
+
+// PropertyGroup is one group of a property query's results
+type PropertyGroup struct {
+	Group  string          // Group-by value, NoGroup, or "" when not grouping
+	Values []PropertyCount // Most common first
+}
+
+// PropertyCount is one value of the queried property and the blocks holding it
+type PropertyCount struct {
+	Value  string
+	Blocks []models.PropertyBlock // In file and line order
+}
+
+// Count returns the blocks in the group across all values
+func (g PropertyGroup) Count() int {
+	total := 0
+	for _, v := range g.Values {
+		total += len(v.Blocks)
+	}
+	return total
+}
+
+// QueryProperties counts the values of q.Key across blocks and pages, split
+// by the value of q.GroupBy. A block without the group-by property takes its
+// page's, so "project:: [[Phoenix]]" at the top of a page groups every block
+// on it. Multi-valued properties (see parser.PropertyValues) count once per
+// value, and values differing only in case are merged. Groups are sorted by
+// block count, with NoGroup last.
+func QueryProperties(blocks []models.PropertyBlock, q PropertyQuery) []PropertyGroup {
+	key := strings.ToLower(q.Key)
+	groupBy := strings.ToLower(q.GroupBy)
+
+	pageProperties := make(map[string]map[string]string) // File -> page properties
+	for _, block := range blocks {
+		if block.Page {
+			pageProperties[block.SourceFile] = block.Properties
+		}
+	}
+
+	type counts struct {
+		group  string
+		values map[string]*PropertyCount // Keyed by lowercase value
+	}
+	groups := make(map[string]*counts) // Keyed by lowercase group
+	for _, block := range blocks {
+		raw, ok := block.Properties[key]
+		if !ok {
+			continue
+		}
+
+		groupNames := []string{""}
+		switch {
+		case groupBy == "":
+		case groupBy == "page":
+			groupNames = []string{logseqPageName(block.SourceFile)}
+		default:
+			value, ok := block.Properties[groupBy]
+			if !ok {
+				value = pageProperties[block.SourceFile][groupBy]
+			}
+			groupNames = parser.PropertyValues(groupBy, value)
+			if len(groupNames) == 0 {
+				groupNames = []string{NoGroup}
+			}
+		}
+
+		for _, value := range parser.PropertyValues(key, raw) {
+			if q.Value != "" && !strings.EqualFold(value, q.Value) {
+				continue
+			}
+			for _, name := range groupNames {
+				g := groups[strings.ToLower(name)]
+				if g == nil {
+					g = &counts{group: name, values: make(map[string]*PropertyCount)}
+					groups[strings.ToLower(name)] = g
+				}
+				c := g.values[strings.ToLower(value)]
+				if c == nil {
+					c = &PropertyCount{Value: value}
+					g.values[strings.ToLower(value)] = c
+				}
+				c.Blocks = append(c.Blocks, block)
+			}
+		}
+	}
+
+	var result []PropertyGroup
+	for _, g := range groups {
+		group := PropertyGroup{Group: g.group}
+		for _, c := range g.values {
+			sort.SliceStable(c.Blocks, func(i, j int) bool {
+				a, b := c.Blocks[i], c.Blocks[j]
+				if a.SourceFile != b.SourceFile {
+					return a.SourceFile < b.SourceFile
+				}
+				return a.LineNumber < b.LineNumber
+			})
+			group.Values = append(group.Values, *c)
+		}
+		sort.Slice(group.Values, func(i, j int) bool {
+			a, b := group.Values[i], group.Values[j]
+			if len(a.Blocks) != len(b.Blocks) {
+				return len(a.Blocks) > len(b.Blocks)
+			}
+			return strings.ToLower(a.Value) < strings.ToLower(b.Value)
+		})
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if (a.Group == NoGroup) != (b.Group == NoGroup) {
+			return b.Group == NoGroup
+		}
+		if a.Count() != b.Count() {
+			return a.Count() > b.Count()
+		}
+		return strings.ToLower(a.Group) < strings.ToLower(b.Group)
+	})
+	return result
+}
@@ -0,0 +1,49 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestQueryProperties(t *testing.T) {
+	blocks := []models.PropertyBlock{
+		{SourceFile: "pages/Phoenix.md", LineNumber: 1, Page: true, Properties: map[string]string{"project": "[[Phoenix]]"}},
+		{SourceFile: "pages/Phoenix.md", LineNumber: 6, Properties: map[string]string{"status": "Blocked"}},
+		{SourceFile: "pages/Phoenix.md", LineNumber: 3, Properties: map[string]string{"status": "active"}},
+		{SourceFile: "journals/2025_11_06.md", LineNumber: 1, Properties: map[string]string{"status": "blocked", "project": "[[Phoenix]]"}},
+		{SourceFile: "pages/Reading.md", LineNumber: 1, Properties: map[string]string{"status": "active", "type": "book"}},
+		{SourceFile: "pages/Reading.md", LineNumber: 4, Properties: map[string]string{"type": "book"}},
+	}
+
+	all := QueryProperties(blocks, PropertyQuery{Key: "Status"})
+	if len(all) != 1 || all[0].Group != "" || all[0].Count() != 4 {
+		t.Fatalf("Expected one ungrouped group of 4, got %+v", all)
+	}
+	if len(all[0].Values) != 2 {
+		t.Fatalf("Expected values merged ignoring case, got %+v", all[0].Values)
+	}
+	if active := all[0].Values[0]; active.Value != "active" || len(active.Blocks) != 2 {
+		t.Errorf("Expected 2 active blocks first (tied, sorted by value), got %+v", active)
+	}
+
+	grouped := QueryProperties(blocks, PropertyQuery{Key: "status", GroupBy: "project"})
+	if len(grouped) != 2 {
+		t.Fatalf("Expected 2 groups, got %+v", grouped)
+	}
+	phoenix := grouped[0]
+	if phoenix.Group != "Phoenix" || phoenix.Count() != 3 {
+		t.Errorf("Expected blocks to inherit the page's project, got %+v", phoenix)
+	}
+	if v := phoenix.Values[0]; len(v.Blocks) != 2 || v.Blocks[0].SourceFile != "journals/2025_11_06.md" {
+		t.Errorf("Expected blocked blocks in file order, got %+v", v)
+	}
+	if grouped[1].Group != NoGroup || grouped[1].Count() != 1 {
+		t.Errorf("Expected ungrouped blocks last, got %+v", grouped[1])
+	}
+
+	byPage := QueryProperties(blocks, PropertyQuery{Key: "type", GroupBy: "page", Value: "BOOK"})
+	if len(byPage) != 1 || byPage[0].Group != "Reading" || byPage[0].Count() != 2 {
+		t.Errorf("Expected 2 books on Reading, got %+v", byPage)
+	}
+}
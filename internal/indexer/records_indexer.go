@@ -0,0 +1,133 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// Record is a personal best on a day, or in a week starting on Date
+type Record struct {
+	Date       time.Time
+	TimeLogged time.Duration // For time records
+	Count      int           // For completion records
+}
+
+// Records are streaks and personal bests, surfaced for motivation
+type Records struct {
+	CurrentStreak     int       // Consecutive days with logged time, ending today (or yesterday, if nothing is logged yet today)
+	LongestStreak     int       // Longest run of consecutive days with logged time
+	LongestStreakEnd  time.Time // Last day of the longest streak (the most recent one on ties)
+	BestDay           Record    // Most time logged in a day
+	MostCompletedDay  Record    // Most tasks completed in a day
+	MostCompletedWeek Record    // Most tasks completed in a week (Date is the Monday)
+}
+
+// ApplyRecords computes streaks and personal bests. Logged time counts
+// towards the days it was logged on. A task's completion day is its completed::
+// date, else the end of its last logbook entry, else its journal's date;
+// DONE tasks with none of these are skipped.
+func (ti *TimeTrackingIndex) ApplyRecords(tasks []models.Task, now time.Time) {
+	records := &Records{}
+
+	dailyTime := make(map[time.Time]time.Duration)
+	dailyDone := make(map[time.Time]int)
+	weeklyDone := make(map[time.Time]int)
+
+	for _, task := range tasks {
+		for _, entry := range task.Logbook {
+			// Entries left running past midnight are split across the days they cover
+			start, end := entry.Start, entry.Start.Add(entry.Duration)
+			for start.Before(end) {
+				next := startOfDay(start).AddDate(0, 0, 1)
+				if next.After(end) {
+					next = end
+				}
+				dailyTime[startOfDay(start)] += next.Sub(start)
+				start = next
+			}
+		}
+
+		if task.Status != models.StatusDONE {
+			continue
+		}
+		if completed, ok := completionDate(task); ok {
+			dailyDone[startOfDay(completed)]++
+			weeklyDone[startOfDay(getWeekStart(completed))]++
+		}
+	}
+
+	// Personal bests, the earliest date winning ties
+	for day, logged := range dailyTime {
+		if logged > records.BestDay.TimeLogged || (logged == records.BestDay.TimeLogged && day.Before(records.BestDay.Date)) {
+			records.BestDay = Record{Date: day, TimeLogged: logged}
+		}
+	}
+	records.MostCompletedDay = mostCompleted(dailyDone)
+	records.MostCompletedWeek = mostCompleted(weeklyDone)
+
+	// Streaks: walk each run of consecutive days from its first day
+	for day := range dailyTime {
+		if dailyTime[day.AddDate(0, 0, -1)] > 0 {
+			continue // Not the start of a run
+		}
+		length := 1
+		end := day
+		for dailyTime[end.AddDate(0, 0, 1)] > 0 {
+			end = end.AddDate(0, 0, 1)
+			length++
+		}
+		if length > records.LongestStreak || (length == records.LongestStreak && end.After(records.LongestStreakEnd)) {
+			records.LongestStreak = length
+			records.LongestStreakEnd = end
+		}
+	}
+
+	day := startOfDay(now)
+	if dailyTime[day] == 0 {
+		day = day.AddDate(0, 0, -1) // The streak survives until today is over
+	}
+	for dailyTime[day] > 0 {
+		records.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	ti.Records = records
+}
+
+// completionDate returns the day a DONE task was completed, if known
+func completionDate(task models.Task) (time.Time, bool) {
+	if !task.CompletedAt.IsZero() {
+		return task.CompletedAt, true
+	}
+	var last time.Time
+	for _, entry := range task.Logbook {
+		if entry.End.After(last) {
+			last = entry.End
+		}
+	}
+	if !last.IsZero() {
+		return last, true
+	}
+	if date, err := extractDateFromJournalPath(task.SourceFile); err == nil {
+		return date, true
+	}
+	return time.Time{}, false
+}
+
+// mostCompleted returns the date with the highest count, the earliest winning ties
+func mostCompleted(counts map[time.Time]int) Record {
+	var best Record
+	for date, count := range counts {
+		if count > best.Count || (count == best.Count && date.Before(best.Date)) {
+			best = Record{Date: date, Count: count}
+		}
+	}
+	return best
+}
+
+// startOfDay returns midnight UTC on t's calendar date. Logbook times are
+// parsed as UTC wall-clock times, so this keeps days comparable with now.
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
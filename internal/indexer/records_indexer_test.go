@@ -0,0 +1,65 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyRecords(t *testing.T) {
+	session := func(day int, length time.Duration) models.LogbookEntry {
+		start := time.Date(2025, 11, day, 9, 0, 0, 0, time.UTC)
+		return models.LogbookEntry{Start: start, End: start.Add(length), Duration: length}
+	}
+	completed := func(day int) time.Time { return time.Date(2025, 11, day, 0, 0, 0, 0, time.UTC) }
+
+	tasks := []models.Task{
+		// Nov 1-5 is the longest streak; Nov 8-10 is the current one
+		{Status: models.StatusDOING, Logbook: []models.LogbookEntry{
+			session(1, time.Hour), session(2, time.Hour), session(3, 3*time.Hour), session(4, time.Hour), session(5, time.Hour),
+			session(8, time.Hour), session(9, time.Hour),
+		}},
+		{Status: models.StatusDONE, Logbook: []models.LogbookEntry{session(10, 30*time.Minute)}}, // Completed Nov 10
+		{Status: models.StatusDONE, CompletedAt: completed(4)},
+		{Status: models.StatusDONE, CompletedAt: completed(4)},
+		{Status: models.StatusDONE, SourceFile: "journals/2025_11_05.md"},
+		{Status: models.StatusDONE, SourceFile: "pages/Undated.md"}, // No completion date
+	}
+
+	index := &TimeTrackingIndex{}
+	index.ApplyRecords(tasks, time.Date(2025, 11, 11, 8, 0, 0, 0, time.Local)) // Nothing logged yet today
+	r := index.Records
+
+	if r.CurrentStreak != 3 {
+		t.Errorf("Expected current streak 3, got %d", r.CurrentStreak)
+	}
+	if r.LongestStreak != 5 || !r.LongestStreakEnd.Equal(completed(5)) {
+		t.Errorf("Expected longest streak 5 ending Nov 5, got %d ending %s", r.LongestStreak, r.LongestStreakEnd)
+	}
+	if r.BestDay.TimeLogged != 3*time.Hour || !r.BestDay.Date.Equal(completed(3)) {
+		t.Errorf("Expected best day Nov 3 with 3h, got %+v", r.BestDay)
+	}
+	if r.MostCompletedDay.Count != 2 || !r.MostCompletedDay.Date.Equal(completed(4)) {
+		t.Errorf("Expected 2 tasks completed on Nov 4, got %+v", r.MostCompletedDay)
+	}
+	// Nov 3-9 is one week (Monday Nov 3)
+	if r.MostCompletedWeek.Count != 3 || !r.MostCompletedWeek.Date.Equal(completed(3)) {
+		t.Errorf("Expected 3 tasks completed in the week of Nov 3, got %+v", r.MostCompletedWeek)
+	}
+
+	// An entry left running past midnight counts towards both days
+	late := time.Date(2025, 11, 20, 22, 0, 0, 0, time.UTC)
+	index.ApplyRecords([]models.Task{{Logbook: []models.LogbookEntry{
+		{Start: late, End: late.Add(4 * time.Hour), Duration: 4 * time.Hour},
+	}}}, late)
+	if index.Records.LongestStreak != 2 || index.Records.BestDay.TimeLogged != 2*time.Hour {
+		t.Errorf("Expected a 2-day streak with 2h per day, got %+v", index.Records)
+	}
+
+	// A day without logged time ends the current streak
+	index.ApplyRecords(tasks, time.Date(2025, 11, 12, 8, 0, 0, 0, time.UTC))
+	if index.Records.CurrentStreak != 0 {
+		t.Errorf("Expected current streak 0, got %d", index.Records.CurrentStreak)
+	}
+}
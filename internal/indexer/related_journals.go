@@ -0,0 +1,64 @@
+package indexer
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// JournalMention is a journal day that references a page
+type JournalMention struct {
+	Date     time.Time
+	Page     string // Journal page name, e.g. "2025_11_03"
+	File     string
+	Line     int    // First line referencing the page that day
+	Mentions int    // References to the page that day
+	Snippet  string // Context of the first reference
+}
+
+// ApplyRelatedJournals lists, for each page, the most recent journal days
+// that reference it (at most limit, newest first), answering "when did I
+// last work on or think about this?"
+func (index *PageDetailsIndex) ApplyRelatedJournals(limit int) {
+	for _, page := range index.Pages {
+		page.RecentJournals = nil
+		page.JournalDays = 0
+
+		days := make(map[string]*JournalMention)
+		var mentions []*JournalMention
+		for _, ref := range page.Backlinks {
+			if !strings.HasPrefix(filepath.ToSlash(ref.SourceFile), "journals/") {
+				continue
+			}
+			date, err := extractDateFromJournalPath(ref.SourceFile)
+			if err != nil {
+				continue
+			}
+
+			mention, exists := days[ref.SourcePage]
+			if !exists {
+				mention = &JournalMention{Date: date, Page: ref.SourcePage, File: ref.SourceFile, Line: ref.LineNumber, Snippet: ref.Context}
+				days[ref.SourcePage] = mention
+				mentions = append(mentions, mention)
+			}
+			mention.Mentions++
+			if ref.LineNumber < mention.Line {
+				mention.Line = ref.LineNumber
+				mention.Snippet = ref.Context
+			}
+		}
+
+		sort.SliceStable(mentions, func(i, j int) bool {
+			return mentions[i].Date.After(mentions[j].Date)
+		})
+
+		page.JournalDays = len(mentions)
+		if limit > 0 && len(mentions) > limit {
+			mentions = mentions[:limit]
+		}
+		for _, m := range mentions {
+			page.RecentJournals = append(page.RecentJournals, *m)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package indexer
+
+import (
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyRelatedJournals(t *testing.T) {
+	ref := func(source, file string, line int, context string) models.PageReference {
+		return models.PageReference{SourcePage: source, SourceFile: file, TargetPage: "Phoenix", LineNumber: line, Context: context}
+	}
+	index := &PageDetailsIndex{Pages: []*PageDetail{
+		{
+			Name: "Phoenix",
+			Backlinks: []models.PageReference{
+				ref("2025_11_05", "journals/2025_11_05.md", 9, "Deployed [[Phoenix]]"),
+				ref("2025_11_05", "journals/2025_11_05.md", 2, "Planning [[Phoenix]] release"),
+				ref("2025_11_03", "journals/2025_11_03.md", 4, "Kickoff for [[Phoenix]]"),
+				ref("2025_10_20", "journals/2025_10_20.md", 1, "Idea: [[Phoenix]]"),
+				ref("Roadmap", "pages/Roadmap.md", 3, "- [[Phoenix]] in Q4"),
+			},
+		},
+		{Name: "Notes"},
+	}}
+
+	index.ApplyRelatedJournals(2)
+	phoenix := index.Pages[0]
+
+	if phoenix.JournalDays != 3 {
+		t.Errorf("Expected 3 journal days (pages don't count), got %d", phoenix.JournalDays)
+	}
+	if len(phoenix.RecentJournals) != 2 {
+		t.Fatalf("Expected the 2 most recent days, got %+v", phoenix.RecentJournals)
+	}
+
+	latest := phoenix.RecentJournals[0]
+	if latest.Page != "2025_11_05" || latest.Mentions != 2 || latest.Line != 2 || latest.Snippet != "Planning [[Phoenix]] release" {
+		t.Errorf("Expected the newest day with its first mention, got %+v", latest)
+	}
+	if phoenix.RecentJournals[1].Page != "2025_11_03" {
+		t.Errorf("Expected 2025_11_03 second, got %s", phoenix.RecentJournals[1].Page)
+	}
+	if index.Pages[1].RecentJournals != nil || index.Pages[1].JournalDays != 0 {
+		t.Errorf("Expected no journals for an unreferenced page, got %+v", index.Pages[1])
+	}
+}
@@ -0,0 +1,109 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// RemindersIndex lists open tasks due soon, for notification tools
+type RemindersIndex struct {
+	GeneratedAt time.Time
+	WithinDays  int        // Tasks due up to this many days ahead are included
+	Reminders   []Reminder // Sorted by due date, then priority
+}
+
+// Reminder is an open task with a deadline or scheduled date
+type Reminder struct {
+	Task      models.Task
+	Due       time.Time // Deadline, or scheduled date when there's no deadline
+	DaysUntil int       // Negative when overdue
+	Page      string    // Logseq page holding the task (journal pages use their title, e.g. "Nov 6th, 2025")
+}
+
+// Overdue reports whether the due date has passed
+func (r Reminder) Overdue() bool {
+	return r.DaysUntil < 0
+}
+
+// BuildRemindersIndex collects open tasks due within withinDays of now,
+// including overdue ones. Snoozed tasks are left out until their snooze::
+// date, even when due.
+func BuildRemindersIndex(tasks []models.Task, now time.Time, withinDays int) *RemindersIndex {
+	index := &RemindersIndex{
+		GeneratedAt: now,
+		WithinDays:  withinDays,
+		Reminders:   []Reminder{},
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, task := range tasks {
+		due := task.DueDate()
+		if models.IsClosed(task.Status) || due.IsZero() || task.Snooze.After(today) {
+			continue
+		}
+
+		dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+		daysUntil := int(dueDay.Sub(today).Hours() / 24)
+		if daysUntil > withinDays {
+			continue
+		}
+
+		index.Reminders = append(index.Reminders, Reminder{
+			Task:      task,
+			Due:       dueDay,
+			DaysUntil: daysUntil,
+			Page:      logseqPageName(task.SourceFile),
+		})
+	}
+
+	sort.SliceStable(index.Reminders, func(i, j int) bool {
+		a, b := index.Reminders[i], index.Reminders[j]
+		if !a.Due.Equal(b.Due) {
+			return a.Due.Before(b.Due)
+		}
+		return priorityRank(a.Task.Priority) < priorityRank(b.Task.Priority)
+	})
+
+	return index
+}
+
+// priorityRank orders priorities highest first, with no priority last
+func priorityRank(p models.Priority) int {
+	for i, level := range models.AllPriorities() {
+		if level == p {
+			return i
+		}
+	}
+	return len(models.AllPriorities())
+}
+
+// logseqPageName returns the page Logseq shows for a file: journals use
+// Logseq's default title format ("Nov 6th, 2025"), and namespaced page files
+// (Projects___App.md) map back to "Projects/App"
+func logseqPageName(sourceFile string) string {
+	if date, err := extractDateFromJournalPath(sourceFile); err == nil {
+		return fmt.Sprintf("%s %d%s, %d", date.Format("Jan"), date.Day(), ordinalSuffix(date.Day()), date.Year())
+	}
+	name := extractPageNameFromPath(sourceFile)
+	return strings.ReplaceAll(name, "___", "/")
+}
+
+// ordinalSuffix returns "st", "nd", "rd", or "th" for a day of the month
+func ordinalSuffix(day int) string {
+	if day >= 11 && day <= 13 {
+		return "th"
+	}
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
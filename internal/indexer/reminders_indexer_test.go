@@ -0,0 +1,73 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildRemindersIndex(t *testing.T) {
+	now := time.Date(2025, 11, 6, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC) }
+
+	tasks := []models.Task{
+		{Description: "Later this week", Status: models.StatusTODO, Deadline: day(9), SourceFile: "pages/Projects___Phoenix.md"},
+		{Description: "Overdue", Status: models.StatusNOW, Scheduled: day(4), SourceFile: "journals/2025_11_01.md"},
+		{Description: "Too far out", Status: models.StatusTODO, Deadline: day(20)},
+		{Description: "Already done", Status: models.StatusDONE, Deadline: day(7)},
+		{Description: "No date", Status: models.StatusTODO},
+		{Description: "Same day, high priority", Status: models.StatusLATER, Priority: models.PriorityHigh, Deadline: day(9)},
+		{Description: "Today", Status: models.StatusTODO, Scheduled: day(6), Deadline: day(7)},
+		{Description: "Snoozed though overdue", Status: models.StatusTODO, Deadline: day(5), Snooze: day(10)},
+		{Description: "Snooze over", Status: models.StatusTODO, Deadline: day(8), Snooze: day(6)},
+	}
+
+	index := BuildRemindersIndex(tasks, now, 3)
+
+	want := []string{"Overdue", "Today", "Snooze over", "Same day, high priority", "Later this week"}
+	if len(index.Reminders) != len(want) {
+		t.Fatalf("Expected %d reminders, got %d: %+v", len(want), len(index.Reminders), index.Reminders)
+	}
+	for i, desc := range want {
+		if index.Reminders[i].Task.Description != desc {
+			t.Errorf("Reminder %d: expected %q, got %q", i, desc, index.Reminders[i].Task.Description)
+		}
+	}
+
+	overdue := index.Reminders[0]
+	if !overdue.Overdue() || overdue.DaysUntil != -2 || overdue.Page != "Nov 1st, 2025" {
+		t.Errorf("Unexpected overdue reminder: %+v", overdue)
+	}
+	if today := index.Reminders[1]; today.DaysUntil != 1 {
+		t.Errorf("Expected the deadline (tomorrow) to win over the scheduled date, got %d days", today.DaysUntil)
+	}
+	if page := index.Reminders[4].Page; page != "Projects/Phoenix" {
+		t.Errorf("Expected namespaced page name, got %q", page)
+	}
+}
+
+func TestBuildRemindersIndex_ClosedStatuses(t *testing.T) {
+	models.SetClosedStatuses([]models.TaskStatus{"CANCELED"})
+	defer models.SetClosedStatuses(nil)
+
+	now := time.Date(2025, 11, 6, 15, 0, 0, 0, time.UTC)
+	deadline := time.Date(2025, 11, 7, 0, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{Description: "Canceled", Status: "CANCELED", Deadline: deadline},
+		{Description: "Waiting", Status: "WAITING", Deadline: deadline},
+	}
+
+	index := BuildRemindersIndex(tasks, now, 3)
+	if len(index.Reminders) != 1 || index.Reminders[0].Task.Description != "Waiting" {
+		t.Errorf("Expected only the open WAITING task, got %+v", index.Reminders)
+	}
+}
+
+func TestOrdinalSuffix(t *testing.T) {
+	for day, want := range map[int]string{1: "st", 2: "nd", 3: "rd", 4: "th", 11: "th", 12: "th", 13: "th", 21: "st", 22: "nd", 23: "rd", 31: "st"} {
+		if got := ordinalSuffix(day); got != want {
+			t.Errorf("ordinalSuffix(%d) = %q, want %q", day, got, want)
+		}
+	}
+}
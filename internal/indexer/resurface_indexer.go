@@ -0,0 +1,151 @@
+package indexer
+
+import (
+	"hash/fnv"
+	"math"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// ResurfaceMilestones are the days untouched after which a page is due for a revisit
+var ResurfaceMilestones = []int{90, 180, 365}
+
+// ResurfacePage is an old page scheduled for a revisit
+type ResurfacePage struct {
+	Page        string
+	FilePath    string
+	LastTouched time.Time // Last edit, or last mention in a journal if later
+	DaysSince   int       // Days since LastTouched
+	Milestone   int       // Largest of ResurfaceMilestones passed, e.g. 180
+	References  int       // Pages referencing this one
+	Tasks       int       // Tasks linking this page
+	Pinned      bool
+	Importance  int // 1 + references + tasks, doubled when pinned
+}
+
+// ResurfaceTier counts the pages due at one milestone
+type ResurfaceTier struct {
+	Milestone int
+	Pages     int
+}
+
+// ResurfaceIndex is the revisit queue for old notes
+type ResurfaceIndex struct {
+	GeneratedAt time.Time
+	Today       []ResurfacePage // Pages to revisit today, most important first
+	Tiers       []ResurfaceTier // Due pages per milestone, in ResurfaceMilestones order
+	Due         int             // All pages due for a revisit
+}
+
+// BuildResurfaceIndex schedules existing pages not touched in 90, 180, or 365
+// days for a revisit and picks count of them for today. pageModified maps page
+// file paths (forward slashes) to their last edit; a mention in a journal dated
+// later counts as a touch too. The pick is a weighted sample favouring pages
+// with more references and linked tasks, seeded by the date so it is stable
+// within a day and rotates through the queue across days.
+func BuildResurfaceIndex(graph *ReferenceGraph, pageModified map[string]time.Time, refs []models.PageReference, now time.Time, count int) *ResurfaceIndex {
+	index := &ResurfaceIndex{GeneratedAt: now}
+
+	lastMention := make(map[string]time.Time)
+	for _, ref := range refs {
+		date, err := extractDateFromJournalPath(ref.SourceFile)
+		if err != nil || !isJournalPath(ref.SourceFile) {
+			continue
+		}
+		if date.After(lastMention[ref.TargetPage]) {
+			lastMention[ref.TargetPage] = date
+		}
+	}
+
+	today := startOfDay(now)
+	tierCounts := make(map[int]int)
+	var due []ResurfacePage
+	for name, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+		touched, known := pageModified[filepath.ToSlash(node.FilePath)]
+		if !known {
+			continue
+		}
+		if mentioned := lastMention[name]; mentioned.After(touched) {
+			touched = mentioned
+		}
+
+		days := int(today.Sub(startOfDay(touched)).Hours() / 24)
+		milestone := resurfaceMilestone(days)
+		if milestone == 0 {
+			continue
+		}
+
+		importance := 1 + node.ReferenceCount + node.TaskCount
+		if node.Pinned {
+			importance *= 2
+		}
+		due = append(due, ResurfacePage{
+			Page:        name,
+			FilePath:    node.FilePath,
+			LastTouched: touched,
+			DaysSince:   days,
+			Milestone:   milestone,
+			References:  node.ReferenceCount,
+			Tasks:       node.TaskCount,
+			Pinned:      node.Pinned,
+			Importance:  importance,
+		})
+		tierCounts[milestone]++
+	}
+
+	index.Due = len(due)
+	for _, milestone := range ResurfaceMilestones {
+		index.Tiers = append(index.Tiers, ResurfaceTier{Milestone: milestone, Pages: tierCounts[milestone]})
+	}
+
+	// Weighted sampling without replacement: the pages with the largest
+	// u^(1/importance), where u is a per-day pseudo-random number in (0, 1)
+	seed := today.Format("2006-01-02")
+	keys := make(map[string]float64, len(due))
+	for _, page := range due {
+		keys[page.Page] = math.Pow(resurfaceRandom(seed, page.Page), 1/float64(page.Importance))
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if keys[due[i].Page] != keys[due[j].Page] {
+			return keys[due[i].Page] > keys[due[j].Page]
+		}
+		return due[i].Page < due[j].Page
+	})
+	if len(due) > count {
+		due = due[:count]
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].Importance != due[j].Importance {
+			return due[i].Importance > due[j].Importance
+		}
+		return due[i].Page < due[j].Page
+	})
+	index.Today = due
+
+	return index
+}
+
+// resurfaceMilestone returns the largest milestone days has passed, or 0 if the page isn't due
+func resurfaceMilestone(days int) int {
+	milestone := 0
+	for _, m := range ResurfaceMilestones {
+		if days >= m {
+			milestone = m
+		}
+	}
+	return milestone
+}
+
+// resurfaceRandom hashes seed and page to a number in (0, 1)
+func resurfaceRandom(seed, page string) float64 {
+	h := fnv.New64a()
+	h.Write([]byte(seed + "\x00" + page))
+	return (float64(h.Sum64()>>11) + 0.5) / (1 << 53)
+}
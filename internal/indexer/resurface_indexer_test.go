@@ -0,0 +1,84 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildResurfaceIndex(t *testing.T) {
+	now := time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Fresh":      {PageName: "Fresh", FilePath: "pages/Fresh.md"},
+		"Quarter":    {PageName: "Quarter", FilePath: "pages/Quarter.md", ReferenceCount: 2},
+		"Half":       {PageName: "Half", FilePath: "pages/Half.md", TaskCount: 1, Pinned: true},
+		"Ancient":    {PageName: "Ancient", FilePath: "pages/Ancient.md"},
+		"Mentioned":  {PageName: "Mentioned", FilePath: "pages/Mentioned.md"},
+		"Missing":    {PageName: "Missing"},
+		"2024_01_01": {PageName: "2024_01_01", FilePath: "journals/2024_01_01.md"},
+	}}
+	pageModified := map[string]time.Time{
+		"pages/Fresh.md":         daysAgo(10),
+		"pages/Quarter.md":       daysAgo(100),
+		"pages/Half.md":          daysAgo(200),
+		"pages/Ancient.md":       daysAgo(800),
+		"pages/Mentioned.md":     daysAgo(400),
+		"journals/2024_01_01.md": daysAgo(600),
+	}
+	refs := []models.PageReference{
+		{SourceFile: "journals/2025_11_01.md", TargetPage: "Mentioned"}, // Touched 9 days ago
+		{SourceFile: "pages/Quarter.md", TargetPage: "Ancient"},         // Page mentions don't count
+	}
+
+	index := BuildResurfaceIndex(graph, pageModified, refs, now, 2)
+
+	if index.Due != 3 {
+		t.Errorf("Expected 3 pages due, got %d", index.Due)
+	}
+	want := []ResurfaceTier{{90, 1}, {180, 1}, {365, 1}}
+	for i, tier := range want {
+		if index.Tiers[i] != tier {
+			t.Errorf("Expected tier %+v, got %+v", tier, index.Tiers[i])
+		}
+	}
+	if len(index.Today) != 2 {
+		t.Fatalf("Expected 2 pages today, got %d", len(index.Today))
+	}
+	if index.Today[0].Importance < index.Today[1].Importance {
+		t.Errorf("Expected today's pages most important first, got %+v", index.Today)
+	}
+	for _, page := range index.Today {
+		if page.Page == "Half" && (page.Importance != 4 || page.DaysSince != 200 || page.Milestone != 180) {
+			t.Errorf("Expected Half to have importance 4, 200 days, milestone 180, got %+v", page)
+		}
+	}
+
+	// The same day picks the same pages
+	again := BuildResurfaceIndex(graph, pageModified, refs, now.Add(8*time.Hour), 2)
+	for i := range index.Today {
+		if again.Today[i].Page != index.Today[i].Page {
+			t.Errorf("Expected a stable pick within the day, got %v then %v", index.Today, again.Today)
+		}
+	}
+}
+
+func TestBuildResurfaceIndex_RotatesAcrossDays(t *testing.T) {
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{}}
+	pageModified := map[string]time.Time{}
+	for _, name := range []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J"} {
+		graph.Nodes[name] = &GraphNode{PageName: name, FilePath: "pages/" + name + ".md"}
+		pageModified["pages/"+name+".md"] = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	seen := make(map[string]bool)
+	for day := 1; day <= 14; day++ {
+		index := BuildResurfaceIndex(graph, pageModified, nil, time.Date(2025, 11, day, 9, 0, 0, 0, time.UTC), 1)
+		seen[index.Today[0].Page] = true
+	}
+	if len(seen) < 3 {
+		t.Errorf("Expected the daily pick to rotate through the queue, saw only %v", seen)
+	}
+}
@@ -0,0 +1,126 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// DefaultRetroWeeks is how long after its last activity a finished project
+// still gets a retro prompt
+const DefaultRetroWeeks = 12
+
+// Retro is the material for a finished project's retrospective
+type Retro struct {
+	Page        string
+	FilePath    string
+	Started     time.Time     // Earliest dated event
+	Finished    time.Time     // Latest dated event
+	TotalTime   time.Duration // Logged on the project's tasks
+	Tasks       []models.Task // DONE tasks, most time logged first, then highest priority
+	Timeline    []RetroEvent  // Oldest first
+	LinkedPages []LinkedPage  // Pages most linked from the project and its tasks
+}
+
+// RetroEvent is a dated step in a project's history
+type RetroEvent struct {
+	Date time.Time
+	Text string // e.g. "Completed: Ship v1" or a journal mention's context
+}
+
+// LinkedPage is a page connected to a project, with how often it's linked
+type LinkedPage struct {
+	Page  string
+	Count int
+}
+
+// BuildRetros returns a retro for each project page whose referencing tasks
+// are all DONE and whose last activity is within withinWeeks of now, most
+// recently finished first. Projects without dated activity are skipped.
+func BuildRetros(tasks []models.Task, graph *ReferenceGraph, refs []models.PageReference, now time.Time, withinWeeks int) []Retro {
+	if withinWeeks <= 0 {
+		withinWeeks = DefaultRetroWeeks
+	}
+	cutoff := now.AddDate(0, 0, -7*withinWeeks)
+
+	var retros []Retro
+	for page, project := range finishedProjects(tasks, graph, refs) {
+		if project.LastActivity.IsZero() || project.LastActivity.Before(cutoff) {
+			continue
+		}
+
+		retro := Retro{Page: page, FilePath: graph.Nodes[page].FilePath}
+		links := make(map[string]int)
+		for target, weight := range graph.Nodes[page].OutboundWeights {
+			links[target] += weight
+		}
+
+		taskLines := make(map[string]bool)
+		for _, task := range project.Tasks {
+			taskLines[fmt.Sprintf("%s:%d", task.SourceFile, task.LineNumber)] = true
+			retro.TotalTime += task.TotalDuration()
+			if completed, ok := completionDate(task); ok {
+				retro.Timeline = append(retro.Timeline, RetroEvent{Date: completed, Text: "Completed: " + task.Description})
+			}
+			for _, ref := range task.PageRefs {
+				if ref != page {
+					links[ref]++
+				}
+			}
+		}
+		retro.Tasks = append(retro.Tasks, project.Tasks...)
+		sort.SliceStable(retro.Tasks, func(i, j int) bool {
+			a, b := retro.Tasks[i], retro.Tasks[j]
+			if a.TotalDuration() != b.TotalDuration() {
+				return a.TotalDuration() > b.TotalDuration()
+			}
+			return priorityRank(a.Priority) < priorityRank(b.Priority)
+		})
+
+		// One event per journal day, using its first mention that isn't
+		// one of the project's tasks (those are already in as completions)
+		mentioned := make(map[string]bool)
+		for _, ref := range project.JournalRefs {
+			if mentioned[ref.SourcePage] || taskLines[fmt.Sprintf("%s:%d", ref.SourceFile, ref.LineNumber)] {
+				continue
+			}
+			mentioned[ref.SourcePage] = true
+			if date, err := extractDateFromJournalPath(ref.SourceFile); err == nil {
+				retro.Timeline = append(retro.Timeline, RetroEvent{Date: date, Text: ref.Context})
+			}
+		}
+		sort.SliceStable(retro.Timeline, func(i, j int) bool {
+			return retro.Timeline[i].Date.Before(retro.Timeline[j].Date)
+		})
+		if len(retro.Timeline) > 0 {
+			retro.Started = retro.Timeline[0].Date
+			retro.Finished = retro.Timeline[len(retro.Timeline)-1].Date
+		}
+
+		for target, count := range links {
+			if node, exists := graph.Nodes[target]; exists && isJournalNode(node) {
+				continue
+			}
+			retro.LinkedPages = append(retro.LinkedPages, LinkedPage{Page: target, Count: count})
+		}
+		sort.Slice(retro.LinkedPages, func(i, j int) bool {
+			a, b := retro.LinkedPages[i], retro.LinkedPages[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Page < b.Page
+		})
+
+		retros = append(retros, retro)
+	}
+
+	sort.Slice(retros, func(i, j int) bool {
+		if !retros[i].Finished.Equal(retros[j].Finished) {
+			return retros[i].Finished.After(retros[j].Finished)
+		}
+		return retros[i].Page < retros[j].Page
+	})
+	return retros
+}
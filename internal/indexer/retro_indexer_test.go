@@ -0,0 +1,54 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildRetros(t *testing.T) {
+	now := time.Date(2025, 12, 1, 12, 0, 0, 0, time.UTC)
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Launch":     {PageName: "Launch", FilePath: "pages/Launch.md", OutboundWeights: map[string]int{"Design": 2, "2025_11_01": 1}},
+		"Ancient":    {PageName: "Ancient", FilePath: "pages/Ancient.md"},
+		"Ongoing":    {PageName: "Ongoing", FilePath: "pages/Ongoing.md"},
+		"Design":     {PageName: "Design", FilePath: "pages/Design.md"},
+		"2025_11_01": {PageName: "2025_11_01", FilePath: "journals/2025_11_01.md"},
+	}}
+	hour := models.LogbookEntry{Start: time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC), End: time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC), Duration: time.Hour}
+
+	tasks := []models.Task{
+		{Status: models.StatusDONE, Description: "Write copy", PageRefs: []string{"Launch", "Marketing"}, CompletedAt: time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDONE, Description: "Build site", PageRefs: []string{"Launch", "Design"}, Logbook: []models.LogbookEntry{hour, hour}},
+		{Status: models.StatusDONE, Description: "Old work", PageRefs: []string{"Ancient"}, CompletedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDONE, Description: "Part one", PageRefs: []string{"Ongoing"}, CompletedAt: time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusTODO, Description: "Part two", PageRefs: []string{"Ongoing"}},
+	}
+	refs := []models.PageReference{
+		{SourceFile: "journals/2025_11_01.md", SourcePage: "2025_11_01", TargetPage: "Launch", Context: "Kicked off [[Launch]]"},
+		{SourceFile: "journals/2025_11_01.md", SourcePage: "2025_11_01", TargetPage: "Launch", Context: "Second mention"},
+	}
+
+	retros := BuildRetros(tasks, graph, refs, now, 12)
+	if len(retros) != 1 {
+		t.Fatalf("Expected only Launch (Ancient is too old, Ongoing has open work), got %+v", retros)
+	}
+
+	retro := retros[0]
+	if retro.TotalTime != 2*time.Hour {
+		t.Errorf("Expected 2h logged, got %s", retro.TotalTime)
+	}
+	if retro.Tasks[0].Description != "Build site" {
+		t.Errorf("Expected the most time-consuming task first, got %s", retro.Tasks[0].Description)
+	}
+	if len(retro.Timeline) != 3 || retro.Timeline[0].Text != "Kicked off [[Launch]]" || retro.Timeline[2].Text != "Completed: Write copy" {
+		t.Errorf("Unexpected timeline: %+v", retro.Timeline)
+	}
+	if !retro.Started.Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)) || !retro.Finished.Equal(time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected span %s - %s", retro.Started, retro.Finished)
+	}
+	if len(retro.LinkedPages) != 2 || retro.LinkedPages[0] != (LinkedPage{Page: "Design", Count: 3}) {
+		t.Errorf("Expected Design then Marketing, without journals, got %+v", retro.LinkedPages)
+	}
+}
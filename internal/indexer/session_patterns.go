@@ -0,0 +1,101 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// SessionPattern describes when and for how long a project is usually worked on
+type SessionPattern struct {
+	Sessions      int           // Logbook entries counted
+	TimeOfDay     string        // "mornings", "afternoons", "evenings", "nights", or "varied times"
+	TypicalStart  time.Duration // Median start, as time since midnight, rounded to 15 minutes
+	TypicalLength time.Duration // Median session length, rounded to 5 minutes
+}
+
+// timeOfDay names the part of the day a session started in
+func timeOfDay(t time.Time) string {
+	switch hour := t.Hour(); {
+	case hour >= 5 && hour < 12:
+		return "mornings"
+	case hour >= 12 && hour < 17:
+		return "afternoons"
+	case hour >= 17 && hour < 22:
+		return "evenings"
+	}
+	return "nights"
+}
+
+// BuildSessionPatterns summarises the logbook sessions of each project (a
+// task's first page reference, as in time tracking). Projects with fewer
+// than minSessions sessions are left out. A part of the day is only named
+// when most sessions started in it.
+func BuildSessionPatterns(tasks []models.Task, minSessions int) map[string]SessionPattern {
+	starts := make(map[string][]time.Duration)
+	lengths := make(map[string][]time.Duration)
+	parts := make(map[string]map[string]int)
+
+	for _, task := range tasks {
+		if len(task.PageRefs) == 0 {
+			continue
+		}
+		project := task.PageRefs[0]
+		for _, entry := range task.Logbook {
+			if entry.Duration <= 0 || entry.Start.IsZero() {
+				continue
+			}
+			sinceMidnight := time.Duration(entry.Start.Hour())*time.Hour + time.Duration(entry.Start.Minute())*time.Minute
+			starts[project] = append(starts[project], sinceMidnight)
+			lengths[project] = append(lengths[project], entry.Duration)
+			if parts[project] == nil {
+				parts[project] = make(map[string]int)
+			}
+			parts[project][timeOfDay(entry.Start)]++
+		}
+	}
+
+	patterns := make(map[string]SessionPattern)
+	for project, projectStarts := range starts {
+		if len(projectStarts) < minSessions {
+			continue
+		}
+
+		pattern := SessionPattern{
+			Sessions:      len(projectStarts),
+			TimeOfDay:     "varied times",
+			TypicalStart:  median(projectStarts).Round(15 * time.Minute),
+			TypicalLength: median(lengths[project]).Round(5 * time.Minute),
+		}
+
+		// Most common part of the day, ties broken alphabetically for stable output
+		names := make([]string, 0, len(parts[project]))
+		for name := range parts[project] {
+			names = append(names, name)
+		}
+		sort.Slice(names, func(i, j int) bool {
+			if parts[project][names[i]] != parts[project][names[j]] {
+				return parts[project][names[i]] > parts[project][names[j]]
+			}
+			return names[i] < names[j]
+		})
+		if top := names[0]; parts[project][top]*2 > pattern.Sessions {
+			pattern.TimeOfDay = top
+		}
+
+		patterns[project] = pattern
+	}
+
+	return patterns
+}
+
+// ApplySessionPatterns attaches each project's session pattern to its page
+func (index *PageDetailsIndex) ApplySessionPatterns(patterns map[string]SessionPattern) {
+	for _, page := range index.Pages {
+		if pattern, ok := patterns[page.Name]; ok {
+			p := pattern
+			page.Sessions = &p
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildSessionPatterns(t *testing.T) {
+	session := func(day, hour, minute int, length time.Duration) models.LogbookEntry {
+		start := time.Date(2025, 11, day, hour, minute, 0, 0, time.UTC)
+		return models.LogbookEntry{Start: start, End: start.Add(length), Duration: length}
+	}
+
+	tasks := []models.Task{
+		{PageRefs: []string{"Phoenix", "Auth"}, Logbook: []models.LogbookEntry{
+			session(3, 9, 0, 90*time.Minute),
+			session(4, 9, 40, 80*time.Minute),
+		}},
+		{PageRefs: []string{"Phoenix"}, Logbook: []models.LogbookEntry{
+			session(5, 10, 5, 2*time.Hour),
+			session(6, 15, 0, 30*time.Minute),
+		}},
+		// Spread over the day
+		{PageRefs: []string{"Admin"}, Logbook: []models.LogbookEntry{
+			session(3, 8, 0, 20*time.Minute),
+			session(4, 13, 0, 20*time.Minute),
+			session(5, 19, 0, 20*time.Minute),
+		}},
+		// Too few sessions, or no project
+		{PageRefs: []string{"Side Project"}, Logbook: []models.LogbookEntry{session(3, 21, 0, time.Hour)}},
+		{Logbook: []models.LogbookEntry{session(3, 9, 0, time.Hour), session(4, 9, 0, time.Hour), session(5, 9, 0, time.Hour)}},
+	}
+
+	patterns := BuildSessionPatterns(tasks, 3)
+
+	if len(patterns) != 2 {
+		t.Fatalf("Expected patterns for 2 projects, got %+v", patterns)
+	}
+
+	phoenix := patterns["Phoenix"]
+	if phoenix.Sessions != 4 || phoenix.TimeOfDay != "mornings" {
+		t.Errorf("Unexpected Phoenix pattern %+v", phoenix)
+	}
+	if want := 9*time.Hour + 45*time.Minute; phoenix.TypicalStart != want {
+		t.Errorf("Expected typical start %v, got %v", want, phoenix.TypicalStart)
+	}
+	if want := 80 * time.Minute; phoenix.TypicalLength != want {
+		t.Errorf("Expected typical length %v, got %v", want, phoenix.TypicalLength)
+	}
+
+	if admin := patterns["Admin"]; admin.TimeOfDay != "varied times" {
+		t.Errorf("Expected varied times for Admin, got %+v", admin)
+	}
+}
+
+func TestApplySessionPatterns(t *testing.T) {
+	index := &PageDetailsIndex{Pages: []*PageDetail{{Name: "Phoenix"}, {Name: "Notes"}}}
+	index.ApplySessionPatterns(map[string]SessionPattern{"Phoenix": {Sessions: 5, TimeOfDay: "evenings"}})
+
+	if index.Pages[0].Sessions == nil || index.Pages[0].Sessions.TimeOfDay != "evenings" {
+		t.Errorf("Expected Phoenix session pattern, got %+v", index.Pages[0].Sessions)
+	}
+	if index.Pages[1].Sessions != nil {
+		t.Errorf("Expected no pattern for Notes, got %+v", index.Pages[1].Sessions)
+	}
+}
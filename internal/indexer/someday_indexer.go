@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// SomedayOptions controls which tasks are moved into the someday/maybe backlog
+type SomedayOptions struct {
+	Tag        string // Tag marking a task as someday/maybe (e.g. "someday"), empty disables
+	LaterAfter int    // LATER tasks older than this many days are moved too (0 disables)
+}
+
+// SnoozeReturnDays is how long a task is flagged "back from snooze" after its snooze:: date
+const SnoozeReturnDays = 7
+
+// SomedayTask is a backlog task with the reason it was moved out of the active index
+type SomedayTask struct {
+	Task    models.Task
+	Reason  string // "snoozed", "tagged", or "stale"
+	AgeDays int    // Days since the task's journal date (-1 if unknown)
+}
+
+// SomedayIndex holds tasks that are parked rather than actionable
+type SomedayIndex struct {
+	GeneratedAt time.Time
+	Options     SomedayOptions
+	Tasks       []SomedayTask // Sorted oldest first
+}
+
+// SplitSomedayTasks separates someday/maybe tasks from actionable ones.
+// Open tasks snoozed until a later date, carrying the someday tag, or LATER
+// tasks from journals older than LaterAfter days go into the backlog;
+// everything else is returned as active.
+func SplitSomedayTasks(tasks []models.Task, opts SomedayOptions, now time.Time) ([]models.Task, *SomedayIndex) {
+	index := &SomedayIndex{
+		GeneratedAt: now,
+		Options:     opts,
+	}
+
+	var active []models.Task
+	for _, task := range tasks {
+		if models.IsClosed(task.Status) {
+			active = append(active, task)
+			continue
+		}
+
+		age := taskAgeDays(task, now)
+
+		switch {
+		case task.Snooze.After(startOfDay(now)):
+			index.Tasks = append(index.Tasks, SomedayTask{Task: task, Reason: "snoozed", AgeDays: age})
+		case opts.Tag != "" && hasTag(task, opts.Tag):
+			index.Tasks = append(index.Tasks, SomedayTask{Task: task, Reason: "tagged", AgeDays: age})
+		case opts.LaterAfter > 0 && task.Status == models.StatusLATER && age > opts.LaterAfter:
+			index.Tasks = append(index.Tasks, SomedayTask{Task: task, Reason: "stale", AgeDays: age})
+		default:
+			active = append(active, task)
+		}
+	}
+
+	// Oldest first so long-parked ideas are easy to review or drop
+	sort.SliceStable(index.Tasks, func(i, j int) bool {
+		return index.Tasks[i].AgeDays > index.Tasks[j].AgeDays
+	})
+
+	return active, index
+}
+
+// BackFromSnooze reports whether an open task's snooze:: date passed within
+// the last SnoozeReturnDays days, so it just reappeared in the active lists
+func BackFromSnooze(task models.Task, now time.Time) bool {
+	if task.Snooze.IsZero() || models.IsClosed(task.Status) {
+		return false
+	}
+	today := startOfDay(now)
+	return !task.Snooze.After(today) && today.Sub(task.Snooze) < SnoozeReturnDays*24*time.Hour
+}
+
+// taskAgeDays returns the days since the journal a task was written in, or -1 for page tasks
+func taskAgeDays(task models.Task, now time.Time) int {
+	date, err := extractDateFromJournalPath(task.SourceFile)
+	if err != nil {
+		return -1
+	}
+	return int(now.Sub(date).Hours() / 24)
+}
+
+// hasTag checks if a task carries the given tag (case-insensitive)
+func hasTag(task models.Task, tag string) bool {
+	for _, t := range task.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestSplitSomedayTasks(t *testing.T) {
+	now := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Actionable", SourceFile: "journals/2025_11_09.md"},
+		{Status: models.StatusLATER, Description: "Learn Rust #someday", Tags: []string{"someday"}, SourceFile: "pages/Ideas.md"},
+		{Status: models.StatusLATER, Description: "Old idea", SourceFile: "journals/2025_01_01.md"},
+		{Status: models.StatusLATER, Description: "Fresh idea", SourceFile: "journals/2025_11_01.md"},
+		{Status: models.StatusDONE, Description: "Done someday", Tags: []string{"Someday"}, SourceFile: "journals/2025_01_01.md"},
+	}
+
+	active, index := SplitSomedayTasks(tasks, SomedayOptions{Tag: "someday", LaterAfter: 90}, now)
+
+	if len(active) != 3 {
+		t.Fatalf("Expected 3 active tasks, got %d", len(active))
+	}
+	if len(index.Tasks) != 2 {
+		t.Fatalf("Expected 2 someday tasks, got %d", len(index.Tasks))
+	}
+
+	// Oldest first: the stale journal task has a known age, the page task does not
+	if index.Tasks[0].Task.Description != "Old idea" || index.Tasks[0].Reason != "stale" {
+		t.Errorf("Expected stale 'Old idea' first, got %q (%s)", index.Tasks[0].Task.Description, index.Tasks[0].Reason)
+	}
+	if index.Tasks[1].Reason != "tagged" || index.Tasks[1].AgeDays != -1 {
+		t.Errorf("Expected tagged page task with unknown age, got %s (%d)", index.Tasks[1].Reason, index.Tasks[1].AgeDays)
+	}
+
+	// DONE tasks are never parked, even when tagged
+	for _, task := range active {
+		if task.Description == "Old idea" || task.Description == "Learn Rust #someday" {
+			t.Errorf("Task %q should have been parked", task.Description)
+		}
+	}
+}
+
+func TestSplitSomedayTasks_Disabled(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusLATER, Tags: []string{"someday"}, SourceFile: "journals/2020_01_01.md"},
+	}
+
+	active, index := SplitSomedayTasks(tasks, SomedayOptions{}, time.Now())
+
+	if len(active) != 1 || len(index.Tasks) != 0 {
+		t.Errorf("Expected nothing parked when disabled, got %d active / %d parked", len(active), len(index.Tasks))
+	}
+}
+
+func TestSplitSomedayTasks_Snooze(t *testing.T) {
+	now := time.Date(2025, 11, 10, 15, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC) }
+
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Renew passport", SourceFile: "pages/Admin.md", Snooze: day(20)},
+		{Status: models.StatusTODO, Description: "Call the bank", SourceFile: "pages/Admin.md", Snooze: day(10)},
+		{Status: models.StatusDONE, Description: "Done while snoozed", SourceFile: "pages/Admin.md", Snooze: day(20)},
+	}
+
+	// Snoozing works without any someday options
+	active, index := SplitSomedayTasks(tasks, SomedayOptions{}, now)
+
+	if len(index.Tasks) != 1 || index.Tasks[0].Task.Description != "Renew passport" || index.Tasks[0].Reason != "snoozed" {
+		t.Fatalf("Expected the task snoozed until Nov 20 parked, got %+v", index.Tasks)
+	}
+	if len(active) != 2 {
+		t.Errorf("Expected the task snoozed until today and the DONE task active, got %d", len(active))
+	}
+}
+
+func TestBackFromSnooze(t *testing.T) {
+	now := time.Date(2025, 11, 10, 15, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name   string
+		task   models.Task
+		expect bool
+	}{
+		{"snoozed until today", models.Task{Status: models.StatusTODO, Snooze: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)}, true},
+		{"returned 6 days ago", models.Task{Status: models.StatusTODO, Snooze: time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC)}, true},
+		{"returned a week ago", models.Task{Status: models.StatusTODO, Snooze: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)}, false},
+		{"still snoozed", models.Task{Status: models.StatusTODO, Snooze: time.Date(2025, 11, 11, 0, 0, 0, 0, time.UTC)}, false},
+		{"done", models.Task{Status: models.StatusDONE, Snooze: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)}, false},
+		{"never snoozed", models.Task{Status: models.StatusTODO}, false},
+	} {
+		if got := BackFromSnooze(tc.task, now); got != tc.expect {
+			t.Errorf("%s: BackFromSnooze = %v, want %v", tc.name, got, tc.expect)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package indexer
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// MinStartDelayTasks is how many measured tasks a project needs for its own
+// line in the time to first start breakdown
+const MinStartDelayTasks = 3
+
+// StartDelay is how long a group of tasks waited before work on them began
+type StartDelay struct {
+	Group   string // Priority ("" for none) or project
+	Tasks   int
+	Median  time.Duration
+	Average time.Duration
+}
+
+// StartDelays measures time to first start: from writing a task down to
+// first clocking in on it
+type StartDelays struct {
+	Tasks      int // Tasks measured
+	FromGit    int // Of those, dated by their page's first commit rather than a journal
+	Median     time.Duration
+	ByPriority []StartDelay // Configured priority order, no priority last
+	ByProject  []StartDelay // Longest median first
+}
+
+// ApplyStartDelays measures each clocked task's wait from creation to its
+// earliest clock entry, then summarizes by priority and by project (first
+// page reference, only projects with MinStartDelayTasks measured tasks). A
+// journal task was created on the journal's day. A page task dates from its
+// page's first commit in pageCreated (keyed by slash path), which overstates
+// the wait of tasks added to an older page; tasks git doesn't date are
+// skipped. Clocking in before the creation date counts as no wait.
+func (ti *TimeTrackingIndex) ApplyStartDelays(tasks []models.Task, pageCreated map[string]time.Time) {
+	ti.StartDelays = StartDelays{}
+
+	var all []time.Duration
+	byPriority := make(map[models.Priority][]time.Duration)
+	byProject := make(map[string][]time.Duration)
+	for _, task := range tasks {
+		var started time.Time
+		for _, entry := range task.Logbook {
+			if started.IsZero() || entry.Start.Before(started) {
+				started = entry.Start
+			}
+		}
+		if started.IsZero() {
+			continue
+		}
+
+		created, err := extractDateFromJournalPath(task.SourceFile)
+		if err != nil {
+			var ok bool
+			if created, ok = pageCreated[filepath.ToSlash(task.SourceFile)]; !ok {
+				continue
+			}
+			ti.StartDelays.FromGit++
+		}
+
+		wait := max(started.Sub(created), 0)
+		all = append(all, wait)
+		byPriority[task.Priority] = append(byPriority[task.Priority], wait)
+		if len(task.PageRefs) > 0 {
+			byProject[task.PageRefs[0]] = append(byProject[task.PageRefs[0]], wait)
+		}
+	}
+	if len(all) == 0 {
+		return
+	}
+
+	ti.StartDelays.Tasks = len(all)
+	ti.StartDelays.Median = median(all)
+	for _, priority := range models.AllPriorities() {
+		if waits := byPriority[priority]; len(waits) > 0 {
+			ti.StartDelays.ByPriority = append(ti.StartDelays.ByPriority, summarizeWaits(string(priority), waits))
+		}
+	}
+	for project, waits := range byProject {
+		if len(waits) >= MinStartDelayTasks {
+			ti.StartDelays.ByProject = append(ti.StartDelays.ByProject, summarizeWaits(project, waits))
+		}
+	}
+	sort.Slice(ti.StartDelays.ByProject, func(i, j int) bool {
+		a, b := ti.StartDelays.ByProject[i], ti.StartDelays.ByProject[j]
+		if a.Median != b.Median {
+			return a.Median > b.Median
+		}
+		return a.Group < b.Group
+	})
+}
+
+// summarizeWaits returns the median and average of waits
+func summarizeWaits(group string, waits []time.Duration) StartDelay {
+	var total time.Duration
+	for _, wait := range waits {
+		total += wait
+	}
+	return StartDelay{Group: group, Tasks: len(waits), Median: median(waits), Average: total / time.Duration(len(waits))}
+}
@@ -0,0 +1,61 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyStartDelays(t *testing.T) {
+	clockedOn := func(day, hour int) []models.LogbookEntry {
+		start := time.Date(2025, 11, day, hour, 0, 0, 0, time.UTC)
+		return []models.LogbookEntry{{Start: start, End: start.Add(time.Hour), Duration: time.Hour}}
+	}
+
+	tasks := []models.Task{
+		// Journal tasks, created on the journal's day
+		{SourceFile: "journals/2025_11_03.md", Priority: models.PriorityHigh, PageRefs: []string{"Phoenix"}, Logbook: clockedOn(3, 12)},
+		{SourceFile: "journals/2025_11_03.md", Priority: models.PriorityHigh, PageRefs: []string{"Phoenix"}, Logbook: clockedOn(4, 12)},
+		{SourceFile: "journals/2025_11_01.md", PageRefs: []string{"Phoenix"}, Logbook: append(clockedOn(10, 0), clockedOn(5, 0)...)},
+		// Page task dated by git, clocked before its first commit
+		{SourceFile: "pages/Admin.md", Logbook: clockedOn(2, 0)},
+		// Skipped: never clocked, and a page task git doesn't know
+		{SourceFile: "journals/2025_11_01.md", Priority: models.PriorityHigh},
+		{SourceFile: "pages/Untracked.md", Logbook: clockedOn(5, 0)},
+	}
+	pageCreated := map[string]time.Time{"pages/Admin.md": time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)}
+
+	index := &TimeTrackingIndex{}
+	index.ApplyStartDelays(tasks, pageCreated)
+	delays := index.StartDelays
+
+	if delays.Tasks != 4 || delays.FromGit != 1 {
+		t.Errorf("Expected 4 tasks measured, 1 from git, got %d and %d", delays.Tasks, delays.FromGit)
+	}
+	if delays.Median != 12*time.Hour {
+		t.Errorf("Expected a median of 12h, got %s", delays.Median)
+	}
+
+	if len(delays.ByPriority) != 2 {
+		t.Fatalf("Expected [#A] and no priority, got %+v", delays.ByPriority)
+	}
+	if a := delays.ByPriority[0]; a.Group != "A" || a.Tasks != 2 || a.Median != 12*time.Hour || a.Average != 24*time.Hour {
+		t.Errorf("Unexpected [#A] delay: %+v", a)
+	}
+	if none := delays.ByPriority[1]; none.Group != "" || none.Tasks != 2 || none.Median != 0 || none.Average != 48*time.Hour {
+		t.Errorf("Unexpected no-priority delay: %+v", none)
+	}
+
+	if len(delays.ByProject) != 1 || delays.ByProject[0].Group != "Phoenix" || delays.ByProject[0].Median != 36*time.Hour {
+		t.Errorf("Expected Phoenix with a 36h median, got %+v", delays.ByProject)
+	}
+}
+
+func TestApplyStartDelays_NothingClocked(t *testing.T) {
+	index := &TimeTrackingIndex{}
+	index.ApplyStartDelays([]models.Task{{SourceFile: "journals/2025_11_01.md"}}, nil)
+	if index.StartDelays.Tasks != 0 || index.StartDelays.ByPriority != nil {
+		t.Errorf("Expected no start delays, got %+v", index.StartDelays)
+	}
+}
@@ -0,0 +1,126 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// TagSuggestion is a candidate tag for an untagged page
+type TagSuggestion struct {
+	Tag      string
+	Reason   string // Why the tag was suggested, e.g. "keyword" or "links to [[X]]"
+	Existing bool   // Already used elsewhere in the graph (safe to apply)
+}
+
+// PageTagSuggestions holds the candidate tags for one untagged page
+type PageTagSuggestions struct {
+	Page        string
+	FilePath    string
+	Suggestions []TagSuggestion // Existing tags first
+}
+
+// ExistingTags returns the suggested tags already in the graph's vocabulary
+func (p *PageTagSuggestions) ExistingTags() []string {
+	var tags []string
+	for _, s := range p.Suggestions {
+		if s.Existing {
+			tags = append(tags, s.Tag)
+		}
+	}
+	return tags
+}
+
+// TagSuggestionsIndex holds tag suggestions for pages without a tags:: property
+type TagSuggestionsIndex struct {
+	GeneratedAt time.Time
+	Vocabulary  []string              // Every tag in use, sorted
+	TaggedPages int                   // Existing pages that already have tags
+	Pages       []*PageTagSuggestions // Untagged pages with at least one suggestion, sorted by name
+}
+
+// BuildTagSuggestionsIndex suggests up to maxPerPage tags for each existing,
+// non-journal page without a tags:: property. pageTags maps page name to its
+// tags:: values; inlineTags are #tags used on blocks and tasks. Tags from the
+// existing vocabulary are matched against the page's keywords and outbound
+// links; remaining slots are filled with the page's top keywords as new tags.
+func BuildTagSuggestionsIndex(graph *ReferenceGraph, pageTags map[string][]string, inlineTags []string, maxPerPage int) *TagSuggestionsIndex {
+	index := &TagSuggestionsIndex{GeneratedAt: time.Now()}
+
+	// Vocabulary keyed by lowercase form, keeping the first spelling seen
+	vocabulary := make(map[string]string)
+	addTag := func(tag string) {
+		key := strings.ToLower(tag)
+		if _, exists := vocabulary[key]; !exists {
+			vocabulary[key] = tag
+		}
+	}
+	for _, tags := range pageTags {
+		for _, tag := range tags {
+			addTag(tag)
+		}
+	}
+	for _, tag := range inlineTags {
+		addTag(tag)
+	}
+	for _, tag := range vocabulary {
+		index.Vocabulary = append(index.Vocabulary, tag)
+	}
+	sort.Strings(index.Vocabulary)
+
+	for name, node := range graph.Nodes {
+		if node.FilePath == "" || isJournalNode(node) {
+			continue
+		}
+		if len(pageTags[name]) > 0 {
+			index.TaggedPages++
+			continue
+		}
+
+		page := &PageTagSuggestions{Page: name, FilePath: node.FilePath}
+		seen := map[string]bool{strings.ToLower(name): true}
+		add := func(s TagSuggestion) {
+			key := strings.ToLower(s.Tag)
+			if seen[key] || len(page.Suggestions) >= maxPerPage {
+				return
+			}
+			seen[key] = true
+			page.Suggestions = append(page.Suggestions, s)
+		}
+
+		// Existing tags matching a keyword (allowing a simple plural)
+		for _, keyword := range node.Keywords {
+			for _, form := range []string{keyword, strings.TrimSuffix(keyword, "s")} {
+				if tag, exists := vocabulary[form]; exists {
+					add(TagSuggestion{Tag: tag, Reason: "keyword", Existing: true})
+					seen[keyword] = true // Don't also suggest the keyword as a new tag
+					break
+				}
+			}
+		}
+
+		// Existing tags this page already links to
+		targets := append([]string(nil), node.OutboundRefs...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			if tag, exists := vocabulary[strings.ToLower(target)]; exists {
+				add(TagSuggestion{Tag: tag, Reason: "links to [[" + target + "]]", Existing: true})
+			}
+		}
+
+		// New tags from the strongest keywords
+		for _, keyword := range node.Keywords {
+			add(TagSuggestion{Tag: keyword, Reason: "keyword"})
+		}
+
+		if len(page.Suggestions) > 0 {
+			index.Pages = append(index.Pages, page)
+		}
+	}
+
+	sort.Slice(index.Pages, func(i, j int) bool {
+		return index.Pages[i].Page < index.Pages[j].Page
+	})
+
+	return index
+}
@@ -0,0 +1,60 @@
+package indexer
+
+import (
+	"testing"
+)
+
+func TestBuildTagSuggestionsIndex(t *testing.T) {
+	graph := &ReferenceGraph{
+		Nodes: map[string]*GraphNode{
+			"Phoenix": {
+				PageName:     "Phoenix",
+				FilePath:     "pages/Phoenix.md",
+				OutboundRefs: []string{"Backend"},
+				Keywords:     []string{"budgets", "kubernetes", "rollout"},
+			},
+			"Mobile": {
+				PageName: "Mobile",
+				FilePath: "pages/Mobile.md",
+				Keywords: []string{"flutter"},
+			},
+			"2025_11_03": {
+				PageName: "2025_11_03",
+				FilePath: "journals/2025_11_03.md",
+				Keywords: []string{"budget"},
+			},
+			"Backend": {PageName: "Backend"}, // Missing page
+		},
+	}
+	pageTags := map[string][]string{
+		"Mobile": {"Apps"},
+	}
+
+	index := BuildTagSuggestionsIndex(graph, pageTags, []string{"Budget", "backend"}, 3)
+
+	if index.TaggedPages != 1 {
+		t.Errorf("Expected 1 tagged page, got %d", index.TaggedPages)
+	}
+	if len(index.Vocabulary) != 3 {
+		t.Errorf("Expected 3 vocabulary tags, got %v", index.Vocabulary)
+	}
+	if len(index.Pages) != 1 {
+		t.Fatalf("Expected suggestions only for the untagged page, got %d", len(index.Pages))
+	}
+
+	page := index.Pages[0]
+	if page.Page != "Phoenix" {
+		t.Errorf("Expected Phoenix, got %s", page.Page)
+	}
+	if len(page.Suggestions) != 3 {
+		t.Fatalf("Expected 3 suggestions, got %v", page.Suggestions)
+	}
+
+	existing := page.ExistingTags()
+	if len(existing) != 2 || existing[0] != "Budget" || existing[1] != "backend" {
+		t.Errorf("Expected existing tags [Budget backend], got %v", existing)
+	}
+	if last := page.Suggestions[2]; last.Tag != "kubernetes" || last.Existing {
+		t.Errorf("Expected a new keyword tag to fill the last slot, got %+v", last)
+	}
+}
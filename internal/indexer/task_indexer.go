@@ -9,13 +9,36 @@ import (
 
 // TaskIndex organizes tasks by status, priority, and project for easy querying
 type TaskIndex struct {
-	GeneratedAt time.Time
-	TotalTasks  int
-	ByStatus    map[models.TaskStatus][]models.Task
-	ByPriority  map[models.Priority][]models.Task // Grouped by priority level
-	ByProject   map[string][]models.Task           // Keyed by first page reference
-	Recent      []models.Task                      // Last 30 days
-	Statistics  TaskStatistics                     // Summary statistics
+	GeneratedAt          time.Time
+	TotalTasks           int
+	ByStatus             map[models.TaskStatus][]models.Task
+	ByPriority           map[models.Priority][]models.Task // Grouped by priority level
+	ByProject            map[string][]models.Task          // Keyed by first page reference
+	Recent               []models.Task                     // Last 30 days
+	WaitingOn            []DelegationGroup                 // Open delegated tasks grouped by person
+	Orphans              []OrphanTask                      // Open journal tasks referencing nothing, oldest first
+	BackFromSnooze       []models.Task                     // Open tasks whose snooze:: date just passed (see BackFromSnooze), soonest snooze first
+	CompletionCandidates []CompletionCandidate             // Project pages that look finished (see ApplyCompletionCandidates)
+	Statistics           TaskStatistics                    // Summary statistics
+}
+
+// DelegationGroup holds open tasks waiting on one person
+type DelegationGroup struct {
+	Person string
+	Tasks  []DelegatedTask // Oldest first
+}
+
+// DelegatedTask is an open task waiting on someone else
+type DelegatedTask struct {
+	Task    models.Task
+	AgeDays int // Days since the task's journal date (-1 if unknown)
+}
+
+// OrphanTask is an open journal task with no page references, tags,
+// delegation, or dates: nothing ties it to the rest of the graph
+type OrphanTask struct {
+	Task    models.Task
+	AgeDays int // Days since the task's journal date
 }
 
 // TaskStatistics provides summary statistics for tasks
@@ -43,27 +66,17 @@ func BuildTaskIndex(tasks []models.Task) *TaskIndex {
 	}
 
 	// Initialize status maps with empty slices
-	for _, status := range []models.TaskStatus{
-		models.StatusNOW,
-		models.StatusLATER,
-		models.StatusTODO,
-		models.StatusDOING,
-		models.StatusDONE,
-	} {
+	for _, status := range models.Statuses() {
 		index.ByStatus[status] = []models.Task{}
 	}
 
 	// Initialize priority maps with empty slices
-	for _, priority := range []models.Priority{
-		models.PriorityHigh,
-		models.PriorityMedium,
-		models.PriorityLow,
-		models.PriorityNone,
-	} {
+	for _, priority := range models.AllPriorities() {
 		index.ByPriority[priority] = []models.Task{}
 	}
 
 	thirtyDaysAgo := time.Now().AddDate(0, 0, -30)
+	delegated := make(map[string][]DelegatedTask)
 
 	for _, task := range tasks {
 		// Group by status
@@ -85,6 +98,26 @@ func BuildTaskIndex(tasks []models.Task) *TaskIndex {
 			index.Recent = append(index.Recent, task)
 		}
 
+		// Collect open tasks waiting on others
+		if task.DelegatedTo != "" && !models.IsClosed(task.Status) {
+			delegated[task.DelegatedTo] = append(delegated[task.DelegatedTo], DelegatedTask{
+				Task:    task,
+				AgeDays: taskAgeDays(task, index.GeneratedAt),
+			})
+		}
+
+		// Collect floating journal TODOs, the easiest to forget
+		if isOrphanTask(task) {
+			index.Orphans = append(index.Orphans, OrphanTask{
+				Task:    task,
+				AgeDays: taskAgeDays(task, index.GeneratedAt),
+			})
+		}
+
+		if BackFromSnooze(task, index.GeneratedAt) {
+			index.BackFromSnooze = append(index.BackFromSnooze, task)
+		}
+
 		// Track time logging statistics
 		if len(task.Logbook) > 0 {
 			index.Statistics.WithTimeTracking++
@@ -97,6 +130,14 @@ func BuildTaskIndex(tasks []models.Task) *TaskIndex {
 		return getMostRecentTime(index.Recent[i]).After(getMostRecentTime(index.Recent[j]))
 	})
 
+	index.WaitingOn = groupDelegations(delegated)
+	sort.SliceStable(index.Orphans, func(i, j int) bool {
+		return index.Orphans[i].AgeDays > index.Orphans[j].AgeDays
+	})
+	sort.SliceStable(index.BackFromSnooze, func(i, j int) bool {
+		return index.BackFromSnooze[i].Snooze.Before(index.BackFromSnooze[j].Snooze)
+	})
+
 	// Calculate statistics
 	if len(tasks) > 0 {
 		index.Statistics.CompletionRate = float64(index.Statistics.StatusBreakdown[models.StatusDONE]) / float64(len(tasks)) * 100
@@ -106,6 +147,39 @@ func BuildTaskIndex(tasks []models.Task) *TaskIndex {
 	return index
 }
 
+// groupDelegations sorts delegated tasks into groups, people with most tasks first
+func groupDelegations(delegated map[string][]DelegatedTask) []DelegationGroup {
+	var groups []DelegationGroup
+	for person, tasks := range delegated {
+		sort.SliceStable(tasks, func(i, j int) bool {
+			return tasks[i].AgeDays > tasks[j].AgeDays
+		})
+		groups = append(groups, DelegationGroup{Person: person, Tasks: tasks})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].Tasks) != len(groups[j].Tasks) {
+			return len(groups[i].Tasks) > len(groups[j].Tasks)
+		}
+		return groups[i].Person < groups[j].Person
+	})
+
+	return groups
+}
+
+// isOrphanTask reports whether a task is open, written in a journal, and has
+// no page references, tags, delegation, or scheduled, deadline, or snooze date
+func isOrphanTask(task models.Task) bool {
+	if models.IsClosed(task.Status) || !isJournalPath(task.SourceFile) {
+		return false
+	}
+	if _, err := extractDateFromJournalPath(task.SourceFile); err != nil {
+		return false
+	}
+	return len(task.PageRefs) == 0 && len(task.Tags) == 0 && task.DelegatedTo == "" &&
+		task.Scheduled.IsZero() && task.Deadline.IsZero() && task.Snooze.IsZero()
+}
+
 // hasRecentActivity checks if a task has logbook entries within the time window
 func hasRecentActivity(task models.Task, since time.Time) bool {
 	for _, entry := range task.Logbook {
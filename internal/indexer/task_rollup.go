@@ -0,0 +1,89 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// TaskRollup summarises the tasks on or linking a page
+type TaskRollup struct {
+	Page       string
+	Open       int                       // Tasks not DONE
+	ByStatus   map[models.TaskStatus]int // Open tasks per status
+	NextDue    time.Time                 // Earliest deadline or scheduled date of an open task, zero if none
+	NextTask   string                    // Description of the task due at NextDue
+	TimeLogged time.Duration             // Logged on all the tasks, DONE ones included
+}
+
+// ApplyTaskRollups rolls up, for each page, the tasks written on it or
+// linking it, and the same for its one-hop neighbours (pages it links or is
+// linked from, journals aside) that have open tasks: at most neighborLimit,
+// most open tasks first. A single backlinks file then answers "what's the
+// state of everything around this page?"
+func (index *PageDetailsIndex) ApplyTaskRollups(graph *ReferenceGraph, tasks []models.Task, neighborLimit int) {
+	rollups := make(map[string]*TaskRollup)
+	for _, task := range tasks {
+		pages := map[string]bool{extractPageNameFromPath(task.SourceFile): !isJournalPath(task.SourceFile)}
+		for _, page := range task.PageRefs {
+			pages[page] = true
+		}
+
+		for page, include := range pages {
+			if !include {
+				continue
+			}
+			rollup := rollups[page]
+			if rollup == nil {
+				rollup = &TaskRollup{Page: page, ByStatus: make(map[models.TaskStatus]int)}
+				rollups[page] = rollup
+			}
+			rollup.TimeLogged += task.TotalDuration()
+			if models.IsClosed(task.Status) {
+				continue
+			}
+			rollup.Open++
+			rollup.ByStatus[task.Status]++
+			if due := task.DueDate(); !due.IsZero() && (rollup.NextDue.IsZero() || due.Before(rollup.NextDue)) {
+				rollup.NextDue = due
+				rollup.NextTask = task.Description
+			}
+		}
+	}
+
+	for _, page := range index.Pages {
+		page.Tasks = rollups[page.Name]
+		page.NeighborTasks = nil
+
+		node := graph.Nodes[page.Name]
+		if node == nil {
+			continue
+		}
+		seen := map[string]bool{page.Name: true}
+		for _, neighbor := range append(append([]string{}, node.OutboundRefs...), node.InboundRefs...) {
+			if seen[neighbor] {
+				continue
+			}
+			seen[neighbor] = true
+			if n := graph.Nodes[neighbor]; n != nil && isJournalNode(n) {
+				continue
+			}
+			if rollup := rollups[neighbor]; rollup != nil && rollup.Open > 0 {
+				page.NeighborTasks = append(page.NeighborTasks, *rollup)
+			}
+		}
+
+		sort.Slice(page.NeighborTasks, func(i, j int) bool {
+			a, b := page.NeighborTasks[i], page.NeighborTasks[j]
+			if a.Open != b.Open {
+				return a.Open > b.Open
+			}
+			return strings.ToLower(a.Page) < strings.ToLower(b.Page)
+		})
+		if neighborLimit > 0 && len(page.NeighborTasks) > neighborLimit {
+			page.NeighborTasks = page.NeighborTasks[:neighborLimit]
+		}
+	}
+}
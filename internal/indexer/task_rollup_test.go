@@ -0,0 +1,52 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyTaskRollups(t *testing.T) {
+	graph := &ReferenceGraph{Nodes: map[string]*GraphNode{
+		"Phoenix":    {PageName: "Phoenix", FilePath: "pages/Phoenix.md", OutboundRefs: []string{"Vendor", "Design"}, InboundRefs: []string{"2025_11_03", "Roadmap"}},
+		"Vendor":     {PageName: "Vendor"}, // Missing page
+		"Design":     {PageName: "Design", FilePath: "pages/Design.md"},
+		"Roadmap":    {PageName: "Roadmap", FilePath: "pages/Roadmap.md", OutboundRefs: []string{"Phoenix"}},
+		"2025_11_03": {PageName: "2025_11_03", FilePath: "journals/2025_11_03.md", OutboundRefs: []string{"Phoenix"}},
+	}}
+	due := func(day int) time.Time { return time.Date(2025, 11, day, 0, 0, 0, 0, time.UTC) }
+	hour := []models.LogbookEntry{{Duration: time.Hour}}
+
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Draft plan", SourceFile: "pages/Phoenix.md", Deadline: due(20)},
+		{Status: models.StatusNOW, Description: "Call [[Vendor]]", PageRefs: []string{"Phoenix", "Vendor"}, SourceFile: "journals/2025_11_03.md", Scheduled: due(12), Logbook: hour},
+		{Status: models.StatusDONE, Description: "Kickoff", SourceFile: "pages/Phoenix.md", Logbook: hour},
+		{Status: models.StatusLATER, Description: "Review", SourceFile: "pages/Roadmap.md", PageRefs: []string{"Vendor"}},
+		{Status: models.StatusDONE, Description: "Mockups", SourceFile: "pages/Design.md"},
+	}
+
+	index := &PageDetailsIndex{Pages: []*PageDetail{{Name: "Phoenix"}, {Name: "Design"}}}
+	index.ApplyTaskRollups(graph, tasks, 10)
+
+	phoenix := index.Pages[0]
+	if phoenix.Tasks == nil || phoenix.Tasks.Open != 2 || phoenix.Tasks.TimeLogged != 2*time.Hour {
+		t.Fatalf("Expected 2 open tasks and 2h logged on Phoenix, got %+v", phoenix.Tasks)
+	}
+	if phoenix.Tasks.ByStatus[models.StatusNOW] != 1 || phoenix.Tasks.ByStatus[models.StatusTODO] != 1 {
+		t.Errorf("Expected 1 NOW and 1 TODO, got %v", phoenix.Tasks.ByStatus)
+	}
+	if !phoenix.Tasks.NextDue.Equal(due(12)) || phoenix.Tasks.NextTask != "Call [[Vendor]]" {
+		t.Errorf("Expected the Nov 12 call next, got %s %q", phoenix.Tasks.NextDue, phoenix.Tasks.NextTask)
+	}
+
+	// Vendor (2 open) and Roadmap (1 open); Design has none and the journal is skipped
+	if len(phoenix.NeighborTasks) != 2 || phoenix.NeighborTasks[0].Page != "Vendor" || phoenix.NeighborTasks[1].Page != "Roadmap" {
+		t.Errorf("Expected Vendor then Roadmap as neighbours, got %+v", phoenix.NeighborTasks)
+	}
+
+	design := index.Pages[1]
+	if design.Tasks == nil || design.Tasks.Open != 0 {
+		t.Errorf("Expected Design's DONE task rolled up with nothing open, got %+v", design.Tasks)
+	}
+}
@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// AnomalyKind is why a logbook entry looks wrong
+type AnomalyKind string
+
+const (
+	AnomalyFuture      AnomalyKind = "future"       // Ends after the run, e.g. a typo in the year
+	AnomalyMultiDay    AnomalyKind = "multi-day"    // Spans MaxSessionDays or more calendar days, usually a forgotten clock
+	AnomalyLongSession AnomalyKind = "long-session" // Longer than MaxSessionLength
+)
+
+// MaxSessionLength is the longest plausible single clock session
+const MaxSessionLength = 12 * time.Hour
+
+// MaxSessionDays is how many calendar days a session may touch before it's
+// flagged; a late session crossing midnight touches two
+const MaxSessionDays = 3
+
+// LogbookAnomaly is a suspicious clock entry and the task it belongs to
+type LogbookAnomaly struct {
+	Task  models.Task
+	Entry models.LogbookEntry
+	Kind  AnomalyKind
+}
+
+// FindLogbookAnomalies flags clock entries that would distort the time
+// aggregates: future timestamps, sessions touching MaxSessionDays or more
+// days, and sessions over MaxSessionLength. Each entry gets its most specific
+// kind. Results are sorted by duration, longest first.
+func FindLogbookAnomalies(tasks []models.Task, now time.Time) []LogbookAnomaly {
+	var anomalies []LogbookAnomaly
+	for _, task := range tasks {
+		for _, entry := range task.Logbook {
+			if kind, ok := classifyEntry(entry, now); ok {
+				anomalies = append(anomalies, LogbookAnomaly{Task: task, Entry: entry, Kind: kind})
+			}
+		}
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return anomalies[i].Entry.Duration > anomalies[j].Entry.Duration
+	})
+	return anomalies
+}
+
+// classifyEntry returns the anomaly kind of one clock entry, if any
+func classifyEntry(entry models.LogbookEntry, now time.Time) (AnomalyKind, bool) {
+	switch {
+	case entry.End.After(now) || entry.Start.After(now):
+		return AnomalyFuture, true
+	case int(startOfDay(entry.End).Sub(startOfDay(entry.Start)).Hours()/24)+1 >= MaxSessionDays:
+		return AnomalyMultiDay, true
+	case entry.Duration > MaxSessionLength:
+		return AnomalyLongSession, true
+	}
+	return "", false
+}
+
+// ExcludeAnomalies returns copies of the tasks with the anomalous clock
+// entries removed from their logbooks, for --exclude-anomalies
+func ExcludeAnomalies(tasks []models.Task, anomalies []LogbookAnomaly) []models.Task {
+	if len(anomalies) == 0 {
+		return tasks
+	}
+
+	type entryKey struct {
+		file       string
+		line       int
+		start, end time.Time
+	}
+	flagged := make(map[entryKey]bool, len(anomalies))
+	for _, a := range anomalies {
+		flagged[entryKey{a.Task.SourceFile, a.Task.LineNumber, a.Entry.Start, a.Entry.End}] = true
+	}
+
+	cleaned := make([]models.Task, len(tasks))
+	for i, task := range tasks {
+		cleaned[i] = task
+		var kept []models.LogbookEntry
+		removed := false
+		for _, entry := range task.Logbook {
+			if flagged[entryKey{task.SourceFile, task.LineNumber, entry.Start, entry.End}] {
+				removed = true
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		if removed {
+			cleaned[i].Logbook = kept
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,68 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func clock(start time.Time, d time.Duration) models.LogbookEntry {
+	return models.LogbookEntry{Start: start, End: start.Add(d), Duration: d}
+}
+
+func TestFindLogbookAnomalies(t *testing.T) {
+	now := time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC)
+	day := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+
+	tasks := []models.Task{
+		{Description: "Normal", SourceFile: "a.md", LineNumber: 1, Logbook: []models.LogbookEntry{
+			clock(day.Add(9*time.Hour), 2*time.Hour),
+			clock(day.Add(22*time.Hour), 4*time.Hour), // Late night crossing midnight is fine
+		}},
+		{Description: "Marathon", SourceFile: "a.md", LineNumber: 2, Logbook: []models.LogbookEntry{
+			clock(day.Add(6*time.Hour), 14*time.Hour),
+		}},
+		{Description: "Forgot to stop", SourceFile: "b.md", LineNumber: 1, Logbook: []models.LogbookEntry{
+			clock(day.Add(17*time.Hour), 40*time.Hour),
+		}},
+		{Description: "Typo in year", SourceFile: "c.md", LineNumber: 1, Logbook: []models.LogbookEntry{
+			clock(time.Date(2052, 11, 3, 9, 0, 0, 0, time.UTC), time.Hour),
+		}},
+	}
+
+	anomalies := FindLogbookAnomalies(tasks, now)
+
+	want := []struct {
+		desc string
+		kind AnomalyKind
+	}{
+		{"Forgot to stop", AnomalyMultiDay},
+		{"Marathon", AnomalyLongSession},
+		{"Typo in year", AnomalyFuture},
+	}
+	if len(anomalies) != len(want) {
+		t.Fatalf("Expected %d anomalies, got %+v", len(want), anomalies)
+	}
+	for i, w := range want {
+		if anomalies[i].Task.Description != w.desc || anomalies[i].Kind != w.kind {
+			t.Errorf("Anomaly %d = %s (%s), want %s (%s)", i, anomalies[i].Task.Description, anomalies[i].Kind, w.desc, w.kind)
+		}
+	}
+
+	cleaned := ExcludeAnomalies(tasks, anomalies)
+	if len(cleaned[0].Logbook) != 2 {
+		t.Errorf("Normal entries should be kept, got %d", len(cleaned[0].Logbook))
+	}
+	if len(cleaned[1].Logbook) != 0 || len(cleaned[2].Logbook) != 0 {
+		t.Error("Anomalous entries should be removed")
+	}
+	if len(tasks[1].Logbook) != 1 {
+		t.Error("ExcludeAnomalies must not modify the input tasks")
+	}
+
+	index := BuildTimeTrackingIndex(cleaned)
+	if index.TotalTimeLogged != 6*time.Hour {
+		t.Errorf("TotalTimeLogged = %v, want 6h once anomalies are excluded", index.TotalTimeLogged)
+	}
+}
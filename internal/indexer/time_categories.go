@@ -0,0 +1,127 @@
+package indexer
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// Uncategorized is the category of tracked time matching no configured category
+const Uncategorized = "Uncategorized"
+
+// TimeCategory maps projects and tags to a higher-level bucket such as Meetings
+type TimeCategory struct {
+	Name     string
+	Projects []string // First page references on a task
+	Tags     []string // #tags on a task, without the leading #
+}
+
+// CategoryTime is the time logged in one category
+type CategoryTime struct {
+	Category   string
+	TimeLogged time.Duration
+	TaskCount  int
+}
+
+// ApplyCategories totals logged time per category, overall and per week.
+// A task's project (first page reference) decides its category; failing
+// that, the first of its tags that belongs to a category. Matching ignores
+// case, and tracked time matching nothing is Uncategorized.
+func (ti *TimeTrackingIndex) ApplyCategories(tasks []models.Task, categories []TimeCategory) {
+	ti.Categories = nil
+	ti.ByCategoryWeek = make(map[string]map[string]time.Duration)
+	if len(categories) == 0 {
+		return
+	}
+
+	byProject := make(map[string]string)
+	byTag := make(map[string]string)
+	for _, category := range categories {
+		for _, project := range category.Projects {
+			byProject[strings.ToLower(project)] = category.Name
+		}
+		for _, tag := range category.Tags {
+			byTag[strings.ToLower(strings.TrimPrefix(tag, "#"))] = category.Name
+		}
+	}
+
+	totals := make(map[string]*CategoryTime)
+	for _, task := range tasks {
+		if len(task.Logbook) == 0 {
+			continue
+		}
+		name := taskCategory(task, byProject, byTag)
+		ct := totals[name]
+		if ct == nil {
+			ct = &CategoryTime{Category: name}
+			totals[name] = ct
+		}
+
+		var logged time.Duration
+		for _, entry := range task.Logbook {
+			if entry.Duration <= 0 {
+				continue
+			}
+			logged += entry.Duration
+			weekKey := getWeekStart(entry.Start).Format("2006-01-02")
+			if ti.ByCategoryWeek[weekKey] == nil {
+				ti.ByCategoryWeek[weekKey] = make(map[string]time.Duration)
+			}
+			ti.ByCategoryWeek[weekKey][name] += entry.Duration
+		}
+		if logged == 0 {
+			continue
+		}
+		ct.TimeLogged += logged
+		ct.TaskCount++
+	}
+
+	for _, ct := range totals {
+		if ct.TimeLogged > 0 {
+			ti.Categories = append(ti.Categories, *ct)
+		}
+	}
+	sortCategoryTimes(ti.Categories)
+}
+
+// CategoriesInWeek returns the time per category in the week starting weekKey
+// (a Monday, "2006-01-02"), most time first
+func (ti *TimeTrackingIndex) CategoriesInWeek(weekKey string) []CategoryTime {
+	var week []CategoryTime
+	for name, d := range ti.ByCategoryWeek[weekKey] {
+		week = append(week, CategoryTime{Category: name, TimeLogged: d})
+	}
+	sortCategoryTimes(week)
+	return week
+}
+
+// taskCategory returns the category a task's time counts towards
+func taskCategory(task models.Task, byProject, byTag map[string]string) string {
+	if len(task.PageRefs) > 0 {
+		if name, ok := byProject[strings.ToLower(task.PageRefs[0])]; ok {
+			return name
+		}
+	}
+	for _, tag := range task.Tags {
+		if name, ok := byTag[strings.ToLower(tag)]; ok {
+			return name
+		}
+	}
+	return Uncategorized
+}
+
+// sortCategoryTimes orders categories by time, most first, with Uncategorized last
+func sortCategoryTimes(categories []CategoryTime) {
+	sort.Slice(categories, func(i, j int) bool {
+		a, b := categories[i], categories[j]
+		if (a.Category == Uncategorized) != (b.Category == Uncategorized) {
+			return b.Category == Uncategorized
+		}
+		if a.TimeLogged != b.TimeLogged {
+			return a.TimeLogged > b.TimeLogged
+		}
+		return a.Category < b.Category
+	})
+}
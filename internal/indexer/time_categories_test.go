@@ -0,0 +1,63 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestApplyCategories(t *testing.T) {
+	session := func(day int, length time.Duration) models.LogbookEntry {
+		start := time.Date(2025, 11, day, 9, 0, 0, 0, time.UTC)
+		return models.LogbookEntry{Start: start, End: start.Add(length), Duration: length}
+	}
+
+	tasks := []models.Task{
+		{PageRefs: []string{"standup"}, Logbook: []models.LogbookEntry{session(3, time.Hour), session(10, time.Hour)}},
+		{PageRefs: []string{"Phoenix"}, Tags: []string{"Meeting"}, Logbook: []models.LogbookEntry{session(4, 2*time.Hour)}},
+		{PageRefs: []string{"Phoenix"}, Logbook: []models.LogbookEntry{session(5, 3*time.Hour)}},
+		{Tags: []string{"email"}, Logbook: []models.LogbookEntry{session(11, 30*time.Minute)}},
+		{PageRefs: []string{"Standup"}}, // Nothing logged
+	}
+	categories := []TimeCategory{
+		{Name: "Meetings", Projects: []string{"Standup"}, Tags: []string{"#meeting"}},
+		{Name: "Admin", Tags: []string{"email"}},
+	}
+
+	index := &TimeTrackingIndex{}
+	index.ApplyCategories(tasks, categories)
+
+	want := []CategoryTime{
+		{Category: "Meetings", TimeLogged: 4 * time.Hour, TaskCount: 2},
+		{Category: "Admin", TimeLogged: 30 * time.Minute, TaskCount: 1},
+		{Category: Uncategorized, TimeLogged: 3 * time.Hour, TaskCount: 1},
+	}
+	if len(index.Categories) != len(want) {
+		t.Fatalf("Expected %d categories, got %+v", len(want), index.Categories)
+	}
+	for i := range want {
+		if index.Categories[i] != want[i] {
+			t.Errorf("Category %d: expected %+v, got %+v", i, want[i], index.Categories[i])
+		}
+	}
+
+	// Nov 10 starts a new week
+	week := index.CategoriesInWeek("2025-11-10")
+	if len(week) != 2 || week[0].Category != "Meetings" || week[0].TimeLogged != time.Hour ||
+		week[1].Category != "Admin" || week[1].TimeLogged != 30*time.Minute {
+		t.Errorf("Unexpected categories for the week of Nov 10: %+v", week)
+	}
+	if got := index.ByCategoryWeek["2025-11-03"]["Meetings"]; got != 3*time.Hour {
+		t.Errorf("Expected 3h of meetings in the week of Nov 3, got %v", got)
+	}
+}
+
+func TestApplyCategories_NoneConfigured(t *testing.T) {
+	index := &TimeTrackingIndex{}
+	index.ApplyCategories([]models.Task{{Logbook: []models.LogbookEntry{{Duration: time.Hour}}}}, nil)
+
+	if len(index.Categories) != 0 || len(index.CategoriesInWeek("2025-11-03")) != 0 {
+		t.Errorf("Expected no categories, got %+v", index.Categories)
+	}
+}
@@ -0,0 +1,48 @@
+package indexer
+
+import (
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// MicroSessions counts the clock entries left out of the time aggregates for
+// being shorter than the configured minimum, usually accidental clock-in/out
+type MicroSessions struct {
+	MinDuration time.Duration // Entries shorter than this were left out
+	Entries     int
+	Tasks       int           // Tasks with at least one micro-session
+	TimeLogged  time.Duration // Total time of the left-out entries
+}
+
+// ExcludeMicroSessions returns copies of the tasks with clock entries shorter
+// than minDuration removed from their logbooks, and counts what was removed.
+// A task whose entries are all removed no longer counts as tracked. With
+// minDuration 0 the tasks are returned as they are.
+func ExcludeMicroSessions(tasks []models.Task, minDuration time.Duration) ([]models.Task, MicroSessions) {
+	stats := MicroSessions{MinDuration: minDuration}
+	if minDuration <= 0 {
+		return tasks, stats
+	}
+
+	cleaned := make([]models.Task, len(tasks))
+	for i, task := range tasks {
+		cleaned[i] = task
+		var kept []models.LogbookEntry
+		removed := false
+		for _, entry := range task.Logbook {
+			if entry.Duration < minDuration {
+				stats.Entries++
+				stats.TimeLogged += entry.Duration
+				removed = true
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		if removed {
+			stats.Tasks++
+			cleaned[i].Logbook = kept
+		}
+	}
+	return cleaned, stats
+}
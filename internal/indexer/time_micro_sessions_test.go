@@ -0,0 +1,47 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestExcludeMicroSessions(t *testing.T) {
+	start := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{Description: "Real work", SourceFile: "journals/2025_11_03.md", Logbook: []models.LogbookEntry{
+			clock(start, time.Hour),
+			clock(start.Add(2*time.Hour), 3*time.Second),
+		}},
+		{Description: "Accidental clock", SourceFile: "journals/2025_11_03.md", LineNumber: 5, Logbook: []models.LogbookEntry{
+			clock(start.Add(3*time.Hour), 2*time.Second),
+			clock(start.Add(4*time.Hour), 5*time.Second),
+		}},
+		{Description: "Untracked", SourceFile: "journals/2025_11_03.md", LineNumber: 9},
+	}
+
+	unchanged, stats := ExcludeMicroSessions(tasks, 0)
+	if &unchanged[0] != &tasks[0] || stats.Entries != 0 {
+		t.Error("Expected tasks returned as they are without a minimum")
+	}
+
+	cleaned, stats := ExcludeMicroSessions(tasks, 30*time.Second)
+	if stats.Entries != 3 || stats.Tasks != 2 || stats.TimeLogged != 10*time.Second || stats.MinDuration != 30*time.Second {
+		t.Errorf("Unexpected micro-session stats %+v", stats)
+	}
+	if len(tasks[0].Logbook) != 2 {
+		t.Error("ExcludeMicroSessions must not modify the input tasks")
+	}
+
+	index := BuildTimeTrackingIndex(cleaned)
+	if index.TotalTimeLogged != time.Hour {
+		t.Errorf("TotalTimeLogged = %v, want 1h", index.TotalTimeLogged)
+	}
+	if index.Statistics.TasksWithTracking != 1 {
+		t.Errorf("Expected only the real task to count as tracked, got %d", index.Statistics.TasksWithTracking)
+	}
+	if week := index.WeeklySummary[0]; week.TaskCount != 1 {
+		t.Errorf("Expected micro-sessions out of the weekly task count, got %d", week.TaskCount)
+	}
+}
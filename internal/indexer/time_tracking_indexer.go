@@ -1,7 +1,9 @@
 package indexer
 
 import (
+	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
@@ -32,15 +34,35 @@ type TimeStatistics struct {
 	MostProductiveWeek WeeklyTime
 }
 
+// ProjectBudget compares time logged on a project against its weekly budget
+type ProjectBudget struct {
+	Project      string
+	WeeklyBudget time.Duration
+	ThisWeek     time.Duration // Logged in the current (partial) week
+	RecentAvg    time.Duration // Average over the last 4 complete weeks
+	Status       string        // "over", "under", or "on track" (based on RecentAvg)
+}
+
 // TimeTrackingIndex aggregates all time tracking data
 type TimeTrackingIndex struct {
 	TotalTimeLogged time.Duration
 	ByProject       map[string]time.Duration
 	ByWeek          map[string]time.Duration // Key: "2025-11-04" (Monday of week)
+	ByProjectWeek   map[string]map[string]time.Duration // Project -> week key -> time
 	ByPriority      map[models.Priority]time.Duration
 	ByStatus        map[models.TaskStatus]time.Duration
+	ByFileType      map[string]time.Duration // "journal" or "page": where the task is written
+	ByNamespace     map[string]time.Duration // Top-level namespace of page tasks ("" for pages outside a namespace)
 	TopProjects     []ProjectTime
 	WeeklySummary   []WeeklyTime
+	Budgets         []ProjectBudget // Only populated when budgets are configured
+	Categories      []CategoryTime  // Time per configured category (see ApplyCategories)
+	ByCategoryWeek  map[string]map[string]time.Duration // Week key -> category -> time
+	Records         *Records        // Streaks and personal bests (see ApplyRecords)
+	Anomalies       []LogbookAnomaly // Suspicious clock entries (see FindLogbookAnomalies)
+	AnomaliesExcluded bool // Anomalies were left out of the aggregates (--exclude-anomalies)
+	MicroSessions   MicroSessions // Short entries left out of the aggregates (see ExcludeMicroSessions)
+	StartDelays     StartDelays   // Time to first start (see ApplyStartDelays)
 	Statistics      TimeStatistics
 }
 
@@ -51,6 +73,11 @@ func BuildTimeTrackingIndex(tasks []models.Task) *TimeTrackingIndex {
 		ByWeek:     make(map[string]time.Duration),
 		ByPriority: make(map[models.Priority]time.Duration),
 		ByStatus:   make(map[models.TaskStatus]time.Duration),
+
+		ByFileType:  make(map[string]time.Duration),
+		ByNamespace: make(map[string]time.Duration),
+
+		ByProjectWeek: make(map[string]map[string]time.Duration),
 	}
 
 	projectTaskCounts := make(map[string]int)
@@ -84,6 +111,11 @@ func BuildTimeTrackingIndex(tasks []models.Task) *TimeTrackingIndex {
 				weekKey := weekStart.Format("2006-01-02")
 				index.ByWeek[weekKey] += entry.Duration
 				weekTaskCounts[weekKey]++
+
+				if index.ByProjectWeek[project] == nil {
+					index.ByProjectWeek[project] = make(map[string]time.Duration)
+				}
+				index.ByProjectWeek[project][weekKey] += entry.Duration
 			}
 
 			// Aggregate by priority
@@ -91,6 +123,14 @@ func BuildTimeTrackingIndex(tasks []models.Task) *TimeTrackingIndex {
 
 			// Aggregate by status
 			index.ByStatus[task.Status] += totalTaskTime
+
+			// Aggregate by where the task is written
+			if strings.HasPrefix(filepath.ToSlash(task.SourceFile), "journals/") {
+				index.ByFileType[models.FileTypeJournal.String()] += totalTaskTime
+			} else {
+				index.ByFileType[models.FileTypePage.String()] += totalTaskTime
+				index.ByNamespace[topLevelNamespace(task.SourceFile)] += totalTaskTime
+			}
 		}
 	}
 
@@ -145,6 +185,48 @@ func BuildTimeTrackingIndex(tasks []models.Task) *TimeTrackingIndex {
 	return index
 }
 
+// ApplyBudgets compares each budgeted project's logged time against its weekly budget.
+// A project is "over" or "under" when its recent 4-week average is more than 10%
+// away from the budget.
+func (ti *TimeTrackingIndex) ApplyBudgets(budgets map[string]time.Duration, now time.Time) {
+	ti.Budgets = nil
+
+	currentWeek := getWeekStart(now)
+	const recentWeeks = 4
+
+	for project, budget := range budgets {
+		weeks := ti.ByProjectWeek[project]
+
+		pb := ProjectBudget{
+			Project:      project,
+			WeeklyBudget: budget,
+			ThisWeek:     weeks[currentWeek.Format("2006-01-02")],
+		}
+
+		var recentTotal time.Duration
+		for i := 1; i <= recentWeeks; i++ {
+			weekKey := currentWeek.AddDate(0, 0, -7*i).Format("2006-01-02")
+			recentTotal += weeks[weekKey]
+		}
+		pb.RecentAvg = recentTotal / recentWeeks
+
+		switch {
+		case pb.RecentAvg > budget+budget/10:
+			pb.Status = "over"
+		case pb.RecentAvg < budget-budget/10:
+			pb.Status = "under"
+		default:
+			pb.Status = "on track"
+		}
+
+		ti.Budgets = append(ti.Budgets, pb)
+	}
+
+	sort.Slice(ti.Budgets, func(i, j int) bool {
+		return ti.Budgets[i].Project < ti.Budgets[j].Project
+	})
+}
+
 // getWeekStart returns the Monday of the week for the given time
 func getWeekStart(t time.Time) time.Time {
 	// Get the weekday (0 = Sunday, 1 = Monday, ...)
@@ -162,3 +244,17 @@ func getWeekStart(t time.Time) time.Time {
 	// Normalize to start of day
 	return time.Date(monday.Year(), monday.Month(), monday.Day(), 0, 0, 0, 0, monday.Location())
 }
+
+// topLevelNamespace returns the first segment of a namespaced page's name,
+// e.g. "Projects" for pages/Projects___Phoenix.md, or "" outside a namespace.
+// Handles the current "___" file name separator and the legacy "%2F" one.
+func topLevelNamespace(sourceFile string) string {
+	name := models.PageName(sourceFile)
+	name = strings.ReplaceAll(name, "___", "/")
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "%2F", "/"), "%2f", "/")
+
+	if namespace, _, found := strings.Cut(name, "/"); found {
+		return namespace
+	}
+	return ""
+}
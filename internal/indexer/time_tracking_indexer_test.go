@@ -381,3 +381,124 @@ func TestGetWeekStart(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyBudgets(t *testing.T) {
+	now := time.Date(2025, 11, 12, 12, 0, 0, 0, time.UTC) // Wednesday
+
+	session := func(day time.Time, d time.Duration) models.LogbookEntry {
+		return models.LogbookEntry{Start: day, End: day.Add(d), Duration: d}
+	}
+
+	tasks := []models.Task{
+		{
+			Status:   models.StatusNOW,
+			PageRefs: []string{"Phoenix"},
+			Logbook: []models.LogbookEntry{
+				session(time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC), 3*time.Hour),  // This week
+				session(time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC), 12*time.Hour),  // 1 week ago
+				session(time.Date(2025, 10, 27, 9, 0, 0, 0, time.UTC), 12*time.Hour), // 2 weeks ago
+				session(time.Date(2025, 10, 20, 9, 0, 0, 0, time.UTC), 12*time.Hour), // 3 weeks ago
+				session(time.Date(2025, 10, 13, 9, 0, 0, 0, time.UTC), 12*time.Hour), // 4 weeks ago
+			},
+		},
+		{
+			Status:   models.StatusTODO,
+			PageRefs: []string{"Admin"},
+			Logbook: []models.LogbookEntry{
+				session(time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC), 4*time.Hour),
+			},
+		},
+	}
+
+	index := BuildTimeTrackingIndex(tasks)
+	index.ApplyBudgets(map[string]time.Duration{
+		"Phoenix":  10 * time.Hour,
+		"Admin":    1 * time.Hour,
+		"Research": 5 * time.Hour,
+	}, now)
+
+	if len(index.Budgets) != 3 {
+		t.Fatalf("Expected 3 budgets, got %d", len(index.Budgets))
+	}
+
+	byProject := make(map[string]ProjectBudget)
+	for _, b := range index.Budgets {
+		byProject[b.Project] = b
+	}
+
+	phoenix := byProject["Phoenix"]
+	if phoenix.ThisWeek != 3*time.Hour {
+		t.Errorf("Phoenix: expected 3h this week, got %v", phoenix.ThisWeek)
+	}
+	if phoenix.RecentAvg != 12*time.Hour || phoenix.Status != "over" {
+		t.Errorf("Phoenix: expected 12h avg and over, got %v %s", phoenix.RecentAvg, phoenix.Status)
+	}
+
+	if admin := byProject["Admin"]; admin.RecentAvg != time.Hour || admin.Status != "on track" {
+		t.Errorf("Admin: expected 1h avg on track, got %v %s", admin.RecentAvg, admin.Status)
+	}
+
+	if research := byProject["Research"]; research.RecentAvg != 0 || research.Status != "under" {
+		t.Errorf("Research: expected 0 avg under, got %v %s", research.RecentAvg, research.Status)
+	}
+
+	// Sorted by project name
+	if index.Budgets[0].Project != "Admin" {
+		t.Errorf("Expected budgets sorted by project, got %s first", index.Budgets[0].Project)
+	}
+}
+
+func TestBuildTimeTrackingIndex_ByFileTypeAndNamespace(t *testing.T) {
+	session := func(d time.Duration) []models.LogbookEntry {
+		start := time.Date(2025, 11, 6, 9, 0, 0, 0, time.UTC)
+		return []models.LogbookEntry{{Start: start, End: start.Add(d), Duration: d}}
+	}
+
+	tasks := []models.Task{
+		{Status: models.StatusDONE, SourceFile: "journals/2025_11_06.md", Logbook: session(time.Hour)},
+		{Status: models.StatusDONE, SourceFile: "pages/Projects___Phoenix.md", Logbook: session(2 * time.Hour)},
+		{Status: models.StatusNOW, SourceFile: "pages/Projects%2FAtlas.md", Logbook: session(30 * time.Minute)},
+		{Status: models.StatusTODO, SourceFile: "pages/Reading List.md", Logbook: session(15 * time.Minute)},
+		{Status: models.StatusTODO, SourceFile: "pages/Areas___Health___Sleep.md"},
+	}
+
+	index := BuildTimeTrackingIndex(tasks)
+
+	if got := index.ByFileType["journal"]; got != time.Hour {
+		t.Errorf("Expected 1h in journals, got %v", got)
+	}
+	if got := index.ByFileType["page"]; got != 2*time.Hour+45*time.Minute {
+		t.Errorf("Expected 2h45m in pages, got %v", got)
+	}
+
+	if got := index.ByNamespace["Projects"]; got != 2*time.Hour+30*time.Minute {
+		t.Errorf("Expected 2h30m in Projects namespace, got %v", got)
+	}
+	if got := index.ByNamespace[""]; got != 15*time.Minute {
+		t.Errorf("Expected 15m outside any namespace, got %v", got)
+	}
+	if _, exists := index.ByNamespace["Areas"]; exists {
+		t.Error("Untracked tasks should not add a namespace entry")
+	}
+	if len(index.ByNamespace) != 2 {
+		t.Errorf("Journal tasks should not be counted by namespace, got %v", index.ByNamespace)
+	}
+}
+
+func TestTopLevelNamespace(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"pages/Projects___Phoenix.md", "Projects"},
+		{"pages/Areas___Health___Sleep.md", "Areas"},
+		{"pages/Projects%2FAtlas.md", "Projects"},
+		{"pages/Reading List.md", ""},
+	}
+
+	for _, tt := range tests {
+		if got := topLevelNamespace(tt.path); got != tt.want {
+			t.Errorf("topLevelNamespace(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
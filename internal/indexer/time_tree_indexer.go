@@ -0,0 +1,126 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// TimeTreeNode is a task with the time logged on it and its subtasks
+type TimeTreeNode struct {
+	Task     models.Task
+	Own      time.Duration   // Logged on the task itself
+	Total    time.Duration   // Own plus every subtask's
+	Children []*TimeTreeNode // Subtasks with logged time, most time first
+}
+
+// TimeTreeProject is the task trees of one project
+type TimeTreeProject struct {
+	Name  string // First page reference of the top-level task, or "No Project"
+	Total time.Duration
+	Roots []*TimeTreeNode // Most time first
+}
+
+// TimeTreeIndex rolls logged time up through nested tasks, so a parent
+// task's total includes the time logged on its subtasks
+type TimeTreeIndex struct {
+	GeneratedAt time.Time
+	Projects    []TimeTreeProject // Most time first, "No Project" last
+}
+
+// BuildTimeTreeIndex links tasks to the task they're nested under (by
+// ParentLine in the same file) and sums logged time up each tree. Trees
+// are grouped by project like the time tracking report: the top-level
+// task's first page reference, which its subtasks inherit. Tasks and
+// subtrees without logged time are left out.
+func BuildTimeTreeIndex(tasks []models.Task, now time.Time) *TimeTreeIndex {
+	index := &TimeTreeIndex{GeneratedAt: now}
+
+	type taskKey struct {
+		file string
+		line int
+	}
+	nodes := make(map[taskKey]*TimeTreeNode, len(tasks))
+	for _, task := range tasks {
+		nodes[taskKey{task.SourceFile, task.LineNumber}] = &TimeTreeNode{Task: task, Own: task.TotalDuration()}
+	}
+
+	var roots []*TimeTreeNode
+	for _, task := range tasks {
+		node := nodes[taskKey{task.SourceFile, task.LineNumber}]
+		if parent, ok := nodes[taskKey{task.SourceFile, task.ParentLine}]; ok && task.ParentLine > 0 {
+			parent.Children = append(parent.Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+
+	byProject := make(map[string]*TimeTreeProject)
+	for _, root := range roots {
+		if sumTimeTree(root) == 0 {
+			continue
+		}
+		name := "No Project"
+		if len(root.Task.PageRefs) > 0 {
+			name = root.Task.PageRefs[0]
+		}
+		project, ok := byProject[name]
+		if !ok {
+			project = &TimeTreeProject{Name: name}
+			byProject[name] = project
+		}
+		project.Total += root.Total
+		project.Roots = append(project.Roots, root)
+	}
+
+	for _, project := range byProject {
+		sortTimeTree(project.Roots)
+		index.Projects = append(index.Projects, *project)
+	}
+	sort.Slice(index.Projects, func(i, j int) bool {
+		a, b := index.Projects[i], index.Projects[j]
+		if (a.Name == "No Project") != (b.Name == "No Project") {
+			return b.Name == "No Project"
+		}
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+
+	return index
+}
+
+// sumTimeTree sets the totals of a tree, drops subtrees without logged
+// time, and returns the root's total
+func sumTimeTree(node *TimeTreeNode) time.Duration {
+	node.Total = node.Own
+	children := node.Children[:0]
+	for _, child := range node.Children {
+		if total := sumTimeTree(child); total > 0 {
+			node.Total += total
+			children = append(children, child)
+		}
+	}
+	node.Children = children
+	return node.Total
+}
+
+// sortTimeTree orders nodes and their subtasks by most time first, then by
+// file position
+func sortTimeTree(nodes []*TimeTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i], nodes[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		if a.Task.SourceFile != b.Task.SourceFile {
+			return a.Task.SourceFile < b.Task.SourceFile
+		}
+		return a.Task.LineNumber < b.Task.LineNumber
+	})
+	for _, node := range nodes {
+		sortTimeTree(node.Children)
+	}
+}
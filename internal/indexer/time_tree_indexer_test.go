@@ -0,0 +1,51 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildTimeTreeIndex(t *testing.T) {
+	start := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	logged := func(d time.Duration) []models.LogbookEntry {
+		return []models.LogbookEntry{{Start: start, End: start.Add(d), Duration: d}}
+	}
+	tasks := []models.Task{
+		{Description: "Launch", PageRefs: []string{"Phoenix"}, SourceFile: "pages/Phoenix.md", LineNumber: 1, Logbook: logged(time.Hour)},
+		{Description: "Docs", SourceFile: "pages/Phoenix.md", LineNumber: 2, ParentLine: 1, Logbook: logged(3 * time.Hour)},
+		{Description: "Load tests", SourceFile: "pages/Phoenix.md", LineNumber: 3, ParentLine: 1, Logbook: logged(30 * time.Minute)},
+		{Description: "Scripts", SourceFile: "pages/Phoenix.md", LineNumber: 4, ParentLine: 3, Logbook: logged(2 * time.Hour)},
+		{Description: "Not started", SourceFile: "pages/Phoenix.md", LineNumber: 5, ParentLine: 1},
+		{Description: "Hotfix", PageRefs: []string{"Phoenix"}, SourceFile: "journals/2025_11_03.md", LineNumber: 1, Logbook: logged(time.Hour)},
+		{Description: "Same line elsewhere", SourceFile: "journals/2025_11_03.md", LineNumber: 4, ParentLine: 3, Logbook: logged(time.Hour)},
+		{Description: "Untracked", PageRefs: []string{"Hiring"}, SourceFile: "pages/Hiring.md", LineNumber: 1},
+	}
+
+	index := BuildTimeTreeIndex(tasks, start)
+
+	if len(index.Projects) != 2 {
+		t.Fatalf("Expected Phoenix and No Project, got %+v", index.Projects)
+	}
+	phoenix := index.Projects[0]
+	if phoenix.Name != "Phoenix" || phoenix.Total != 7*time.Hour+30*time.Minute || len(phoenix.Roots) != 2 {
+		t.Fatalf("Unexpected Phoenix project: %s %v with %d roots", phoenix.Name, phoenix.Total, len(phoenix.Roots))
+	}
+
+	launch := phoenix.Roots[0]
+	if launch.Task.Description != "Launch" || launch.Own != time.Hour || launch.Total != 6*time.Hour+30*time.Minute {
+		t.Errorf("Expected Launch with 1h own and 6h 30m total, got %s %v/%v", launch.Task.Description, launch.Own, launch.Total)
+	}
+	if len(launch.Children) != 2 || launch.Children[0].Task.Description != "Docs" {
+		t.Fatalf("Expected Docs then Load tests under Launch, without the untracked subtask, got %d children", len(launch.Children))
+	}
+	if load := launch.Children[1]; load.Total != 2*time.Hour+30*time.Minute || len(load.Children) != 1 {
+		t.Errorf("Expected Load tests to roll up Scripts, got %v with %d children", load.Total, len(load.Children))
+	}
+
+	// A parent line in another file doesn't link tasks
+	if none := index.Projects[1]; none.Name != "No Project" || none.Total != time.Hour {
+		t.Errorf("Expected the journal subtask under No Project, got %s %v", none.Name, none.Total)
+	}
+}
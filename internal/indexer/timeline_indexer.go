@@ -1,7 +1,6 @@
 package indexer
 
 import (
-	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,14 +14,31 @@ type TimelineDay struct {
 	Date         time.Time
 	JournalPath  string
 	TasksCreated []models.Task
+	PageTasks    []BackfilledTask // Tasks from pages attributed to this day (see BackfillPageTasks)
+	PagesCreated []string         // Pages first committed this day (see ApplyPageActivity)
+	PagesEdited  []string         // Pages with significant edits this day, excluding new pages
 	TimeLogged   time.Duration
-	KeyActivity  []string // Summary bullets
+	WordsWritten int // Prose words in the day's journal (see ApplyWriting)
+}
+
+// Sources for attributing a page task to a day
+const (
+	DateSourceLogbook   = "logbook"   // Clocked time on that day
+	DateSourceCompleted = "completed" // completed:: property
+	DateSourceGit       = "git"       // Last commit touching the page
+)
+
+// BackfilledTask is a task from pages/ placed on the timeline by a fallback date
+type BackfilledTask struct {
+	models.Task
+	Source string // DateSourceLogbook, DateSourceCompleted, or DateSourceGit
 }
 
 // TimelineIndex organizes activity chronologically by date
 type TimelineIndex struct {
 	GeneratedAt time.Time
 	Entries     []TimelineDay // Sorted newest first
+	Writing     *WritingStats // Journaling statistics (see ApplyWriting)
 }
 
 // BuildTimelineIndex creates a timeline from tasks and files
@@ -68,11 +84,6 @@ func BuildTimelineIndex(tasks []models.Task, files []models.File) *TimelineIndex
 		}
 	}
 
-	// Generate key activity summaries
-	for _, day := range dayMap {
-		day.KeyActivity = generateKeyActivity(day)
-	}
-
 	// Convert map to sorted slice (newest first)
 	for _, day := range dayMap {
 		index.Entries = append(index.Entries, *day)
@@ -85,91 +96,229 @@ func BuildTimelineIndex(tasks []models.Task, files []models.File) *TimelineIndex
 	return index
 }
 
-// extractDateFromJournalPath extracts date from journal file path
-// journals/2025_11_06.md -> Nov 6, 2025
-// journals/2025-11-06.md -> Nov 6, 2025
-func extractDateFromJournalPath(path string) (time.Time, error) {
-	filename := filepath.Base(path)
-	filename = strings.TrimSuffix(filename, ".md")
+// BackfillPageTasks adds tasks from non-journal files to the timeline, which
+// otherwise only sees journal tasks. Each task is attributed by the first
+// available source:
+//   - logbook: every day with a CLOCK entry (time is added to that day)
+//   - completed: the completed:: property date
+//   - git: the last commit date of the task's file (from pageModified)
+//
+// Tasks with none of these are skipped. Days without a journal are created.
+func (ti *TimelineIndex) BackfillPageTasks(tasks []models.Task, pageModified map[string]time.Time) {
+	days := newTimelineDays(ti)
 
-	// Try underscore format: 2025_11_06
-	if strings.Contains(filename, "_") {
-		t, err := time.Parse("2006_01_02", filename)
-		if err == nil {
-			return t, nil
+	for _, task := range tasks {
+		if _, err := extractDateFromJournalPath(task.SourceFile); err == nil {
+			continue // Journal tasks are already on the timeline
 		}
-	}
 
-	// Try dash format: 2025-11-06
-	if strings.Contains(filename, "-") {
-		t, err := time.Parse("2006-01-02", filename)
-		if err == nil {
-			return t, nil
+		switch {
+		case len(task.Logbook) > 0:
+			seen := make(map[*TimelineDay]bool)
+			for _, entry := range task.Logbook {
+				day := days.day(entry.Start)
+				day.TimeLogged += entry.Duration
+				if !seen[day] {
+					seen[day] = true
+					day.PageTasks = append(day.PageTasks, BackfilledTask{Task: task, Source: DateSourceLogbook})
+				}
+			}
+		case !task.CompletedAt.IsZero():
+			day := days.day(task.CompletedAt)
+			day.PageTasks = append(day.PageTasks, BackfilledTask{Task: task, Source: DateSourceCompleted})
+		default:
+			modified, exists := pageModified[filepath.ToSlash(task.SourceFile)]
+			if !exists {
+				continue
+			}
+			day := days.day(modified)
+			day.PageTasks = append(day.PageTasks, BackfilledTask{Task: task, Source: DateSourceGit})
 		}
 	}
 
-	return time.Time{}, &time.ParseError{
-		Layout:     "2006_01_02 or 2006-01-02",
-		Value:      filename,
-		LayoutElem: "journal filename",
+	days.commit()
+}
+
+// timelineDays finds or creates the timeline day for a date while a timeline
+// is being updated
+type timelineDays struct {
+	ti    *TimelineIndex
+	byKey map[string]*TimelineDay // Pointers into ti.Entries, plus the added days
+	added []*TimelineDay
+}
+
+func newTimelineDays(ti *TimelineIndex) *timelineDays {
+	byKey := make(map[string]*TimelineDay, len(ti.Entries))
+	for i := range ti.Entries {
+		byKey[ti.Entries[i].Date.Format("2006-01-02")] = &ti.Entries[i]
 	}
+	return &timelineDays{ti: ti, byKey: byKey}
 }
 
-// generateKeyActivity creates summary bullets for a day
-func generateKeyActivity(day *TimelineDay) []string {
-	var activity []string
+// day returns the timeline day of date, creating it if needed
+func (d *timelineDays) day(date time.Time) *TimelineDay {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	key := date.Format("2006-01-02")
+	day, exists := d.byKey[key]
+	if !exists {
+		day = &TimelineDay{Date: date}
+		d.byKey[key] = day
+		d.added = append(d.added, day)
+	}
+	return day
+}
 
-	if len(day.TasksCreated) == 0 {
-		return activity
+// commit adds the created days to the timeline, newest first. byKey holds
+// pointers into Entries, so it must be called only after all updates.
+func (d *timelineDays) commit() {
+	if len(d.added) == 0 {
+		return
+	}
+	for _, day := range d.added {
+		d.ti.Entries = append(d.ti.Entries, *day)
 	}
+	sort.Slice(d.ti.Entries, func(i, j int) bool {
+		return d.ti.Entries[i].Date.After(d.ti.Entries[j].Date)
+	})
+	d.added = nil
+}
 
-	// Count by status
-	statusCounts := make(map[models.TaskStatus]int)
-	var highPriorityTasks []models.Task
+// bulkTouchMin is how many pages must share a day's unknown-size edits before
+// it's taken for a bulk touch (see ApplyPageActivity)
+const bulkTouchMin = 10
 
-	for _, task := range day.TasksCreated {
-		statusCounts[task.Status]++
-		if task.Priority == models.PriorityHigh {
-			highPriorityTasks = append(highPriorityTasks, task)
+// PageEdit is one change to a page file, from git history or the file's mtime
+type PageEdit struct {
+	Date    time.Time
+	Lines   int  // Lines added plus deleted; -1 if unknown (mtime)
+	Created bool // The change that added the page
+}
+
+// ApplyPageActivity records page creations and significant edits on the
+// timeline, so days spent writing notes show up alongside task activity.
+// edits maps page file paths to their changes; edits smaller than minLines
+// are ignored. Edits of unknown size count, except on a day holding most of
+// them (and at least bulkTouchMin pages): without git, a checkout, sync, or
+// copy sets every page's mtime to the same day. Journal files are skipped,
+// since the journal itself is the day's entry.
+func (ti *TimelineIndex) ApplyPageActivity(edits map[string][]PageEdit, minLines int) {
+	days := newTimelineDays(ti)
+
+	// Visit paths in order so page lists are stable between runs
+	paths := make([]string, 0, len(edits))
+	for path := range edits {
+		if _, err := extractDateFromJournalPath(path); err == nil || strings.HasPrefix(path, "journals/") {
+			continue
 		}
+		paths = append(paths, path)
 	}
+	sort.Strings(paths)
+	bulk := bulkTouchDays(paths, edits)
 
-	// Add status summary
-	if statusCounts[models.StatusNOW] > 0 {
-		activity = append(activity, formatTaskCount(statusCounts[models.StatusNOW], "NOW"))
-	}
-	if statusCounts[models.StatusDOING] > 0 {
-		activity = append(activity, formatTaskCount(statusCounts[models.StatusDOING], "DOING"))
+	for _, path := range paths {
+		page := models.PageName(path)
+
+		recorded := make(map[string]bool) // One entry per page per day
+		for _, edit := range edits[path] {
+			if !edit.Created && edit.Lines >= 0 && edit.Lines < minLines {
+				continue
+			}
+			if !edit.Created && edit.Lines < 0 && bulk[edit.Date.Format("2006-01-02")] {
+				continue
+			}
+			day := days.day(edit.Date)
+			key := day.Date.Format("2006-01-02")
+
+			if edit.Created {
+				day.PagesCreated = append(day.PagesCreated, page)
+				recorded[key] = true
+			} else if !recorded[key] {
+				day.PagesEdited = append(day.PagesEdited, page)
+				recorded[key] = true
+			}
+		}
 	}
-	if statusCounts[models.StatusTODO] > 0 {
-		activity = append(activity, formatTaskCount(statusCounts[models.StatusTODO], "TODO"))
+
+	for _, day := range days.byKey {
+		day.PagesEdited = removeAll(day.PagesEdited, day.PagesCreated)
 	}
-	if statusCounts[models.StatusLATER] > 0 {
-		activity = append(activity, formatTaskCount(statusCounts[models.StatusLATER], "LATER"))
+	days.commit()
+}
+
+// bulkTouchDays returns the days holding more than half of the pages' edits
+// of unknown size, if at least bulkTouchMin pages were touched that day
+func bulkTouchDays(paths []string, edits map[string][]PageEdit) map[string]bool {
+	perDay := make(map[string]int)
+	pages := 0
+	for _, path := range paths {
+		seen := make(map[string]bool)
+		for _, edit := range edits[path] {
+			if edit.Lines >= 0 || edit.Created {
+				continue
+			}
+			key := edit.Date.Format("2006-01-02")
+			if !seen[key] {
+				seen[key] = true
+				perDay[key]++
+			}
+		}
+		if len(seen) > 0 {
+			pages++
+		}
 	}
-	if statusCounts[models.StatusDONE] > 0 {
-		activity = append(activity, formatTaskCount(statusCounts[models.StatusDONE], "DONE"))
+
+	bulk := make(map[string]bool)
+	for key, n := range perDay {
+		if n >= bulkTouchMin && n*2 > pages {
+			bulk[key] = true
+		}
 	}
+	return bulk
+}
 
-	// Highlight high priority tasks (up to 2)
-	for i, task := range highPriorityTasks {
-		if i >= 2 {
-			break
+// removeAll returns items without any of the excluded values
+func removeAll(items, excluded []string) []string {
+	if len(excluded) == 0 {
+		return items
+	}
+	skip := make(map[string]bool, len(excluded))
+	for _, e := range excluded {
+		skip[e] = true
+	}
+	var kept []string
+	for _, item := range items {
+		if !skip[item] {
+			kept = append(kept, item)
 		}
-		desc := task.Description
-		if len(desc) > 60 {
-			desc = desc[:57] + "..."
+	}
+	return kept
+}
+
+// extractDateFromJournalPath extracts date from journal file path
+// journals/2025_11_06.md -> Nov 6, 2025
+// journals/2025-11-06.md -> Nov 6, 2025
+func extractDateFromJournalPath(path string) (time.Time, error) {
+	filename := models.PageName(path)
+
+	// Try underscore format: 2025_11_06
+	if strings.Contains(filename, "_") {
+		t, err := time.Parse("2006_01_02", filename)
+		if err == nil {
+			return t, nil
 		}
-		activity = append(activity, "🔥 "+desc)
 	}
 
-	return activity
-}
+	// Try dash format: 2025-11-06
+	if strings.Contains(filename, "-") {
+		t, err := time.Parse("2006-01-02", filename)
+		if err == nil {
+			return t, nil
+		}
+	}
 
-// formatTaskCount formats a count with proper pluralization
-func formatTaskCount(count int, status models.TaskStatus) string {
-	if count == 1 {
-		return "1 " + string(status) + " task"
+	return time.Time{}, &time.ParseError{
+		Layout:     "2006_01_02 or 2006-01-02",
+		Value:      filename,
+		LayoutElem: "journal filename",
 	}
-	return fmt.Sprintf("%d %s tasks", count, status)
 }
@@ -1,6 +1,7 @@
 package indexer
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -87,11 +88,6 @@ func TestBuildTimelineIndex(t *testing.T) {
 	if len(nov5.TasksCreated) != 1 {
 		t.Errorf("Expected 1 task on Nov 5, got %d", len(nov5.TasksCreated))
 	}
-
-	// Check key activity was generated
-	if len(nov6.KeyActivity) == 0 {
-		t.Error("Expected key activity for Nov 6, got none")
-	}
 }
 
 func TestBuildTimelineIndex_EmptyData(t *testing.T) {
@@ -184,127 +180,119 @@ func TestExtractDateFromJournalPath(t *testing.T) {
 	}
 }
 
-func TestGenerateKeyActivity(t *testing.T) {
-	day := &TimelineDay{
-		Date: time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
-		TasksCreated: []models.Task{
-			{
-				Status:      models.StatusNOW,
-				Priority:    models.PriorityHigh,
-				Description: "High priority urgent task",
-			},
-			{
-				Status:   models.StatusTODO,
-				Priority: models.PriorityNone,
-			},
-			{
-				Status:   models.StatusTODO,
-				Priority: models.PriorityNone,
-			},
-			{
-				Status:   models.StatusDONE,
-				Priority: models.PriorityNone,
+func TestBackfillPageTasks(t *testing.T) {
+	files := []models.File{
+		{Path: "journals/2025_11_06.md", Type: models.FileTypeJournal},
+	}
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Journal task", SourceFile: "journals/2025_11_06.md"},
+		{
+			Status:      models.StatusDOING,
+			Description: "Clocked page task",
+			SourceFile:  "pages/Phoenix.md",
+			Logbook: []models.LogbookEntry{
+				{Start: time.Date(2025, 11, 6, 9, 0, 0, 0, time.UTC), Duration: time.Hour},
+				{Start: time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC), Duration: 30 * time.Minute},
 			},
 		},
+		{Status: models.StatusDONE, Description: "Completed page task", SourceFile: "pages/Phoenix.md", CompletedAt: time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusTODO, Description: "Undated page task", SourceFile: "pages/Mobile.md"},
+		{Status: models.StatusTODO, Description: "Untracked page task", SourceFile: "pages/Untracked.md"},
 	}
 
-	activity := generateKeyActivity(day)
-
-	// Should have status summaries
-	foundNOW := false
-	foundTODO := false
-	foundDONE := false
-	foundHighPri := false
+	index := BuildTimelineIndex(tasks, files)
+	index.BackfillPageTasks(tasks, map[string]time.Time{
+		"pages/Mobile.md": time.Date(2025, 11, 5, 18, 0, 0, 0, time.UTC),
+	})
 
-	for _, line := range activity {
-		if line == "1 NOW task" {
-			foundNOW = true
-		}
-		if line == "2 TODO tasks" {
-			foundTODO = true
-		}
-		if line == "1 DONE task" {
-			foundDONE = true
-		}
-		if line == "🔥 High priority urgent task" {
-			foundHighPri = true
-		}
+	if len(index.Entries) != 3 {
+		t.Fatalf("Expected 3 days (journal + 2 backfilled), got %d", len(index.Entries))
 	}
 
-	if !foundNOW {
-		t.Error("Expected NOW task summary in key activity")
-	}
-	if !foundTODO {
-		t.Error("Expected TODO tasks summary in key activity")
+	nov6 := index.Entries[0]
+	if nov6.Date.Day() != 6 || len(nov6.TasksCreated) != 1 || len(nov6.PageTasks) != 2 {
+		t.Fatalf("Unexpected Nov 6 entry: %d journal tasks, %d page tasks", len(nov6.TasksCreated), len(nov6.PageTasks))
 	}
-	if !foundDONE {
-		t.Error("Expected DONE task summary in key activity")
+	if nov6.TimeLogged != time.Hour {
+		t.Errorf("Expected 1h logged on Nov 6, got %v", nov6.TimeLogged)
 	}
-	if !foundHighPri {
-		t.Error("Expected high priority task highlight in key activity")
+	if nov6.JournalPath != "journals/2025_11_06.md" {
+		t.Errorf("Expected journal path to be kept, got %q", nov6.JournalPath)
 	}
-}
 
-func TestGenerateKeyActivity_EmptyDay(t *testing.T) {
-	day := &TimelineDay{
-		Date:         time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
-		TasksCreated: []models.Task{},
+	nov5 := index.Entries[1]
+	if nov5.Date.Day() != 5 || len(nov5.PageTasks) != 1 || nov5.PageTasks[0].Source != DateSourceGit {
+		t.Errorf("Expected git-dated task on Nov 5, got %+v", nov5.PageTasks)
 	}
 
-	activity := generateKeyActivity(day)
-
-	if len(activity) != 0 {
-		t.Errorf("Expected no key activity for empty day, got %d items", len(activity))
+	nov4 := index.Entries[2]
+	if nov4.Date.Day() != 4 || nov4.TimeLogged != 30*time.Minute || nov4.JournalPath != "" {
+		t.Errorf("Unexpected Nov 4 entry: %+v", nov4)
+	}
+	if len(nov4.PageTasks) != 1 || nov4.PageTasks[0].Source != DateSourceLogbook {
+		t.Errorf("Expected logbook-dated task on Nov 4, got %+v", nov4.PageTasks)
 	}
 }
 
-func TestGenerateKeyActivity_TruncateLongDescription(t *testing.T) {
-	day := &TimelineDay{
-		Date: time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
-		TasksCreated: []models.Task{
-			{
-				Status:      models.StatusNOW,
-				Priority:    models.PriorityHigh,
-				Description: "This is a very long task description that should be truncated to 60 characters",
-			},
+func TestApplyPageActivity(t *testing.T) {
+	nov6 := time.Date(2025, 11, 6, 15, 0, 0, 0, time.UTC)
+	nov5 := time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)
+
+	index := BuildTimelineIndex(nil, []models.File{
+		{Path: "journals/2025_11_06.md", Type: models.FileTypeJournal},
+	})
+	index.ApplyPageActivity(map[string][]PageEdit{
+		"pages/Phoenix.md": {
+			{Date: nov6, Lines: 20},
+			{Date: nov6, Lines: 2},
+			{Date: nov5, Lines: 40, Created: true},
 		},
-	}
+		"pages/Typo.md":          {{Date: nov6, Lines: 1}},
+		"pages/Notes.md":         {{Date: nov6, Lines: -1}}, // mtime only
+		"journals/2025_11_06.md": {{Date: nov6, Lines: 50}},
+	}, 5)
 
-	activity := generateKeyActivity(day)
+	if len(index.Entries) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(index.Entries))
+	}
 
-	foundTruncated := false
-	for _, line := range activity {
-		// Check if line starts with fire emoji and ends with ...
-		if strings.HasPrefix(line, "🔥") && strings.HasSuffix(line, "...") {
-			foundTruncated = true
-			// Verify it's actually shorter than the original
-			if len(line) < 81 { // Original description is 81 chars
-				break
-			}
-		}
+	day6 := index.Entries[0]
+	if got := strings.Join(day6.PagesEdited, ","); got != "Notes,Phoenix" {
+		t.Errorf("Expected Notes and Phoenix edited on Nov 6, got %q", got)
+	}
+	if len(day6.PagesCreated) != 0 {
+		t.Errorf("Expected no pages created on Nov 6, got %v", day6.PagesCreated)
 	}
 
-	if !foundTruncated {
-		t.Error("Expected long description to be truncated")
+	day5 := index.Entries[1]
+	if len(day5.PagesCreated) != 1 || day5.PagesCreated[0] != "Phoenix" || len(day5.PagesEdited) != 0 {
+		t.Errorf("Expected Phoenix created (not edited) on Nov 5, got created=%v edited=%v", day5.PagesCreated, day5.PagesEdited)
 	}
 }
 
-func TestFormatTaskCount(t *testing.T) {
-	tests := []struct {
-		count    int
-		status   models.TaskStatus
-		expected string
-	}{
-		{1, models.StatusNOW, "1 NOW task"},
-		{2, models.StatusTODO, "2 TODO tasks"},
-		{5, models.StatusDONE, "5 DONE tasks"},
-		{1, models.StatusDOING, "1 DOING task"},
+func TestApplyPageActivity_BulkTouch(t *testing.T) {
+	nov6 := time.Date(2025, 11, 6, 15, 0, 0, 0, time.UTC)
+	nov5 := time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)
+
+	// A fresh copy of a graph without git: every page has the copy's mtime
+	edits := map[string][]PageEdit{
+		"pages/Phoenix.md": {{Date: nov6, Lines: 20}},
+		"pages/Draft.md":   {{Date: nov5, Lines: -1}},
+	}
+	for i := 0; i < bulkTouchMin; i++ {
+		edits[fmt.Sprintf("pages/Page %d.md", i)] = []PageEdit{{Date: nov6, Lines: -1}}
 	}
 
-	for _, tt := range tests {
-		result := formatTaskCount(tt.count, tt.status)
-		if result != tt.expected {
-			t.Errorf("formatTaskCount(%d, %s) = %q, expected %q", tt.count, tt.status, result, tt.expected)
-		}
+	index := BuildTimelineIndex(nil, nil)
+	index.ApplyPageActivity(edits, 5)
+
+	if len(index.Entries) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(index.Entries))
+	}
+	if got := strings.Join(index.Entries[0].PagesEdited, ","); got != "Phoenix" {
+		t.Errorf("Expected only the sized edit on the bulk-touched day, got %q", got)
+	}
+	if got := strings.Join(index.Entries[1].PagesEdited, ","); got != "Draft" {
+		t.Errorf("Expected mtime edits on other days to count, got %q", got)
 	}
 }
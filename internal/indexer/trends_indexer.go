@@ -0,0 +1,162 @@
+package indexer
+
+import (
+	"iter"
+	"maps"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// TrendsIndex lists topics whose mentions in journals are rising quickly
+type TrendsIndex struct {
+	GeneratedAt  time.Time
+	WindowDays   int       // Length of each comparison window
+	WindowEnd    time.Time // Last journal day included in the recent window
+	RecentDays   int       // Journal days written in the recent window
+	PreviousDays int       // Journal days written in the previous window
+	Emerging     []Trend   // Fastest growing first
+}
+
+// Trend compares how often a topic came up in the recent window with the window before it
+type Trend struct {
+	Topic    string  // Page name for page references, otherwise a content word
+	IsPage   bool    // Topic is a [[page]] rather than a plain word
+	Recent   int     // Journal days mentioning the topic in the recent window
+	Previous int     // Journal days mentioning the topic in the previous window
+	Growth   float64 // How many times larger the topic's share of journal days became (smoothed)
+}
+
+// BuildTrendsIndex compares the last windowDays of journals with the
+// windowDays before them and keeps topics mentioned on at least minDays
+// recent journal days whose share of journal days at least doubled (or
+// that weren't mentioned before). Shares rather than raw counts are
+// compared so journaling more often doesn't make everything trend.
+// journalWords maps journal file paths to their content words (see
+// parser.ExtractWords); page references made from journals count as topics too.
+//
+// The windows end at the latest journal on or before now, so a break from
+// journaling doesn't empty the report.
+func BuildTrendsIndex(journalWords map[string][]string, refs []models.PageReference, now time.Time, windowDays, minDays int) *TrendsIndex {
+	return BuildTrendsIndexFrom(maps.All(journalWords), refs, now, windowDays, minDays)
+}
+
+// BuildTrendsIndexFrom is BuildTrendsIndex reading journal words from a
+// sequence, one journal at a time
+func BuildTrendsIndexFrom(journalWords iter.Seq2[string, []string], refs []models.PageReference, now time.Time, windowDays, minDays int) *TrendsIndex {
+	index := &TrendsIndex{
+		GeneratedAt: now,
+		WindowDays:  windowDays,
+		Emerging:    []Trend{},
+	}
+
+	// Topics per journal day; each day counts once per topic
+	days := make(map[time.Time]map[string]bool)
+	addTopic := func(path, topic string) {
+		date, err := extractDateFromJournalPath(path)
+		if err != nil || date.After(now) {
+			return
+		}
+		if days[date] == nil {
+			days[date] = make(map[string]bool)
+		}
+		days[date][topic] = true
+	}
+
+	pages := make(map[string]bool) // Lowercase referenced page names
+	for _, ref := range refs {
+		if !isJournalPath(ref.SourceFile) {
+			continue
+		}
+		addTopic(ref.SourceFile, "[["+ref.TargetPage+"]]")
+		pages[strings.ToLower(ref.TargetPage)] = true
+	}
+	for path, words := range journalWords {
+		for _, word := range words {
+			// Linked pages are already counted as page topics
+			if !pages[word] {
+				addTopic(path, word)
+			}
+		}
+	}
+
+	for date := range days {
+		if date.After(index.WindowEnd) {
+			index.WindowEnd = date
+		}
+	}
+	if index.WindowEnd.IsZero() {
+		return index
+	}
+
+	recentStart := index.WindowEnd.AddDate(0, 0, -windowDays+1)
+	previousStart := recentStart.AddDate(0, 0, -windowDays)
+
+	counts := make(map[string]*Trend)
+	for date, topics := range days {
+		if date.Before(previousStart) {
+			continue
+		}
+		if date.Before(recentStart) {
+			index.PreviousDays++
+		} else {
+			index.RecentDays++
+		}
+		for topic := range topics {
+			trend, ok := counts[topic]
+			if !ok {
+				trend = &Trend{Topic: topic}
+				if strings.HasPrefix(topic, "[[") {
+					trend.Topic = strings.TrimSuffix(strings.TrimPrefix(topic, "[["), "]]")
+					trend.IsPage = true
+				}
+				counts[topic] = trend
+			}
+			if date.Before(recentStart) {
+				trend.Previous++
+			} else {
+				trend.Recent++
+			}
+		}
+	}
+
+	// A graph with no journals before the recent window has no baseline to compare against
+	if index.PreviousDays == 0 {
+		return index
+	}
+
+	for _, trend := range counts {
+		if trend.Recent < minDays {
+			continue
+		}
+		recentShare := float64(trend.Recent) / float64(index.RecentDays)
+		previousShare := float64(trend.Previous) / float64(index.PreviousDays)
+		if trend.Previous > 0 && recentShare < 2*previousShare {
+			continue
+		}
+		trend.Growth = (float64(trend.Recent+1) / float64(index.RecentDays+1)) /
+			(float64(trend.Previous+1) / float64(index.PreviousDays+1))
+		index.Emerging = append(index.Emerging, *trend)
+	}
+
+	sort.Slice(index.Emerging, func(i, j int) bool {
+		a, b := index.Emerging[i], index.Emerging[j]
+		if a.Growth != b.Growth {
+			return a.Growth > b.Growth
+		}
+		if a.Recent != b.Recent {
+			return a.Recent > b.Recent
+		}
+		return a.Topic < b.Topic
+	})
+
+	return index
+}
+
+// isJournalPath reports whether a repository-relative path is in journals/
+func isJournalPath(path string) bool {
+	return strings.HasPrefix(filepath.ToSlash(path), "journals/")
+}
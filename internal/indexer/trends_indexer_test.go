@@ -0,0 +1,92 @@
+package indexer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildTrendsIndex(t *testing.T) {
+	journal := func(day int) string {
+		return fmt.Sprintf("journals/2025_11_%02d.md", day)
+	}
+
+	journalWords := map[string][]string{}
+	var refs []models.PageReference
+
+	// "standup" comes up steadily in both windows
+	for day := 1; day <= 20; day++ {
+		journalWords[journal(day)] = []string{"standup"}
+	}
+	// Nov 7-20 is the recent window (14 journals), Nov 1-6 the previous one (6 journals).
+	// "hiring" is new; "deploy" grows from 1 in 6 days to 8 in 14
+	for _, day := range []int{10, 12, 15, 18} {
+		journalWords[journal(day)] = append(journalWords[journal(day)], "hiring", "hiring")
+	}
+	for _, day := range []int{2, 7, 8, 9, 11, 13, 17, 19, 20} {
+		journalWords[journal(day)] = append(journalWords[journal(day)], "deploy")
+	}
+	// [[Kubernetes]] is new; its lowercase word form isn't counted separately
+	for _, day := range []int{14, 16, 19} {
+		refs = append(refs, models.PageReference{SourceFile: journal(day), TargetPage: "Kubernetes"})
+		journalWords[journal(day)] = append(journalWords[journal(day)], "kubernetes")
+	}
+	// Too few mentions, and page-to-page references are ignored
+	journalWords[journal(20)] = append(journalWords[journal(20)], "offsite")
+	for i := 0; i < 5; i++ {
+		refs = append(refs, models.PageReference{SourceFile: "pages/Ideas.md", TargetPage: "Blog"})
+	}
+
+	now := time.Date(2025, 11, 25, 9, 0, 0, 0, time.UTC)
+	index := BuildTrendsIndex(journalWords, refs, now, 14, 3)
+
+	if want := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC); !index.WindowEnd.Equal(want) {
+		t.Errorf("Expected windows to end at the latest journal %v, got %v", want, index.WindowEnd)
+	}
+
+	if index.RecentDays != 14 || index.PreviousDays != 6 {
+		t.Errorf("Expected 14 recent and 6 previous journal days, got %d and %d", index.RecentDays, index.PreviousDays)
+	}
+
+	var got []string
+	for _, trend := range index.Emerging {
+		got = append(got, trend.Topic)
+	}
+	if want := "hiring,deploy,Kubernetes"; strings.Join(got, ",") != want {
+		t.Fatalf("Expected emerging topics %s, got %+v", want, index.Emerging)
+	}
+
+	kube := index.Emerging[2]
+	if !kube.IsPage || kube.Recent != 3 || kube.Previous != 0 {
+		t.Errorf("Unexpected page trend: %+v", kube)
+	}
+	deploy := index.Emerging[1]
+	if deploy.IsPage || deploy.Recent != 8 || deploy.Previous != 1 {
+		t.Errorf("Unexpected word trend: %+v", deploy)
+	}
+}
+
+func TestBuildTrendsIndex_NoJournals(t *testing.T) {
+	index := BuildTrendsIndex(map[string][]string{"pages/Notes.md": {"topic"}}, nil, time.Now(), 14, 3)
+	if len(index.Emerging) != 0 || !index.WindowEnd.IsZero() {
+		t.Errorf("Expected no trends without journals, got %+v", index)
+	}
+}
+
+func TestBuildTrendsIndex_IgnoresFutureJournals(t *testing.T) {
+	journalWords := map[string][]string{
+		"journals/2025_10_25.md": {"standup"}, // Baseline in the previous window
+		"journals/2025_11_01.md": {"planning"},
+		"journals/2025_11_02.md": {"planning"},
+		"journals/2025_11_03.md": {"planning"},
+		"journals/2025_12_24.md": {"holiday"}, // Written ahead of time
+	}
+	index := BuildTrendsIndex(journalWords, nil, time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC), 7, 3)
+
+	if len(index.Emerging) != 1 || index.Emerging[0].Topic != "planning" {
+		t.Errorf("Expected only planning to trend, got %+v", index.Emerging)
+	}
+}
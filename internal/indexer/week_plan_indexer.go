@@ -0,0 +1,141 @@
+package indexer
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// CapacityWeeks is how many complete weeks of tracked time the weekly
+// capacity is averaged over
+const CapacityWeeks = 8
+
+// Why a task is in next week's plan
+const (
+	PlanOverdue  = "overdue"  // Due before today
+	PlanDue      = "due"      // Due next week
+	PlanPriority = "priority" // Highest priority level, not due sooner
+)
+
+// WeekPlan compares the work lined up for next week with the time usually
+// tracked in a week
+type WeekPlan struct {
+	GeneratedAt   time.Time
+	WeekStart     time.Time     // Monday of next week
+	Tasks         []PlannedTask // Overdue, then due (soonest first), then priority
+	Capacity      time.Duration // Average tracked time per week, zero without history
+	CapacityWeeks int           // Weeks with tracked time the average is taken over
+	Estimated     time.Duration // Remaining effort of the tasks with an estimate
+	Unestimated   int           // Tasks without one
+}
+
+// PlannedTask is one task in next week's plan
+type PlannedTask struct {
+	Task   models.Task
+	Reason string        // One of the Plan constants
+	Effort time.Duration // Remaining effort, zero when unknown
+	Basis  string        // BasisEstimate or BasisHistory, "" when unknown
+}
+
+// Projected returns the plan's expected effort: the estimated tasks, plus
+// each unestimated task at the average estimate
+func (p *WeekPlan) Projected() time.Duration {
+	estimated := len(p.Tasks) - p.Unestimated
+	if estimated == 0 {
+		return 0
+	}
+	return p.Estimated + p.Estimated/time.Duration(estimated)*time.Duration(p.Unestimated)
+}
+
+// Load returns Projected as a fraction of Capacity, 0 without a capacity
+func (p *WeekPlan) Load() float64 {
+	if p.Capacity == 0 {
+		return 0
+	}
+	return float64(p.Projected()) / float64(p.Capacity)
+}
+
+// BuildWeekPlan lines up next week's work from open, undelegated tasks:
+// anything overdue, anything due next week, and tasks at the highest
+// priority level that aren't due before then. Effort comes from the task's
+// estimate minus time already logged, or the effort index's history-based
+// guess. Capacity is the average time tracked in the last CapacityWeeks
+// complete weeks, counting only weeks with any tracked time so holidays
+// don't drag it down.
+func BuildWeekPlan(tasks []models.Task, effort *EffortIndex, timeTracking *TimeTrackingIndex, now time.Time) *WeekPlan {
+	today := startOfDay(now) // Due dates are UTC midnights
+	thisWeek := getWeekStart(today)
+	plan := &WeekPlan{GeneratedAt: now, WeekStart: thisWeek.AddDate(0, 0, 7)}
+	weekEnd := plan.WeekStart.AddDate(0, 0, 7)
+
+	key := func(task models.Task) string {
+		return fmt.Sprintf("%s:%d", task.SourceFile, task.LineNumber)
+	}
+	guesses := make(map[string]EffortEstimate)
+	for _, e := range append(append([]EffortEstimate(nil), effort.QuickWins...), effort.DeepWork...) {
+		if e.Basis == BasisHistory {
+			guesses[key(e.Task)] = e
+		}
+	}
+
+	var top models.Priority
+	if levels := models.Priorities(); len(levels) > 0 {
+		top = levels[0]
+	}
+	for _, task := range tasks {
+		if models.IsClosed(task.Status) || task.DelegatedTo != "" {
+			continue
+		}
+
+		due := task.DueDate()
+		planned := PlannedTask{Task: task}
+		switch {
+		case !due.IsZero() && due.Before(today):
+			planned.Reason = PlanOverdue
+		case !due.IsZero() && !due.Before(plan.WeekStart) && due.Before(weekEnd):
+			planned.Reason = PlanDue
+		case top != "" && task.Priority == top && (due.IsZero() || !due.Before(weekEnd)):
+			planned.Reason = PlanPriority
+		default:
+			continue
+		}
+
+		if task.Estimate > 0 && task.Estimate > task.TotalDuration() {
+			planned.Effort, planned.Basis = task.Estimate-task.TotalDuration(), BasisEstimate
+		} else if guess, ok := guesses[key(task)]; ok {
+			planned.Effort, planned.Basis = guess.Duration, BasisHistory
+		}
+		if planned.Basis == "" {
+			plan.Unestimated++
+		}
+		plan.Estimated += planned.Effort
+		plan.Tasks = append(plan.Tasks, planned)
+	}
+
+	order := map[string]int{PlanOverdue: 0, PlanDue: 1, PlanPriority: 2}
+	sort.SliceStable(plan.Tasks, func(i, j int) bool {
+		a, b := plan.Tasks[i], plan.Tasks[j]
+		if order[a.Reason] != order[b.Reason] {
+			return order[a.Reason] < order[b.Reason]
+		}
+		if !a.Task.DueDate().Equal(b.Task.DueDate()) {
+			return a.Task.DueDate().Before(b.Task.DueDate())
+		}
+		return priorityRank(a.Task.Priority) < priorityRank(b.Task.Priority)
+	})
+
+	var tracked time.Duration
+	for i := 1; i <= CapacityWeeks; i++ {
+		if logged := timeTracking.ByWeek[thisWeek.AddDate(0, 0, -7*i).Format("2006-01-02")]; logged > 0 {
+			tracked += logged
+			plan.CapacityWeeks++
+		}
+	}
+	if plan.CapacityWeeks > 0 {
+		plan.Capacity = tracked / time.Duration(plan.CapacityWeeks)
+	}
+
+	return plan
+}
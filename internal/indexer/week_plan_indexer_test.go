@@ -0,0 +1,86 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestBuildWeekPlan(t *testing.T) {
+	now := time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC) // Wednesday
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC) }
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "File taxes", SourceFile: "pages/Admin.md", LineNumber: 1, Deadline: day(1),
+			Estimate: 2 * time.Hour, Logbook: []models.LogbookEntry{{Duration: 30 * time.Minute}}},
+		{Status: models.StatusLATER, Description: "Book dentist", SourceFile: "pages/Health.md", LineNumber: 2, Scheduled: day(12)},
+		{Status: models.StatusTODO, Description: "Due this week", SourceFile: "pages/Admin.md", LineNumber: 3, Deadline: day(7)},
+		{Status: models.StatusTODO, Description: "Budget review", SourceFile: "pages/Finance.md", LineNumber: 4, Priority: models.PriorityHigh, Estimate: 3 * time.Hour},
+		{Status: models.StatusTODO, Description: "Urgent this week", SourceFile: "pages/Finance.md", LineNumber: 5, Priority: models.PriorityHigh, Deadline: day(7)},
+		{Status: models.StatusTODO, Description: "Write the monthly report", SourceFile: "pages/Work.md", LineNumber: 6, Priority: models.PriorityHigh},
+		{Status: models.StatusDONE, Description: "Write the quarterly report", SourceFile: "pages/Work.md", LineNumber: 7, Priority: models.PriorityHigh,
+			Logbook: []models.LogbookEntry{{Duration: 4 * time.Hour}}},
+		{Status: models.StatusTODO, Description: "Review slides", SourceFile: "pages/Work.md", LineNumber: 8, Priority: models.PriorityHigh, DelegatedTo: "Sam"},
+	}
+	timeTracking := &TimeTrackingIndex{ByWeek: map[string]time.Duration{
+		"2025-11-03": 50 * time.Hour, // This week, not complete
+		"2025-10-27": 20 * time.Hour,
+		"2025-10-20": 10 * time.Hour,
+		"2025-08-25": 40 * time.Hour, // More than CapacityWeeks ago
+	}}
+
+	plan := BuildWeekPlan(tasks, BuildEffortIndex(tasks), timeTracking, now)
+
+	if !plan.WeekStart.Equal(day(10)) {
+		t.Errorf("Expected next week to start Monday Nov 10, got %s", plan.WeekStart)
+	}
+	want := []struct {
+		description string
+		reason      string
+		effort      time.Duration
+		basis       string
+	}{
+		{"File taxes", PlanOverdue, 90 * time.Minute, BasisEstimate},
+		{"Book dentist", PlanDue, 0, ""},
+		{"Budget review", PlanPriority, 3 * time.Hour, BasisEstimate},
+		{"Write the monthly report", PlanPriority, 4 * time.Hour, BasisHistory},
+	}
+	if len(plan.Tasks) != len(want) {
+		t.Fatalf("Expected %d planned tasks, got %+v", len(want), plan.Tasks)
+	}
+	for i, w := range want {
+		got := plan.Tasks[i]
+		if got.Task.Description != w.description || got.Reason != w.reason || got.Effort != w.effort || got.Basis != w.basis {
+			t.Errorf("Task %d: expected %+v, got %s/%s/%s/%s", i, w, got.Task.Description, got.Reason, got.Effort, got.Basis)
+		}
+	}
+
+	if plan.Capacity != 15*time.Hour || plan.CapacityWeeks != 2 {
+		t.Errorf("Expected 15h capacity over 2 weeks, got %s over %d", plan.Capacity, plan.CapacityWeeks)
+	}
+	if plan.Estimated != 510*time.Minute || plan.Unestimated != 1 {
+		t.Errorf("Expected 8h30m estimated and 1 unestimated, got %s and %d", plan.Estimated, plan.Unestimated)
+	}
+	// The unestimated task counts at the 2h50m average
+	if got := plan.Projected(); got != 680*time.Minute {
+		t.Errorf("Expected 11h20m projected, got %s", got)
+	}
+	if load := plan.Load(); load < 0.75 || load > 0.76 {
+		t.Errorf("Expected a load of about 0.756, got %f", load)
+	}
+}
+
+func TestBuildWeekPlan_NoHistory(t *testing.T) {
+	now := time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Budget review", SourceFile: "pages/Finance.md", LineNumber: 1, Priority: models.PriorityHigh},
+	}
+	plan := BuildWeekPlan(tasks, BuildEffortIndex(tasks), &TimeTrackingIndex{}, now)
+
+	if plan.Capacity != 0 || plan.Projected() != 0 || plan.Load() != 0 {
+		t.Errorf("Expected no capacity or projection, got %+v", plan)
+	}
+	if plan.Unestimated != 1 {
+		t.Errorf("Expected 1 unestimated task, got %d", plan.Unestimated)
+	}
+}
@@ -0,0 +1,117 @@
+package indexer
+
+import (
+	"sort"
+	"time"
+)
+
+// WritingWeek is the words written in journals in one week
+type WritingWeek struct {
+	WeekStart time.Time // Monday of the week
+	Words     int
+	Days      int // Journal days with writing
+}
+
+// WritingStats describes the journaling habit: how much is written and how regularly
+type WritingStats struct {
+	TotalWords       int
+	WritingDays      int // Journal days with at least one word of prose
+	AvgWords         int // Per writing day
+	BestDay          WritingDay
+	CurrentStreak    int           // Consecutive writing days ending today (or yesterday, if nothing is written yet today)
+	LongestStreak    int           // Longest run of consecutive writing days
+	LongestStreakEnd time.Time     // Last day of the longest streak (the most recent one on ties)
+	Weeks            []WritingWeek // Most recent first, at most writingWeeks
+}
+
+// WritingDay is the words written in one day's journal
+type WritingDay struct {
+	Date  time.Time
+	Words int
+}
+
+// writingWeeks is how many recent weeks WritingStats breaks down
+const writingWeeks = 8
+
+// ApplyWriting records the prose words written in each journal (journal
+// path -> word count, see parser.CountWords) on its timeline day and computes
+// writing statistics and streaks
+func (ti *TimelineIndex) ApplyWriting(journalWords map[string]int, now time.Time) {
+	daily := make(map[time.Time]int)
+	for path, words := range journalWords {
+		date, err := extractDateFromJournalPath(path)
+		if err != nil || words == 0 {
+			continue
+		}
+		daily[startOfDay(date)] += words
+	}
+
+	for i := range ti.Entries {
+		ti.Entries[i].WordsWritten = daily[startOfDay(ti.Entries[i].Date)]
+	}
+
+	stats := &WritingStats{}
+	weekly := make(map[time.Time]*WritingWeek)
+	for day, words := range daily {
+		stats.TotalWords += words
+		stats.WritingDays++
+		if words > stats.BestDay.Words || (words == stats.BestDay.Words && day.Before(stats.BestDay.Date)) {
+			stats.BestDay = WritingDay{Date: day, Words: words}
+		}
+
+		weekStart := startOfDay(getWeekStart(day))
+		if weekly[weekStart] == nil {
+			weekly[weekStart] = &WritingWeek{WeekStart: weekStart}
+		}
+		weekly[weekStart].Words += words
+		weekly[weekStart].Days++
+	}
+	if stats.WritingDays > 0 {
+		stats.AvgWords = stats.TotalWords / stats.WritingDays
+	}
+
+	// Recent weeks, including empty ones so gaps in the habit show
+	thisWeek := startOfDay(getWeekStart(now))
+	if stats.WritingDays > 0 {
+		for i := 0; i < writingWeeks; i++ {
+			weekStart := thisWeek.AddDate(0, 0, -7*i)
+			week := WritingWeek{WeekStart: weekStart}
+			if w := weekly[weekStart]; w != nil {
+				week = *w
+			}
+			stats.Weeks = append(stats.Weeks, week)
+		}
+	}
+
+	// Streaks: walk each run of consecutive days from its first day
+	var starts []time.Time
+	for day := range daily {
+		if daily[day.AddDate(0, 0, -1)] == 0 {
+			starts = append(starts, day)
+		}
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+	for _, start := range starts {
+		length := 1
+		end := start
+		for daily[end.AddDate(0, 0, 1)] > 0 {
+			end = end.AddDate(0, 0, 1)
+			length++
+		}
+		if length >= stats.LongestStreak {
+			stats.LongestStreak = length
+			stats.LongestStreakEnd = end
+		}
+	}
+
+	day := startOfDay(now)
+	if daily[day] == 0 {
+		day = day.AddDate(0, 0, -1) // The streak survives until today is over
+	}
+	for daily[day] > 0 {
+		stats.CurrentStreak++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	ti.Writing = stats
+}
@@ -0,0 +1,65 @@
+package indexer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestApplyWriting(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC) }
+
+	// Nov 1-3 is the longest streak; Nov 9-10 is the current one
+	journalWords := map[string]int{
+		"journals/2025_11_01.md": 100,
+		"journals/2025_11_02.md": 300,
+		"journals/2025_11_03.md": 50,
+		"journals/2025_11_06.md": 0, // Tasks only
+		"journals/2025_11_09.md": 120,
+		"journals/2025_11_10.md": 30,
+		"pages/Not a journal.md": 999,
+	}
+
+	index := &TimelineIndex{Entries: []TimelineDay{{Date: day(2)}, {Date: day(6)}}}
+	index.ApplyWriting(journalWords, time.Date(2025, 11, 11, 8, 0, 0, 0, time.Local)) // Nothing written yet today
+	w := index.Writing
+
+	if index.Entries[0].WordsWritten != 300 || index.Entries[1].WordsWritten != 0 {
+		t.Errorf("Expected 300 and 0 words on the timeline days, got %d and %d",
+			index.Entries[0].WordsWritten, index.Entries[1].WordsWritten)
+	}
+	if w.TotalWords != 600 || w.WritingDays != 5 || w.AvgWords != 120 {
+		t.Errorf("Expected 600 words over 5 days (avg 120), got %d over %d (avg %d)", w.TotalWords, w.WritingDays, w.AvgWords)
+	}
+	if w.BestDay.Words != 300 || !w.BestDay.Date.Equal(day(2)) {
+		t.Errorf("Expected best day Nov 2 with 300 words, got %+v", w.BestDay)
+	}
+	if w.CurrentStreak != 2 {
+		t.Errorf("Expected current streak 2, got %d", w.CurrentStreak)
+	}
+	if w.LongestStreak != 3 || !w.LongestStreakEnd.Equal(day(3)) {
+		t.Errorf("Expected longest streak 3 ending Nov 3, got %d ending %s", w.LongestStreak, w.LongestStreakEnd)
+	}
+
+	// Weeks start on Monday: Nov 10 (this week), Nov 3, Oct 27, ...
+	if len(w.Weeks) != writingWeeks {
+		t.Fatalf("Expected %d weeks, got %d", writingWeeks, len(w.Weeks))
+	}
+	if !w.Weeks[0].WeekStart.Equal(day(10)) || w.Weeks[0].Words != 30 || w.Weeks[0].Days != 1 {
+		t.Errorf("Expected this week to have 30 words on 1 day, got %+v", w.Weeks[0])
+	}
+	if w.Weeks[1].Words != 170 || w.Weeks[1].Days != 2 {
+		t.Errorf("Expected last week to have 170 words on 2 days, got %+v", w.Weeks[1])
+	}
+	if w.Weeks[2].Words != 400 || w.Weeks[3].Words != 0 {
+		t.Errorf("Expected 400 then 0 words in the earlier weeks, got %+v and %+v", w.Weeks[2], w.Weeks[3])
+	}
+}
+
+func TestApplyWriting_NoJournals(t *testing.T) {
+	index := &TimelineIndex{}
+	index.ApplyWriting(nil, time.Now())
+
+	if index.Writing.WritingDays != 0 || len(index.Writing.Weeks) != 0 || index.Writing.CurrentStreak != 0 {
+		t.Errorf("Expected empty writing stats, got %+v", index.Writing)
+	}
+}
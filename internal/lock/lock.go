@@ -0,0 +1,188 @@
+package lock
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileName is the lock file created inside the output directory
+const FileName = ".indexer.lock"
+
+// StaleAfter is how old a lock can get before it's considered abandoned
+// when its process can't be checked (on Windows). A lock whose process is
+// confirmed alive never goes stale, however long the run takes.
+const StaleAfter = 10 * time.Minute
+
+// retryInterval is how often Acquire re-checks a held lock while waiting
+const retryInterval = 200 * time.Millisecond
+
+// asideSeq makes the names stale locks are moved to unique within a process
+var asideSeq atomic.Int64
+
+// ErrLocked is returned when another run holds the lock and waiting timed out
+var ErrLocked = errors.New("output directory is locked by another indexer run")
+
+// Lock is a held lock on an output directory
+type Lock struct {
+	path string
+}
+
+// Acquire takes the lock on dir, waiting up to wait for another run to finish.
+// Locks left behind by crashed runs (dead PID, or older than StaleAfter when
+// the PID can't be checked) are removed, see removeStale.
+func Acquire(dir string, wait time.Duration) (*Lock, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	path := filepath.Join(dir, FileName)
+	deadline := time.Now().Add(wait)
+
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n%s\n", os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+			f.Close()
+			return &Lock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("creating lock file: %w", err)
+		}
+
+		if info, data, err := snapshot(path); err == nil && isStale(info, data) {
+			// Another waiter may remove it first; either way, retry immediately
+			if err := removeStale(path, info, data); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w (%s)", ErrLocked, holderInfo(path))
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// Release removes the lock file
+func (l *Lock) Release() error {
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing lock file: %w", err)
+	}
+	return nil
+}
+
+// Check reports whether dir holds a lock file and, if so, whether it was abandoned
+func Check(dir string) (held, stale bool) {
+	info, data, err := snapshot(filepath.Join(dir, FileName))
+	if err != nil {
+		return false, false
+	}
+	return true, isStale(info, data)
+}
+
+// removeStale removes the lock at path, judged stale from info and data.
+// Waiters that saw the same stale lock race to remove it, and the loser
+// must not remove the live lock the winner created since. So the lock is
+// first moved aside, atomically, and only removed if it's still the one
+// judged stale; otherwise it's put back.
+func removeStale(path string, info os.FileInfo, data []byte) error {
+	aside := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), asideSeq.Add(1))
+	if err := os.Rename(path, aside); err != nil {
+		if os.IsNotExist(err) {
+			return nil // Another waiter removed it first
+		}
+		return fmt.Errorf("removing stale lock file: %w", err)
+	}
+
+	movedInfo, movedData, err := snapshot(aside)
+	if err == nil && (!os.SameFile(info, movedInfo) || !bytes.Equal(data, movedData)) {
+		// A live lock, taken since it was judged: put it back. Link won't
+		// overwrite a lock a third waiter took in the gap; that run keeps it
+		if err := os.Link(aside, path); err != nil && !os.IsExist(err) {
+			return fmt.Errorf("restoring lock file: %w", err)
+		}
+	}
+	return os.Remove(aside)
+}
+
+// snapshot reads the lock file at path along with its file info, from the
+// same open file so both describe the same lock
+func snapshot(path string) (os.FileInfo, []byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, data, nil
+}
+
+// readLock parses the PID and creation time stored in a lock file
+func readLock(path string) (int, time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return parseLock(data)
+}
+
+// parseLock parses the PID and creation time from a lock file's contents
+func parseLock(data []byte) (int, time.Time, error) {
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) < 2 {
+		return 0, time.Time{}, fmt.Errorf("malformed lock file")
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("malformed lock pid: %w", err)
+	}
+	created, err := time.Parse(time.RFC3339, strings.TrimSpace(lines[1]))
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("malformed lock time: %w", err)
+	}
+
+	return pid, created, nil
+}
+
+// isStale reports whether a lock, given its file info and contents, was abandoned
+func isStale(info os.FileInfo, data []byte) bool {
+	pid, created, err := parseLock(data)
+	if err != nil {
+		// A lock being written right now can be briefly empty; only treat
+		// unreadable locks as stale once they've been around for a while
+		return time.Since(info.ModTime()) > time.Second
+	}
+
+	if alive, known := processAlive(pid); known {
+		return !alive
+	}
+	return time.Since(created) > StaleAfter
+}
+
+// holderInfo describes the process holding a lock, for error messages
+func holderInfo(path string) string {
+	pid, created, err := readLock(path)
+	if err != nil {
+		return path
+	}
+	return fmt.Sprintf("pid %d since %s; remove %s if that process is gone",
+		pid, created.Local().Format("15:04:05"), path)
+}
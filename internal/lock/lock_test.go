@@ -0,0 +1,194 @@
+package lock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// deadPID is a process ID no running process has (the largest pid_t)
+const deadPID = 1<<31 - 1
+
+func TestAcquire_Exclusive(t *testing.T) {
+	dir := t.TempDir()
+
+	l, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	// Second acquisition by a live process (us) must fail without waiting
+	if _, err := Acquire(dir, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("Expected ErrLocked, got %v", err)
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+
+	l2, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire after release failed: %v", err)
+	}
+	l2.Release()
+}
+
+func TestAcquire_WaitsForRelease(t *testing.T) {
+	dir := t.TempDir()
+
+	l, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		l.Release()
+	}()
+
+	l2, err := Acquire(dir, 2*time.Second)
+	if err != nil {
+		t.Fatalf("Expected to acquire after waiting, got %v", err)
+	}
+	l2.Release()
+}
+
+func TestAcquire_StaleLock(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+
+	// Lock left by a run that started long ago and is gone
+	old := time.Now().Add(-2 * StaleAfter).UTC().Format(time.RFC3339)
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n%s\n", deadPID, old)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Expected stale lock to be replaced, got %v", err)
+	}
+	l.Release()
+}
+
+func TestAcquire_StaleLockConcurrent(t *testing.T) {
+	for round := 0; round < 3; round++ {
+		dir := t.TempDir()
+		path := filepath.Join(dir, FileName)
+
+		old := time.Now().Add(-2 * StaleAfter).UTC().Format(time.RFC3339)
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n%s\n", deadPID, old)), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		// Waiters that all find the same stale lock must still take turns
+		var holders, maxHolders atomic.Int32
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				l, err := Acquire(dir, 5*time.Second)
+				if err != nil {
+					t.Errorf("Acquire failed: %v", err)
+					return
+				}
+				n := holders.Add(1)
+				for {
+					m := maxHolders.Load()
+					if n <= m || maxHolders.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				time.Sleep(time.Millisecond)
+				holders.Add(-1)
+				l.Release()
+			}()
+		}
+		wg.Wait()
+
+		if n := maxHolders.Load(); n != 1 {
+			t.Fatalf("Round %d: %d waiters held the lock at once", round, n)
+		}
+		if leftovers, _ := filepath.Glob(path + ".stale-*"); len(leftovers) > 0 {
+			t.Errorf("Round %d: stale locks left behind: %v", round, leftovers)
+		}
+	}
+}
+
+func TestRemoveStale_Replaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+
+	old := time.Now().Add(-2 * StaleAfter).UTC().Format(time.RFC3339)
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n%s\n", deadPID, old)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// One waiter judges the lock stale, but another takes it over first
+	info, data, err := snapshot(path)
+	if err != nil || !isStale(info, data) {
+		t.Fatalf("Expected a stale lock, got err=%v", err)
+	}
+	l, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer l.Release()
+
+	// The slower waiter must leave the new, live lock alone
+	if err := removeStale(path, info, data); err != nil {
+		t.Fatalf("removeStale failed: %v", err)
+	}
+	if held, stale := Check(dir); !held || stale {
+		t.Errorf("Expected the live lock to be kept, got held=%v stale=%v", held, stale)
+	}
+	if _, err := Acquire(dir, 0); !errors.Is(err, ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+}
+
+func TestAcquire_LongRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("processes can't be probed on Windows, so old locks go stale")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+
+	// A live run (us) that has held the lock longer than StaleAfter
+	old := time.Now().Add(-2 * StaleAfter).UTC().Format(time.RFC3339)
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%d\n%s\n", os.Getpid(), old)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Acquire(dir, 0); !errors.Is(err, ErrLocked) {
+		t.Fatalf("Expected a live lock to be kept, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	if held, _ := Check(dir); held {
+		t.Error("Expected no lock in an empty directory")
+	}
+
+	l, err := Acquire(dir, 0)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if held, stale := Check(dir); !held || stale {
+		t.Errorf("Expected a live lock, got held=%v stale=%v", held, stale)
+	}
+	l.Release()
+
+	old := time.Now().Add(-2 * StaleAfter).UTC().Format(time.RFC3339)
+	os.WriteFile(filepath.Join(dir, FileName), []byte(fmt.Sprintf("%d\n%s\n", deadPID, old)), 0644)
+	if held, stale := Check(dir); !held || !stale {
+		t.Errorf("Expected a stale lock, got held=%v stale=%v", held, stale)
+	}
+}
@@ -0,0 +1,20 @@
+//go:build !windows
+
+package lock
+
+import (
+	"os"
+	"syscall"
+)
+
+// processAlive checks whether a process with the given PID exists; known is
+// always true, as signal 0 probes it reliably
+func processAlive(pid int) (alive, known bool) {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false, true
+	}
+	err = p.Signal(syscall.Signal(0))
+	// EPERM means the process exists but belongs to another user
+	return err == nil || err == syscall.EPERM, true
+}
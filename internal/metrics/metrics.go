@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// namespace prefixes every exported metric name
+const namespace = "logseq_indexer"
+
+// Recorder collects index generation results and serves them in the
+// Prometheus text exposition format
+type Recorder struct {
+	mu           sync.Mutex
+	successes    int
+	failures     int
+	durationSum  time.Duration
+	lastDuration time.Duration
+	lastRun      time.Time
+	lastSuccess  time.Time
+	counts       map[string]int // Manifest counts from the last successful run
+}
+
+// NewRecorder creates an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{counts: make(map[string]int)}
+}
+
+// Record stores the outcome of one generation run. counts may be nil for
+// runs that wrote nothing (e.g. an empty repository).
+func (r *Recorder) Record(duration time.Duration, counts map[string]int, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastRun = time.Now()
+	r.lastDuration = duration
+	r.durationSum += duration
+
+	if err != nil {
+		r.failures++
+		return
+	}
+
+	r.successes++
+	r.lastSuccess = r.lastRun
+	if counts != nil {
+		r.counts = counts
+	}
+}
+
+// ServeHTTP writes the current metrics
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteTo(w)
+}
+
+// WriteTo writes the current metrics in Prometheus text format
+func (r *Recorder) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+
+	writeMetric(cw, "generations_total", "counter", "Index generation runs by result.",
+		sample{labels: `result="success"`, value: float64(r.successes)},
+		sample{labels: `result="failure"`, value: float64(r.failures)})
+
+	writeMetric(cw, "generation_duration_seconds", "summary", "Time spent generating indexes.",
+		sample{suffix: "_sum", value: r.durationSum.Seconds()},
+		sample{suffix: "_count", value: float64(r.successes + r.failures)})
+
+	writeMetric(cw, "last_generation_duration_seconds", "gauge", "Duration of the most recent run.",
+		sample{value: r.lastDuration.Seconds()})
+
+	writeMetric(cw, "last_run_timestamp_seconds", "gauge", "Unix time of the most recent run, 0 if none.",
+		sample{value: unixSeconds(r.lastRun)})
+
+	writeMetric(cw, "last_success_timestamp_seconds", "gauge", "Unix time of the most recent successful run, 0 if none.",
+		sample{value: unixSeconds(r.lastSuccess)})
+
+	// Counts (files, tasks, errors, warnings, ...) from the manifest
+	names := make([]string, 0, len(r.counts))
+	for name := range r.counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	samples := make([]sample, len(names))
+	for i, name := range names {
+		samples[i] = sample{labels: fmt.Sprintf("kind=%q", name), value: float64(r.counts[name])}
+	}
+	writeMetric(cw, "items", "gauge", "Counts from the last successful run (files, tasks, references, errors, ...).", samples...)
+
+	return cw.n, cw.err
+}
+
+// sample is one line of a metric family
+type sample struct {
+	suffix string // Appended to the metric name, e.g. "_sum"
+	labels string // Label pairs without braces
+	value  float64
+}
+
+// writeMetric writes a metric family's HELP, TYPE, and samples
+func writeMetric(w io.Writer, name, metricType, help string, samples ...sample) {
+	full := namespace + "_" + name
+	fmt.Fprintf(w, "# HELP %s %s\n", full, help)
+	fmt.Fprintf(w, "# TYPE %s %s\n", full, metricType)
+	for _, s := range samples {
+		if s.labels != "" {
+			fmt.Fprintf(w, "%s%s{%s} %g\n", full, s.suffix, s.labels, s.value)
+		} else {
+			fmt.Fprintf(w, "%s%s %g\n", full, s.suffix, s.value)
+		}
+	}
+}
+
+// unixSeconds converts t to fractional Unix seconds, 0 for the zero time
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / 1e9
+}
+
+// countingWriter tracks bytes written and the first error for WriteTo
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
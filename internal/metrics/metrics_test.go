@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder()
+	r.Record(2*time.Second, map[string]int{"files": 12, "errors": 1}, nil)
+	r.Record(time.Second, nil, errors.New("scan failed"))
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	output := rec.Body.String()
+
+	for _, want := range []string{
+		"# TYPE logseq_indexer_generations_total counter",
+		`logseq_indexer_generations_total{result="success"} 1`,
+		`logseq_indexer_generations_total{result="failure"} 1`,
+		"logseq_indexer_generation_duration_seconds_sum 3",
+		"logseq_indexer_generation_duration_seconds_count 2",
+		"logseq_indexer_last_generation_duration_seconds 1",
+		`logseq_indexer_items{kind="errors"} 1`,
+		`logseq_indexer_items{kind="files"} 12`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+
+	// The earlier success is still reported after a failure
+	if strings.Contains(output, "logseq_indexer_last_success_timestamp_seconds 0\n") {
+		t.Error("Expected last success timestamp to be set")
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+}
+
+func TestRecorder_NoRuns(t *testing.T) {
+	var b strings.Builder
+	NewRecorder().WriteTo(&b)
+
+	if !strings.Contains(b.String(), "logseq_indexer_last_success_timestamp_seconds 0\n") {
+		t.Errorf("Expected zero last-success timestamp, got:\n%s", b.String())
+	}
+}
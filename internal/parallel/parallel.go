@@ -0,0 +1,75 @@
+package parallel
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Result is the outcome of processing one item. Value may hold partial
+// results even when Err is set.
+type Result[R any] struct {
+	Value R
+	Err   error // Error returned by the function, or a *PanicError if it panicked
+
+	// Duration is how long the function ran on this item, for spotting slow items
+	Duration time.Duration
+}
+
+// PanicError reports a panic recovered while processing an item
+type PanicError struct {
+	Value any    // Value passed to panic
+	Stack []byte // Stack trace of the panicking goroutine
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Map runs fn over items using up to workers goroutines (runtime.NumCPU() when
+// workers < 1) and returns the results in input order. A panic or error in one
+// item is recorded in its Result and never affects the others.
+func Map[T, R any](items []T, workers int, fn func(T) (R, error)) []Result[R] {
+	results := make([]Result[R], len(items))
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i] = call(items[i], fn)
+			}
+		}()
+	}
+	for i := range items {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return results
+}
+
+// call runs fn on one item, converting a panic into a *PanicError
+func call[T, R any](item T, fn func(T) (R, error)) (result Result[R]) {
+	start := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			result = Result[R]{Err: &PanicError{Value: r, Stack: debug.Stack()}}
+		}
+		result.Duration = time.Since(start)
+	}()
+
+	value, err := fn(item)
+	return Result[R]{Value: value, Err: err}
+}
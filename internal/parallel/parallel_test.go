@@ -0,0 +1,71 @@
+package parallel
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMap(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	errOdd := errors.New("odd")
+
+	results := Map(items, 3, func(n int) (int, error) {
+		switch {
+		case n == 4:
+			panic("boom")
+		case n%2 == 1:
+			return n * 10, errOdd // Partial result alongside an error
+		}
+		return n * 10, nil
+	})
+
+	if len(results) != len(items) {
+		t.Fatalf("Expected %d results, got %d", len(items), len(results))
+	}
+	for i, n := range items {
+		r := results[i]
+		switch {
+		case n == 4:
+			var panicErr *PanicError
+			if !errors.As(r.Err, &panicErr) || panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+				t.Errorf("Item %d: expected recovered panic, got %v", n, r.Err)
+			}
+			if r.Value != 0 {
+				t.Errorf("Item %d: expected no value after panic, got %d", n, r.Value)
+			}
+		case n%2 == 1:
+			if !errors.Is(r.Err, errOdd) || r.Value != n*10 {
+				t.Errorf("Item %d: expected partial result with error, got %+v", n, r)
+			}
+		default:
+			if r.Err != nil || r.Value != n*10 {
+				t.Errorf("Item %d: expected %d, got %+v", n, n*10, r)
+			}
+		}
+	}
+}
+
+func TestMap_Empty(t *testing.T) {
+	results := Map([]string{}, 0, func(s string) (string, error) { return s, nil })
+	if len(results) != 0 {
+		t.Errorf("Expected no results, got %d", len(results))
+	}
+}
+
+func TestMap_Duration(t *testing.T) {
+	results := Map([]time.Duration{0, 20 * time.Millisecond}, 2, func(d time.Duration) (int, error) {
+		time.Sleep(d)
+		if d == 0 {
+			panic("boom")
+		}
+		return 0, nil
+	})
+
+	if results[1].Duration < 20*time.Millisecond {
+		t.Errorf("Expected the slow item's duration to be at least 20ms, got %v", results[1].Duration)
+	}
+	if results[0].Duration <= 0 || results[0].Duration >= results[1].Duration {
+		t.Errorf("Expected a panicking item to still be timed, got %v", results[0].Duration)
+	}
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// Match the :favorites vector in logseq/config.edn
+	favoritesRegex = regexp.MustCompile(`:favorites\s*\[([^\]]*)\]`)
+
+	// Match a quoted EDN string
+	ednStringRegex = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+)
+
+// ParseFavorites extracts the favorite page names from logseq/config.edn content
+// Example: :favorites ["project phoenix" "sarah chen"]
+func ParseFavorites(content string) []string {
+	// Drop ;; comments so commented-out favorites aren't picked up
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if idx := strings.Index(line, ";"); idx != -1 && !insideString(line, idx) {
+			line = line[:idx]
+		}
+		lines = append(lines, line)
+	}
+
+	match := favoritesRegex.FindStringSubmatch(strings.Join(lines, "\n"))
+	if match == nil {
+		return nil
+	}
+
+	var favorites []string
+	for _, m := range ednStringRegex.FindAllStringSubmatch(match[1], -1) {
+		if name := strings.TrimSpace(m[1]); name != "" {
+			favorites = append(favorites, name)
+		}
+	}
+	return favorites
+}
+
+// insideString reports whether position idx of line falls within a quoted string
+func insideString(line string, idx int) bool {
+	return strings.Count(line[:idx], `"`)%2 == 1
+}
+
+// ValidateEDN checks that config.edn content has balanced brackets, braces,
+// and parentheses and no unterminated string. It's a structural check, not a
+// full EDN parser, but catches the hand-editing mistakes that stop Logseq loading.
+func ValidateEDN(content string) error {
+	closers := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	type open struct {
+		char rune
+		line int
+	}
+	var stack []open
+
+	line := 1
+	inString, stringLine := false, 0
+	runes := []rune(content)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if c == '\n' {
+			line++
+		}
+
+		if inString {
+			switch c {
+			case '\\':
+				i++ // Skip the escaped character
+			case '"':
+				inString = false
+			}
+			continue
+		}
+
+		switch c {
+		case '"':
+			inString, stringLine = true, line
+		case ';':
+			// Comment to end of line
+			for i+1 < len(runes) && runes[i+1] != '\n' {
+				i++
+			}
+		case '\\':
+			i++ // Character literal, e.g. \[ or \newline
+		case '(', '[', '{':
+			stack = append(stack, open{c, line})
+		case ')', ']', '}':
+			if len(stack) == 0 {
+				return fmt.Errorf("line %d: unexpected %q", line, c)
+			}
+			top := stack[len(stack)-1]
+			if top.char != closers[c] {
+				return fmt.Errorf("line %d: %q closes %q opened on line %d", line, c, top.char, top.line)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if inString {
+		return fmt.Errorf("line %d: unterminated string", stringLine)
+	}
+	if len(stack) > 0 {
+		top := stack[len(stack)-1]
+		return fmt.Errorf("line %d: %q is never closed", top.line, top.char)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ordinalSuffixRegex matches day ordinals like "6th" in Logseq's default journal title format
+var ordinalSuffixRegex = regexp.MustCompile(`(\d+)(st|nd|rd|th)\b`)
+
+// dateLayouts are the date formats accepted in property values
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006_01_02",
+	"2006/01/02",
+	"Jan 2, 2006",     // Logseq default "MMM do, yyyy" after removing the ordinal
+	"January 2, 2006", // Long month names
+	"2006-01-02 Mon",  // Org-style timestamps, e.g. <2025-11-06 Thu>
+	"2006-01-02 Mon 15:04",
+}
+
+// ParseDate parses a date written in a property value, e.g. "2025-11-06",
+// "[[Nov 6th, 2025]]", or "<2025-11-06 Thu>". Links and brackets are stripped.
+func ParseDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	value = strings.TrimSuffix(strings.TrimPrefix(value, "[["), "]]")
+	value = strings.Trim(value, "<>[] ")
+	value = ordinalSuffixRegex.ReplaceAllString(value, "$1")
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// drawerPropertyRegex matches an org-style drawer property line, e.g. ":Effort: 0:30"
+var drawerPropertyRegex = regexp.MustCompile(`^:([\w-]+):\s*(.*)$`)
+
+// ParsePropertiesDrawer extracts the properties of a :PROPERTIES: drawer, as
+// written by org-mode and org-imported graphs. Keys are lowercased. It returns
+// the properties and the number of lines consumed; like ParseLogbook, a
+// drawer missing its :END: stops at the next bullet.
+func ParsePropertiesDrawer(lines []string, startIdx int) (map[string]string, int) {
+	if startIdx >= len(lines) || !strings.EqualFold(strings.TrimSpace(lines[startIdx]), ":PROPERTIES:") {
+		return nil, 0
+	}
+
+	properties := make(map[string]string)
+	linesConsumed := 1 // The :PROPERTIES: line
+
+	for i := startIdx + 1; i < len(lines); i++ {
+		line := strings.TrimSpace(truncateLine(lines[i]))
+		if isTaskLine(line) {
+			break
+		}
+		linesConsumed++
+
+		if strings.EqualFold(line, ":END:") {
+			break
+		}
+		if match := drawerPropertyRegex.FindStringSubmatch(line); match != nil {
+			properties[strings.ToLower(match[1])] = strings.TrimSpace(match[2])
+		}
+	}
+
+	return properties, linesConsumed
+}
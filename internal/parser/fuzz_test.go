@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// Seeds shared by the fuzz targets; `go test` runs them as regular tests
+var fuzzSeeds = []string{
+	"",
+	"- TODO [#A] Write report [[Project Alpha]] #work @Alice",
+	"- NOW Task\n  :LOGBOOK:\n  CLOCK: [2025-11-06 Thu 09:00:00]--[2025-11-06 Thu 11:30:00] =>  02:30:00\n  :END:",
+	"- DONE Task\n  completed:: 2025-11-06\n- LATER [[a]] [[b]] [[c]]",
+	"- NOW Huge\n  :LOGBOOK:\n  CLOCK: [2025-11-06 Thu 09:00:00]--[2025-11-06 Thu 11:30:00] =>  999999999:00:00\n  :END:",
+	"- NOW Broken\n  :LOGBOOK:\n  CLOCK: [2025-11-06 Thu 09:00:00]--[2025-11-06 Thu 11:30:00] =>  99999999999999999999:00:00\n- TODO Next",
+	"[[[[[[]]]]]] #[[ ]] @[[ @ #",
+	"- TODO \xff\xfe invalid utf-8 [[\xff]]",
+	":LOGBOOK:\n:LOGBOOK:\n:END:",
+}
+
+func FuzzParseTasks(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		tasks, err := ParseTasks(content, "pages/Fuzz.md")
+		if err != nil {
+			t.Fatalf("ParseTasks returned error: %v", err)
+		}
+		lineCount := strings.Count(content, "\n") + 1
+		for _, task := range tasks {
+			if task.LineNumber < 1 || task.LineNumber > lineCount {
+				t.Errorf("Line number %d outside 1..%d", task.LineNumber, lineCount)
+			}
+			if len(task.PageRefs) > MaxRefsPerLine || len(task.Tags) > MaxRefsPerLine {
+				t.Errorf("Task exceeds MaxRefsPerLine: %d refs, %d tags", len(task.PageRefs), len(task.Tags))
+			}
+			if len(task.Logbook) > MaxLogbookEntries {
+				t.Errorf("Task exceeds MaxLogbookEntries: %d", len(task.Logbook))
+			}
+			for _, entry := range task.Logbook {
+				if entry.Duration < 0 && !entry.End.Before(entry.Start) {
+					t.Errorf("Negative duration %v for forward time range", entry.Duration)
+				}
+			}
+		}
+	})
+}
+
+func FuzzParseReferences(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		refs, err := ParseReferences(content, "pages/Fuzz.md")
+		if err != nil {
+			t.Fatalf("ParseReferences returned error: %v", err)
+		}
+		perLine := make(map[int]int)
+		for _, ref := range refs {
+			if ref.TargetPage == "" {
+				t.Error("Empty target page")
+			}
+			perLine[ref.LineNumber]++
+			if perLine[ref.LineNumber] > MaxRefsPerLine {
+				t.Fatalf("Line %d exceeds MaxRefsPerLine", ref.LineNumber)
+			}
+		}
+	})
+}
+
+func FuzzParseLogbook(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed, 0)
+		f.Add(seed, 1)
+	}
+	f.Fuzz(func(t *testing.T, content string, startIdx int) {
+		lines := strings.Split(content, "\n")
+		if startIdx < 0 || startIdx > len(lines) {
+			return
+		}
+		entries, consumed := ParseLogbook(lines, startIdx)
+		if consumed < 0 || consumed > len(lines)-startIdx {
+			t.Errorf("Consumed %d lines from %d available", consumed, len(lines)-startIdx)
+		}
+		if len(entries) > MaxLogbookEntries {
+			t.Errorf("Exceeded MaxLogbookEntries: %d", len(entries))
+		}
+	})
+}
+
+func TestSafetyLimits(t *testing.T) {
+	// Overlong lines are truncated, keeping references before the cut
+	long := "- TODO [[Kept]] " + strings.Repeat("x", MaxLineLength) + " [[Dropped]]"
+	tasks, _ := ParseTasks(long, "pages/Long.md")
+	if len(tasks) != 1 || len(tasks[0].PageRefs) != 1 || tasks[0].PageRefs[0] != "Kept" {
+		t.Errorf("Expected only the reference before the cut, got %+v", tasks)
+	}
+
+	// Truncation never splits a multi-byte character
+	if line := truncateLine(strings.Repeat("x", MaxLineLength-1) + "é"); len(line) != MaxLineLength-1 {
+		t.Errorf("Expected truncation before the split character, got length %d", len(line))
+	}
+
+	// References per line are capped
+	many := strings.Repeat("[[p]] ", MaxRefsPerLine+10)
+	if refs := ExtractPageReferences(many); len(refs) != MaxRefsPerLine {
+		t.Errorf("Expected %d refs, got %d", MaxRefsPerLine, len(refs))
+	}
+
+	// Logbook entries are capped, but the whole logbook is still consumed
+	clock := "CLOCK: [2025-11-06 Thu 09:00:00]--[2025-11-06 Thu 10:00:00] =>  01:00:00"
+	lines := []string{":LOGBOOK:"}
+	for i := 0; i < MaxLogbookEntries+5; i++ {
+		lines = append(lines, clock)
+	}
+	lines = append(lines, ":END:")
+	entries, consumed := ParseLogbook(lines, 0)
+	if len(entries) != MaxLogbookEntries || consumed != len(lines) {
+		t.Errorf("Expected %d entries and %d lines consumed, got %d and %d",
+			MaxLogbookEntries, len(lines), len(entries), consumed)
+	}
+}
+
+func TestParseTasks_UnterminatedLogbook(t *testing.T) {
+	content := `- NOW First
+  :LOGBOOK:
+  CLOCK: [2025-11-06 Thu 09:00:00]--[2025-11-06 Thu 10:00:00] =>  01:00:00
+- TODO Second`
+
+	tasks, _ := ParseTasks(content, "pages/Test.md")
+	if len(tasks) != 2 {
+		t.Fatalf("Expected the task after an unterminated logbook to parse, got %d tasks", len(tasks))
+	}
+	if len(tasks[0].Logbook) != 1 {
+		t.Errorf("Expected 1 logbook entry, got %d", len(tasks[0].Logbook))
+	}
+}
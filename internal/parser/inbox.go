@@ -0,0 +1,69 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// ParseInboxItems extracts the unprocessed quick-capture items of a file:
+// every top-level block when wholePage is set (the file is the inbox page),
+// and any block tagged #tag. DONE tasks and property-only blocks count as
+// processed. An empty tag matches no blocks.
+func ParseInboxItems(content string, filePath string, wholePage bool, tag string) []models.InboxItem {
+	var items []models.InboxItem
+	for i, line := range strings.Split(content, "\n") {
+		line = truncateLine(line)
+		if !isTaskLine(line) || propertyLineRegex.MatchString(line) {
+			continue
+		}
+		if status, found := extractTaskStatus(line); found && models.IsClosed(status) {
+			continue
+		}
+
+		tagged := false
+		var links []string // Other tags
+		for _, t := range ExtractTags(line) {
+			if tag != "" && strings.EqualFold(t, tag) {
+				tagged = true
+			} else {
+				links = append(links, t)
+			}
+		}
+		if !tagged && !(wholePage && indentWidth(line) == 0) {
+			continue
+		}
+
+		var text []string
+		for _, field := range strings.Fields(strings.TrimSpace(line)[2:]) {
+			if tag != "" && (strings.EqualFold(field, "#"+tag) || strings.EqualFold(field, "#[["+tag+"]]")) {
+				continue
+			}
+			text = append(text, field)
+		}
+		if len(text) == 0 {
+			continue
+		}
+
+		item := models.InboxItem{
+			SourceFile: filePath,
+			LineNumber: i + 1,
+			Text:       strings.Join(text, " "),
+			Words:      ExtractWords(line),
+		}
+		for _, ref := range append(ExtractPageReferences(line), links...) {
+			if tag != "" && strings.EqualFold(ref, tag) {
+				continue // #[[tag]] is also a page reference
+			}
+			if date, ok := ParseDate(ref); ok {
+				if item.Date.IsZero() {
+					item.Date = date
+				}
+				continue
+			}
+			item.Links = append(item.Links, ref)
+		}
+		items = append(items, item)
+	}
+	return items
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseInboxItems(t *testing.T) {
+	content := "title:: Inbox\n" +
+		"- Look into deploy caching\n" +
+		"  - A note under it\n" +
+		"- DONE Already filed\n" +
+		"- Read about [[Kubernetes]] [[Nov 3rd, 2025]]\n"
+
+	items := ParseInboxItems(content, "pages/Inbox.md", true, "inbox")
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d: %+v", len(items), items)
+	}
+	if items[0].Text != "Look into deploy caching" || items[0].LineNumber != 2 {
+		t.Errorf("Unexpected first item: %+v", items[0])
+	}
+	if !reflect.DeepEqual(items[1].Links, []string{"Kubernetes"}) {
+		t.Errorf("Expected the date link to be left out of links, got %v", items[1].Links)
+	}
+	if want := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC); !items[1].Date.Equal(want) {
+		t.Errorf("Expected date %v, got %v", want, items[1].Date)
+	}
+}
+
+func TestParseInboxItems_Tagged(t *testing.T) {
+	content := "- Standup notes\n" +
+		"  - Idea: dashboard for deploys #inbox\n" +
+		"- TODO call about budget #[[Inbox]] #finance\n" +
+		"- DONE filed it #inbox\n"
+
+	items := ParseInboxItems(content, "journals/2025_11_01.md", false, "inbox")
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 tagged items, got %d: %+v", len(items), items)
+	}
+	if items[0].Text != "Idea: dashboard for deploys" {
+		t.Errorf("Expected the tag stripped, got %q", items[0].Text)
+	}
+	if !reflect.DeepEqual(items[1].Links, []string{"finance"}) {
+		t.Errorf("Expected other tags kept as links, got %v", items[1].Links)
+	}
+
+	if items := ParseInboxItems(content, "journals/2025_11_01.md", false, ""); len(items) != 0 {
+		t.Errorf("Expected no items without a tag, got %+v", items)
+	}
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Supported page languages (ISO 639-1 codes)
+const (
+	LanguageEnglish = "en"
+	LanguageGerman  = "de"
+)
+
+// languageMarkers are frequent function words that rarely appear in the other language
+var languageMarkers = map[string]map[string]bool{
+	LanguageEnglish: {
+		"the": true, "and": true, "is": true, "of": true, "to": true, "with": true,
+		"for": true, "that": true, "this": true, "on": true, "are": true, "it": true,
+		"we": true, "be": true, "was": true, "at": true, "by": true, "from": true,
+	},
+	LanguageGerman: {
+		"der": true, "die": true, "das": true, "und": true, "ist": true, "nicht": true,
+		"mit": true, "für": true, "ein": true, "eine": true, "auf": true, "zu": true,
+		"den": true, "dem": true, "ich": true, "wir": true, "auch": true, "von": true,
+		"sich": true, "wird": true, "sind": true, "oder": true, "noch": true, "bei": true,
+	},
+}
+
+// minLanguageMarkers is how many marker words a page needs before its language is trusted
+const minLanguageMarkers = 3
+
+// DetectLanguage guesses a page's language from its function words.
+// Returns LanguageEnglish, LanguageGerman, or "" when there isn't enough
+// text to tell (short journal entries, lists of links).
+func DetectLanguage(content string) string {
+	counts := make(map[string]int)
+	total := 0
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		for lang, markers := range languageMarkers {
+			if markers[word] {
+				counts[lang]++
+				total++
+			}
+		}
+		// Umlauts and ß are strong hints for German
+		if strings.ContainsAny(word, "äöüß") {
+			counts[LanguageGerman]++
+			total++
+		}
+	}
+
+	if total < minLanguageMarkers {
+		return ""
+	}
+
+	// Require a clear majority so mixed pages stay unknown
+	switch {
+	case counts[LanguageEnglish] > counts[LanguageGerman]*2:
+		return LanguageEnglish
+	case counts[LanguageGerman] > counts[LanguageEnglish]*2:
+		return LanguageGerman
+	}
+	return ""
+}
@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"english", "- Met with the team to review the roadmap for this quarter", LanguageEnglish},
+		{"german", "- Besprechung mit dem Team über die Planung für das nächste Quartal", LanguageGerman},
+		{"too short", "- [[Project Phoenix]]", ""},
+		{"evenly mixed", "- the and der und", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.content); got != tt.want {
+				t.Errorf("DetectLanguage() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package parser
+
+import "unicode/utf8"
+
+// Safety limits so corrupted or adversarial files can't exhaust memory or
+// stall parsing. Content past a limit is ignored rather than treated as an error.
+const (
+	// MaxLineLength is the longest line parsed, in bytes; longer lines are truncated
+	MaxLineLength = 64 * 1024
+
+	// MaxRefsPerLine caps the [[references]] and #tags taken from a single line
+	MaxRefsPerLine = 256
+
+	// MaxLogbookEntries caps the CLOCK entries kept from a single logbook
+	MaxLogbookEntries = 10000
+)
+
+// maxDurationDigits is the most digits accepted in one field of a CLOCK duration
+// (999999 hours is over a century), keeping time.Duration clear of overflow
+const maxDurationDigits = 6
+
+// truncateLine cuts a line to MaxLineLength bytes without splitting a UTF-8 character
+func truncateLine(line string) string {
+	if len(line) <= MaxLineLength {
+		return line
+	}
+	n := MaxLineLength
+	for n > 0 && !utf8.RuneStart(line[n]) {
+		n--
+	}
+	return line[:n]
+}
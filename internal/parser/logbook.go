@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -17,7 +18,8 @@ var (
 )
 
 // ParseLogbook extracts time tracking entries from a :LOGBOOK: block
-// Returns the logbook entries and the number of lines consumed
+// Returns the logbook entries (at most MaxLogbookEntries) and the number of lines consumed.
+// A logbook missing its :END: stops at the next bullet, so later blocks still parse.
 func ParseLogbook(lines []string, startIdx int) ([]models.LogbookEntry, int) {
 	var entries []models.LogbookEntry
 	linesConsumed := 0
@@ -31,7 +33,10 @@ func ParseLogbook(lines []string, startIdx int) ([]models.LogbookEntry, int) {
 
 	// Parse CLOCK entries until we hit :END:
 	for i := startIdx + 1; i < len(lines); i++ {
-		line := strings.TrimSpace(lines[i])
+		line := strings.TrimSpace(truncateLine(lines[i]))
+		if isTaskLine(line) {
+			break
+		}
 		linesConsumed++
 
 		// Check for end of logbook
@@ -40,6 +45,9 @@ func ParseLogbook(lines []string, startIdx int) ([]models.LogbookEntry, int) {
 		}
 
 		// Try to parse as CLOCK entry
+		if len(entries) >= MaxLogbookEntries {
+			continue
+		}
 		if entry, ok := parseClockLine(line); ok {
 			entries = append(entries, entry)
 		}
@@ -142,6 +150,9 @@ func parseSimpleInt(s string) (int, error) {
 	if s == "" {
 		return 0, nil
 	}
+	if len(s) > maxDurationDigits {
+		return 0, fmt.Errorf("number too long: %q", s)
+	}
 
 	result := 0
 	for _, c := range s {
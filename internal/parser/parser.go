@@ -8,11 +8,21 @@ import (
 var (
 	// Match [[page reference]] links
 	pageRefRegex = regexp.MustCompile(`\[\[([^\]]+)\]\]`)
+
+	// Match #tag and #[[multi word tag]] (a tag must start the line or follow whitespace)
+	tagRegex = regexp.MustCompile(`(?:^|\s)#(?:\[\[([^\]]+)\]\]|([^\s#\[\],.;:!?()]+))`)
+
+	// Match @Name and @[[Multi Word Name]] mentions (must start the line or follow whitespace)
+	mentionRegex = regexp.MustCompile(`(?:^|\s)@(?:\[\[([^\]]+)\]\]|([\p{L}][\p{L}\p{N}_.-]*[\p{L}\p{N}]|[\p{L}]))`)
+
+	// Match the "[[Name]] to:" delegation convention
+	delegateToRegex = regexp.MustCompile(`\[\[([^\]]+)\]\]\s+to:`)
 )
 
 // ExtractPageReferences finds all [[page]] references in a line of text
+// (at most MaxRefsPerLine, within the first MaxLineLength bytes)
 func ExtractPageReferences(line string) []string {
-	matches := pageRefRegex.FindAllStringSubmatch(line, -1)
+	matches := pageRefRegex.FindAllStringSubmatch(truncateLine(line), MaxRefsPerLine)
 
 	var refs []string
 	for _, match := range matches {
@@ -23,6 +33,38 @@ func ExtractPageReferences(line string) []string {
 	return refs
 }
 
+// ExtractTags finds all #tag and #[[tag]] references in a line of text
+// (at most MaxRefsPerLine, within the first MaxLineLength bytes)
+func ExtractTags(line string) []string {
+	matches := tagRegex.FindAllStringSubmatch(truncateLine(line), MaxRefsPerLine)
+
+	var tags []string
+	for _, match := range matches {
+		if match[1] != "" {
+			tags = append(tags, match[1])
+		} else if match[2] != "" {
+			tags = append(tags, match[2])
+		}
+	}
+	return tags
+}
+
+// ExtractDelegate finds the person a task line is delegated to, if any
+// Supports @Name, @[[Name]], and "[[Name]] to:" conventions; the first match wins.
+func ExtractDelegate(line string) string {
+	line = truncateLine(line)
+	if match := delegateToRegex.FindStringSubmatch(line); match != nil {
+		return match[1]
+	}
+	if match := mentionRegex.FindStringSubmatch(line); match != nil {
+		if match[1] != "" {
+			return match[1]
+		}
+		return match[2]
+	}
+	return ""
+}
+
 // ExtractContext returns a substring of the line for context, truncated to maxLen
 func ExtractContext(line string, maxLen int) string {
 	line = strings.TrimSpace(line)
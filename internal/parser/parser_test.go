@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -44,6 +45,53 @@ func TestParseTasks_WithPriority(t *testing.T) {
 	}
 }
 
+func TestParseTasks_ConfiguredPriorities(t *testing.T) {
+	content := `- TODO [#E] Someday nice-to-have
+- TODO [#F] Not a configured level`
+
+	// [#E] is only a priority once configured
+	tasks, _ := ParseTasks(content, "test.md")
+	if tasks[0].Priority != models.PriorityNone {
+		t.Errorf("Expected [#E] to be ignored by default, got %s", tasks[0].Priority)
+	}
+
+	models.SetPriorities([]models.Priority{"A", "B", "C", "D", "E"})
+	defer models.SetPriorities(nil)
+
+	tasks, _ = ParseTasks(content, "test.md")
+	if tasks[0].Priority != "E" {
+		t.Errorf("Expected priority E, got %s", tasks[0].Priority)
+	}
+	if tasks[0].Description != "Someday nice-to-have" {
+		t.Errorf("Priority marker not removed, got %s", tasks[0].Description)
+	}
+	if tasks[1].Priority != models.PriorityNone {
+		t.Errorf("Expected unconfigured [#F] to be ignored, got %s", tasks[1].Priority)
+	}
+}
+
+func TestParseTasks_ConfiguredStatuses(t *testing.T) {
+	content := `- WAITING [[Vendor]] quote
+- TODO Follow up`
+
+	// WAITING is only a status once configured
+	tasks, _ := ParseTasks(content, "test.md")
+	if len(tasks) != 1 {
+		t.Fatalf("Expected only the TODO by default, got %d tasks", len(tasks))
+	}
+
+	models.SetStatuses([]models.TaskStatus{"NOW", "DOING", "WAITING", "TODO", "LATER", "DONE"})
+	defer models.SetStatuses(nil)
+
+	tasks, _ = ParseTasks(content, "test.md")
+	if len(tasks) != 2 || tasks[0].Status != "WAITING" {
+		t.Fatalf("Expected a WAITING task, got %+v", tasks)
+	}
+	if tasks[0].Description != "[[Vendor]] quote" {
+		t.Errorf("Status marker not removed, got %s", tasks[0].Description)
+	}
+}
+
 func TestParseTasks_Basic(t *testing.T) {
 	content := `# Test Page
 - NOW [[Project A]] - Implement feature X
@@ -160,6 +208,50 @@ func TestParseTasks_LongDuration(t *testing.T) {
 	}
 }
 
+func TestParseTasks_NumberedAndHeadings(t *testing.T) {
+	content := `## TODO [#A] Plan the sprint
+:LOGBOOK:
+CLOCK: [2025-11-03 Mon 09:00:00]--[2025-11-03 Mon 10:00:00] =>  01:00:00
+:END:
+1. TODO Book the room
+2) DONE Send the invite
+  10. LATER Nested numbered item
+- ## DOING Heading in a bullet
+## Notes on TODO items
+3. Review what NOW means
+# Sprint`
+
+	tasks, err := ParseTasks(content, "test.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+
+	want := []struct {
+		status      models.TaskStatus
+		description string
+		line        int
+	}{
+		{models.StatusTODO, "Plan the sprint", 1},
+		{models.StatusTODO, "Book the room", 5},
+		{models.StatusDONE, "Send the invite", 6},
+		{models.StatusLATER, "Nested numbered item", 7},
+		{models.StatusDOING, "Heading in a bullet", 8},
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("Expected %d tasks, got %+v", len(want), tasks)
+	}
+	for i, w := range want {
+		task := tasks[i]
+		if task.Status != w.status || task.Description != w.description || task.LineNumber != w.line {
+			t.Errorf("Task %d: expected %s %q on line %d, got %s %q on line %d",
+				i, w.status, w.description, w.line, task.Status, task.Description, task.LineNumber)
+		}
+	}
+	if tasks[0].Priority != models.PriorityHigh || tasks[0].TotalDuration() != time.Hour {
+		t.Errorf("Expected the heading task's priority and logbook, got %+v", tasks[0])
+	}
+}
+
 func TestParseReferences(t *testing.T) {
 	content := `# Test Page
 
@@ -201,6 +293,26 @@ Another line with [[Page A]] again.
 	}
 }
 
+func TestParseReferences_TaskProject(t *testing.T) {
+	content := `- TODO [[Project X]] Review [[Page A]]
+- Notes on [[Project Y]]
+- LATER [#A] [[Project Z]]
+1. TODO [[Project N]] Numbered
+## TODO [[Project H]] Heading`
+
+	refs, err := ParseReferences(content, "journals/2025_11_06.md")
+	if err != nil {
+		t.Fatalf("ParseReferences failed: %v", err)
+	}
+
+	expected := map[string]bool{"Project X": true, "Page A": false, "Project Y": false, "Project Z": true, "Project N": true, "Project H": true}
+	for _, ref := range refs {
+		if ref.Project != expected[ref.TargetPage] {
+			t.Errorf("%s: expected Project=%v", ref.TargetPage, expected[ref.TargetPage])
+		}
+	}
+}
+
 func TestExtractPageReferences(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -318,3 +430,404 @@ func TestTaskTotalDuration(t *testing.T) {
 		t.Errorf("Expected total %v, got %v", expected, total)
 	}
 }
+
+func TestExtractTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			"No tags here",
+			[]string{},
+		},
+		{
+			"- LATER Learn Rust #someday",
+			[]string{"someday"},
+		},
+		{
+			"- TODO [#A] Priority is not a tag #work #[[Deep Work]]",
+			[]string{"work", "Deep Work"},
+		},
+		{
+			"url http://example.com/#anchor",
+			[]string{},
+		},
+	}
+
+	for i, tt := range tests {
+		result := ExtractTags(tt.input)
+		if len(result) != len(tt.expected) {
+			t.Errorf("Test %d: expected %d tags, got %d (%v)", i, len(tt.expected), len(result), result)
+			continue
+		}
+
+		for j, exp := range tt.expected {
+			if result[j] != exp {
+				t.Errorf("Test %d, tag %d: expected '%s', got '%s'", i, j, exp, result[j])
+			}
+		}
+	}
+}
+
+func TestParseFavorites(t *testing.T) {
+	content := `{:meta/version 1
+ ;; :favorites ["commented out"]
+ :default-templates {:journals ""}
+ :favorites ["project phoenix" "Sarah Chen - Tech Lead"
+             "api design; best practices"] ; trailing comment
+ :hidden []}`
+
+	favorites := ParseFavorites(content)
+	expected := []string{"project phoenix", "Sarah Chen - Tech Lead", "api design; best practices"}
+
+	if len(favorites) != len(expected) {
+		t.Fatalf("Expected %d favorites, got %d (%v)", len(expected), len(favorites), favorites)
+	}
+	for i, exp := range expected {
+		if favorites[i] != exp {
+			t.Errorf("Favorite %d: expected %q, got %q", i, exp, favorites[i])
+		}
+	}
+
+	if got := ParseFavorites("{:hidden []}"); len(got) != 0 {
+		t.Errorf("Expected no favorites, got %v", got)
+	}
+}
+
+func TestValidateEDN(t *testing.T) {
+	valid := `{:meta/version 1
+ ;; a comment with ] and "
+ :favorites ["a [b]" "say \"hi\""]
+ :hidden #{"x"}
+ :macros {:c \[}}`
+	if err := ValidateEDN(valid); err != nil {
+		t.Errorf("Expected valid EDN, got %v", err)
+	}
+
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"{:a [1 2}\n", "line 1: '}' closes '['"},
+		{"{:a 1\n :b [2]", "line 1: '{' is never closed"},
+		{"{:a 1}}", "unexpected '}'"},
+		{"{:a \"open\n}", "line 1: unterminated string"},
+	}
+	for _, tt := range tests {
+		err := ValidateEDN(tt.content)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ValidateEDN(%q) = %v, want error containing %q", tt.content, err, tt.want)
+		}
+	}
+}
+
+func TestExtractDelegate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"- TODO Review budget", ""},
+		{"- TODO Review budget @Sarah", "Sarah"},
+		{"- TODO Review budget @[[Sarah Chen]]", "Sarah Chen"},
+		{"- TODO [[Mike Ross]] to: send the contract", "Mike Ross"},
+		{"- TODO Email bob@example.com about it", ""},
+		{"- TODO Ping @J.", "J"},
+	}
+
+	for _, tt := range tests {
+		if got := ExtractDelegate(tt.input); got != tt.expected {
+			t.Errorf("ExtractDelegate(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestExtractWords(t *testing.T) {
+	content := `title:: Kubernetes Notes
+- TODO Migrate [[Payment Service]] to kubernetes #infra
+  :LOGBOOK:
+  CLOCK: [2025-04-06 Sun 10:00:00]--[2025-04-06 Sun 12:00:00] =>  02:00:00
+  :END:
+- See https://kubernetes.io/docs for the 2025-04-06 rollout`
+
+	words := ExtractWords(content)
+	joined := strings.Join(words, " ")
+
+	expected := "migrate payment service kubernetes infra rollout"
+	if joined != expected {
+		t.Errorf("Expected %q, got %q", expected, joined)
+	}
+}
+
+func TestCountWords(t *testing.T) {
+	content := `mood:: good
+- Slept badly, but the [[Payment Service]] demo went well.
+- TODO Migrate payments to kubernetes
+  SCHEDULED: <2025-04-07 Mon>
+  :LOGBOOK:
+  CLOCK: [2025-04-06 Sun 10:00:00]--[2025-04-06 Sun 12:00:00] =>  02:00:00
+  :END:
+-
+- Read https://kubernetes.io/docs - it's long`
+
+	// "Slept badly, but the [[Payment Service]] demo went well." is 9, "Read it's long" is 3
+	if got := CountWords(content); got != 12 {
+		t.Errorf("CountWords = %d, want 12", got)
+	}
+}
+
+func TestParseTasks_CompletedProperty(t *testing.T) {
+	content := `- DONE Ship release
+  completed:: [[Nov 6th, 2025]]
+  :LOGBOOK:
+  CLOCK: [2025-11-05 Wed 10:00:00]--[2025-11-05 Wed 11:00:00] =>  01:00:00
+  :END:
+- DONE Older task
+  completed:: 2025-10-01`
+
+	tasks, err := ParseTasks(content, "pages/Release.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+
+	if want := time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC); !tasks[0].CompletedAt.Equal(want) {
+		t.Errorf("Expected completed Nov 6, got %v", tasks[0].CompletedAt)
+	}
+	if len(tasks[0].Logbook) != 1 {
+		t.Errorf("Expected logbook after properties to be parsed, got %d entries", len(tasks[0].Logbook))
+	}
+	if tasks[1].CompletedAt.Month() != time.October {
+		t.Errorf("Expected completed Oct 1, got %v", tasks[1].CompletedAt)
+	}
+}
+
+func TestParseTasks_SnoozeProperty(t *testing.T) {
+	content := `- TODO Renew passport
+  snooze:: [[Nov 20th, 2025]]
+- TODO Not a date
+  snooze:: next week`
+
+	tasks, err := ParseTasks(content, "pages/Admin.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+	if want := time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC); !tasks[0].Snooze.Equal(want) {
+		t.Errorf("Expected snoozed until Nov 20, got %v", tasks[0].Snooze)
+	}
+	if !tasks[1].Snooze.IsZero() || tasks[1].Properties["snooze"] != "next week" {
+		t.Errorf("Expected an unparseable snooze kept only as a property, got %v", tasks[1].Snooze)
+	}
+}
+
+func TestParseTasks_ScheduledAndDeadline(t *testing.T) {
+	content := `- TODO Submit report
+  SCHEDULED: <2025-11-08 Sat 09:00 .+1w>
+  DEADLINE: <2025-11-10 Mon>
+  priority:: high
+  :LOGBOOK:
+  CLOCK: [2025-11-05 Wed 10:00:00]--[2025-11-05 Wed 11:00:00] =>  01:00:00
+  :END:
+- LATER Both on one line
+  SCHEDULED: <2025-11-12 Wed> DEADLINE: <2025-11-14 Fri>
+- TODO No dates`
+
+	tasks, err := ParseTasks(content, "pages/Report.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(tasks))
+	}
+
+	if tasks[0].Scheduled.Day() != 8 || tasks[0].Deadline.Day() != 10 {
+		t.Errorf("Expected scheduled Nov 8 and deadline Nov 10, got %v / %v", tasks[0].Scheduled, tasks[0].Deadline)
+	}
+	if len(tasks[0].Logbook) != 1 {
+		t.Errorf("Expected logbook after planning lines to be parsed, got %d entries", len(tasks[0].Logbook))
+	}
+	if due := tasks[1].DueDate(); due.Day() != 14 {
+		t.Errorf("Expected due date to prefer the deadline, got %v", due)
+	}
+	if !tasks[2].DueDate().IsZero() {
+		t.Errorf("Expected no due date, got %v", tasks[2].DueDate())
+	}
+}
+
+func TestParseTasks_LogbookLaterInBlock(t *testing.T) {
+	content := "- TODO Parent task\n" +
+		"  Some notes about the task\n" +
+		"  id:: 6543a1b2-0000-0000-0000-000000000000\n" +
+		"\t- Child note\n" +
+		"\t- TODO Child task\n" +
+		"\t  :LOGBOOK:\n" +
+		"\t  CLOCK: [2025-11-05 Wed 09:00:00]--[2025-11-05 Wed 09:30:00] =>  00:30:00\n" +
+		"\t  :END:\n" +
+		"  :LOGBOOK:\n" +
+		"  CLOCK: [2025-11-05 Wed 10:00:00]--[2025-11-05 Wed 12:00:00] =>  02:00:00\n" +
+		"  :END:\n" +
+		"- TODO Sibling without a logbook\n" +
+		"  more text\n" +
+		"- TODO Last"
+
+	tasks, err := ParseTasks(content, "pages/Work.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 4 {
+		t.Fatalf("Expected 4 tasks, got %d", len(tasks))
+	}
+
+	if got := tasks[0].TotalDuration(); got != 2*time.Hour {
+		t.Errorf("Expected the parent's own 2h drawer, got %v", got)
+	}
+	if got := tasks[1].TotalDuration(); got != 30*time.Minute {
+		t.Errorf("Expected the child's 30m drawer, got %v", got)
+	}
+	if len(tasks[2].Logbook) != 0 || len(tasks[3].Logbook) != 0 {
+		t.Errorf("Expected siblings without logbooks, got %v and %v", tasks[2].Logbook, tasks[3].Logbook)
+	}
+}
+
+func TestParseTasks_ParentLine(t *testing.T) {
+	content := "- TODO Epic\n" +
+		"  notes\n" +
+		"  - TODO Subtask\n" +
+		"    - DONE Sub-subtask\n" +
+		"  - Plain bullet\n" +
+		"    - TODO Under a plain bullet\n" +
+		"- Unrelated\n" +
+		"  - TODO Not nested in a task\n" +
+		"1. TODO Numbered\n" +
+		"\t- LATER Tab-indented child"
+
+	tasks, err := ParseTasks(content, "pages/Epic.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+
+	want := map[string]int{
+		"Epic":                 0,
+		"Subtask":              1,
+		"Sub-subtask":          3,
+		"Under a plain bullet": 1,
+		"Not nested in a task": 0,
+		"Numbered":             0,
+		"Tab-indented child":   9,
+	}
+	if len(tasks) != len(want) {
+		t.Fatalf("Expected %d tasks, got %d", len(want), len(tasks))
+	}
+	for _, task := range tasks {
+		if task.ParentLine != want[task.Description] {
+			t.Errorf("%q: expected parent line %d, got %d", task.Description, want[task.Description], task.ParentLine)
+		}
+	}
+}
+
+func TestParseTasks_PropertiesDrawer(t *testing.T) {
+	content := `* TODO Imported from org
+  SCHEDULED: <2025-11-08 Sat>
+  :PROPERTIES:
+  :CATEGORY: Work
+  :Effort:   0:45
+  :CUSTOM_ID: imported-1
+  :END:
+  :LOGBOOK:
+  CLOCK: [2025-11-05 Wed 10:00:00]--[2025-11-05 Wed 11:00:00] =>  01:00:00
+  :END:
+  owner:: Sam
+* TODO Unterminated drawer
+  :PROPERTIES:
+  :CATEGORY: Home
+* TODO Next task`
+
+	tasks, err := ParseTasks(content, "pages/Imported.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("Expected 3 tasks, got %d", len(tasks))
+	}
+
+	task := tasks[0]
+	want := map[string]string{"category": "Work", "effort": "0:45", "custom_id": "imported-1"}
+	for key, value := range want {
+		if task.Properties[key] != value {
+			t.Errorf("Expected property %s = %q, got %q", key, value, task.Properties[key])
+		}
+	}
+	if task.Estimate != 45*time.Minute {
+		t.Errorf("Expected the org Effort to set the estimate, got %v", task.Estimate)
+	}
+	if task.Scheduled.Day() != 8 || len(task.Logbook) != 1 {
+		t.Errorf("Expected planning line and logbook around the drawer, got %v / %d entries", task.Scheduled, len(task.Logbook))
+	}
+
+	if tasks[1].Properties["category"] != "Home" {
+		t.Errorf("Expected unterminated drawer to be parsed, got %v", tasks[1].Properties)
+	}
+	if tasks[2].Description != "Next task" || tasks[2].Properties != nil {
+		t.Errorf("Expected the next task to be unaffected, got %+v", tasks[2])
+	}
+}
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"20m", 20 * time.Minute, true},
+		{"1h30m", 90 * time.Minute, true},
+		{"2h 30m", 150 * time.Minute, true},
+		{"1.5 hours", 90 * time.Minute, true},
+		{"45 min", 45 * time.Minute, true},
+		{"45", 45 * time.Minute, true},
+		{"1:30", 90 * time.Minute, true},
+		{"", 0, false},
+		{"soon", 0, false},
+		{"-5m", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseEstimate(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ParseEstimate(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseTasks_EstimateProperty(t *testing.T) {
+	content := `- TODO Reply to Sam
+  estimate:: 10m
+- TODO Write design doc
+  Effort:: 3h`
+
+	tasks, err := ParseTasks(content, "pages/Work.md")
+	if err != nil {
+		t.Fatalf("ParseTasks failed: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Fatalf("Expected 2 tasks, got %d", len(tasks))
+	}
+	if tasks[0].Estimate != 10*time.Minute || tasks[1].Estimate != 3*time.Hour {
+		t.Errorf("Expected estimates 10m and 3h, got %v and %v", tasks[0].Estimate, tasks[1].Estimate)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	for _, value := range []string{"2025-11-06", "2025_11_06", "[[Nov 6th, 2025]]", "November 6, 2025", "<2025-11-06 Thu>"} {
+		date, ok := ParseDate(value)
+		if !ok || date.Format("2006-01-02") != "2025-11-06" {
+			t.Errorf("ParseDate(%q) = %v, %v", value, date, ok)
+		}
+	}
+	if _, ok := ParseDate("next week"); ok {
+		t.Error("Expected invalid date to fail")
+	}
+}
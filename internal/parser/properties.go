@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// ParsePageTags returns the values of a page's tags:: property.
+// Page properties are the property lines at the very top of the file, before any other content.
+func ParsePageTags(content string) []string {
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !propertyLineRegex.MatchString(line) {
+			break
+		}
+
+		key, value, _ := strings.Cut(strings.TrimLeft(trimmed, "-*+ "), "::")
+		if strings.EqualFold(strings.TrimSpace(key), "tags") {
+			return splitPropertyValues(value)
+		}
+	}
+	return nil
+}
+
+// splitPropertyValues splits a comma-separated property value, stripping [[links]] and #tags
+func splitPropertyValues(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		part = strings.TrimPrefix(part, "#")
+		part = strings.TrimSuffix(strings.TrimPrefix(part, "[["), "]]")
+		if part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// InsertPageTags adds a tags:: page property to the top of content.
+// Multi-word tags are written as [[links]] so Logseq treats them as single pages.
+func InsertPageTags(content string, tags []string) string {
+	formatted := make([]string, len(tags))
+	for i, tag := range tags {
+		if strings.ContainsAny(tag, " ,") {
+			formatted[i] = "[[" + tag + "]]"
+		} else {
+			formatted[i] = tag
+		}
+	}
+	return "tags:: " + strings.Join(formatted, ", ") + "\n" + content
+}
+
+// ParseProperties extracts every block with key:: properties, including
+// :PROPERTIES: drawers, plus the page properties at the top of the file
+// (as in ParsePageTags). Properties inside code fences are ignored.
+func ParseProperties(content string, filePath string) []models.PropertyBlock {
+	var blocks []models.PropertyBlock
+	var current *models.PropertyBlock
+	lines := strings.Split(content, "\n")
+	inPageProperties := true
+	inCode := false
+
+	flush := func() {
+		if current != nil && len(current.Properties) > 0 {
+			blocks = append(blocks, *current)
+		}
+		current = nil
+	}
+	set := func(key, value string) {
+		if current.Properties == nil {
+			current.Properties = make(map[string]string)
+		}
+		current.Properties[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := truncateLine(lines[i])
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(strings.TrimLeft(trimmed, "-*+ "), "```") {
+			inCode = !inCode
+		}
+		if inCode {
+			continue
+		}
+
+		if inPageProperties {
+			if trimmed == "" {
+				continue
+			}
+			if propertyLineRegex.MatchString(line) {
+				if current == nil {
+					current = &models.PropertyBlock{SourceFile: filePath, LineNumber: i + 1, Page: true}
+				}
+				key, value, _ := strings.Cut(strings.TrimLeft(trimmed, "-*+ "), "::")
+				set(key, value)
+				continue
+			}
+			inPageProperties = false
+			flush()
+		}
+
+		switch {
+		case isTaskLine(line):
+			flush()
+			text := strings.TrimSpace(trimmed[2:])
+			current = &models.PropertyBlock{SourceFile: filePath, LineNumber: i + 1}
+			if propertyLineRegex.MatchString(line) {
+				key, value, _ := strings.Cut(text, "::")
+				set(key, value)
+			} else {
+				current.Text = text
+			}
+		case current == nil:
+			// Text before the first bullet belongs to no block
+		case propertyLineRegex.MatchString(line):
+			key, value, _ := strings.Cut(trimmed, "::")
+			set(key, value)
+		default:
+			if properties, consumed := ParsePropertiesDrawer(lines, i); consumed > 0 {
+				for key, value := range properties {
+					set(key, value)
+				}
+				i += consumed - 1
+			}
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// PropertyValues splits a property value into the values it stands for:
+// "[[Phoenix]]" is Phoenix, and comma-separated lists are split when the key
+// is tags or alias or every item is a [[link]] or #tag. Other values, such as
+// prose containing commas, are kept whole.
+func PropertyValues(key, value string) []string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+
+	list := strings.EqualFold(key, "tags") || strings.EqualFold(key, "alias")
+	if !list {
+		list = true
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+			isLink := strings.HasPrefix(part, "[[") && strings.HasSuffix(part, "]]")
+			isTag := strings.HasPrefix(part, "#") && !strings.ContainsAny(part, " \t")
+			if !isLink && !isTag {
+				list = false
+				break
+			}
+		}
+	}
+	if !list {
+		return []string{value}
+	}
+	return splitPropertyValues(value)
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePageTags(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{"plain list", "tags:: backend, infra\n- First block", []string{"backend", "infra"}},
+		{"links and hashtags", "alias:: Phoenix\ntags:: [[Project Work]], #golang\n\n- Notes", []string{"Project Work", "golang"}},
+		{"first block property", "- tags:: planning\n- Notes", []string{"planning"}},
+		{"no tags", "- Just notes about tags:: inline", nil},
+		{"tags after content", "- Notes\ntags:: late", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParsePageTags(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePageTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInsertPageTags(t *testing.T) {
+	content := "- First block\n"
+	got := InsertPageTags(content, []string{"backend", "Project Work"})
+	want := "tags:: backend, [[Project Work]]\n- First block\n"
+	if got != want {
+		t.Errorf("InsertPageTags() = %q, want %q", got, want)
+	}
+
+	if tags := ParsePageTags(got); !reflect.DeepEqual(tags, []string{"backend", "Project Work"}) {
+		t.Errorf("Inserted tags don't round-trip, got %v", tags)
+	}
+}
+
+func TestParseProperties(t *testing.T) {
+	content := "project:: [[Phoenix]]\ntype:: project\n\n- Design review\n  status:: active\n  Owner:: Ana\n- Plain block\n- status:: done\n- ```\n  status:: ignored\n  ```\n- Imported\n  :PROPERTIES:\n  :Effort: 0:30\n  :END:\n"
+	blocks := ParseProperties(content, "pages/Phoenix.md")
+
+	if len(blocks) != 4 {
+		t.Fatalf("Expected 4 property blocks, got %+v", blocks)
+	}
+
+	page := blocks[0]
+	if !page.Page || page.LineNumber != 1 || page.Properties["project"] != "[[Phoenix]]" || page.Properties["type"] != "project" {
+		t.Errorf("Unexpected page properties %+v", page)
+	}
+
+	review := blocks[1]
+	if review.Page || review.LineNumber != 4 || review.Text != "Design review" {
+		t.Errorf("Unexpected block %+v", review)
+	}
+	if !reflect.DeepEqual(review.Properties, map[string]string{"status": "active", "owner": "Ana"}) {
+		t.Errorf("Expected lowercase keys, got %v", review.Properties)
+	}
+
+	if inline := blocks[2]; inline.LineNumber != 8 || inline.Text != "" || inline.Properties["status"] != "done" {
+		t.Errorf("Expected property-only bullet, got %+v", inline)
+	}
+	if drawer := blocks[3]; drawer.Text != "Imported" || drawer.Properties["effort"] != "0:30" {
+		t.Errorf("Expected drawer properties, got %+v", drawer)
+	}
+}
+
+func TestPropertyValues(t *testing.T) {
+	tests := []struct {
+		key, value string
+		want       []string
+	}{
+		{"status", "active", []string{"active"}},
+		{"project", "[[Phoenix]]", []string{"Phoenix"}},
+		{"related", "[[Phoenix]], #infra", []string{"Phoenix", "infra"}},
+		{"tags", "backend, [[Project Work]]", []string{"backend", "Project Work"}},
+		{"summary", "Fast, cheap, good", []string{"Fast, cheap, good"}},
+		{"status", "  ", nil},
+	}
+
+	for _, tt := range tests {
+		if got := PropertyValues(tt.key, tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PropertyValues(%q, %q) = %v, want %v", tt.key, tt.value, got, tt.want)
+		}
+	}
+}
@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"path/filepath"
 	"strings"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
@@ -18,13 +17,17 @@ func ParseReferences(content string, filePath string) ([]models.PageReference, e
 		// Find all page references in this line
 		pageRefs := ExtractPageReferences(line)
 
-		for _, targetPage := range pageRefs {
+		// The first reference on a task line names the task's project
+		_, isTask := taskStatus(line)
+
+		for j, targetPage := range pageRefs {
 			refs = append(refs, models.PageReference{
 				SourceFile: filePath,
 				SourcePage: sourcePage,
 				TargetPage: targetPage,
 				LineNumber: i + 1, // 1-indexed
 				Context:    ExtractContext(line, 100),
+				Project:    isTask && j == 0,
 			})
 		}
 	}
@@ -37,6 +40,5 @@ func ParseReferences(content string, filePath string) ([]models.PageReference, e
 //   journals/2025_04_06.md -> "2025_04_06"
 //   pages/Hearth Insights.md -> "Hearth Insights"
 func extractPageNameFromPath(filePath string) string {
-	base := filepath.Base(filePath)
-	return strings.TrimSuffix(base, ".md")
+	return models.PageName(filePath)
 }
@@ -2,12 +2,15 @@ package parser
 
 import (
 	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
 )
 
-// Task status markers in Logseq
+// Task status markers in Logseq, in the order they're matched
 var taskStatuses = []models.TaskStatus{
 	models.StatusNOW,
 	models.StatusLATER,
@@ -16,21 +19,49 @@ var taskStatuses = []models.TaskStatus{
 	models.StatusDONE,
 }
 
-// ParseTasks extracts all tasks from markdown content
+// matchedStatuses returns the built-in markers followed by any custom ones
+// configured with models.SetStatuses
+func matchedStatuses() []models.TaskStatus {
+	matched := taskStatuses
+	for _, status := range models.Statuses() {
+		if !slices.Contains(taskStatuses, status) {
+			matched = append(matched[:len(matched):len(matched)], status)
+		}
+	}
+	return matched
+}
+
+// planningRegex matches Logseq's SCHEDULED: and DEADLINE: timestamps, e.g.
+// "DEADLINE: <2025-11-10 Mon>" or "SCHEDULED: <2025-11-10 Mon 09:00 .+1w>"
+var planningRegex = regexp.MustCompile(`(SCHEDULED|DEADLINE):\s*<(\d{4}-\d{2}-\d{2})[^>]*>`)
+
+// openTask is a task whose block encloses the lines being parsed
+type openTask struct {
+	indent int
+	line   int
+}
+
+// ParseTasks extracts all tasks from markdown content. A task nested in
+// another task's block, directly or under plain bullets, records that
+// task's line as its ParentLine.
 func ParseTasks(content string, filePath string) ([]models.Task, error) {
 	var tasks []models.Task
+	var enclosing []openTask // Outermost first
 	lines := strings.Split(content, "\n")
 
 	for i := 0; i < len(lines); i++ {
-		line := lines[i]
+		line := truncateLine(lines[i])
 
-		// Check if line is a bullet point (task candidate)
-		if !isTaskLine(line) {
-			continue
+		// A line no deeper than a task's bullet ends that task's block
+		if strings.TrimSpace(line) != "" {
+			indent := indentWidth(line)
+			for len(enclosing) > 0 && enclosing[len(enclosing)-1].indent >= indent {
+				enclosing = enclosing[:len(enclosing)-1]
+			}
 		}
 
-		// Check for task status marker
-		status, found := extractTaskStatus(line)
+		// Check for a task status marker on a bullet, numbered item, or heading
+		status, found := taskStatus(line)
 		if !found {
 			continue
 		}
@@ -44,21 +75,63 @@ func ParseTasks(content string, filePath string) ([]models.Task, error) {
 		// Extract page references
 		pageRefs := ExtractPageReferences(line)
 
+		// Extract #tags
+		tags := ExtractTags(line)
+
 		// Create task
 		task := models.Task{
 			Status:      status,
 			Priority:    priority,
 			Description: description,
 			PageRefs:    pageRefs,
+			Tags:        tags,
+			DelegatedTo: ExtractDelegate(line),
 			SourceFile:  filePath,
 			LineNumber:  i + 1, // 1-indexed
 		}
+		if len(enclosing) > 0 {
+			task.ParentLine = enclosing[len(enclosing)-1].line
+		}
+		enclosing = append(enclosing, openTask{indent: indentWidth(line), line: task.LineNumber})
+
+		// SCHEDULED/DEADLINE lines, properties (e.g. completed:: 2025-11-06),
+		// and :PROPERTIES: drawers sit directly under the task line
+		for i+1 < len(lines) {
+			next := truncateLine(lines[i+1])
+			if planning := planningRegex.FindAllStringSubmatch(next, -1); planning != nil && !isTaskLine(next) {
+				for _, match := range planning {
+					date, err := time.Parse("2006-01-02", match[2])
+					if err != nil {
+						continue
+					}
+					if match[1] == "DEADLINE" {
+						task.Deadline = date
+					} else {
+						task.Scheduled = date
+					}
+				}
+			} else if isTaskPropertyLine(next) {
+				key, value, _ := strings.Cut(strings.TrimSpace(next), "::")
+				setTaskProperty(&task, key, value)
+			} else if properties, consumed := ParsePropertiesDrawer(lines, i+1); consumed > 0 {
+				for key, value := range properties {
+					setTaskProperty(&task, key, value)
+				}
+				i += consumed - 1 // The loop skips the last line
+			} else {
+				break
+			}
+			i++
+		}
 
 		// Check if next line starts a logbook
 		if i+1 < len(lines) && strings.Contains(lines[i+1], ":LOGBOOK:") {
 			logbook, consumed := ParseLogbook(lines, i+1)
 			task.Logbook = logbook
 			i += consumed // Skip past the logbook lines
+		} else if start := findBlockLogbook(lines, i); start >= 0 {
+			// Lines before the drawer may hold child tasks, so they aren't skipped
+			task.Logbook, _ = ParseLogbook(lines, start)
 		}
 
 		tasks = append(tasks, task)
@@ -67,6 +140,114 @@ func ParseTasks(content string, filePath string) ([]models.Task, error) {
 	return tasks, nil
 }
 
+// setTaskProperty records a block property on a task, filling in the fields
+// that properties with a known meaning map to
+func setTaskProperty(task *models.Task, key, value string) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.TrimSpace(value)
+	if task.Properties == nil {
+		task.Properties = make(map[string]string)
+	}
+	task.Properties[key] = value
+
+	switch key {
+	case "completed":
+		if date, ok := ParseDate(value); ok {
+			task.CompletedAt = date
+		}
+	case "estimate", "effort":
+		if estimate, ok := ParseEstimate(value); ok {
+			task.Estimate = estimate
+		}
+	case "snooze":
+		if date, ok := ParseDate(value); ok {
+			task.Snooze = date
+		}
+	}
+}
+
+// estimateUnits normalises the unit words people write in estimates to Go duration units
+var estimateUnits = strings.NewReplacer(
+	"hours", "h", "hour", "h", "hrs", "h", "hr", "h",
+	"minutes", "m", "minute", "m", "mins", "m", "min", "m",
+)
+
+// ParseEstimate parses a task estimate such as "20m", "1h30m", "2h 30m",
+// "1.5 hours", a bare number of minutes ("45"), or org-mode's H:MM ("0:30")
+func ParseEstimate(value string) (time.Duration, bool) {
+	value = strings.ToLower(strings.Join(strings.Fields(value), ""))
+	if hours, minutes, found := strings.Cut(value, ":"); found {
+		value = hours + "h" + minutes + "m"
+	}
+	value = estimateUnits.Replace(value)
+	if value == "" {
+		return 0, false
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		value += "m"
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// findBlockLogbook finds a :LOGBOOK: drawer further down the block starting
+// at taskIdx, after continuation lines or child bullets. A drawer indented
+// deeper than a child bullet belongs to that child and is skipped. It returns
+// the drawer's line index, or -1 if the block has none.
+func findBlockLogbook(lines []string, taskIdx int) int {
+	taskIndent := indentWidth(lines[taskIdx])
+	childIndent := -1 // Shallowest child bullet seen so far
+
+	for j := taskIdx + 1; j < len(lines); j++ {
+		line := truncateLine(lines[j])
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := indentWidth(line)
+		if indent <= taskIndent {
+			return -1 // Next sibling or parent: the block has ended
+		}
+		if isTaskLine(line) {
+			if childIndent < 0 || indent < childIndent {
+				childIndent = indent
+			}
+			continue
+		}
+		if strings.Contains(line, ":LOGBOOK:") && (childIndent < 0 || indent <= childIndent) {
+			return j
+		}
+	}
+	return -1
+}
+
+// indentWidth measures leading whitespace, counting a tab as two spaces so
+// Logseq's tab-indented children line up with its two-space block bodies
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 2
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// isTaskPropertyLine checks if a line is a key:: value property belonging to
+// the block above it (not a bullet of its own)
+func isTaskPropertyLine(line string) bool {
+	return propertyLineRegex.MatchString(line) && !isTaskLine(line)
+}
+
 // isTaskLine checks if a line looks like a task (starts with bullet)
 func isTaskLine(line string) bool {
 	trimmed := strings.TrimSpace(line)
@@ -77,9 +258,29 @@ func isTaskLine(line string) bool {
 		strings.HasPrefix(trimmed, "+ ")
 }
 
+// numberedOrHeadingRegex matches a numbered list item ("1. ", "2) ") or a
+// heading ("## ") opening with an uppercase word, the candidate status marker
+var numberedOrHeadingRegex = regexp.MustCompile(`^\s*(?:\d{1,9}[.)]|#{1,6}) +([A-Z]+) `)
+
+// taskStatus returns the status of a task line: a bullet with a status
+// marker, or a numbered item or heading opening with one ("1. TODO ...",
+// "## TODO ..."). Those two only count when the marker comes first, as
+// headings and numbered items outside a bullet are usually plain text.
+func taskStatus(line string) (models.TaskStatus, bool) {
+	if isTaskLine(line) {
+		return extractTaskStatus(line)
+	}
+	match := numberedOrHeadingRegex.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	status := models.TaskStatus(match[1])
+	return status, slices.Contains(matchedStatuses(), status)
+}
+
 // extractTaskStatus finds the task status marker in a line
 func extractTaskStatus(line string) (models.TaskStatus, bool) {
-	for _, status := range taskStatuses {
+	for _, status := range matchedStatuses() {
 		// Look for "- NOW " or "- LATER " etc.
 		marker := string(status) + " "
 		if strings.Contains(line, marker) {
@@ -90,12 +291,13 @@ func extractTaskStatus(line string) (models.TaskStatus, bool) {
 }
 
 // extractPriority extracts the priority marker from a task line
-// Matches [#A], [#B], [#C] patterns
+// Matches [#A]-style markers for the configured priority levels (see models.SetPriorities)
 func extractPriority(line string) models.Priority {
-	re := regexp.MustCompile(`\[#([ABC])\]`)
-	matches := re.FindStringSubmatch(line)
-	if len(matches) > 1 {
-		return models.Priority(matches[1])
+	re := regexp.MustCompile(`\[#([A-Z])\]`)
+	for _, matches := range re.FindAllStringSubmatch(line, -1) {
+		if priority := models.Priority(matches[1]); models.IsPriority(priority) {
+			return priority
+		}
 	}
 	return models.PriorityNone
 }
@@ -0,0 +1,125 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	// Match URLs so their fragments don't become keywords
+	urlRegex = regexp.MustCompile(`https?://\S+`)
+
+	// Match block property lines (key:: value)
+	propertyLineRegex = regexp.MustCompile(`^\s*(?:[-*+]\s+)?[\w-]+::`)
+)
+
+// stopWords are common English words that carry no topical signal
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "are": true, "but": true, "not": true,
+	"you": true, "all": true, "any": true, "can": true, "had": true, "her": true,
+	"was": true, "one": true, "our": true, "out": true, "has": true, "have": true,
+	"his": true, "how": true, "its": true, "may": true, "new": true, "now": true,
+	"see": true, "two": true, "who": true, "did": true, "get": true, "him": true,
+	"let": true, "say": true, "she": true, "too": true, "use": true, "that": true,
+	"with": true, "this": true, "from": true, "they": true, "will": true, "would": true,
+	"there": true, "their": true, "what": true, "about": true, "which": true, "when": true,
+	"make": true, "like": true, "time": true, "just": true, "know": true, "take": true,
+	"into": true, "your": true, "some": true, "could": true, "them": true, "than": true,
+	"then": true, "only": true, "come": true, "over": true, "also": true, "back": true,
+	"after": true, "first": true, "well": true, "even": true, "want": true, "because": true,
+	"these": true, "most": true, "need": true, "should": true, "been": true, "were": true,
+	"more": true, "very": true, "here": true, "where": true, "does": true, "done": true,
+	"todo": true, "later": true, "doing": true, "logbook": true, "end": true, "clock": true,
+	"properties": true,
+}
+
+// germanStopWords are common German words, so mixed-language graphs get useful keywords
+var germanStopWords = map[string]bool{
+	"der": true, "die": true, "das": true, "und": true, "ist": true, "nicht": true,
+	"mit": true, "für": true, "ein": true, "eine": true, "einen": true, "einem": true,
+	"auf": true, "den": true, "dem": true, "des": true, "ich": true, "wir": true,
+	"auch": true, "von": true, "sich": true, "wird": true, "sind": true, "oder": true,
+	"aber": true, "noch": true, "bei": true, "nach": true, "über": true, "unter": true,
+	"wie": true, "was": true, "wenn": true, "dann": true, "zum": true, "zur": true,
+	"vom": true, "als": true, "aus": true, "durch": true, "hat": true, "haben": true,
+	"war": true, "werden": true, "kann": true, "muss": true, "soll": true, "mehr": true,
+	"sehr": true, "schon": true, "nur": true, "jetzt": true, "hier": true, "dass": true,
+}
+
+// ExtractWords returns the lowercase content words of a page for keyword analysis.
+// Task markers, logbook drawers, property lines, URLs, and stop words are skipped,
+// and [[page links]] contribute their words like plain text.
+func ExtractWords(content string) []string {
+	var words []string
+	inDrawer := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Skip :LOGBOOK: / :PROPERTIES: drawers entirely
+		if strings.HasPrefix(trimmed, ":") && strings.HasSuffix(trimmed, ":") && len(trimmed) > 2 {
+			inDrawer = !strings.EqualFold(trimmed, ":END:")
+			continue
+		}
+		if inDrawer || propertyLineRegex.MatchString(line) {
+			continue
+		}
+
+		line = urlRegex.ReplaceAllString(line, " ")
+
+		for _, word := range strings.FieldsFunc(line, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-'
+		}) {
+			word = strings.ToLower(strings.Trim(word, "-"))
+			if len([]rune(word)) < 3 || stopWords[word] || germanStopWords[word] || isNumeric(word) {
+				continue
+			}
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
+
+// isNumeric checks if a word is made only of digits and dashes (dates, numbers)
+func isNumeric(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// CountWords counts the words written in prose blocks, for writing statistics.
+// Task lines with their SCHEDULED/DEADLINE lines, drawers, and property lines
+// are skipped; unlike ExtractWords, every word counts, stop words included.
+func CountWords(content string) int {
+	count := 0
+	inDrawer := false
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ":") && strings.HasSuffix(trimmed, ":") && len(trimmed) > 2 {
+			inDrawer = !strings.EqualFold(trimmed, ":END:")
+			continue
+		}
+		if inDrawer || propertyLineRegex.MatchString(line) || planningRegex.MatchString(trimmed) {
+			continue
+		}
+		if _, found := taskStatus(line); found {
+			continue
+		}
+
+		line = urlRegex.ReplaceAllString(line, " ")
+		for _, word := range strings.Fields(line) {
+			if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+				count++
+			}
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,271 @@
+// Package scaffold sets a Logseq repository up for the indexer in one step:
+// an example config, the output directory, a git hook, a CLAUDE.md section,
+// and Claude command files, all rendered from templates embedded in the binary.
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/config"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// hookBinary is the command the installed hook runs
+const hookBinary = "logseq-claude-indexer"
+
+// CLAUDE.md section markers, so later runs update the section in place
+const (
+	sectionStart = "<!-- logseq-claude-indexer:start -->"
+	sectionEnd   = "<!-- logseq-claude-indexer:end -->"
+)
+
+// CommandsDir holds the Claude command files, relative to the repository
+const CommandsDir = ".claude/commands"
+
+// Options says where to scaffold and what to leave alone
+type Options struct {
+	RepoPath  string // Absolute path to the Logseq repository
+	OutputDir string // Index directory relative to RepoPath, as written into the hook and CLAUDE.md
+	Profile   string // --profile for the hook's generate run; empty for the default
+	Force     bool   // Overwrite an existing config and command files
+	NoHook    bool   // Don't install the git hook
+}
+
+// Action is what a Step did
+type Action string
+
+const (
+	Created Action = "created"
+	Updated Action = "updated"
+	Skipped Action = "skipped"
+)
+
+// Step is one file or directory Init set up
+type Step struct {
+	Path   string // Relative to the repository
+	Action Action
+	Detail string // Why it was skipped or how it was updated, "" otherwise
+}
+
+// ExampleConfig returns the commented example .logseq-indexer.yml
+func ExampleConfig() []byte {
+	data, _ := templates.ReadFile("templates/logseq-indexer.yml")
+	return data
+}
+
+// Init scaffolds the repository: the example config, the output directory,
+// a post-commit hook running generate, a section in CLAUDE.md pointing
+// Claude at the indexes, and the command files in .claude/commands. Existing
+// files are kept unless opts.Force is set, except that an existing hook gets
+// the generate line appended and CLAUDE.md's section is replaced or appended.
+func Init(opts Options) ([]Step, error) {
+	data := struct{ OutputDir, Profile string }{filepath.ToSlash(opts.OutputDir), opts.Profile}
+	var steps []Step
+
+	step, err := writeFile(opts.RepoPath, config.DefaultFileName, ExampleConfig(), 0644, opts.Force)
+	if err != nil {
+		return steps, err
+	}
+	steps = append(steps, step)
+
+	step = Step{Path: opts.OutputDir, Action: Created}
+	if _, err := os.Stat(filepath.Join(opts.RepoPath, opts.OutputDir)); err == nil {
+		step.Action, step.Detail = Skipped, "already exists"
+	} else if err := os.MkdirAll(filepath.Join(opts.RepoPath, opts.OutputDir), 0755); err != nil {
+		return steps, fmt.Errorf("creating output directory: %w", err)
+	}
+	steps = append(steps, step)
+
+	if !opts.NoHook {
+		hook, err := render("templates/post-commit", data)
+		if err != nil {
+			return steps, err
+		}
+		step, err := installHook(opts.RepoPath, hook)
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, step)
+	}
+
+	section, err := render("templates/CLAUDE.md", data)
+	if err != nil {
+		return steps, err
+	}
+	step, err = writeSection(opts.RepoPath, "CLAUDE.md", section)
+	if err != nil {
+		return steps, err
+	}
+	steps = append(steps, step)
+
+	commands, err := fs.Glob(templates, "templates/commands/*.md")
+	if err != nil {
+		return steps, err
+	}
+	for _, name := range commands {
+		content, err := render(name, data)
+		if err != nil {
+			return steps, err
+		}
+		step, err := writeFile(opts.RepoPath, path.Join(CommandsDir, path.Base(name)), content, 0644, opts.Force)
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, step)
+	}
+
+	return steps, nil
+}
+
+// render executes an embedded template
+func render(name string, data any) ([]byte, error) {
+	tmpl, err := template.New(path.Base(name)).Funcs(template.FuncMap{"shellquote": shellQuote}).ParseFS(templates, name)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering template %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFile writes a file relative to the repository, keeping an existing
+// one unless force is set
+func writeFile(repoPath, name string, content []byte, perm os.FileMode, force bool) (Step, error) {
+	step := Step{Path: name, Action: Created}
+	target := filepath.Join(repoPath, filepath.FromSlash(name))
+	if _, err := os.Stat(target); err == nil {
+		if !force {
+			return Step{Path: name, Action: Skipped, Detail: "already exists; use --force to overwrite"}, nil
+		}
+		step.Action = Updated
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return step, fmt.Errorf("creating directory for %s: %w", name, err)
+	}
+	if err := os.WriteFile(target, content, perm); err != nil {
+		return step, fmt.Errorf("writing %s: %w", name, err)
+	}
+	return step, nil
+}
+
+// installHook writes the post-commit hook, or appends the generate line to
+// an existing hook that doesn't run the indexer yet
+func installHook(repoPath string, hook []byte) (Step, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return Step{Path: ".git/hooks/post-commit", Action: Skipped, Detail: "not a git repository; keep indexes fresh with `logseq-claude-indexer watch`"}, nil
+	}
+	hooksDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(repoPath, hooksDir)
+	}
+	target := filepath.Join(hooksDir, "post-commit")
+	name := filepath.ToSlash(target)
+	if rel, err := filepath.Rel(repoPath, target); err == nil && !strings.HasPrefix(rel, "..") {
+		name = filepath.ToSlash(rel)
+	}
+
+	existing, err := os.ReadFile(target)
+	switch {
+	case err == nil && strings.Contains(string(existing), hookBinary):
+		return Step{Path: name, Action: Skipped, Detail: "already runs " + hookBinary}, nil
+	case err == nil:
+		// Keep the user's hook, adding only the command line
+		command := strings.SplitN(strings.TrimRight(string(hook), "\n"), "\n", 2)[1]
+		content, detail := addToHook(string(existing), command)
+		if err := os.WriteFile(target, []byte(content), 0755); err != nil {
+			return Step{}, fmt.Errorf("updating %s: %w", name, err)
+		}
+		// WriteFile keeps an existing file's mode, and git skips hooks that aren't executable
+		if err := os.Chmod(target, 0755); err != nil {
+			return Step{}, fmt.Errorf("making %s executable: %w", name, err)
+		}
+		return Step{Path: name, Action: Updated, Detail: detail}, nil
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return Step{}, fmt.Errorf("creating hooks directory: %w", err)
+	}
+	if err := os.WriteFile(target, hook, 0755); err != nil {
+		return Step{}, fmt.Errorf("writing %s: %w", name, err)
+	}
+	return Step{Path: name, Action: Created}, nil
+}
+
+// addToHook adds command to an existing hook script: before a final exit or
+// exec line, which would otherwise stop it from running, or else at the end
+func addToHook(existing, command string) (string, string) {
+	lines := strings.Split(strings.TrimRight(existing, "\n"), "\n")
+	for i := len(lines) - 1; i > 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "exit" || strings.HasPrefix(line, "exit ") || strings.HasPrefix(line, "exec ") {
+			before := strings.TrimRight(strings.Join(lines[:i], "\n"), "\n")
+			return before + "\n\n" + command + "\n\n" + strings.Join(lines[i:], "\n") + "\n",
+				"inserted the generate command before the final " + strings.Fields(line)[0]
+		}
+		break
+	}
+	return strings.Join(lines, "\n") + "\n\n" + command + "\n", "appended the generate command"
+}
+
+// shellQuote quotes a value for a POSIX shell script
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// writeSection adds the indexer section to a markdown file between markers,
+// replacing the section written by an earlier run
+func writeSection(repoPath, name string, section []byte) (Step, error) {
+	target := filepath.Join(repoPath, name)
+	block := sectionStart + "\n" + strings.TrimRight(string(section), "\n") + "\n" + sectionEnd + "\n"
+
+	existing, err := os.ReadFile(target)
+	if os.IsNotExist(err) {
+		if err := os.WriteFile(target, []byte(block), 0644); err != nil {
+			return Step{}, fmt.Errorf("writing %s: %w", name, err)
+		}
+		return Step{Path: name, Action: Created}, nil
+	} else if err != nil {
+		return Step{}, fmt.Errorf("reading %s: %w", name, err)
+	}
+
+	content := string(existing)
+	start, end := strings.Index(content, sectionStart), strings.Index(content, sectionEnd)
+	var updated string
+	detail := "replaced the indexer section"
+	if start >= 0 && end > start {
+		rest := strings.TrimPrefix(content[end+len(sectionEnd):], "\n")
+		updated = content[:start] + block + rest
+	} else if strings.TrimSpace(content) == "" {
+		updated = block
+	} else {
+		updated = strings.TrimRight(content, "\n") + "\n\n" + block
+		detail = "appended the indexer section"
+	}
+	if updated == content {
+		return Step{Path: name, Action: Skipped, Detail: "indexer section is up to date"}, nil
+	}
+	if err := os.WriteFile(target, []byte(updated), 0644); err != nil {
+		return Step{}, fmt.Errorf("writing %s: %w", name, err)
+	}
+	return Step{Path: name, Action: Updated, Detail: detail}, nil
+}
@@ -0,0 +1,180 @@
+package scaffold
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/config"
+)
+
+func TestExampleConfig_Loads(t *testing.T) {
+	path := filepath.Join(t.TempDir(), config.DefaultFileName)
+	if err := os.WriteFile(path, ExampleConfig(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := config.Load("", path); err != nil {
+		t.Errorf("Example config doesn't load: %v", err)
+	}
+}
+
+func TestInit(t *testing.T) {
+	repo := t.TempDir()
+	write(t, repo, "CLAUDE.md", "# Notes\n\nKeep this.\n")
+
+	steps, err := Init(Options{RepoPath: repo, OutputDir: "out/indexes", NoHook: true})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	want := []Step{
+		{Path: config.DefaultFileName, Action: Created},
+		{Path: "out/indexes", Action: Created},
+		{Path: "CLAUDE.md", Action: Updated, Detail: "appended the indexer section"},
+		{Path: CommandsDir + "/plan-day.md", Action: Created},
+		{Path: CommandsDir + "/weekly-review.md", Action: Created},
+	}
+	if len(steps) != len(want) {
+		t.Fatalf("Expected %d steps, got %+v", len(want), steps)
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("Step %d: expected %+v, got %+v", i, want[i], steps[i])
+		}
+	}
+
+	claude := read(t, repo, "CLAUDE.md")
+	if !strings.HasPrefix(claude, "# Notes\n\nKeep this.\n\n"+sectionStart) || !strings.Contains(claude, "`out/indexes/dashboard.md`") {
+		t.Errorf("Unexpected CLAUDE.md:\n%s", claude)
+	}
+	if command := read(t, repo, CommandsDir+"/plan-day.md"); !strings.Contains(command, "out/indexes/prompts/daily-planning.md") {
+		t.Errorf("Expected the command to use the output directory, got:\n%s", command)
+	}
+
+	// A second run keeps everything, and a changed output directory updates the section in place
+	write(t, repo, CommandsDir+"/plan-day.md", "my own version\n")
+	steps, err = Init(Options{RepoPath: repo, OutputDir: ".claude/indexes", NoHook: true})
+	if err != nil {
+		t.Fatalf("Second Init failed: %v", err)
+	}
+	if steps[0].Action != Skipped || steps[3].Action != Skipped {
+		t.Errorf("Expected existing files to be kept, got %+v", steps)
+	}
+	if got := read(t, repo, CommandsDir+"/plan-day.md"); got != "my own version\n" {
+		t.Errorf("Expected the edited command kept, got %q", got)
+	}
+	claude = read(t, repo, "CLAUDE.md")
+	if strings.Count(claude, sectionStart) != 1 || !strings.Contains(claude, "`.claude/indexes/dashboard.md`") {
+		t.Errorf("Expected one updated section, got:\n%s", claude)
+	}
+
+	if _, err := Init(Options{RepoPath: repo, OutputDir: ".claude/indexes", NoHook: true, Force: true}); err != nil {
+		t.Fatalf("Forced Init failed: %v", err)
+	}
+	if got := read(t, repo, CommandsDir+"/plan-day.md"); got == "my own version\n" {
+		t.Error("Expected --force to overwrite the command")
+	}
+}
+
+func TestInit_Hook(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "--quiet")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	write(t, repo, ".git/hooks/post-commit", "#!/bin/sh\necho committed\n")
+
+	steps, err := Init(Options{RepoPath: repo, OutputDir: ".claude/indexes"})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if steps[2] != (Step{Path: ".git/hooks/post-commit", Action: Updated, Detail: "appended the generate command"}) {
+		t.Errorf("Expected the existing hook to be extended, got %+v", steps[2])
+	}
+
+	hook := read(t, repo, ".git/hooks/post-commit")
+	if !strings.HasPrefix(hook, "#!/bin/sh\necho committed\n\n") ||
+		!strings.Contains(hook, "logseq-claude-indexer generate --repo . --output '.claude/indexes' --quiet") {
+		t.Errorf("Unexpected hook:\n%s", hook)
+	}
+	if info, err := os.Stat(filepath.Join(repo, ".git/hooks/post-commit")); err != nil || info.Mode().Perm()&0111 == 0 {
+		t.Error("Expected the hook to be executable")
+	}
+
+	steps, err = Init(Options{RepoPath: repo, OutputDir: ".claude/indexes"})
+	if err != nil {
+		t.Fatalf("Second Init failed: %v", err)
+	}
+	if steps[2].Action != Skipped {
+		t.Errorf("Expected the hook to be left alone the second time, got %+v", steps[2])
+	}
+}
+
+func TestInit_HookExit(t *testing.T) {
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "--quiet")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	write(t, repo, ".git/hooks/post-commit", "#!/bin/sh\necho committed\nexit 0\n")
+
+	steps, err := Init(Options{RepoPath: repo, OutputDir: "it's indexes"})
+	if err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if steps[2].Detail != "inserted the generate command before the final exit" {
+		t.Errorf("Expected the command to go before the exit, got %+v", steps[2])
+	}
+
+	want := "#!/bin/sh\necho committed\n\n" +
+		"# Regenerate the Claude indexes after each commit\n" +
+		"logseq-claude-indexer generate --repo . --output 'it'\\''s indexes' --quiet\n\n" +
+		"exit 0\n"
+	if hook := read(t, repo, ".git/hooks/post-commit"); hook != want {
+		t.Errorf("Unexpected hook:\n%s", hook)
+	}
+}
+
+func TestInit_HookProfile(t *testing.T) {
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "--quiet")
+	cmd.Dir = repo
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+
+	if _, err := Init(Options{RepoPath: repo, OutputDir: ".cursor/rules/logseq", Profile: "cursor"}); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	if hook := read(t, repo, ".git/hooks/post-commit"); !strings.Contains(hook, "generate --repo . --profile cursor --output '.cursor/rules/logseq' --quiet") {
+		t.Errorf("Expected the hook to generate with the profile, got:\n%s", hook)
+	}
+}
+
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func read(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
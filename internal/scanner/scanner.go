@@ -5,11 +5,69 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
 )
 
+// DefaultExtensions are the file extensions indexed when none are configured
+var DefaultExtensions = []string{".md", ".markdown", ".mdx"}
+
+// markdownExtensions are always indexed when configured. Files with any other
+// configured extension (e.g. .txt) are only indexed if they contain
+// Logseq-style bullets.
+var markdownExtensions = map[string]bool{".md": true, ".markdown": true, ".mdx": true}
+
+// extensions is the configured extension list (see SetExtensions)
+var extensions = DefaultExtensions
+
+// SetExtensions configures which file extensions are indexed, e.g.
+// [".md", ".txt"]. Extensions are matched case-insensitively; a missing
+// leading dot is added. An empty list restores DefaultExtensions.
+func SetExtensions(exts []string) {
+	if len(exts) == 0 {
+		extensions = DefaultExtensions
+		return
+	}
+	extensions = make([]string, 0, len(exts))
+	for _, ext := range exts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		extensions = append(extensions, ext)
+	}
+}
+
+// Extensions returns the configured extension list
+func Extensions() []string {
+	return extensions
+}
+
+// HasIndexedExtension reports whether path has one of the configured extensions
+func HasIndexedExtension(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, indexed := range extensions {
+		if ext == indexed {
+			return true
+		}
+	}
+	return false
+}
+
+// bulletRegex matches a Logseq block line: "- " after optional indentation
+var bulletRegex = regexp.MustCompile(`(?m)^[ \t]*- \S`)
+
+// hasLogseqBullets reports whether a plain-text file is written as Logseq blocks
+func hasLogseqBullets(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return bulletRegex.Match(content)
+}
+
 // Scanner walks a Logseq repository and finds all markdown files
 type Scanner struct {
 	repoPath string
@@ -49,7 +107,7 @@ func (s *Scanner) Scan() ([]models.File, error) {
 	return files, nil
 }
 
-// scanDirectory walks a specific directory and finds all .md files
+// scanDirectory walks a specific directory and finds all files with an indexed extension
 func (s *Scanner) scanDirectory(dir string, fileType models.FileType) ([]models.File, error) {
 	dirPath := filepath.Join(s.repoPath, dir)
 
@@ -76,8 +134,12 @@ func (s *Scanner) scanDirectory(dir string, fileType models.FileType) ([]models.
 			return nil
 		}
 
-		// Skip non-markdown files
-		if !strings.HasSuffix(path, ".md") {
+		// Skip files without a configured extension, and plain-text files
+		// that aren't written as Logseq blocks
+		if !HasIndexedExtension(path) {
+			return nil
+		}
+		if !markdownExtensions[strings.ToLower(filepath.Ext(path))] && !hasLogseqBullets(path) {
 			return nil
 		}
 
@@ -116,3 +178,126 @@ func (s *Scanner) scanDirectory(dir string, fileType models.FileType) ([]models.
 
 	return files, nil
 }
+
+// Files returns the listed files a Scan would find, for indexing an explicit
+// set such as the output of `git diff --name-only`. Paths are relative to the
+// repository or absolute. Paths outside pages/ and journals/, in skipped
+// directories, without an indexed extension, or that don't exist (such as
+// deleted files) are returned in skipped instead. Duplicates are dropped.
+func (s *Scanner) Files(paths []string) (files []models.File, skipped []string) {
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		file, ok := s.listedFile(path)
+		if !ok {
+			skipped = append(skipped, path)
+			continue
+		}
+		if !seen[file.Path] {
+			seen[file.Path] = true
+			files = append(files, file)
+		}
+	}
+	return files, skipped
+}
+
+// listedFile checks one listed path the way scanDirectory checks the files it walks
+func (s *Scanner) listedFile(path string) (models.File, bool) {
+	relPath := filepath.Clean(path)
+	if filepath.IsAbs(relPath) {
+		rel, err := filepath.Rel(s.repoPath, relPath)
+		if err != nil {
+			return models.File{}, false
+		}
+		relPath = rel
+	}
+
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	var fileType models.FileType
+	switch parts[0] {
+	case "journals":
+		fileType = models.FileTypeJournal
+	case "pages":
+		fileType = models.FileTypePage
+	default:
+		return models.File{}, false
+	}
+	for _, part := range parts[1:] {
+		if strings.HasPrefix(part, ".") || part == "bak" {
+			return models.File{}, false
+		}
+	}
+
+	absPath := filepath.Join(s.repoPath, relPath)
+	info, err := os.Stat(absPath)
+	if err != nil || !info.Mode().IsRegular() || !HasIndexedExtension(absPath) {
+		return models.File{}, false
+	}
+	if !markdownExtensions[strings.ToLower(filepath.Ext(absPath))] && !hasLogseqBullets(absPath) {
+		return models.File{}, false
+	}
+
+	return models.File{
+		Path:         relPath,
+		AbsolutePath: absPath,
+		Type:         fileType,
+		ModTime:      info.ModTime(),
+	}, true
+}
+
+// ScanDeleted returns the copies Logseq keeps of pages: deleted ones in
+// logseq/.recycle (as pages_Project.md) and overwritten versions in
+// logseq/bak (as pages/Project/<timestamp>.md). Journals are left out, and
+// so is every copy from Scan. A repository without these directories has
+// none.
+func (s *Scanner) ScanDeleted() ([]models.DeletedFile, error) {
+	var deleted []models.DeletedFile
+	for _, dir := range []string{".recycle", "bak"} {
+		root := filepath.Join(s.repoPath, "logseq", dir)
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !HasIndexedExtension(path) || strings.HasPrefix(d.Name(), ".") {
+				return nil
+			}
+			rel, err := filepath.Rel(root, path)
+			if err != nil {
+				return nil
+			}
+			page, ok := deletedPageName(filepath.ToSlash(rel))
+			if !ok {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			relPath, err := filepath.Rel(s.repoPath, path)
+			if err != nil {
+				return nil
+			}
+			deleted = append(deleted, models.DeletedFile{
+				File: models.File{Path: relPath, AbsolutePath: path, Type: models.FileTypePage, ModTime: info.ModTime()},
+				Page: page,
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("walking directory logseq/%s: %w", dir, err)
+		}
+	}
+	return deleted, nil
+}
+
+// deletedPageName returns the page a copy under logseq/.recycle or
+// logseq/bak stands for, from its path there: pages_Project.md,
+// pages/Project.md, or pages/Project/<timestamp>.md
+func deletedPageName(rel string) (string, bool) {
+	parts := strings.Split(rel, "/")
+	switch {
+	case len(parts) == 1 && strings.HasPrefix(parts[0], "pages_"):
+		return models.PageName(strings.TrimPrefix(parts[0], "pages_")), true
+	case len(parts) == 2 && parts[0] == "pages":
+		return models.PageName(parts[1]), true
+	case len(parts) == 3 && parts[0] == "pages":
+		return parts[1], true
+	}
+	return "", false
+}
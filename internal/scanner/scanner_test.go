@@ -3,6 +3,7 @@ package scanner
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/dyluth/logseq-claude-indexer/pkg/models"
@@ -167,3 +168,146 @@ func TestScanner_NestedDirectories(t *testing.T) {
 		}
 	}
 }
+
+func TestScanner_Extensions(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFiles := map[string]string{
+		"pages/Plain.md":         "# Plain",
+		"pages/Long.markdown":    "- A block",
+		"pages/Component.mdx":    "- Another block",
+		"pages/Notes.txt":        "- TODO Logseq-style block\n  - child",
+		"pages/Shopping.txt":     "eggs\nmilk",
+		"pages/Diagram.svg":      "<svg/>",
+		"journals/2025_04_06.MD": "- Upper-case extension",
+	}
+	for relPath, content := range testFiles {
+		fullPath := filepath.Join(tmpDir, relPath)
+		os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", relPath, err)
+		}
+	}
+
+	scan := func() map[string]bool {
+		files, err := New(tmpDir).Scan()
+		if err != nil {
+			t.Fatalf("Scan failed: %v", err)
+		}
+		found := make(map[string]bool)
+		for _, f := range files {
+			found[filepath.ToSlash(f.Path)] = true
+		}
+		return found
+	}
+
+	found := scan()
+	for _, path := range []string{"pages/Plain.md", "pages/Long.markdown", "pages/Component.mdx", "journals/2025_04_06.MD"} {
+		if !found[path] {
+			t.Errorf("Expected %s with the default extensions", path)
+		}
+	}
+	if len(found) != 4 {
+		t.Errorf("Expected 4 files with the default extensions, got %v", found)
+	}
+
+	SetExtensions([]string{"md", ".TXT"})
+	defer SetExtensions(nil)
+
+	found = scan()
+	if !found["pages/Notes.txt"] || !found["pages/Plain.md"] {
+		t.Errorf("Expected Notes.txt and Plain.md, got %v", found)
+	}
+	if found["pages/Shopping.txt"] {
+		t.Error("Expected a .txt file without bullets to be skipped")
+	}
+	if found["pages/Long.markdown"] {
+		t.Error("Expected .markdown to be skipped when not configured")
+	}
+}
+
+func TestScanner_Files(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, relPath := range []string{"pages/Project.md", "journals/2025_04_06.md", "pages/bak/Project.md", "logseq/custom.css", "README.md"} {
+		fullPath := filepath.Join(tmpDir, relPath)
+		os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err := os.WriteFile(fullPath, []byte("- A block"), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", relPath, err)
+		}
+	}
+
+	files, skipped := New(tmpDir).Files([]string{
+		"pages/Project.md",
+		filepath.Join(tmpDir, "journals", "2025_04_06.md"), // Absolute
+		"./pages/Project.md", // Duplicate
+		"pages/Deleted.md",
+		"pages/bak/Project.md",
+		"logseq/custom.css",
+		"README.md",
+	})
+
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 files, got %+v", files)
+	}
+	if files[0].Path != filepath.Join("pages", "Project.md") || files[0].Type != models.FileTypePage {
+		t.Errorf("Unexpected page: %+v", files[0])
+	}
+	if files[1].Path != filepath.Join("journals", "2025_04_06.md") || files[1].Type != models.FileTypeJournal {
+		t.Errorf("Unexpected journal: %+v", files[1])
+	}
+	want := []string{"pages/Deleted.md", "pages/bak/Project.md", "logseq/custom.css", "README.md"}
+	if !reflect.DeepEqual(skipped, want) {
+		t.Errorf("Expected skipped %v, got %v", want, skipped)
+	}
+}
+
+func TestScanner_ScanDeleted(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, relPath := range []string{
+		"pages/Project.md",
+		"logseq/.recycle/pages_Old Idea.md",
+		"logseq/.recycle/journals_2025_04_06.md",
+		"logseq/bak/pages/Work___Roadmap/2025-04-06T10_00_00.000Z.Desktop.md",
+		"logseq/bak/pages/Notes.md",
+		"logseq/bak/journals/2025_04_06/2025-04-06T10_00_00.000Z.Desktop.md",
+		"logseq/custom.css",
+	} {
+		fullPath := filepath.Join(tmpDir, relPath)
+		os.MkdirAll(filepath.Dir(fullPath), 0755)
+		if err := os.WriteFile(fullPath, []byte("- A block"), 0644); err != nil {
+			t.Fatalf("Failed to create test file %s: %v", relPath, err)
+		}
+	}
+
+	deleted, err := New(tmpDir).ScanDeleted()
+	if err != nil {
+		t.Fatalf("ScanDeleted() failed: %v", err)
+	}
+
+	var pages []string
+	for _, d := range deleted {
+		pages = append(pages, d.Page)
+	}
+	want := []string{"Old Idea", "Notes", "Work___Roadmap"}
+	if !reflect.DeepEqual(pages, want) {
+		t.Errorf("Expected pages %v, got %v", want, pages)
+	}
+	if deleted[0].Path != filepath.Join("logseq", ".recycle", "pages_Old Idea.md") {
+		t.Errorf("Expected the copy's path relative to the repository, got %s", deleted[0].Path)
+	}
+
+	// Scan never sees the copies
+	files, err := New(tmpDir).Scan()
+	if err != nil {
+		t.Fatalf("Scan() failed: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected only pages/Project.md from Scan, got %+v", files)
+	}
+}
+
+func TestScanner_ScanDeleted_NoDirectories(t *testing.T) {
+	deleted, err := New(t.TempDir()).ScanDeleted()
+	if err != nil || len(deleted) != 0 {
+		t.Errorf("Expected no copies and no error, got %v, %v", deleted, err)
+	}
+}
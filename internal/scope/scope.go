@@ -0,0 +1,280 @@
+// Package scope narrows a graph to a subtree of pages, optionally widened to
+// the pages a few links away, plus the journal blocks mentioning them, so a
+// scoped index set never sees other content.
+package scope
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/parser"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// Scope selects pages by path patterns relative to the repository root, such
+// as "pages/work/**". Patterns use path.Match syntax, and a "**" segment
+// matches any number of directories.
+type Scope struct {
+	patterns []string
+
+	// Hops widens the scope to pages within this many links of a matching
+	// page, following links in either direction. 0 keeps only the matches.
+	Hops int
+}
+
+// New checks the patterns and returns a Scope matching any of them
+func New(patterns []string) (*Scope, error) {
+	s := &Scope{}
+	for _, pattern := range patterns {
+		pattern = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(pattern)), "./")
+		if pattern == "" {
+			continue
+		}
+		for _, segment := range strings.Split(pattern, "/") {
+			if _, err := path.Match(segment, ""); err != nil {
+				return nil, fmt.Errorf("invalid scope pattern %q: %w", pattern, err)
+			}
+		}
+		s.patterns = append(s.patterns, pattern)
+	}
+	if len(s.patterns) == 0 {
+		return nil, fmt.Errorf("no scope patterns given")
+	}
+	return s, nil
+}
+
+// Matches reports whether a file path relative to the repository root is in scope
+func (s *Scope) Matches(file string) bool {
+	parts := strings.Split(filepath.ToSlash(file), "/")
+	for _, pattern := range s.patterns {
+		if matchSegments(strings.Split(pattern, "/"), parts) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments against pattern segments, "**"
+// standing for zero or more segments
+func matchSegments(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchSegments(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(parts) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], parts[1:])
+}
+
+// Result counts what Render kept
+type Result struct {
+	Pages    int       // Pages in scope
+	Journals int       // Journals mentioning them
+	Reach    []HopSize // Pages kept at each link distance, index 0 being the matches
+	Pruned   int       // Pages left out
+}
+
+// HopSize is the size of the pages kept at one link distance
+type HopSize struct {
+	Pages int
+	Bytes int
+}
+
+// Render copies the in-scope files to dir under their relative paths. Journals
+// outside the patterns keep only their top-level blocks that link or tag an
+// in-scope page, other lines blanked so line numbers still match the
+// original. Files keep their modification times.
+func (s *Scope) Render(files []models.File, dir string) (Result, error) {
+	var result Result
+
+	contents := make([][]byte, len(files))
+	for i, file := range files {
+		content, err := os.ReadFile(file.AbsolutePath)
+		if err != nil {
+			return result, fmt.Errorf("reading %s: %w", file.Path, err)
+		}
+		contents[i] = content
+	}
+
+	// Lowercase names of in-scope pages, with their distance from a match
+	pages := s.reach(files, contents)
+	result.Reach = make([]HopSize, s.Hops+1)
+
+	for i, file := range files {
+		content := contents[i]
+		distance, inScope := pages[strings.ToLower(pageName(file.Path))]
+		inScope = inScope && (distance > 0 || s.Matches(file.Path)) // Not a namesake of a match
+
+		switch {
+		case file.Type == models.FileTypeJournal && s.Matches(file.Path):
+			result.Journals++
+		case file.Type == models.FileTypeJournal:
+			kept, ok := keepBlocks(string(content), pages)
+			if !ok {
+				continue
+			}
+			content = []byte(kept)
+			result.Journals++
+		case inScope:
+			result.Pages++
+			result.Reach[distance].Pages++
+			result.Reach[distance].Bytes += len(content)
+		default:
+			result.Pruned++
+			continue
+		}
+
+		target := filepath.Join(dir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return result, fmt.Errorf("creating directory: %w", err)
+		}
+		if err := os.WriteFile(target, content, 0644); err != nil {
+			return result, fmt.Errorf("writing %s: %w", file.Path, err)
+		}
+		if err := os.Chtimes(target, file.ModTime, file.ModTime); err != nil {
+			return result, fmt.Errorf("dating %s: %w", file.Path, err)
+		}
+	}
+	return result, nil
+}
+
+// Deleted returns the deleted copies of in-scope pages: pages Render kept in
+// scoped, or, for pages no longer in the graph, those whose file would match
+// the patterns if it were back in pages/
+func (s *Scope) Deleted(deleted []models.DeletedFile, scoped []models.File) []models.DeletedFile {
+	pages := make(map[string]bool)
+	for _, file := range scoped {
+		if file.Type == models.FileTypePage {
+			pages[strings.ToLower(pageName(file.Path))] = true
+		}
+	}
+
+	var kept []models.DeletedFile
+	for _, d := range deleted {
+		file := "pages/" + d.Page + ".md"
+		if pages[strings.ToLower(pageName(file))] || s.Matches(file) {
+			kept = append(kept, d)
+		}
+	}
+	return kept
+}
+
+// reach returns the lowercase names of the pages within s.Hops links of a
+// matching page, mapped to their distance. Links through journals don't
+// count: a busy day links everything.
+func (s *Scope) reach(files []models.File, contents [][]byte) map[string]int {
+	pages := make(map[string]int)
+	var frontier []string
+	for _, file := range files {
+		if file.Type != models.FileTypeJournal && s.Matches(file.Path) {
+			name := strings.ToLower(pageName(file.Path))
+			if _, seen := pages[name]; !seen {
+				pages[name] = 0
+				frontier = append(frontier, name)
+			}
+		}
+	}
+	if s.Hops == 0 {
+		return pages
+	}
+
+	existing := make(map[string]bool)
+	for _, file := range files {
+		if file.Type != models.FileTypeJournal {
+			existing[strings.ToLower(pageName(file.Path))] = true
+		}
+	}
+	links := make(map[string][]string) // Both directions
+	for i, file := range files {
+		if file.Type == models.FileTypeJournal {
+			continue
+		}
+		from := strings.ToLower(pageName(file.Path))
+		// Line by line, as the extractors stop after MaxLineLength bytes and
+		// MaxRefsPerLine references
+		for _, line := range strings.Split(string(contents[i]), "\n") {
+			for _, ref := range append(parser.ExtractPageReferences(line), parser.ExtractTags(line)...) {
+				to := strings.ToLower(ref)
+				if to != from && existing[to] {
+					links[from] = append(links[from], to)
+					links[to] = append(links[to], from)
+				}
+			}
+		}
+	}
+
+	for hop := 1; hop <= s.Hops && len(frontier) > 0; hop++ {
+		var next []string
+		for _, name := range frontier {
+			for _, linked := range links[name] {
+				if _, seen := pages[linked]; !seen {
+					pages[linked] = hop
+					next = append(next, linked)
+				}
+			}
+		}
+		frontier = next
+	}
+	return pages
+}
+
+// keepBlocks blanks every top-level block of content that doesn't mention one
+// of pages, reporting whether any block was kept
+func keepBlocks(content string, pages map[string]int) (string, bool) {
+	lines := strings.Split(content, "\n")
+	kept := false
+
+	flush := func(start, end int) {
+		for _, line := range lines[start:end] {
+			if mentions(line, pages) {
+				kept = true
+				return
+			}
+		}
+		for i := start; i < end; i++ {
+			lines[i] = ""
+		}
+	}
+
+	start := 0
+	for i, line := range lines {
+		if i > start && (strings.HasPrefix(line, "- ") || line == "-") {
+			flush(start, i)
+			start = i
+		}
+	}
+	flush(start, len(lines))
+
+	return strings.Join(lines, "\n"), kept
+}
+
+// mentions reports whether a line links or tags one of pages
+func mentions(line string, pages map[string]int) bool {
+	for _, ref := range append(parser.ExtractPageReferences(line), parser.ExtractTags(line)...) {
+		if _, ok := pages[strings.ToLower(ref)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// pageName returns the page a file stands for, with namespaces written
+// with "/" as in links: "Work/Roadmap" for pages/Work___Roadmap.md
+func pageName(file string) string {
+	name := strings.ReplaceAll(models.PageName(file), "___", "/")
+	return strings.ReplaceAll(strings.ReplaceAll(name, "%2F", "/"), "%2f", "/")
+}
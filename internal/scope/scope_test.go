@@ -0,0 +1,213 @@
+package scope
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestMatches(t *testing.T) {
+	s, err := New([]string{"pages/work/**", "./pages/Client___*.md"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	for file, want := range map[string]bool{
+		"pages/work/Roadmap.md":      true,
+		"pages/work/2025/Q1 Plan.md": true,
+		"pages/Client___Acme.md":     true,
+		"pages/Diary.md":             false,
+		"pages/workshop/Notes.md":    false,
+		"journals/2025_11_03.md":     false,
+	} {
+		if got := s.Matches(file); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", file, got, want)
+		}
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New([]string{"pages/[work"}); err == nil {
+		t.Error("Expected error for a malformed pattern")
+	}
+	if _, err := New([]string{" "}); err == nil {
+		t.Error("Expected error without patterns")
+	}
+}
+
+func TestRender(t *testing.T) {
+	repo := t.TempDir()
+	modTime := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	write := func(path, content string, fileType models.FileType) models.File {
+		abs := filepath.Join(repo, path)
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return models.File{Path: path, AbsolutePath: abs, Type: fileType, ModTime: modTime}
+	}
+
+	files := []models.File{
+		write("pages/work/Roadmap.md", "- Q1 goals\n", models.FileTypePage),
+		write("pages/Work___Hiring.md", "- open roles\n", models.FileTypePage),
+		write("pages/Diary.md", "- personal\n", models.FileTypePage),
+		write("journals/2025_11_03.md", "- TODO call the bank\n- TODO update [[roadmap]]\n\t- details\n- dinner #home\n- sync on #[[Work/Hiring]]\n", models.FileTypeJournal),
+		write("journals/2025_11_04.md", "- gym\n", models.FileTypeJournal),
+	}
+	s, err := New([]string{"pages/work/**", "pages/Work___*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := t.TempDir()
+	result, err := s.Render(files, out)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if result.Pages != 2 || result.Journals != 1 {
+		t.Errorf("Expected 2 pages and 1 journal, got %+v", result)
+	}
+
+	journal, err := os.ReadFile(filepath.Join(out, "journals/2025_11_03.md"))
+	if err != nil {
+		t.Fatalf("Expected the journal mentioning Roadmap: %v", err)
+	}
+	// Other blocks are blanked so line numbers match the original
+	want := "\n- TODO update [[roadmap]]\n\t- details\n\n- sync on #[[Work/Hiring]]\n"
+	if string(journal) != want {
+		t.Errorf("Expected journal %q, got %q", want, journal)
+	}
+	if info, err := os.Stat(filepath.Join(out, "pages/work/Roadmap.md")); err != nil || !info.ModTime().Equal(modTime) {
+		t.Errorf("Expected Roadmap copied with its modification time, got %v", err)
+	}
+	for _, missing := range []string{"pages/Diary.md", "journals/2025_11_04.md"} {
+		if _, err := os.Stat(filepath.Join(out, missing)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s left out of scope", missing)
+		}
+	}
+	if strings.Contains(string(journal), "bank") {
+		t.Error("Expected unrelated journal blocks removed")
+	}
+}
+
+func TestRender_Hops(t *testing.T) {
+	repo := t.TempDir()
+	write := func(path, content string, fileType models.FileType) models.File {
+		abs := filepath.Join(repo, path)
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return models.File{Path: path, AbsolutePath: abs, Type: fileType}
+	}
+
+	// Phoenix -> Design -> Fonts -> Typography, and Alice -> Phoenix
+	files := []models.File{
+		write("pages/Phoenix.md", "- see [[Design]]\n", models.FileTypePage),
+		write("pages/Design.md", "- uses [[Fonts]]\n", models.FileTypePage),
+		write("pages/Fonts.md", "- #Typography\n", models.FileTypePage),
+		write("pages/Typography.md", "- serif\n", models.FileTypePage),
+		write("pages/Alice.md", "- works on [[Phoenix]]\n", models.FileTypePage),
+		write("journals/2025_11_03.md", "- [[Phoenix]] [[Typography]]\n- lunch with [[Alice]]\n- read about [[Typography]]\n", models.FileTypeJournal),
+	}
+	s, err := New([]string{"pages/Phoenix.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Hops = 1
+
+	out := t.TempDir()
+	result, err := s.Render(files, out)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	want := []HopSize{{Pages: 1, Bytes: 17}, {Pages: 2, Bytes: 40}}
+	if result.Pages != 3 || result.Pruned != 2 || len(result.Reach) != 2 ||
+		result.Reach[0] != want[0] || result.Reach[1] != want[1] {
+		t.Errorf("Expected Phoenix, Design and Alice with 2 pruned, got %+v", result)
+	}
+	for _, page := range []string{"pages/Phoenix.md", "pages/Design.md", "pages/Alice.md"} {
+		if _, err := os.Stat(filepath.Join(out, page)); err != nil {
+			t.Errorf("Expected %s within one hop: %v", page, err)
+		}
+	}
+	for _, page := range []string{"pages/Fonts.md", "pages/Typography.md"} {
+		if _, err := os.Stat(filepath.Join(out, page)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s pruned", page)
+		}
+	}
+	// The journal keeps blocks mentioning pages within reach, and its links
+	// don't widen the scope
+	journal, err := os.ReadFile(filepath.Join(out, "journals/2025_11_03.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(journal), "- [[Phoenix]] [[Typography]]\n- lunch with [[Alice]]\n\n"; got != want {
+		t.Errorf("Expected journal %q, got %q", want, got)
+	}
+}
+
+func TestRender_HopsLongPage(t *testing.T) {
+	repo := t.TempDir()
+	var files []models.File
+	for path, content := range map[string]string{
+		// The link to Design comes after more references than one extractor call returns
+		"pages/Phoenix.md": strings.Repeat("- [[Note]]\n", 300) + "- see [[Design]]\n",
+		"pages/Design.md":  "- fonts\n",
+	} {
+		abs := filepath.Join(repo, path)
+		if err := os.MkdirAll(filepath.Dir(abs), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(abs, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, models.File{Path: path, AbsolutePath: abs, Type: models.FileTypePage})
+	}
+	s, err := New([]string{"pages/Phoenix.md"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Hops = 1
+
+	out := t.TempDir()
+	if _, err := s.Render(files, out); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(out, "pages/Design.md")); err != nil {
+		t.Errorf("Expected Design within one hop of a long page: %v", err)
+	}
+}
+
+func TestDeleted(t *testing.T) {
+	s, err := New([]string{"pages/work/**", "pages/Client___*.md"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	scoped := []models.File{
+		{Path: "pages/work/Roadmap.md", Type: models.FileTypePage},
+		{Path: "journals/2025_11_03.md", Type: models.FileTypeJournal},
+	}
+	deleted := []models.DeletedFile{
+		{File: models.File{Path: "logseq/bak/pages/Roadmap/2025-11-03T10_00_00.000Z.Desktop.md"}, Page: "Roadmap"},
+		{File: models.File{Path: "logseq/bak/pages/Secret/2025-11-03T10_00_00.000Z.Desktop.md"}, Page: "Secret"},
+		{File: models.File{Path: "logseq/.recycle/pages_Client___Acme.md"}, Page: "Client___Acme"},
+		{File: models.File{Path: "logseq/.recycle/pages_2025_11_03.md"}, Page: "2025_11_03"},
+	}
+
+	var pages []string
+	for _, d := range s.Deleted(deleted, scoped) {
+		pages = append(pages, d.Page)
+	}
+	if got, want := strings.Join(pages, ","), "Roadmap,Client___Acme"; got != want {
+		t.Errorf("Expected deleted copies %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,270 @@
+package selfupdate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAPIURL is the GitHub API endpoint for the latest release
+const DefaultAPIURL = "https://api.github.com/repos/dyluth/logseq-claude-indexer/releases/latest"
+
+// ChecksumsAsset is the release asset listing SHA-256 sums in sha256sum format
+const ChecksumsAsset = "checksums.txt"
+
+// binaryName is the prefix of every release binary (see `make build-all`)
+const binaryName = "logseq-claude-indexer"
+
+// ErrPackageManaged is returned when the binary belongs to a package manager,
+// which should do the update instead
+var ErrPackageManaged = errors.New("installed by a package manager")
+
+// Release is a published release and its downloadable assets
+type Release struct {
+	Version string            // Tag without a leading "v", e.g. "0.2.0"
+	Assets  map[string]string // Asset name -> download URL
+}
+
+// Client checks for and downloads releases
+type Client struct {
+	HTTP   *http.Client
+	APIURL string
+}
+
+// NewClient creates a client for the project's GitHub releases
+func NewClient() *Client {
+	return &Client{
+		HTTP:   &http.Client{Timeout: 60 * time.Second},
+		APIURL: DefaultAPIURL,
+	}
+}
+
+// Latest fetches the latest published release
+func (c *Client) Latest(ctx context.Context) (*Release, error) {
+	body, err := c.get(ctx, c.APIURL)
+	if err != nil {
+		return nil, fmt.Errorf("checking latest release: %w", err)
+	}
+
+	var payload struct {
+		TagName string `json:"tag_name"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("decoding release: %w", err)
+	}
+	if payload.TagName == "" {
+		return nil, fmt.Errorf("release has no tag")
+	}
+
+	release := &Release{
+		Version: strings.TrimPrefix(payload.TagName, "v"),
+		Assets:  make(map[string]string, len(payload.Assets)),
+	}
+	for _, asset := range payload.Assets {
+		release.Assets[asset.Name] = asset.URL
+	}
+	return release, nil
+}
+
+// Download fetches the binary for goos/goarch and verifies it against the
+// release's checksums. It fails if either asset is missing or the sum differs.
+func (c *Client) Download(ctx context.Context, release *Release, goos, goarch string) ([]byte, error) {
+	name := AssetName(goos, goarch)
+	binaryURL, ok := release.Assets[name]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no binary for %s/%s", release.Version, goos, goarch)
+	}
+	checksumsURL, ok := release.Assets[ChecksumsAsset]
+	if !ok {
+		return nil, fmt.Errorf("release %s has no %s; refusing to install an unverified binary", release.Version, ChecksumsAsset)
+	}
+
+	checksums, err := c.get(ctx, checksumsURL)
+	if err != nil {
+		return nil, fmt.Errorf("downloading checksums: %w", err)
+	}
+	want, err := lookupChecksum(checksums, name)
+	if err != nil {
+		return nil, err
+	}
+
+	binary, err := c.get(ctx, binaryURL)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", name, err)
+	}
+	sum := sha256.Sum256(binary)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return nil, fmt.Errorf("checksum mismatch for %s: got %s, want %s", name, got, want)
+	}
+
+	return binary, nil
+}
+
+// get fetches a URL, treating non-2xx responses as errors
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// AssetName returns the release binary name for a platform,
+// e.g. logseq-claude-indexer-darwin-arm64 or logseq-claude-indexer-windows-amd64.exe
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("%s-%s-%s", binaryName, goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// lookupChecksum finds a file's SHA-256 in sha256sum output ("<hex>  <name>")
+func lookupChecksum(checksums []byte, name string) (string, error) {
+	sc := bufio.NewScanner(bytes.NewReader(checksums))
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("no checksum listed for %s", name)
+}
+
+// IsNewer reports whether latest is a newer version than current.
+// Versions are dotted numbers with an optional "v" prefix; a pre-release
+// suffix (e.g. "-rc1") sorts before the release itself.
+func IsNewer(current, latest string) bool {
+	return compareVersions(latest, current) > 0
+}
+
+// compareVersions returns -1, 0, or 1 comparing version a with b
+func compareVersions(a, b string) int {
+	aNums, aPre := splitVersion(a)
+	bNums, bPre := splitVersion(b)
+
+	for i := 0; i < len(aNums) || i < len(bNums); i++ {
+		var x, y int
+		if i < len(aNums) {
+			x = aNums[i]
+		}
+		if i < len(bNums) {
+			y = bNums[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case aPre == bPre:
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	case aPre < bPre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// splitVersion parses "v1.2.3-rc1" into [1 2 3] and "rc1"
+func splitVersion(version string) ([]int, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	version, pre, _ := strings.Cut(version, "-")
+
+	var nums []int
+	for _, part := range strings.Split(version, ".") {
+		n, _ := strconv.Atoi(part)
+		nums = append(nums, n)
+	}
+	return nums, pre
+}
+
+// CheckManaged returns ErrPackageManaged if exePath is inside a Homebrew or
+// scoop installation, naming the command that should update it instead
+func CheckManaged(exePath string) error {
+	path := strings.ReplaceAll(strings.ToLower(exePath), `\`, "/")
+	switch {
+	case strings.Contains(path, "/cellar/") || strings.Contains(path, "/homebrew/") || strings.Contains(path, "/linuxbrew/"):
+		return fmt.Errorf("%w: run `brew upgrade %s`", ErrPackageManaged, binaryName)
+	case strings.Contains(path, "/scoop/apps/"):
+		return fmt.Errorf("%w: run `scoop update %s`", ErrPackageManaged, binaryName)
+	}
+	return nil
+}
+
+// Replace swaps the executable at exePath for binary. The new file is written
+// next to it first, and the current binary is moved aside to exePath+".old"
+// until the new one is in place, then moved back if installing it fails.
+func Replace(exePath string, binary []byte) error {
+	info, err := os.Stat(exePath)
+	if err != nil {
+		return fmt.Errorf("reading current binary: %w", err)
+	}
+
+	dir := filepath.Dir(exePath)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(exePath)+".new-*")
+	if err != nil {
+		return fmt.Errorf("creating new binary: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // No-op once renamed into place
+
+	if _, err := tmp.Write(binary); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing new binary: %w", err)
+	}
+	if err := os.Chmod(tmpPath, info.Mode().Perm()|0111); err != nil {
+		return fmt.Errorf("making new binary executable: %w", err)
+	}
+
+	// Windows can't overwrite a running executable, but it can rename it
+	oldPath := exePath + ".old"
+	os.Remove(oldPath)
+	if err := os.Rename(exePath, oldPath); err != nil {
+		return fmt.Errorf("moving current binary aside: %w", err)
+	}
+	if err := os.Rename(tmpPath, exePath); err != nil {
+		if restoreErr := os.Rename(oldPath, exePath); restoreErr != nil {
+			return fmt.Errorf("installing new binary: %w (the previous binary is at %s: %v)", err, oldPath, restoreErr)
+		}
+		return fmt.Errorf("installing new binary: %w", err)
+	}
+	os.Remove(oldPath) // Fails harmlessly on Windows while the old binary runs
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package selfupdate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsNewer(t *testing.T) {
+	tests := []struct {
+		current, latest string
+		want            bool
+	}{
+		{"0.1.0", "0.2.0", true},
+		{"0.1.0", "v0.1.0", false},
+		{"0.10.0", "0.9.0", false},
+		{"1.0", "1.0.1", true},
+		{"1.0.0-rc1", "1.0.0", true},
+		{"1.0.0", "1.0.0-rc1", false},
+		{"2.0.0", "1.9.9", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsNewer(tt.current, tt.latest); got != tt.want {
+			t.Errorf("IsNewer(%q, %q) = %v, want %v", tt.current, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	if got := AssetName("darwin", "arm64"); got != "logseq-claude-indexer-darwin-arm64" {
+		t.Errorf("Unexpected darwin asset: %s", got)
+	}
+	if got := AssetName("windows", "amd64"); got != "logseq-claude-indexer-windows-amd64.exe" {
+		t.Errorf("Unexpected windows asset: %s", got)
+	}
+}
+
+func TestCheckManaged(t *testing.T) {
+	if err := CheckManaged("/opt/homebrew/Cellar/logseq-claude-indexer/0.1.0/bin/logseq-claude-indexer"); !errors.Is(err, ErrPackageManaged) || !strings.Contains(err.Error(), "brew upgrade") {
+		t.Errorf("Expected Homebrew install to be detected, got %v", err)
+	}
+	if err := CheckManaged(`C:\Users\me\scoop\apps\logseq-claude-indexer\current\logseq-claude-indexer.exe`); !errors.Is(err, ErrPackageManaged) || !strings.Contains(err.Error(), "scoop update") {
+		t.Errorf("Expected scoop install to be detected, got %v", err)
+	}
+	if err := CheckManaged("/home/me/.local/bin/logseq-claude-indexer"); err != nil {
+		t.Errorf("Expected user install to be updatable, got %v", err)
+	}
+}
+
+// newReleaseServer serves a latest release with one binary and a checksums file
+func newReleaseServer(t *testing.T, binary []byte, checksum string) *httptest.Server {
+	t.Helper()
+	name := AssetName("linux", "amd64")
+
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"tag_name": "v0.2.0", "assets": [
+			{"name": %q, "browser_download_url": "%s/bin"},
+			{"name": "checksums.txt", "browser_download_url": "%s/checksums"}
+		]}`, name, server.URL, server.URL)
+	})
+	mux.HandleFunc("/bin", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(binary)
+	})
+	mux.HandleFunc("/checksums", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  %s\n", strings.Repeat("0", 64), AssetName("darwin", "arm64"))
+		fmt.Fprintf(w, "%s  %s\n", checksum, name)
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestLatestAndDownload(t *testing.T) {
+	binary := []byte("new binary")
+	sum := sha256.Sum256(binary)
+	server := newReleaseServer(t, binary, hex.EncodeToString(sum[:]))
+
+	client := &Client{HTTP: server.Client(), APIURL: server.URL + "/latest"}
+	release, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if release.Version != "0.2.0" {
+		t.Errorf("Expected version 0.2.0, got %s", release.Version)
+	}
+
+	got, err := client.Download(context.Background(), release, "linux", "amd64")
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if string(got) != string(binary) {
+		t.Errorf("Unexpected binary %q", got)
+	}
+
+	if _, err := client.Download(context.Background(), release, "plan9", "386"); err == nil {
+		t.Error("Expected error for a platform without a binary")
+	}
+}
+
+func TestDownload_ChecksumMismatch(t *testing.T) {
+	server := newReleaseServer(t, []byte("tampered"), strings.Repeat("a", 64))
+
+	client := &Client{HTTP: server.Client(), APIURL: server.URL + "/latest"}
+	release, err := client.Latest(context.Background())
+	if err != nil {
+		t.Fatalf("Latest failed: %v", err)
+	}
+	if _, err := client.Download(context.Background(), release, "linux", "amd64"); err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Errorf("Expected checksum mismatch, got %v", err)
+	}
+
+	delete(release.Assets, ChecksumsAsset)
+	if _, err := client.Download(context.Background(), release, "linux", "amd64"); err == nil {
+		t.Error("Expected error when the release has no checksums")
+	}
+}
+
+func TestReplace(t *testing.T) {
+	exe := filepath.Join(t.TempDir(), "logseq-claude-indexer")
+	if err := os.WriteFile(exe, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Replace(exe, []byte("new")); err != nil {
+		t.Fatalf("Replace failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(exe)
+	if string(content) != "new" {
+		t.Errorf("Expected new binary, got %q", content)
+	}
+	info, _ := os.Stat(exe)
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected executable permissions, got %v", info.Mode())
+	}
+	entries, _ := os.ReadDir(filepath.Dir(exe))
+	if len(entries) != 1 {
+		t.Errorf("Expected no leftover files, got %d entries", len(entries))
+	}
+}
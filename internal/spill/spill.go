@@ -0,0 +1,128 @@
+// Package spill holds the per-file word lists the indexers need after
+// parsing, either in memory or, for --low-memory, in a temporary file so page
+// content isn't kept for the whole run.
+package spill
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+)
+
+// Store collects word lists by key and replays them, in insertion order for
+// file stores. All may be called more than once.
+type Store interface {
+	Add(key string, words []string)
+	All() iter.Seq2[string, []string]
+	Err() error   // First error writing or reading the store
+	Close() error // Releases the store's resources
+}
+
+// Memory is a Store kept in memory
+type Memory struct {
+	keys  []string
+	words map[string][]string
+}
+
+// NewMemory returns an empty in-memory Store
+func NewMemory() *Memory {
+	return &Memory{words: make(map[string][]string)}
+}
+
+func (m *Memory) Add(key string, words []string) {
+	if _, exists := m.words[key]; !exists {
+		m.keys = append(m.keys, key)
+	}
+	m.words[key] = words
+}
+
+func (m *Memory) All() iter.Seq2[string, []string] {
+	return func(yield func(string, []string) bool) {
+		for _, key := range m.keys {
+			if !yield(key, m.words[key]) {
+				return
+			}
+		}
+	}
+}
+
+func (m *Memory) Err() error   { return nil }
+func (m *Memory) Close() error { return nil }
+
+// File is a Store backed by a temporary JSON lines file
+type File struct {
+	file *os.File
+	buf  *bufio.Writer
+	err  error
+}
+
+// entry is one line of a File store
+type entry struct {
+	Key   string   `json:"k"`
+	Words []string `json:"w"`
+}
+
+// NewFile creates a Store in a temporary file in dir (os.TempDir() when empty)
+func NewFile(dir string) (*File, error) {
+	f, err := os.CreateTemp(dir, "logseq-indexer-words-*.jsonl")
+	if err != nil {
+		return nil, fmt.Errorf("creating spill file: %w", err)
+	}
+	return &File{file: f, buf: bufio.NewWriter(f)}, nil
+}
+
+func (s *File) Add(key string, words []string) {
+	if s.err != nil {
+		return
+	}
+	line, err := json.Marshal(entry{key, words})
+	if err == nil {
+		_, err = s.buf.Write(append(line, '\n'))
+	}
+	if err != nil {
+		s.err = fmt.Errorf("writing spill file: %w", err)
+	}
+}
+
+// All reads the file back one entry at a time. Adding while iterating isn't supported.
+func (s *File) All() iter.Seq2[string, []string] {
+	return func(yield func(string, []string) bool) {
+		if s.err != nil {
+			return
+		}
+		if err := s.buf.Flush(); err != nil {
+			s.err = fmt.Errorf("writing spill file: %w", err)
+			return
+		}
+		if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+			s.err = fmt.Errorf("reading spill file: %w", err)
+			return
+		}
+		defer s.file.Seek(0, io.SeekEnd)
+
+		dec := json.NewDecoder(bufio.NewReader(s.file))
+		for {
+			var e entry
+			if err := dec.Decode(&e); err == io.EOF {
+				return
+			} else if err != nil {
+				s.err = fmt.Errorf("reading spill file: %w", err)
+				return
+			}
+			if !yield(e.Key, e.Words) {
+				return
+			}
+		}
+	}
+}
+
+func (s *File) Err() error { return s.err }
+
+// Close removes the temporary file
+func (s *File) Close() error {
+	s.file.Close()
+	return os.Remove(s.file.Name())
+}
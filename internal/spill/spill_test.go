@@ -0,0 +1,61 @@
+package spill
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestStores(t *testing.T) {
+	file, err := NewFile(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFile failed: %v", err)
+	}
+
+	for name, store := range map[string]Store{"memory": NewMemory(), "file": file} {
+		store.Add("Alpha", []string{"kubernetes", "budget"})
+		store.Add("journals/2025_11_03.md", nil)
+		store.Add("Beta", []string{"roadmap"})
+
+		// Each pass replays every entry in order
+		for pass := 0; pass < 2; pass++ {
+			var keys []string
+			var words [][]string
+			for key, w := range store.All() {
+				keys = append(keys, key)
+				words = append(words, w)
+			}
+			if want := []string{"Alpha", "journals/2025_11_03.md", "Beta"}; !reflect.DeepEqual(keys, want) {
+				t.Errorf("%s pass %d: expected keys %v, got %v", name, pass, want, keys)
+			}
+			if len(words) != 3 || !reflect.DeepEqual(words[0], []string{"kubernetes", "budget"}) || len(words[1]) != 0 {
+				t.Errorf("%s pass %d: unexpected words %v", name, pass, words)
+			}
+		}
+
+		// Stopping early leaves the store readable
+		for range store.All() {
+			break
+		}
+		store.Add("Gamma", []string{"later"})
+		count := 0
+		for range store.All() {
+			count++
+		}
+		if count != 4 {
+			t.Errorf("%s: expected 4 entries after adding more, got %d", name, count)
+		}
+
+		if err := store.Err(); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+	}
+
+	path := file.file.Name()
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the spill file to be removed")
+	}
+}
@@ -0,0 +1,108 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/scanner"
+	"github.com/fsnotify/fsnotify"
+)
+
+// NotifyWatcher uses OS file notifications (inotify, FSEvents, ReadDirectoryChangesW)
+type NotifyWatcher struct {
+	repoPath string
+	fsw      *fsnotify.Watcher
+}
+
+// newNotifyWatcher creates an fsnotify watcher on all watched directories
+func newNotifyWatcher(repoPath string) (*NotifyWatcher, error) {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("creating fsnotify watcher: %w", err)
+	}
+
+	w := &NotifyWatcher{repoPath: repoPath, fsw: fsw}
+	for _, dir := range watchedDirs {
+		if err := w.addTree(filepath.Join(repoPath, dir)); err != nil {
+			fsw.Close()
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Name returns the strategy name
+func (w *NotifyWatcher) Name() string {
+	return string(StrategyNotify)
+}
+
+// Watch forwards markdown change events until ctx is cancelled
+func (w *NotifyWatcher) Watch(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		defer close(changes)
+		defer w.fsw.Close()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-w.fsw.Events:
+				if !ok {
+					return
+				}
+
+				// fsnotify isn't recursive, so start watching new subdirectories
+				if event.Has(fsnotify.Create) {
+					if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+						w.addTree(event.Name)
+						continue
+					}
+				}
+
+				if !scanner.HasIndexedExtension(event.Name) {
+					continue
+				}
+				select {
+				case changes <- struct{}{}:
+				default: // A change is already pending
+				}
+			case _, ok := <-w.fsw.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// addTree watches dir and all its non-hidden subdirectories
+func (w *NotifyWatcher) addTree(dir string) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "bak") {
+			return filepath.SkipDir
+		}
+		if err := w.fsw.Add(path); err != nil {
+			return fmt.Errorf("watching %s: %w", path, err)
+		}
+		return nil
+	})
+}
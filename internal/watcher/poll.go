@@ -0,0 +1,114 @@
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/scanner"
+)
+
+// DefaultPollInterval is used when no interval is configured
+const DefaultPollInterval = 2 * time.Second
+
+// PollWatcher detects changes by periodically comparing file modification times and sizes
+type PollWatcher struct {
+	repoPath string
+	interval time.Duration
+}
+
+// fileState is the part of a file's metadata that signals a change
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewPollWatcher creates a polling watcher; a non-positive interval uses DefaultPollInterval
+func NewPollWatcher(repoPath string, interval time.Duration) *PollWatcher {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	return &PollWatcher{repoPath: repoPath, interval: interval}
+}
+
+// Name returns the strategy name
+func (w *PollWatcher) Name() string {
+	return string(StrategyPoll)
+}
+
+// Watch polls until ctx is cancelled
+func (w *PollWatcher) Watch(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	previous := w.snapshot()
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				current := w.snapshot()
+				if !sameSnapshot(previous, current) {
+					previous = current
+					select {
+					case changes <- struct{}{}:
+					default: // A change is already pending
+					}
+				}
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// snapshot records the state of every markdown file in the watched directories
+func (w *PollWatcher) snapshot() map[string]fileState {
+	state := make(map[string]fileState)
+
+	for _, dir := range watchedDirs {
+		filepath.WalkDir(filepath.Join(w.repoPath, dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() {
+				if strings.HasPrefix(d.Name(), ".") || d.Name() == "bak" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !scanner.HasIndexedExtension(path) {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			state[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+			return nil
+		})
+	}
+
+	return state
+}
+
+// sameSnapshot reports whether two snapshots describe the same files
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, sa := range a {
+		sb, ok := b[path]
+		if !ok || !sa.modTime.Equal(sb.modTime) || sa.size != sb.size {
+			return false
+		}
+	}
+	return true
+}
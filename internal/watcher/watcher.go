@@ -0,0 +1,62 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Strategy selects how filesystem changes are detected
+type Strategy string
+
+const (
+	StrategyAuto   Strategy = "auto"   // fsnotify, falling back to polling when unavailable or unreliable
+	StrategyNotify Strategy = "notify" // OS file notifications via fsnotify
+	StrategyPoll   Strategy = "poll"   // Periodic modification-time scan
+)
+
+// Watcher reports changes to a Logseq repository's pages/ and journals/ directories
+type Watcher interface {
+	// Watch blocks until ctx is cancelled, sending on the returned channel
+	// whenever one or more markdown files change
+	Watch(ctx context.Context) (<-chan struct{}, error)
+	// Name returns the strategy in use, for logging
+	Name() string
+}
+
+// watchedDirs are the repository subdirectories that affect index output
+var watchedDirs = []string{"journals", "pages"}
+
+// New creates a Watcher for repoPath using the given strategy.
+// With StrategyAuto, polling is used on WSL (where inotify misses changes made
+// from Windows) or when fsnotify can't be initialised, e.g. on network filesystems.
+func New(repoPath string, strategy Strategy, interval time.Duration) (Watcher, error) {
+	switch strategy {
+	case StrategyPoll:
+		return NewPollWatcher(repoPath, interval), nil
+	case StrategyNotify:
+		return newNotifyWatcher(repoPath)
+	case StrategyAuto, "":
+		if isWSL() {
+			return NewPollWatcher(repoPath, interval), nil
+		}
+		w, err := newNotifyWatcher(repoPath)
+		if err != nil {
+			return NewPollWatcher(repoPath, interval), nil
+		}
+		return w, nil
+	default:
+		return nil, fmt.Errorf("unknown watch strategy %q (expected auto, notify, or poll)", strategy)
+	}
+}
+
+// isWSL detects the Windows Subsystem for Linux
+func isWSL() bool {
+	data, err := os.ReadFile("/proc/version")
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(data)), "microsoft")
+}
@@ -0,0 +1,72 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPollWatcher_DetectsChanges(t *testing.T) {
+	repo := t.TempDir()
+	pagesDir := filepath.Join(repo, "pages")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pagesDir, "A.md"), []byte("- note"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	w := NewPollWatcher(repo, 10*time.Millisecond)
+	changes, err := w.Watch(ctx)
+	if err != nil {
+		t.Fatalf("Watch failed: %v", err)
+	}
+
+	// Non-markdown files are ignored
+	if err := os.WriteFile(filepath.Join(pagesDir, "image.png"), []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+		t.Fatal("Unexpected change for non-markdown file")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if err := os.WriteFile(filepath.Join(pagesDir, "B.md"), []byte("- new page"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+	case <-time.After(time.Second):
+		t.Fatal("Expected change notification for new markdown file")
+	}
+
+	cancel()
+	for range changes {
+		// Drain until the watcher closes the channel
+	}
+}
+
+func TestNew_Strategies(t *testing.T) {
+	repo := t.TempDir()
+
+	w, err := New(repo, StrategyPoll, 0)
+	if err != nil {
+		t.Fatalf("New(poll) failed: %v", err)
+	}
+	if w.Name() != "poll" {
+		t.Errorf("Expected poll watcher, got %s", w.Name())
+	}
+	if pw := w.(*PollWatcher); pw.interval != DefaultPollInterval {
+		t.Errorf("Expected default interval, got %v", pw.interval)
+	}
+
+	if _, err := New(repo, "inotify-please", time.Second); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
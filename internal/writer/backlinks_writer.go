@@ -0,0 +1,200 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// BacklinksDir is the output subdirectory holding one file per page
+const BacklinksDir = "backlinks"
+
+// maxBacklinkContexts caps the contexts listed per page to keep files small
+const maxBacklinkContexts = 30
+
+// WritePageDetails writes one backlinks/<Page>.md file per existing page
+func WritePageDetails(index *indexer.PageDetailsIndex, outputDir string) error {
+	dir := filepath.Join(outputDir, BacklinksDir)
+
+	// Start clean so renamed or deleted pages don't leave stale files behind
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("clearing backlinks directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating backlinks directory: %w", err)
+	}
+
+	for _, page := range index.Pages {
+		if err := writePageDetail(page, index.GeneratedAt, dir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writePageDetail writes a single page's backlinks file
+func writePageDetail(page *indexer.PageDetail, generatedAt time.Time, dir string) error {
+	filePath := filepath.Join(dir, PageDetailFileName(page.Name))
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# [[%s]]\n\n", page.Name)
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), generatedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "- **%s**: `%s`\n", tr("File"), page.FilePath)
+	if len(page.Keywords) > 0 {
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Keywords"), strings.Join(page.Keywords, ", "))
+	}
+	if page.Language != "" {
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Language"), page.Language)
+	}
+	if s := page.Sessions; s != nil {
+		fmt.Fprintf(f, "- **%s**: %s", tr("Usually worked on"), s.TimeOfDay)
+		if s.TimeOfDay != "varied times" {
+			fmt.Fprintf(f, " ("+tr("around %02d:%02d")+")", int(s.TypicalStart.Hours()), int(s.TypicalStart.Minutes())%60)
+		}
+		fmt.Fprintf(f, ", "+tr("~%s sessions (%d logged)")+"\n", formatDuration(s.TypicalLength), s.Sessions)
+	}
+	if len(page.RecentJournals) > 0 {
+		fmt.Fprintf(f, "- **%s**: %s (%s)\n", tr("Last in journals"), page.RecentJournals[0].Date.Format("2006-01-02"), daysAgo(page.RecentJournals[0].Date, generatedAt))
+	}
+	fmt.Fprintf(f, "\n")
+
+	writeTaskRollups(f, page, generatedAt)
+
+	// Journal days mentioning the page, newest first
+	if len(page.RecentJournals) > 0 {
+		switch {
+		case page.JournalDays > len(page.RecentJournals):
+			fmt.Fprintf(f, "## "+tr("Recent Journals (%d of %d days)")+"\n\n", len(page.RecentJournals), page.JournalDays)
+		case page.JournalDays == 1:
+			fmt.Fprintf(f, "## %s\n\n", tr("Recent Journals (1 day)"))
+		default:
+			fmt.Fprintf(f, "## "+tr("Recent Journals (%d days)")+"\n\n", page.JournalDays)
+		}
+		for _, j := range page.RecentJournals {
+			mentions := ""
+			if j.Mentions > 1 {
+				mentions = fmt.Sprintf(" (%d %s)", j.Mentions, tr("mentions"))
+			}
+			fmt.Fprintf(f, "- **[[%s]]**%s `%s:%d` %s\n", j.Page, mentions, j.File, j.Line, j.Snippet)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Backlinks grouped by source page
+	fmt.Fprintf(f, "## %s (%d)\n\n", tr("Backlinks"), len(page.Backlinks))
+	if len(page.Backlinks) == 0 {
+		fmt.Fprintf(f, "*%s*\n", tr("No pages link here."))
+		return nil
+	}
+
+	limit := maxBacklinkContexts
+	if len(page.Backlinks) < limit {
+		limit = len(page.Backlinks)
+	}
+
+	currentSource := ""
+	for _, ref := range page.Backlinks[:limit] {
+		if ref.SourcePage != currentSource {
+			currentSource = ref.SourcePage
+			fmt.Fprintf(f, "### [[%s]]\n", ref.SourcePage)
+		}
+		fmt.Fprintf(f, "- `%s:%d` %s\n", ref.SourceFile, ref.LineNumber, ref.Context)
+	}
+
+	if len(page.Backlinks) > limit {
+		fmt.Fprintf(f, "\n*"+tr("+%d more backlinks")+"*\n", len(page.Backlinks)-limit)
+	}
+	fmt.Fprintf(f, "\n")
+
+	return nil
+}
+
+// writeTaskRollups writes the open tasks on and around a page: one row for
+// the page itself, then one per neighbouring page with open tasks
+func writeTaskRollups(f *os.File, page *indexer.PageDetail, now time.Time) {
+	if (page.Tasks == nil || page.Tasks.Open == 0) && len(page.NeighborTasks) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Open Tasks"))
+	fmt.Fprintf(f, "| %s | %s | %s | %s | %s |\n", tr("Page"), tr("Open"), tr("By Status"), tr("Next Due"), tr("Time Logged"))
+	fmt.Fprintf(f, "|------|------|-----------|----------|-------------|\n")
+	self := indexer.TaskRollup{Page: page.Name}
+	if page.Tasks != nil {
+		self = *page.Tasks
+	}
+	writeTaskRollupRow(f, self, "**"+tr("This page")+"**", now)
+	for _, rollup := range page.NeighborTasks {
+		writeTaskRollupRow(f, rollup, "[["+rollup.Page+"]]", now)
+	}
+	fmt.Fprintf(f, "\n*%s*\n\n", tr("Tasks written on or linking each page. Neighbours are pages linked from or to this one."))
+}
+
+// writeTaskRollupRow writes one row of the open tasks table
+func writeTaskRollupRow(f *os.File, rollup indexer.TaskRollup, label string, now time.Time) {
+	var statuses []string
+	for _, status := range models.Statuses() {
+		if count := rollup.ByStatus[status]; count > 0 {
+			statuses = append(statuses, fmt.Sprintf("%d %s", count, status))
+		}
+	}
+	byStatus := strings.Join(statuses, ", ")
+	if byStatus == "" {
+		byStatus = "-"
+	}
+
+	nextDue := "-"
+	if !rollup.NextDue.IsZero() {
+		nextDue = rollup.NextDue.Format("2006-01-02")
+		if nextDue < now.Format("2006-01-02") {
+			nextDue += " (" + tr("overdue") + ")"
+		}
+	}
+
+	timeLogged := "-"
+	if rollup.TimeLogged > 0 {
+		timeLogged = formatDuration(rollup.TimeLogged)
+	}
+
+	fmt.Fprintf(f, "| %s | %d | %s | %s | %s |\n", label, rollup.Open, byStatus, nextDue, timeLogged)
+}
+
+// daysAgo describes how long before now a journal date was
+func daysAgo(date, now time.Time) string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	switch days := int(today.Sub(day).Hours() / 24); {
+	case days <= 0:
+		return tr("today")
+	case days == 1:
+		return tr("yesterday")
+	default:
+		return fmt.Sprintf(tr("%d days ago"), days)
+	}
+}
+
+// PageDetailFileName converts a page name into a safe file name, following
+// Logseq's convention of encoding namespace slashes as "___"
+func PageDetailFileName(pageName string) string {
+	name := strings.ReplaceAll(pageName, "/", "___")
+	name = strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', ':', '*', '?', '"', '<', '>', '|':
+			return '_'
+		}
+		return r
+	}, name)
+	return name + ".md"
+}
@@ -0,0 +1,142 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWritePageDetails(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A stale file from a previous run should be removed
+	staleDir := filepath.Join(tmpDir, BacklinksDir)
+	os.MkdirAll(staleDir, 0755)
+	os.WriteFile(filepath.Join(staleDir, "Deleted.md"), []byte("old"), 0644)
+
+	index := &indexer.PageDetailsIndex{
+		GeneratedAt: time.Date(2025, 11, 15, 10, 0, 0, 0, time.Local),
+		Pages: []*indexer.PageDetail{
+			{
+				Name:     "Projects/Phoenix",
+				FilePath: "pages/Projects___Phoenix.md",
+				Keywords: []string{"budget", "kubernetes"},
+				Sessions: &indexer.SessionPattern{
+					Sessions:      12,
+					TimeOfDay:     "mornings",
+					TypicalStart:  9*time.Hour + 30*time.Minute,
+					TypicalLength: 90 * time.Minute,
+				},
+				Backlinks: []models.PageReference{
+					{SourcePage: "2025_11_03", TargetPage: "Projects/Phoenix", SourceFile: "journals/2025_11_03.md", LineNumber: 2, Context: "Review [[Projects/Phoenix]]"},
+				},
+				RecentJournals: []indexer.JournalMention{
+					{Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), Page: "2025_11_03", File: "journals/2025_11_03.md", Line: 2, Mentions: 2, Snippet: "Review [[Projects/Phoenix]]"},
+				},
+				JournalDays: 4,
+			},
+		},
+	}
+
+	if err := WritePageDetails(index, tmpDir); err != nil {
+		t.Fatalf("WritePageDetails failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(staleDir, "Deleted.md")); !os.IsNotExist(err) {
+		t.Error("Expected stale backlinks file to be removed")
+	}
+
+	content, err := os.ReadFile(filepath.Join(staleDir, "Projects___Phoenix.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "- **Keywords**: budget, kubernetes") {
+		t.Errorf("Expected keywords line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- **Usually worked on**: mornings (around 09:30), ~1h 30m sessions (12 logged)") {
+		t.Errorf("Expected session hint, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- **Last in journals**: 2025-11-03 (12 days ago)") {
+		t.Errorf("Expected last journal line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "## Recent Journals (1 of 4 days)\n\n- **[[2025_11_03]]** (2 mentions) `journals/2025_11_03.md:2` Review [[Projects/Phoenix]]") {
+		t.Errorf("Expected recent journals section, got:\n%s", output)
+	}
+	if !strings.Contains(output, "## Backlinks (1)") {
+		t.Error("Expected backlinks section")
+	}
+	if !strings.Contains(output, "Review [[Projects/Phoenix]]") {
+		t.Error("Expected backlink context")
+	}
+}
+
+func TestPageDetailFileName(t *testing.T) {
+	tests := map[string]string{
+		"Simple":        "Simple.md",
+		"Projects/App":  "Projects___App.md",
+		"What? Why: Me": "What_ Why_ Me.md",
+	}
+	for name, want := range tests {
+		if got := PageDetailFileName(name); got != want {
+			t.Errorf("PageDetailFileName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestWritePageDetails_TaskRollups(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.PageDetailsIndex{
+		GeneratedAt: time.Date(2025, 11, 15, 10, 0, 0, 0, time.UTC),
+		Pages: []*indexer.PageDetail{
+			{
+				Name:     "Phoenix",
+				FilePath: "pages/Phoenix.md",
+				Tasks: &indexer.TaskRollup{
+					Page:       "Phoenix",
+					Open:       3,
+					ByStatus:   map[models.TaskStatus]int{models.StatusTODO: 2, models.StatusNOW: 1},
+					NextDue:    time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC),
+					TimeLogged: 90 * time.Minute,
+				},
+				NeighborTasks: []indexer.TaskRollup{
+					{Page: "Vendor", Open: 1, ByStatus: map[models.TaskStatus]int{models.StatusLATER: 1}},
+				},
+			},
+			{Name: "Quiet", FilePath: "pages/Quiet.md"},
+		},
+	}
+
+	if err := WritePageDetails(index, tmpDir); err != nil {
+		t.Fatalf("WritePageDetails failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, BacklinksDir, "Phoenix.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+	for _, want := range []string{
+		"## Open Tasks",
+		"| **This page** | 3 | 1 NOW, 2 TODO | 2025-11-12 (overdue) | 1h 30m |",
+		"| [[Vendor]] | 1 | 1 LATER | - | - |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+
+	quiet, err := os.ReadFile(filepath.Join(tmpDir, BacklinksDir, "Quiet.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if strings.Contains(string(quiet), "## Open Tasks") {
+		t.Errorf("Expected no task rollup without open tasks, got:\n%s", quiet)
+	}
+}
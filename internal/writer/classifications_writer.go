@@ -0,0 +1,89 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// ClassificationsFileName lists every heuristic guess with its confidence
+const ClassificationsFileName = "classifications.json"
+
+// classificationsJSON holds the heuristic guesses behind the markdown
+// indexes, so a reader can tell a strong signal from a weak one
+type classificationsJSON struct {
+	GeneratedAt   time.Time                `json:"generated_at"`
+	MinConfidence float64                  `json:"min_confidence"` // Guesses below it are hidden from markdown outputs
+	PageTypes     []pageTypeGuessJSON      `json:"page_types"`
+	ProjectStatus []projectStatusGuessJSON `json:"project_status"`
+}
+
+// pageTypeGuessJSON is the guessed type of a missing page
+type pageTypeGuessJSON struct {
+	Page       string  `json:"page"`
+	Type       string  `json:"type"` // person, date, project, concept, or a custom type
+	Confidence float64 `json:"confidence"`
+	References int     `json:"references"`
+}
+
+// projectStatusGuessJSON is a project that looks finished
+type projectStatusGuessJSON struct {
+	Page         string  `json:"page"`
+	Status       string  `json:"status"` // "possibly_complete"
+	Confidence   float64 `json:"confidence"`
+	DoneTasks    int     `json:"done_tasks"`
+	LastActivity string  `json:"last_activity"` // YYYY-MM-DD
+	QuietWeeks   int     `json:"quiet_weeks"`
+	File         string  `json:"file"`
+}
+
+// WriteClassifications writes classifications.json: the type guessed for
+// each missing page and the projects guessed to be complete, each with its
+// confidence from 0 to 1
+func WriteClassifications(missingPages *indexer.MissingPagesIndex, tasks *indexer.TaskIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	out := classificationsJSON{
+		GeneratedAt:   time.Now().UTC(),
+		MinConfidence: minConfidence,
+		PageTypes:     []pageTypeGuessJSON{},
+		ProjectStatus: []projectStatusGuessJSON{},
+	}
+	for _, page := range missingPages.MissingPages {
+		out.PageTypes = append(out.PageTypes, pageTypeGuessJSON{
+			Page:       page.Name,
+			Type:       page.PageType,
+			Confidence: roundConfidence(page.TypeConfidence),
+			References: page.ReferenceCount,
+		})
+	}
+	for _, c := range tasks.CompletionCandidates {
+		out.ProjectStatus = append(out.ProjectStatus, projectStatusGuessJSON{
+			Page:         c.Page,
+			Status:       "possibly_complete",
+			Confidence:   roundConfidence(c.Confidence),
+			DoneTasks:    c.DoneTasks,
+			LastActivity: c.LastActivity.Format("2006-01-02"),
+			QuietWeeks:   c.QuietWeeks,
+			File:         filepath.ToSlash(c.FilePath),
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding classifications: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, ClassificationsFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing classifications: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteClassifications(t *testing.T) {
+	if err := SetMinConfidence(0.5); err != nil {
+		t.Fatal(err)
+	}
+	defer SetMinConfidence(0)
+
+	tmpDir := t.TempDir()
+	missing := &indexer.MissingPagesIndex{MissingPages: []indexer.MissingPage{
+		{Name: "Alice Johnson", ReferenceCount: 8, PageType: "person", TypeConfidence: 0.7000000000000001},
+		{Name: "GraphQL", ReferenceCount: 5, PageType: "concept", TypeConfidence: indexer.ConfidenceFallback},
+	}}
+	tasks := &indexer.TaskIndex{CompletionCandidates: []indexer.CompletionCandidate{
+		{Page: "Phoenix", FilePath: "pages/Phoenix.md", DoneTasks: 3, QuietWeeks: 9, Confidence: 0.8,
+			LastActivity: time.Date(2025, 9, 29, 0, 0, 0, 0, time.UTC)},
+	}}
+
+	if err := WriteClassifications(missing, tasks, tmpDir); err != nil {
+		t.Fatalf("WriteClassifications failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ClassificationsFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	var got classificationsJSON
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, data)
+	}
+
+	if got.MinConfidence != 0.5 {
+		t.Errorf("Expected min_confidence 0.5, got %v", got.MinConfidence)
+	}
+	if len(got.PageTypes) != 2 || got.PageTypes[0] != (pageTypeGuessJSON{"Alice Johnson", "person", 0.7, 8}) {
+		t.Errorf("Unexpected page types: %+v", got.PageTypes)
+	}
+	want := projectStatusGuessJSON{"Phoenix", "possibly_complete", 0.8, 3, "2025-09-29", 9, "pages/Phoenix.md"}
+	if len(got.ProjectStatus) != 1 || got.ProjectStatus[0] != want {
+		t.Errorf("Expected %+v, got %+v", want, got.ProjectStatus)
+	}
+}
+
+func TestSetMinConfidence_Invalid(t *testing.T) {
+	for _, v := range []float64{-0.1, 1.5} {
+		if err := SetMinConfidence(v); err == nil {
+			t.Errorf("Expected an error for %v", v)
+		}
+	}
+}
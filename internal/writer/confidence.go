@@ -0,0 +1,43 @@
+package writer
+
+import (
+	"fmt"
+	"math"
+)
+
+// minConfidence is the configured confidence below which markdown outputs
+// hide heuristic guesses (see SetMinConfidence)
+var minConfidence float64
+
+// SetMinConfidence hides heuristic guesses scoring below min (0 to 1) from
+// markdown outputs. Zero shows everything. JSON outputs keep every guess
+// with its score.
+func SetMinConfidence(min float64) error {
+	if min < 0 || min > 1 {
+		return fmt.Errorf("invalid minimum confidence %g (expected 0 to 1)", min)
+	}
+	minConfidence = min
+	return nil
+}
+
+// confident reports whether a guess scores high enough to show in markdown
+func confident(confidence float64) bool {
+	return roundConfidence(confidence) >= minConfidence
+}
+
+// unclassified is the page type shown for a type guess below the minimum confidence
+const unclassified = "unclassified"
+
+// shownPageType returns a missing page's type, or "unclassified" when the
+// guess isn't confident enough to show
+func shownPageType(pageType string, confidence float64) string {
+	if !confident(confidence) {
+		return unclassified
+	}
+	return pageType
+}
+
+// roundConfidence rounds a confidence to two decimals for JSON outputs
+func roundConfidence(confidence float64) float64 {
+	return math.Round(confidence*100) / 100
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -17,6 +18,10 @@ func WriteDashboard(
 	timelineIndex *indexer.TimelineIndex,
 	missingPagesIndex *indexer.MissingPagesIndex,
 	timeTrackingIndex *indexer.TimeTrackingIndex,
+	trendsIndex *indexer.TrendsIndex,
+	effortIndex *indexer.EffortIndex,
+	changes *indexer.Changes,
+	inbox *indexer.InboxIndex,
 	outputDir string,
 ) error {
 	outputPath := filepath.Join(outputDir, "dashboard.md")
@@ -27,23 +32,39 @@ func WriteDashboard(
 	defer f.Close()
 
 	// Header
-	fmt.Fprintf(f, "# Knowledge Dashboard\n\n")
-	fmt.Fprintf(f, "**Generated**: %s\n\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "# %s\n\n", tr("Knowledge Dashboard"))
+	fmt.Fprintf(f, "**%s**: %s\n\n", tr("Generated"), time.Now().UTC().Format(time.RFC3339))
 
 	// Quick Stats
-	fmt.Fprintf(f, "## 📊 Quick Stats\n\n")
-	fmt.Fprintf(f, "- **Total Tasks**: %d\n", taskIndex.TotalTasks)
+	fmt.Fprintf(f, "## %s%s\n\n", icon("📊"), tr("Quick Stats"))
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("Total Tasks"), taskIndex.TotalTasks)
 
 	// Calculate completed tasks from status breakdown
 	doneCount := 0
 	if count, exists := taskIndex.Statistics.StatusBreakdown[models.StatusDONE]; exists {
 		doneCount = count
 	}
-	fmt.Fprintf(f, "- **Completion Rate**: %.1f%% (%d DONE)\n",
+	fmt.Fprintf(f, "- **%s**: %.1f%% (%d DONE)\n", tr("Completion Rate"),
 		taskIndex.Statistics.CompletionRate, doneCount)
 
+	if len(taskIndex.Orphans) > 0 {
+		fmt.Fprintf(f, "- **%s**: "+plural(len(taskIndex.Orphans),
+			"%d open journal task referencing nothing, oldest %dd (see tasks-by-status.md)",
+			"%d open journal tasks referencing nothing, oldest %dd (see tasks-by-status.md)")+"\n",
+			tr("Orphan Tasks"), len(taskIndex.Orphans), taskIndex.Orphans[0].AgeDays)
+	}
+
+	if inbox != nil {
+		oldest := ""
+		if age := inbox.Oldest(); age >= 0 {
+			oldest = fmt.Sprintf(tr(", oldest %dd"), age)
+		}
+		fmt.Fprintf(f, "- **%s**: "+plural(len(inbox.Items), "%d unprocessed item%s (see inbox.md)", "%d unprocessed items%s (see inbox.md)")+"\n",
+			tr("Inbox"), len(inbox.Items), oldest)
+	}
+
 	if timeTrackingIndex.Statistics.TasksWithTracking > 0 {
-		fmt.Fprintf(f, "- **Time Tracking**: %.1f%% adoption, %s logged\n",
+		fmt.Fprintf(f, "- **%s**: "+tr("%.1f%% adoption, %s logged")+"\n", tr("Time Tracking"),
 			timeTrackingIndex.Statistics.AdoptionRate,
 			formatDuration(timeTrackingIndex.TotalTimeLogged))
 	}
@@ -53,10 +74,60 @@ func WriteDashboard(
 	for _, node := range graphIndex.Nodes {
 		totalRefs += len(node.InboundRefs)
 	}
-	fmt.Fprintf(f, "- **Knowledge Graph**: %d pages, %d references\n",
+	fmt.Fprintf(f, "- **%s**: "+tr("%d pages, %d references")+"\n", tr("Knowledge Graph"),
 		len(graphIndex.Nodes), totalRefs)
 	fmt.Fprintf(f, "\n")
 
+	// Since Last Run (deltas against the previous generation; nil on the first run)
+	if changes != nil {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("🔄"), tr("Since Last Run"))
+		fmt.Fprintf(f, "- **%s**: %d\n", tr("Tasks Added"), len(changes.TasksAdded))
+		fmt.Fprintf(f, "- **%s**: %d\n", tr("Tasks Completed"), len(changes.TasksCompleted))
+		fmt.Fprintf(f, "- **%s**: %d\n", tr("New Pages"), len(changes.NewPages))
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Time Logged"), formatDuration(changes.TimeLogged))
+
+		limit := 5
+		if len(changes.TasksCompleted) < limit {
+			limit = len(changes.TasksCompleted)
+		}
+		if limit > 0 {
+			fmt.Fprintf(f, "\n")
+		}
+		for _, task := range changes.TasksCompleted[:limit] {
+			desc := task.Description
+			if len(desc) > 80 {
+				desc = desc[:77] + "..."
+			}
+			fmt.Fprintf(f, "- %s%s `%s:%d`\n", statusMarker(task.Status), desc, task.SourceFile, task.LineNumber)
+		}
+		if len(changes.NewPages) > 0 {
+			shown := changes.NewPages
+			if len(shown) > 5 {
+				shown = shown[:5]
+			}
+			fmt.Fprintf(f, "\n%s: [[%s]]", tr("New Pages"), strings.Join(shown, "]], [["))
+			if len(changes.NewPages) > len(shown) {
+				fmt.Fprintf(f, " *"+tr("+%d more")+"*", len(changes.NewPages)-len(shown))
+			}
+			fmt.Fprintf(f, "\n")
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Pinned Pages (config.edn favorites and Contents links)
+	if len(graphIndex.Pinned) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📌"), tr("Pinned Pages"))
+		for _, pageName := range graphIndex.Pinned {
+			node := graphIndex.Nodes[pageName]
+			if node.FilePath != "" {
+				fmt.Fprintf(f, "- **[[%s]]** ("+plural(node.ReferenceCount, "%d ref", "%d refs")+") `%s`\n", pageName, node.ReferenceCount, node.FilePath)
+			} else {
+				fmt.Fprintf(f, "- **[[%s]]** ("+plural(node.ReferenceCount, "%d ref", "%d refs")+", *%s*)\n", pageName, node.ReferenceCount, tr("not yet created"))
+			}
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
 	// Current Priorities (High priority NOW and TODO tasks)
 	highPriorityTasks := []models.Task{}
 	if tasks, exists := taskIndex.ByPriority[models.PriorityHigh]; exists {
@@ -68,7 +139,7 @@ func WriteDashboard(
 	}
 
 	if len(highPriorityTasks) > 0 {
-		fmt.Fprintf(f, "## 🎯 Current Priorities [#A]\n\n")
+		fmt.Fprintf(f, "## %s%s\n\n", icon("🎯"), tr("Current Priorities [#A]"))
 		count := 0
 		for _, task := range highPriorityTasks {
 			if count >= 5 { // Show max 5
@@ -78,8 +149,8 @@ func WriteDashboard(
 			if len(desc) > 80 {
 				desc = desc[:77] + "..."
 			}
-			fmt.Fprintf(f, "- **[%s]** %s `%s:%d`\n",
-				task.Status, desc, task.SourceFile, task.LineNumber)
+			fmt.Fprintf(f, "- %s%s `%s:%d`\n",
+				statusMarker(task.Status), desc, task.SourceFile, task.LineNumber)
 			count++
 		}
 		highPriorityCount := 0
@@ -87,54 +158,156 @@ func WriteDashboard(
 			highPriorityCount = count
 		}
 		if highPriorityCount > 5 {
-			fmt.Fprintf(f, "\n*+%d more high priority tasks*\n", highPriorityCount-5)
+			fmt.Fprintf(f, "\n*"+tr("+%d more high priority tasks")+"*\n", highPriorityCount-5)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Quick Wins (open tasks under 30 minutes)
+	if len(effortIndex.QuickWins) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("⚡"), tr("Quick Wins"))
+		fmt.Fprintf(f, "*"+tr("Open tasks likely to take under %s")+"*\n\n", formatDuration(indexer.QuickWinLimit))
+		limit := 5
+		if len(effortIndex.QuickWins) < limit {
+			limit = len(effortIndex.QuickWins)
+		}
+		for _, e := range effortIndex.QuickWins[:limit] {
+			desc := e.Task.Description
+			if len(desc) > 80 {
+				desc = desc[:77] + "..."
+			}
+			fmt.Fprintf(f, "- %s%s (%s) `%s:%d`\n",
+				statusMarker(e.Task.Status), desc, effortLabel(e), e.Task.SourceFile, e.Task.LineNumber)
+		}
+		if len(effortIndex.QuickWins) > limit {
+			fmt.Fprintf(f, "\n*"+tr("+%d more quick wins")+"*\n", len(effortIndex.QuickWins)-limit)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Waiting on Others (delegated open tasks)
+	if len(taskIndex.WaitingOn) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("🤝"), tr("Waiting on Others"))
+		for _, group := range taskIndex.WaitingOn {
+			oldest := ""
+			if age := group.Tasks[0].AgeDays; age >= 0 {
+				oldest = fmt.Sprintf(tr(", oldest %dd"), age)
+			}
+			fmt.Fprintf(f, "- **%s**: "+plural(len(group.Tasks), "%d task", "%d tasks")+"%s\n",
+				group.Person, len(group.Tasks), oldest)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Back from Snooze (tasks that just returned to the active lists)
+	if len(taskIndex.BackFromSnooze) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("⏰"), tr("Back from Snooze"))
+		for _, task := range taskIndex.BackFromSnooze {
+			desc := task.Description
+			if len(desc) > 80 {
+				desc = desc[:77] + "..."
+			}
+			fmt.Fprintf(f, "- %s%s (%s %s) `%s:%d`\n", statusMarker(task.Status), desc,
+				tr("snoozed until"), task.Snooze.Format("2006-01-02"), task.SourceFile, task.LineNumber)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Inbox (oldest unprocessed quick captures)
+	if inbox != nil && len(inbox.Items) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📥"), tr("Inbox"))
+		for _, entry := range inbox.Items[:min(3, len(inbox.Items))] {
+			age := ""
+			if entry.AgeDays >= 0 {
+				age = fmt.Sprintf(" (%dd)", entry.AgeDays)
+			}
+			fmt.Fprintf(f, "- %s%s\n", entry.Item.Text, age)
+		}
+		if len(inbox.Items) > 3 {
+			fmt.Fprintf(f, "- *"+tr("+%d more in [inbox.md](./inbox.md)")+"*\n", len(inbox.Items)-3)
 		}
 		fmt.Fprintf(f, "\n")
 	}
 
 	// Recent Activity (last 3 days)
 	if len(timelineIndex.Entries) > 0 {
-		fmt.Fprintf(f, "## 📅 Recent Activity\n\n")
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📅"), tr("Recent Activity"))
 		limit := 3
 		if len(timelineIndex.Entries) < limit {
 			limit = len(timelineIndex.Entries)
 		}
 		for i := 0; i < limit; i++ {
 			day := timelineIndex.Entries[i]
-			fmt.Fprintf(f, "### %s\n", day.Date.Format("Monday, Jan 2"))
+			fmt.Fprintf(f, "### %s\n", formatDate(day.Date, "Monday, Jan 2"))
 
-			statusCounts := make(map[string]int)
+			statusCounts := make(map[models.TaskStatus]int)
 			for _, task := range day.TasksCreated {
-				statusCounts[string(task.Status)]++
+				statusCounts[task.Status]++
 			}
 
 			// Show task counts by status
 			if len(statusCounts) > 0 {
-				for _, status := range []string{"NOW", "TODO", "DONE", "DOING", "LATER"} {
+				for _, status := range models.Statuses() {
 					if count := statusCounts[status]; count > 0 {
-						fmt.Fprintf(f, "- %d %s task%s\n", count, status, pluralize(count))
+						fmt.Fprintf(f, "- "+plural(count, "%d %s task", "%d %s tasks")+"\n", count, status)
 					}
 				}
 			}
 
 			if day.TimeLogged > 0 {
-				fmt.Fprintf(f, "- ⏱ %s logged\n", formatDuration(day.TimeLogged))
+				fmt.Fprintf(f, "- %s%s %s\n", emoji("⏱ ", ""), formatDuration(day.TimeLogged), tr("logged"))
 			}
 
 			// Show key activity bullets
-			if len(day.KeyActivity) > 0 {
+			if activity := dayActivity(day); len(activity) > 0 {
 				fmt.Fprintf(f, "\n")
-				for _, activity := range day.KeyActivity {
-					fmt.Fprintf(f, "%s\n", activity)
+				for _, activity := range activity {
+					fmt.Fprintf(f, "%s\n", stripEmoji(activity))
 				}
 			}
 			fmt.Fprintf(f, "\n")
 		}
 	}
 
+	// Writing (journaling habit)
+	if w := timelineIndex.Writing; w != nil && w.WritingDays > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("✍️"), tr("Writing"))
+		thisWeek, lastWeek := 0, 0
+		if len(w.Weeks) > 1 {
+			thisWeek, lastWeek = w.Weeks[0].Words, w.Weeks[1].Words
+		}
+		fmt.Fprintf(f, "- **%s**: %d %s (%d %s)\n", tr("This Week"), thisWeek, tr("words"), lastWeek, tr("last week"))
+		fmt.Fprintf(f, "- **%s**: "+plural(w.CurrentStreak, "%d day", "%d days")+" (%s: %d)\n", tr("Current Streak"),
+			w.CurrentStreak, tr("longest"), w.LongestStreak)
+		fmt.Fprintf(f, "- **%s**: %d\n", tr("Avg Words/Day"), w.AvgWords)
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Emerging Topics (rising journal mentions)
+	if len(trendsIndex.Emerging) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📈"), tr("Emerging Topics"))
+		fmt.Fprintf(f, "*"+tr("Journal days mentioning each topic: the %d before (%d entries) → last %d days (%d entries)")+"*\n\n",
+			trendsIndex.WindowDays, trendsIndex.PreviousDays, trendsIndex.WindowDays, trendsIndex.RecentDays)
+		limit := 5
+		if len(trendsIndex.Emerging) < limit {
+			limit = len(trendsIndex.Emerging)
+		}
+		for _, trend := range trendsIndex.Emerging[:limit] {
+			topic := trend.Topic
+			if trend.IsPage {
+				topic = "[[" + topic + "]]"
+			}
+			fmt.Fprintf(f, "- **%s**: "+tr("%d → %d days")+"\n", topic, trend.Previous, trend.Recent)
+		}
+		if len(trendsIndex.Emerging) > limit {
+			fmt.Fprintf(f, "\n*"+tr("+%d more rising topics")+"*\n", len(trendsIndex.Emerging)-limit)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
 	// Top Projects
 	if len(timeTrackingIndex.TopProjects) > 0 || len(taskIndex.ByProject) > 0 {
-		fmt.Fprintf(f, "## 📁 Top Projects\n\n")
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📁"), tr("Top Projects"))
 
 		// Merge data from both indexes
 		projectData := make(map[string]struct {
@@ -146,7 +319,7 @@ func WriteDashboard(
 		for project, tasks := range taskIndex.ByProject {
 			activeTasks := 0
 			for _, task := range tasks {
-				if task.Status != models.StatusDONE {
+				if !models.IsClosed(task.Status) {
 					activeTasks++
 				}
 			}
@@ -178,7 +351,7 @@ func WriteDashboard(
 				fmt.Fprintf(f, "- **%s**", proj.Project)
 			}
 			if data.TaskCount > 0 {
-				fmt.Fprintf(f, " (%d active task%s)", data.TaskCount, pluralize(data.TaskCount))
+				fmt.Fprintf(f, " ("+plural(data.TaskCount, "%d active task", "%d active tasks")+")", data.TaskCount)
 			}
 			fmt.Fprintf(f, "\n")
 			count++
@@ -186,10 +359,61 @@ func WriteDashboard(
 		fmt.Fprintf(f, "\n")
 	}
 
+	// Possibly Complete (projects with only DONE tasks and no recent activity)
+	var candidates []indexer.CompletionCandidate
+	for _, c := range taskIndex.CompletionCandidates {
+		if confident(c.Confidence) {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("🏁"), tr("Possibly Complete"))
+		fmt.Fprintf(f, "*%s*\n\n", tr("Every task is DONE and nothing has happened for weeks: archive the page or write a retro"))
+		limit := 5
+		if len(candidates) < limit {
+			limit = len(candidates)
+		}
+		for _, c := range candidates[:limit] {
+			fmt.Fprintf(f, "- **[[%s]]**: "+plural(c.DoneTasks, "%d task done", "%d tasks done")+", "+tr("last activity %s (%d weeks ago)")+" `%s`\n",
+				c.Page, c.DoneTasks, c.LastActivity.Format("2006-01-02"), c.QuietWeeks, c.FilePath)
+		}
+		if len(candidates) > limit {
+			fmt.Fprintf(f, "\n*"+tr("+%d more possibly complete projects")+"*\n", len(candidates)-limit)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Time Budgets
+	if len(timeTrackingIndex.Budgets) > 0 {
+		fmt.Fprintf(f, "## %s%s\n\n", icon("⏳"), tr("Time Budgets"))
+		for _, b := range timeTrackingIndex.Budgets {
+			fmt.Fprintf(f, "- %s **%s**: "+tr("%s this week / %s budget (%s)")+"\n",
+				budgetIndicator(b.Status),
+				b.Project,
+				formatDuration(b.ThisWeek),
+				formatDuration(b.WeeklyBudget),
+				tr(b.Status))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Time by Category, for the latest week with logged time
+	if len(timeTrackingIndex.Categories) > 0 && len(timeTrackingIndex.WeeklySummary) > 0 {
+		week := timeTrackingIndex.WeeklySummary[0]
+		weekKey := week.WeekStart.Format("2006-01-02")
+		fmt.Fprintf(f, "## %s%s\n\n", icon("🗂️"), tr("Time by Category"))
+		fmt.Fprintf(f, "*%s %s*\n\n", tr("Week of"), weekKey)
+		for _, c := range timeTrackingIndex.CategoriesInWeek(weekKey) {
+			fmt.Fprintf(f, "- **%s**: %s (%.0f%%)\n", c.Category, formatDuration(c.TimeLogged),
+				float64(c.TimeLogged)/float64(week.TimeLogged)*100)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
 	// Top Missing Pages
 	if len(missingPagesIndex.MissingPages) > 0 {
-		fmt.Fprintf(f, "## 📝 Pages to Create\n\n")
-		fmt.Fprintf(f, "*Pages with 5+ references that don't exist yet*\n\n")
+		fmt.Fprintf(f, "## %s%s\n\n", icon("📝"), tr("Pages to Create"))
+		fmt.Fprintf(f, "*"+tr("Pages with %d+ references that don't exist yet")+"*\n\n", 5)
 
 		limit := 5
 		if len(missingPagesIndex.MissingPages) < limit {
@@ -198,30 +422,52 @@ func WriteDashboard(
 
 		for i := 0; i < limit; i++ {
 			page := missingPagesIndex.MissingPages[i]
-			fmt.Fprintf(f, "- **%s** (%d refs, %s)\n",
-				page.Name, page.ReferenceCount, page.PageType)
+			fmt.Fprintf(f, "- **%s** ("+plural(page.ReferenceCount, "%d ref", "%d refs")+", %s)\n",
+				page.Name, page.ReferenceCount, shownPageType(page.PageType, page.TypeConfidence))
 		}
 
 		if len(missingPagesIndex.MissingPages) > 5 {
-			fmt.Fprintf(f, "\n*+%d more suggested pages*\n", len(missingPagesIndex.MissingPages)-5)
+			fmt.Fprintf(f, "\n*"+tr("+%d more suggested pages")+"*\n", len(missingPagesIndex.MissingPages)-5)
 		}
 		fmt.Fprintf(f, "\n")
 	}
 
 	// Quick Links
-	fmt.Fprintf(f, "## 🔗 Detailed Reports\n\n")
-	fmt.Fprintf(f, "- [Tasks by Status](./tasks-by-status.md) - All tasks organized by workflow stage\n")
-	fmt.Fprintf(f, "- [Tasks by Priority](./tasks-by-priority.md) - High priority tasks requiring attention\n")
-	fmt.Fprintf(f, "- [Timeline (Recent)](./timeline-recent.md) - Activity from last 7 days\n")
-	fmt.Fprintf(f, "- [Timeline (Full)](./timeline-full.md) - Complete activity history\n")
-	fmt.Fprintf(f, "- [Missing Pages](./missing-pages.md) - Suggested pages to create\n")
-	fmt.Fprintf(f, "- [Time Tracking](./time-tracking.md) - Time allocation analytics\n")
-	fmt.Fprintf(f, "- [Reference Graph](./reference-graph.md) - Page connections and relationships\n")
+	fmt.Fprintf(f, "## %s%s\n\n", icon("🔗"), tr("Detailed Reports"))
+	reports := []struct{ title, file, description string }{
+		{"Tasks by Status", "tasks-by-status.md", "All tasks organized by workflow stage"},
+		{"Tasks by Priority", "tasks-by-priority.md", "High priority tasks requiring attention"},
+		{"Someday/Maybe", "backlog-someday.md", "Parked ideas excluded from active counts"},
+		{"Timeline (Recent)", "timeline-recent.md", "Activity from last 7 days"},
+		{"Timeline (Full)", "timeline-full.md", "Complete activity history, one file per year"},
+		{"Missing Pages", "missing-pages.md", "Suggested pages to create"},
+		{"Time Tracking", "time-tracking.md", "Time allocation analytics"},
+		{"Reference Graph", "reference-graph.md", "Page connections and relationships"},
+		{"Graph Health", "graph-health.md", "Suggestions for a more navigable graph"},
+		{"Resurface", "resurface.md", "Old pages to revisit today"},
+	}
+	if inbox != nil {
+		reports = append(reports, struct{ title, file, description string }{"Inbox", "inbox.md", "Quick captures to file, with suggested destinations"})
+	}
+	for _, r := range reports {
+		fmt.Fprintf(f, "- [%s](./%s) - %s\n", tr(r.title), r.file, tr(r.description))
+	}
 	fmt.Fprintf(f, "\n")
 
 	return nil
 }
 
+// effortLabel explains a task's size and where it came from, e.g. "~15m, similar tasks"
+func effortLabel(e indexer.EffortEstimate) string {
+	switch e.Basis {
+	case indexer.BasisEstimate:
+		return "~" + formatDuration(e.Duration) + " " + tr("estimated")
+	case indexer.BasisHistory:
+		return "~" + formatDuration(e.Duration) + ", " + tr("based on similar tasks")
+	}
+	return tr("judging by the wording")
+}
+
 // pluralize adds "s" if count != 1
 func pluralize(count int) string {
 	if count == 1 {
@@ -1,6 +1,7 @@
 package writer
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -53,7 +54,7 @@ func TestWriteDashboard_Minimal(t *testing.T) {
 		},
 	}
 
-	err := WriteDashboard(taskIndex, graphIndex, timelineIndex, missingPagesIndex, timeTrackingIndex, tmpDir)
+	err := WriteDashboard(taskIndex, graphIndex, timelineIndex, missingPagesIndex, timeTrackingIndex, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
 	if err != nil {
 		t.Fatalf("WriteDashboard failed: %v", err)
 	}
@@ -74,6 +75,190 @@ func TestWriteDashboard_Minimal(t *testing.T) {
 	if !strings.Contains(output, "50.0%") {
 		t.Error("Expected completion rate")
 	}
+	if strings.Contains(output, "Since Last Run") {
+		t.Error("First run should have no Since Last Run section")
+	}
+}
+
+func TestWriteDashboard_SinceLastRun(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	changes := &indexer.Changes{
+		Since:          time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC),
+		TasksAdded:     []models.Task{{Status: models.StatusTODO, Description: "Review draft"}},
+		TasksCompleted: []models.Task{{Status: models.StatusDONE, Description: "Write spec", SourceFile: "pages/Alpha.md", LineNumber: 3}},
+		NewPages:       []string{"Beta", "Gamma"},
+		TimeLogged:     90 * time.Minute,
+	}
+	err := WriteDashboard(&indexer.TaskIndex{}, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, &indexer.TimelineIndex{},
+		&indexer.MissingPagesIndex{}, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, changes, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## 🔄 Since Last Run",
+		"- **Tasks Added**: 1\n- **Tasks Completed**: 1\n- **New Pages**: 2\n- **Time Logged**: 1h 30m",
+		"Write spec `pages/Alpha.md:3`",
+		"New Pages: [[Beta]], [[Gamma]]",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected dashboard to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteDashboard_Writing(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	timeline := &indexer.TimelineIndex{Writing: &indexer.WritingStats{
+		WritingDays:   12,
+		AvgWords:      180,
+		CurrentStreak: 4,
+		LongestStreak: 9,
+		Weeks:         []indexer.WritingWeek{{Words: 650, Days: 4}, {Words: 900, Days: 5}},
+	}}
+	err := WriteDashboard(&indexer.TaskIndex{}, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, timeline,
+		&indexer.MissingPagesIndex{}, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	want := "## ✍️ Writing\n\n- **This Week**: 650 words (900 last week)\n- **Current Streak**: 4 days (longest: 9)\n- **Avg Words/Day**: 180\n"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected dashboard to contain %q, got:\n%s", want, content)
+	}
+}
+
+func TestWriteDashboard_Inbox(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	inbox := &indexer.InboxIndex{Page: "Inbox"}
+	for i, age := range []int{12, 5, 2, -1} {
+		inbox.Items = append(inbox.Items, indexer.InboxEntry{
+			Item:    models.InboxItem{SourceFile: "pages/Inbox.md", LineNumber: i + 1, Text: fmt.Sprintf("Item %d", i+1)},
+			AgeDays: age,
+		})
+	}
+	err := WriteDashboard(&indexer.TaskIndex{}, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, &indexer.TimelineIndex{},
+		&indexer.MissingPagesIndex{}, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, inbox, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	for _, want := range []string{
+		"- **Inbox**: 4 unprocessed items, oldest 12d (see inbox.md)",
+		"## 📥 Inbox\n\n- Item 1 (12d)\n- Item 2 (5d)\n- Item 3 (2d)\n- *+1 more in [inbox.md](./inbox.md)*\n",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected dashboard to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestWriteDashboard_BackFromSnooze(t *testing.T) {
+	tmpDir := t.TempDir()
+	yesterday := time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -1)
+	task := models.Task{Status: models.StatusTODO, Description: "Call the bank", SourceFile: "pages/Admin.md", LineNumber: 2, Snooze: yesterday}
+	taskIndex := indexer.BuildTaskIndex([]models.Task{task})
+
+	err := WriteDashboard(taskIndex, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, &indexer.TimelineIndex{},
+		&indexer.MissingPagesIndex{}, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	want := "## ⏰ Back from Snooze\n\n- **[TODO]** Call the bank (snoozed until " + yesterday.Format("2006-01-02") + ") `pages/Admin.md:2`\n"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected dashboard to contain %q, got:\n%s", want, content)
+	}
+
+	if err := WriteTaskIndex(taskIndex, tmpDir); err != nil {
+		t.Fatalf("WriteTaskIndex failed: %v", err)
+	}
+	tasks, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatalf("Failed to read task index: %v", err)
+	}
+	if !strings.Contains(string(tasks), "- **Call the bank** ⏰ *back from snooze* `pages/Admin.md:2`") {
+		t.Errorf("Expected the task flagged in tasks-by-status.md, got:\n%s", tasks)
+	}
+}
+
+func TestWriteDashboard_MinConfidence(t *testing.T) {
+	if err := SetMinConfidence(0.6); err != nil {
+		t.Fatal(err)
+	}
+	defer SetMinConfidence(0)
+
+	tmpDir := t.TempDir()
+	tasks := &indexer.TaskIndex{CompletionCandidates: []indexer.CompletionCandidate{
+		{Page: "Phoenix", DoneTasks: 3, QuietWeeks: 9, Confidence: 0.8},
+		{Page: "Hunch", DoneTasks: 1, QuietWeeks: 4, Confidence: indexer.ConfidenceMixed},
+	}}
+	missing := &indexer.MissingPagesIndex{MissingPages: []indexer.MissingPage{
+		{Name: "Bob Ray", ReferenceCount: 6, PageType: "person", TypeConfidence: indexer.ConfidenceFallback},
+	}}
+	err := WriteDashboard(tasks, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, &indexer.TimelineIndex{},
+		missing, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	output := string(content)
+	if !strings.Contains(output, "[[Phoenix]]") || strings.Contains(output, "[[Hunch]]") {
+		t.Errorf("Expected only the confident completion guess, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- **Bob Ray** (6 refs, unclassified)") {
+		t.Errorf("Expected the weak type guess hidden, got:\n%s", output)
+	}
+}
+
+func TestWriteDashboard_TimeByCategory(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	timeTracking := &indexer.TimeTrackingIndex{
+		WeeklySummary: []indexer.WeeklyTime{{WeekStart: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC), TimeLogged: 10 * time.Hour}},
+		Categories:    []indexer.CategoryTime{{Category: "Meetings", TimeLogged: 20 * time.Hour}},
+		ByCategoryWeek: map[string]map[string]time.Duration{
+			"2025-11-10": {"Meetings": 4 * time.Hour, "Focus": 6 * time.Hour},
+		},
+	}
+	err := WriteDashboard(&indexer.TaskIndex{}, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, &indexer.TimelineIndex{},
+		&indexer.MissingPagesIndex{}, timeTracking, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatalf("Failed to read dashboard file: %v", err)
+	}
+	want := "## 🗂️ Time by Category\n\n*Week of 2025-11-10*\n\n- **Focus**: 6h (60%)\n- **Meetings**: 4h (40%)\n"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected dashboard to contain %q, got:\n%s", want, content)
+	}
 }
 
 func TestWriteDashboard_WithAllData(t *testing.T) {
@@ -115,6 +300,9 @@ func TestWriteDashboard_WithAllData(t *testing.T) {
 				{Status: models.StatusNOW},
 			},
 		},
+		CompletionCandidates: []indexer.CompletionCandidate{
+			{Page: "Website Relaunch", FilePath: "pages/Website Relaunch.md", DoneTasks: 7, LastActivity: time.Date(2025, 9, 12, 0, 0, 0, 0, time.UTC), QuietWeeks: 8},
+		},
 		Statistics: indexer.TaskStatistics{
 			StatusBreakdown: map[models.TaskStatus]int{
 				models.StatusDONE: 30,
@@ -140,14 +328,10 @@ func TestWriteDashboard_WithAllData(t *testing.T) {
 				Date: time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
 				TasksCreated: []models.Task{
 					{Status: models.StatusNOW},
-					{Status: models.StatusNOW},
+					{Status: models.StatusNOW, Priority: models.PriorityHigh, Description: "Deploy to production"},
 					{Status: models.StatusDONE},
 				},
 				TimeLogged: 5 * time.Hour,
-				KeyActivity: []string{
-					"- **[NOW]** Fix critical bug",
-					"🔥 Deploy to production",
-				},
 			},
 			{
 				Date: time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
@@ -185,7 +369,31 @@ func TestWriteDashboard_WithAllData(t *testing.T) {
 		},
 	}
 
-	err := WriteDashboard(taskIndex, graphIndex, timelineIndex, missingPagesIndex, timeTrackingIndex, tmpDir)
+	trendsIndex := &indexer.TrendsIndex{
+		WindowDays: 14,
+		Emerging: []indexer.Trend{
+			{Topic: "Kubernetes Migration", IsPage: true, Recent: 6, Previous: 0},
+			{Topic: "hiring", Recent: 4, Previous: 1},
+		},
+	}
+
+	effortIndex := &indexer.EffortIndex{
+		QuickWins: []indexer.EffortEstimate{
+			{
+				Task:     models.Task{Status: models.StatusTODO, Description: "Reply to Sam", SourceFile: "journals/2025_11_05.md", LineNumber: 4},
+				Class:    indexer.EffortQuick,
+				Duration: 10 * time.Minute,
+				Basis:    indexer.BasisEstimate,
+			},
+			{
+				Task:  models.Task{Status: models.StatusLATER, Description: "Renew passport", SourceFile: "pages/Admin.md", LineNumber: 2},
+				Class: indexer.EffortQuick,
+				Basis: indexer.BasisHeuristic,
+			},
+		},
+	}
+
+	err := WriteDashboard(taskIndex, graphIndex, timelineIndex, missingPagesIndex, timeTrackingIndex, trendsIndex, effortIndex, nil, nil, tmpDir)
 	if err != nil {
 		t.Fatalf("WriteDashboard failed: %v", err)
 	}
@@ -245,6 +453,30 @@ func TestWriteDashboard_WithAllData(t *testing.T) {
 		t.Error("Expected project time")
 	}
 
+	// Check Quick Wins
+	if !strings.Contains(output, "- **[TODO]** Reply to Sam (~10m estimated) `journals/2025_11_05.md:4`") {
+		t.Error("Expected estimated quick win")
+	}
+	if !strings.Contains(output, "- **[LATER]** Renew passport (judging by the wording) `pages/Admin.md:2`") {
+		t.Error("Expected heuristic quick win")
+	}
+
+	// Check Emerging Topics
+	if !strings.Contains(output, "Emerging Topics") {
+		t.Error("Expected Emerging Topics section")
+	}
+	if !strings.Contains(output, "**[[Kubernetes Migration]]**: 0 → 6 days") {
+		t.Error("Expected emerging page topic")
+	}
+	if !strings.Contains(output, "**hiring**: 1 → 4 days") {
+		t.Error("Expected emerging word topic")
+	}
+
+	// Check Possibly Complete
+	if !strings.Contains(output, "- **[[Website Relaunch]]**: 7 tasks done, last activity 2025-09-12 (8 weeks ago) `pages/Website Relaunch.md`") {
+		t.Error("Expected completion candidate")
+	}
+
 	// Check Missing Pages
 	if !strings.Contains(output, "Pages to Create") {
 		t.Error("Expected Pages to Create section")
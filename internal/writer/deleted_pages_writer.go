@@ -0,0 +1,78 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// DeletedPagesFileName lists recently deleted pages and what still links them
+const DeletedPagesFileName = "recently-deleted.md"
+
+// maxDeletedReferrers caps the linking pages listed per deleted page
+const maxDeletedReferrers = 5
+
+// WriteDeletedPages writes recently-deleted.md: deleted pages still linked
+// from the graph, which may be worth restoring, then the rest
+func WriteDeletedPages(index *indexer.DeletedPagesIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, DeletedPagesFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Recently Deleted"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "*"+tr("Pages deleted in the last %d days, from the copies Logseq keeps in logseq/.recycle and logseq/bak. None of them are part of the other indexes. To restore one, move its copy back to pages/ under the page's name.")+"*\n\n", index.Days)
+	fmt.Fprintf(f, "---\n\n")
+
+	if len(index.Pages) == 0 {
+		fmt.Fprintf(f, "*"+tr("No pages deleted in the last %d days.")+"*\n", index.Days)
+		return nil
+	}
+
+	referenced := index.Referenced()
+	if referenced > 0 {
+		fmt.Fprintf(f, "## %s (%d)\n\n", tr("Still Linked"), referenced)
+		fmt.Fprintf(f, "*%s*\n\n", tr("These links now point at a missing page. Restore the page, or update the links."))
+		for _, page := range index.Pages[:referenced] {
+			from := page.ReferencedFrom
+			more := ""
+			if len(from) > maxDeletedReferrers {
+				more = fmt.Sprintf(" "+tr("and %d more"), len(from)-maxDeletedReferrers)
+				from = from[:maxDeletedReferrers]
+			}
+			fmt.Fprintf(f, "- **%s** - %s %s, %s [[%s]]%s%s\n",
+				page.Name, tr("deleted"), page.DeletedAt.Format("2006-01-02"), tr("linked from"), strings.Join(from, "]], [["), more, deletedCopy(page))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	if rest := index.Pages[referenced:]; len(rest) > 0 {
+		fmt.Fprintf(f, "## %s (%d)\n\n", tr("Not Linked"), len(rest))
+		for _, page := range rest {
+			fmt.Fprintf(f, "- **%s** - %s %s%s\n", page.Name, tr("deleted"), page.DeletedAt.Format("2006-01-02"), deletedCopy(page))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
+
+// deletedCopy formats where the newest copy of a deleted page is
+func deletedCopy(page indexer.DeletedPage) string {
+	if page.Copies > 1 {
+		return fmt.Sprintf(" `%s` ("+tr("newest of %d copies")+")", filepath.ToSlash(page.Copy), page.Copies)
+	}
+	return fmt.Sprintf(" `%s`", filepath.ToSlash(page.Copy))
+}
@@ -0,0 +1,60 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteDeletedPages(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.DeletedPagesIndex{
+		GeneratedAt: time.Now(),
+		Days:        30,
+		Pages: []indexer.DeletedPage{
+			{Name: "Old Idea", Copy: "logseq/.recycle/pages_Old Idea.md", Copies: 2, DeletedAt: time.Date(2025, 11, 7, 10, 0, 0, 0, time.UTC),
+				ReferencedFrom: []string{"A", "B", "C", "D", "E", "F", "G"}},
+			{Name: "Scratch", Copy: "logseq/.recycle/pages_Scratch.md", Copies: 1, DeletedAt: time.Date(2025, 11, 8, 10, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	if err := WriteDeletedPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteDeletedPages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, DeletedPagesFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## Still Linked (1)",
+		"- **Old Idea** - deleted 2025-11-07, linked from [[A]], [[B]], [[C]], [[D]], [[E]] and 2 more `logseq/.recycle/pages_Old Idea.md` (newest of 2 copies)",
+		"## Not Linked (1)\n\n- **Scratch** - deleted 2025-11-08 `logseq/.recycle/pages_Scratch.md`",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected recently-deleted.md to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteDeletedPages_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.DeletedPagesIndex{GeneratedAt: time.Now(), Days: 30}
+	if err := WriteDeletedPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteDeletedPages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, DeletedPagesFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "*No pages deleted in the last 30 days.*") || strings.Contains(string(content), "## ") {
+		t.Errorf("Expected only the empty note, got:\n%s", content)
+	}
+}
@@ -0,0 +1,87 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// WriteDiagnostics writes warnings and errors from the run to diagnostics.md
+func WriteDiagnostics(index *indexer.DiagnosticsIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "diagnostics.md")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# Indexing Diagnostics\n\n")
+	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
+
+	if len(index.Diagnostics) == 0 {
+		fmt.Fprintf(f, "*No problems found.*\n")
+		return nil
+	}
+
+	fmt.Fprintf(f, "**Errors**: %d | **Warnings**: %d\n\n",
+		index.Count(models.SeverityError), index.Count(models.SeverityWarning))
+	if len(index.History) > 0 {
+		counts := make([]string, 0, len(index.History)+1)
+		for _, run := range index.History {
+			counts = append(counts, strconv.Itoa(run.Errors))
+		}
+		counts = append(counts, strconv.Itoa(index.Count(models.SeverityError)))
+		fmt.Fprintf(f, "**Errors per run** (oldest first): %s\n\n", strings.Join(counts, ", "))
+	}
+	fmt.Fprintf(f, "---\n\n")
+
+	if index.ErrorJump || len(index.Regressions) > 0 {
+		fmt.Fprintf(f, "## Regressions\n\n")
+		if index.ErrorJump {
+			fmt.Fprintf(f, "**Errors jumped** to %d from an average of %.1f over the last %d run%s. A Logseq upgrade or a bad sync may have changed the files' syntax.\n\n",
+				index.Count(models.SeverityError), index.AverageErrors(), len(index.History), pluralize(len(index.History)))
+		}
+		for _, r := range index.Regressions {
+			if r.Before == 0 {
+				fmt.Fprintf(f, "- `%s` - %d error%s, clean last run\n", r.File, r.After, pluralize(r.After))
+			} else {
+				fmt.Fprintf(f, "- `%s` - %d error%s, up from %d\n", r.File, r.After, pluralize(r.After), r.Before)
+			}
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	// Group by code, keeping the index's sort order
+	currentCode := ""
+	for _, d := range index.Diagnostics {
+		if d.Code != currentCode {
+			if currentCode != "" {
+				fmt.Fprintf(f, "\n")
+			}
+			currentCode = d.Code
+			fmt.Fprintf(f, "## %s `%s`\n\n", d.Severity, d.Code)
+		}
+
+		location := d.File
+		if d.Line > 0 {
+			location = fmt.Sprintf("%s:%d", d.File, d.Line)
+		}
+		fmt.Fprintf(f, "- `%s` - %s\n", location, d.Message)
+	}
+	fmt.Fprintf(f, "\n")
+
+	return nil
+}
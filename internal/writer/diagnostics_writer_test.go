@@ -0,0 +1,129 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteDiagnostics(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := indexer.BuildDiagnosticsIndex([]models.Diagnostic{
+		{Severity: models.SeverityWarning, Code: "invalid-journal-date", File: "journals/2025_13_40.md", Message: "not a valid date"},
+		{Severity: models.SeverityError, Code: "read-failed", File: "pages/Locked.md", Line: 3, Message: "permission denied"},
+	})
+
+	if err := WriteDiagnostics(index, tmpDir); err != nil {
+		t.Fatalf("WriteDiagnostics failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "diagnostics.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	expected := []string{
+		"**Errors**: 1 | **Warnings**: 1",
+		"## error `read-failed`",
+		"- `pages/Locked.md:3` - permission denied",
+		"## warning `invalid-journal-date`",
+		"- `journals/2025_13_40.md` - not a valid date",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q", exp)
+		}
+	}
+}
+
+func TestWriteDiagnostics_Regressions(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := indexer.BuildDiagnosticsIndex([]models.Diagnostic{
+		{Severity: models.SeverityError, Code: "parse-failed", File: "pages/Broken.md", Message: "bad drawer"},
+	})
+	index.History = []indexer.DiagnosticsRun{{Errors: 0}, {Errors: 1}}
+	index.Regressions = []indexer.DiagnosticsRegression{
+		{File: "journals/2025_11_03.md", After: 2},
+		{File: "pages/Broken.md", Before: 1, After: 3},
+	}
+	index.ErrorJump = true
+
+	if err := WriteDiagnostics(index, tmpDir); err != nil {
+		t.Fatalf("WriteDiagnostics failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "diagnostics.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"**Errors per run** (oldest first): 0, 1, 1",
+		"## Regressions",
+		"**Errors jumped** to 1 from an average of 0.5 over the last 2 runs.",
+		"- `journals/2025_11_03.md` - 2 errors, clean last run",
+		"- `pages/Broken.md` - 3 errors, up from 1",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteDiagnostics_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteDiagnostics(indexer.BuildDiagnosticsIndex(nil), tmpDir); err != nil {
+		t.Fatalf("WriteDiagnostics failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "diagnostics.md"))
+	if !strings.Contains(string(content), "*No problems found.*") {
+		t.Error("Expected empty message")
+	}
+}
+
+func TestWriteManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := &Manifest{
+		ToolVersion: "1.2.3",
+		GeneratedAt: time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC),
+		Files:       []string{"dashboard.md"},
+		Counts:      map[string]int{"journal_date_warnings": 2},
+	}
+
+	if err := WriteManifest(manifest, tmpDir); err != nil {
+		t.Fatalf("WriteManifest failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, ManifestFileName))
+	if err != nil {
+		t.Fatalf("Failed to read manifest: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Manifest is not valid JSON: %v", err)
+	}
+	if decoded["tool_version"] != "1.2.3" {
+		t.Errorf("Expected tool_version 1.2.3, got %v", decoded["tool_version"])
+	}
+	counts := decoded["counts"].(map[string]interface{})
+	if counts["journal_date_warnings"] != float64(2) {
+		t.Errorf("Expected journal_date_warnings 2, got %v", counts["journal_date_warnings"])
+	}
+
+	read, err := ReadManifest(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadManifest failed: %v", err)
+	}
+	if read.ToolVersion != "1.2.3" || !read.GeneratedAt.Equal(manifest.GeneratedAt) || read.Files[0] != "dashboard.md" {
+		t.Errorf("Manifest did not round-trip: %+v", read)
+	}
+}
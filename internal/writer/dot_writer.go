@@ -0,0 +1,95 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// WriteReferenceGraphDOT exports the reference graph as Graphviz DOT to
+// reference-graph.dot, with edge thickness scaled by reference weight
+func WriteReferenceGraphDOT(graph *indexer.ReferenceGraph, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "reference-graph.dot")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Sort for stable, diff-friendly output
+	var pageNames []string
+	for name := range graph.Nodes {
+		pageNames = append(pageNames, name)
+	}
+	sort.Strings(pageNames)
+
+	fmt.Fprintf(f, "digraph logseq {\n")
+	fmt.Fprintf(f, "  node [shape=box];\n")
+
+	// Missing pages are dashed so gaps in the graph stand out
+	for _, name := range pageNames {
+		node := graph.Nodes[name]
+		if node.FilePath == "" {
+			fmt.Fprintf(f, "  %s [style=dashed];\n", dotQuote(name))
+		} else if node.Pinned {
+			fmt.Fprintf(f, "  %s [style=bold];\n", dotQuote(name))
+		}
+	}
+
+	for _, source := range pageNames {
+		node := graph.Nodes[source]
+		targets := append([]string{}, node.OutboundRefs...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			weight := node.OutboundWeights[target]
+			if weight < 1 {
+				weight = 1
+			}
+			fmt.Fprintf(f, "  %s -> %s [weight=%d, penwidth=%s];\n",
+				dotQuote(source), dotQuote(target), weight, dotPenWidth(weight))
+		}
+	}
+
+	// Task project edges (see indexer.ApplyProjectRefs) are dotted
+	for _, target := range pageNames {
+		node := graph.Nodes[target]
+		sources := make([]string, 0, len(node.ProjectWeights))
+		for source := range node.ProjectWeights {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			weight := node.ProjectWeights[source]
+			fmt.Fprintf(f, "  %s -> %s [style=dotted, weight=%d, penwidth=%s];\n",
+				dotQuote(source), dotQuote(target), weight, dotPenWidth(weight))
+		}
+	}
+
+	fmt.Fprintf(f, "}\n")
+
+	return nil
+}
+
+// dotQuote quotes a page name as a DOT identifier
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// dotPenWidth scales edge thickness with weight, capped so hubs stay readable
+func dotPenWidth(weight int) string {
+	width := 1 + float64(weight-1)*0.5
+	if width > 6 {
+		width = 6
+	}
+	return fmt.Sprintf("%.1f", width)
+}
@@ -0,0 +1,45 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteReferenceGraphDOT(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "A", TargetPage: "B"},
+		{SourcePage: "A", TargetPage: "B"},
+		{SourcePage: "A", TargetPage: `Say "Hi"`},
+	}
+	graph := indexer.BuildReferenceGraph(refs, []models.File{{Path: "pages/A.md"}, {Path: "pages/B.md"}})
+	graph.ApplyProjectRefs([]models.PageReference{{SourcePage: "A", TargetPage: "B", Project: true}})
+
+	tmpDir := t.TempDir()
+	if err := WriteReferenceGraphDOT(graph, tmpDir); err != nil {
+		t.Fatalf("WriteReferenceGraphDOT failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "reference-graph.dot"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	expected := []string{
+		"digraph logseq {",
+		`"A" -> "B" [weight=2, penwidth=1.5];`,
+		`"A" -> "Say \"Hi\"" [weight=1, penwidth=1.0];`,
+		`"Say \"Hi\"" [style=dashed];`,
+		`"A" -> "B" [style=dotted, weight=1, penwidth=1.0];`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q, got:\n%s", exp, output)
+		}
+	}
+}
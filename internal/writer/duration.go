@@ -0,0 +1,133 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DurationFormat selects how durations are written in markdown outputs
+type DurationFormat string
+
+const (
+	DurationShort   DurationFormat = "short"   // "2h 30m" (default)
+	DurationDecimal DurationFormat = "decimal" // "2.5h"
+	DurationClock   DurationFormat = "clock"   // "2:30"
+	DurationISO8601 DurationFormat = "iso8601" // "PT2H30M"
+)
+
+// durationFormat is the configured markdown duration format (see SetDurationFormat)
+var durationFormat = DurationShort
+
+// SetDurationFormat configures the markdown duration format; empty restores the default
+func SetDurationFormat(format DurationFormat) error {
+	switch format {
+	case "":
+		durationFormat = DurationShort
+	case DurationShort, DurationDecimal, DurationClock, DurationISO8601:
+		durationFormat = format
+	default:
+		return fmt.Errorf("unknown duration format %q (expected short, decimal, clock, or iso8601)", format)
+	}
+	return nil
+}
+
+// durationRounding is the configured markdown rounding unit (see SetDurationRounding)
+var durationRounding time.Duration
+
+// SetDurationRounding rounds markdown durations to the nearest multiple of
+// unit (e.g. 15m), the way timesheets are usually reported. Zero disables
+// rounding. JSON outputs always keep the raw values.
+func SetDurationRounding(unit time.Duration) error {
+	if unit < 0 || unit%time.Minute != 0 {
+		return fmt.Errorf("invalid duration rounding %s (expected a whole number of minutes)", unit)
+	}
+	durationRounding = unit
+	return nil
+}
+
+// formatDuration formats a duration for markdown using the configured
+// rounding and format
+func formatDuration(d time.Duration) string {
+	if durationRounding > 0 {
+		d = d.Round(durationRounding)
+	}
+	switch durationFormat {
+	case DurationDecimal:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	case DurationClock:
+		return fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)
+	case DurationISO8601:
+		return formatISO8601(d)
+	}
+	return formatShortDuration(d)
+}
+
+// formatShortDuration formats a duration as "2h 30m", "5m 10s", or "45s"
+func formatShortDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	if hours > 0 {
+		if minutes > 0 {
+			return fmt.Sprintf("%dh %dm", hours, minutes)
+		}
+		return fmt.Sprintf("%dh", hours)
+	}
+
+	if minutes > 0 {
+		if seconds > 0 {
+			return fmt.Sprintf("%dm %ds", minutes, seconds)
+		}
+		return fmt.Sprintf("%dm", minutes)
+	}
+
+	return fmt.Sprintf("%ds", seconds)
+}
+
+// formatISO8601 formats a duration as an ISO 8601 duration, e.g. "PT2H30M".
+// Hours are not rolled up into days, since a "day" of logged time is ambiguous.
+func formatISO8601(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+	b.WriteString("PT")
+
+	hours := int64(d / time.Hour)
+	minutes := int64(d%time.Hour) / int64(time.Minute)
+	seconds := int64(d%time.Minute) / int64(time.Second)
+
+	if hours > 0 {
+		fmt.Fprintf(&b, "%dH", hours)
+	}
+	if minutes > 0 {
+		fmt.Fprintf(&b, "%dM", minutes)
+	}
+	if seconds > 0 || (hours == 0 && minutes == 0) {
+		fmt.Fprintf(&b, "%dS", seconds)
+	}
+	return b.String()
+}
+
+// jsonDuration is a duration in structured outputs, encoded as
+// {"seconds": 9000, "iso8601": "PT2H30M"} so consumers don't parse display strings
+type jsonDuration time.Duration
+
+// MarshalJSON implements json.Marshaler
+func (d jsonDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Seconds int64  `json:"seconds"`
+		ISO8601 string `json:"iso8601"`
+	}{
+		Seconds: int64(time.Duration(d) / time.Second),
+		ISO8601: formatISO8601(time.Duration(d)),
+	})
+}
@@ -0,0 +1,150 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestFormatISO8601(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		expected string
+	}{
+		{0, "PT0S"},
+		{45 * time.Second, "PT45S"},
+		{2*time.Hour + 30*time.Minute, "PT2H30M"},
+		{26*time.Hour + 5*time.Second, "PT26H5S"},
+		{-90 * time.Minute, "-PT1H30M"},
+	}
+
+	for _, tt := range tests {
+		if result := formatISO8601(tt.duration); result != tt.expected {
+			t.Errorf("formatISO8601(%v) = %q, want %q", tt.duration, result, tt.expected)
+		}
+	}
+}
+
+func TestSetDurationFormat(t *testing.T) {
+	defer SetDurationFormat("")
+
+	d := 2*time.Hour + 30*time.Minute
+	tests := []struct {
+		format   DurationFormat
+		expected string
+	}{
+		{DurationShort, "2h 30m"},
+		{DurationDecimal, "2.5h"},
+		{DurationClock, "2:30"},
+		{DurationISO8601, "PT2H30M"},
+	}
+
+	for _, tt := range tests {
+		if err := SetDurationFormat(tt.format); err != nil {
+			t.Fatalf("SetDurationFormat(%q) failed: %v", tt.format, err)
+		}
+		if result := formatDuration(d); result != tt.expected {
+			t.Errorf("format %s: got %q, want %q", tt.format, result, tt.expected)
+		}
+	}
+
+	if err := SetDurationFormat("fortnights"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+}
+
+func TestSetDurationRounding(t *testing.T) {
+	defer SetDurationRounding(0)
+
+	tests := []struct {
+		unit     time.Duration
+		d        time.Duration
+		expected string
+	}{
+		{0, 2*time.Hour + 7*time.Minute, "2h 7m"},
+		{15 * time.Minute, 2*time.Hour + 7*time.Minute, "2h"},
+		{15 * time.Minute, 2*time.Hour + 8*time.Minute, "2h 15m"},
+		{5 * time.Minute, 52*time.Minute + 30*time.Second, "55m"},
+		{15 * time.Minute, 5 * time.Minute, "0s"},
+	}
+
+	for _, tt := range tests {
+		if err := SetDurationRounding(tt.unit); err != nil {
+			t.Fatalf("SetDurationRounding(%s) failed: %v", tt.unit, err)
+		}
+		if result := formatDuration(tt.d); result != tt.expected {
+			t.Errorf("rounding %s of %s: got %q, want %q", tt.unit, tt.d, result, tt.expected)
+		}
+	}
+
+	if err := SetDurationRounding(90 * time.Second); err == nil {
+		t.Error("Expected error for rounding to seconds")
+	}
+}
+
+func TestWriteTimeTrackingJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		TotalTimeLogged: 2*time.Hour + 30*time.Minute,
+		TopProjects: []indexer.ProjectTime{
+			{Project: "Phoenix", TimeLogged: 90 * time.Minute, TaskCount: 2, AvgTimePerTask: 45 * time.Minute},
+		},
+		WeeklySummary: []indexer.WeeklyTime{
+			{WeekStart: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), TimeLogged: time.Hour, TaskCount: 1},
+		},
+		ByFileType:  map[string]time.Duration{"journal": time.Hour},
+		ByNamespace: map[string]time.Duration{"Projects": 90 * time.Minute},
+	}
+
+	if err := WriteTimeTrackingJSON(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTrackingJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.json"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var decoded struct {
+		TotalTimeLogged struct {
+			Seconds int64  `json:"seconds"`
+			ISO8601 string `json:"iso8601"`
+		} `json:"total_time_logged"`
+		Projects []struct {
+			Project string `json:"project"`
+		} `json:"projects"`
+		Weeks []struct {
+			WeekStart string `json:"week_start"`
+		} `json:"weeks"`
+		ByFileType map[string]struct {
+			Seconds int64 `json:"seconds"`
+		} `json:"by_file_type"`
+		ByNamespace map[string]struct {
+			Seconds int64 `json:"seconds"`
+		} `json:"by_namespace"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if decoded.TotalTimeLogged.Seconds != 9000 || decoded.TotalTimeLogged.ISO8601 != "PT2H30M" {
+		t.Errorf("Unexpected total: %+v", decoded.TotalTimeLogged)
+	}
+	if len(decoded.Projects) != 1 || decoded.Projects[0].Project != "Phoenix" {
+		t.Errorf("Unexpected projects: %+v", decoded.Projects)
+	}
+	if decoded.ByFileType["journal"].Seconds != 3600 || decoded.ByNamespace["Projects"].Seconds != 5400 {
+		t.Errorf("Unexpected location split: %+v %+v", decoded.ByFileType, decoded.ByNamespace)
+	}
+	if len(decoded.Weeks) != 1 || decoded.Weeks[0].WeekStart != "2025-11-03" {
+		t.Errorf("Unexpected weeks: %+v", decoded.Weeks)
+	}
+	if strings.Contains(string(data), "2h 30m") {
+		t.Error("Expected no display-format durations in JSON")
+	}
+}
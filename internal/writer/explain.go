@@ -0,0 +1,56 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// WriteExplanation writes what a generated file holds, what it's built
+// from, its caveats, and its sections or fields, from the Artifacts
+// registry. file may be a bare name or a path into the output directory,
+// such as .claude/indexes/backlinks/Phoenix.md.
+func WriteExplanation(w io.Writer, file string) error {
+	artifact, ok := explainedArtifact(file)
+	if !ok {
+		names := make([]string, 0, len(Artifacts))
+		for _, a := range Artifacts {
+			names = append(names, a.Name)
+		}
+		return fmt.Errorf("%q is not a generated file (expected one of: %s)", file, strings.Join(names, ", "))
+	}
+
+	fmt.Fprintf(w, "%s\n\n", artifact.Name)
+	fmt.Fprintf(w, "%s\n\n", artifact.Description)
+	if artifact.Sources != "" {
+		fmt.Fprintf(w, "Built from: %s\n\n", artifact.Sources)
+	}
+	if artifact.Caveats != "" {
+		fmt.Fprintf(w, "Caveats: %s\n\n", artifact.Caveats)
+	}
+	writeArtifactLayout(w, artifact)
+	return nil
+}
+
+// explainedArtifact finds the registry entry for a path, trying it and then
+// ever shorter suffixes, so files inside a generated directory match it
+func explainedArtifact(file string) (Artifact, bool) {
+	file = strings.TrimPrefix(path.Clean(filepath.ToSlash(file)), "/")
+	for {
+		if artifact, ok := lookupArtifact(file); ok {
+			return artifact, true
+		}
+		for _, artifact := range Artifacts {
+			if dir, isDir := strings.CutSuffix(artifact.Name, "/"); isDir && (file == dir || strings.HasPrefix(file, artifact.Name)) {
+				return artifact, true
+			}
+		}
+
+		var found bool
+		if _, file, found = strings.Cut(file, "/"); !found {
+			return Artifact{}, false
+		}
+	}
+}
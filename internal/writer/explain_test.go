@@ -0,0 +1,65 @@
+package writer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteExplanation(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteExplanation(&buf, ".claude/indexes/missing-pages.md"); err != nil {
+		t.Fatalf("WriteExplanation failed: %v", err)
+	}
+	output := buf.String()
+
+	for _, want := range []string{
+		"missing-pages.md\n\nPages referenced often enough",
+		"Built from: [[page]] references",
+		"Caveats: Page types are heuristic guesses",
+		"Sections:\n- One section per page type",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteExplanation_Fields(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteExplanation(&buf, "reminders.json"); err != nil {
+		t.Fatalf("WriteExplanation failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Fields:\n- `") {
+		t.Errorf("Expected JSON fields, got:\n%s", buf.String())
+	}
+}
+
+func TestExplainedArtifact(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"dashboard.md", "dashboard.md"},
+		{"timeline-2024.md", "timeline-*.md"},
+		{"timeline-recent.md", "timeline-recent.md"},
+		{".claude/indexes/README.md", IndexReadmeFileName},
+		{".claude/indexes/backlinks/README.md", BacklinksDir + "/"},
+		{"backlinks", BacklinksDir + "/"},
+		{"prompts/retro/Phoenix.md", RetroDir + "/"},
+		{"prompts/daily-planning.md", DailyPlanningFileName},
+	}
+	for _, tt := range tests {
+		got, ok := explainedArtifact(tt.file)
+		if !ok || got.Name != tt.want {
+			t.Errorf("explainedArtifact(%q) = %q, %v; want %q", tt.file, got.Name, ok, tt.want)
+		}
+	}
+
+	if _, ok := explainedArtifact("notes.md"); ok {
+		t.Error("Expected no artifact for notes.md")
+	}
+	if err := WriteExplanation(&bytes.Buffer{}, "notes.md"); err == nil {
+		t.Error("Expected an error for a file that isn't generated")
+	}
+}
@@ -0,0 +1,99 @@
+package writer
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// FileFormat is the documented shape of a generated file: its markdown
+// sections, or its JSON fields with their types
+type FileFormat struct {
+	Sections []string `json:"sections,omitempty"`
+	Fields   []string `json:"fields,omitempty"` // e.g. "weeks[].time_logged (duration: {seconds, iso8601})"
+}
+
+// FormatChange is a generated file whose shape differs from the previous run's
+type FormatChange struct {
+	File            string   `json:"file"` // Registry name, e.g. "timeline-*.md"
+	AddedSections   []string `json:"added_sections,omitempty"`
+	RemovedSections []string `json:"removed_sections,omitempty"`
+	AddedFields     []string `json:"added_fields,omitempty"`
+	RemovedFields   []string `json:"removed_fields,omitempty"`
+}
+
+// String summarises the change for the log, e.g.
+// "tasks-by-status.md: +section Orphan Tasks"
+func (c FormatChange) String() string {
+	var parts []string
+	for _, s := range c.AddedSections {
+		parts = append(parts, "+section "+s)
+	}
+	for _, s := range c.RemovedSections {
+		parts = append(parts, "-section "+s)
+	}
+	for _, s := range c.AddedFields {
+		parts = append(parts, "+field "+s)
+	}
+	for _, s := range c.RemovedFields {
+		parts = append(parts, "-field "+s)
+	}
+	return fmt.Sprintf("%s: %s", c.File, strings.Join(parts, ", "))
+}
+
+// Formats returns the documented shape of each generated file, keyed by its
+// registry name. Undocumented files are left out.
+func Formats(files []string) map[string]FileFormat {
+	formats := make(map[string]FileFormat)
+	for _, name := range files {
+		artifact, ok := lookupArtifact(name)
+		if !ok {
+			continue
+		}
+		format := FileFormat{Sections: artifact.Sections}
+		if artifact.Schema != nil {
+			for _, field := range jsonFields(reflect.TypeOf(artifact.Schema), "") {
+				format.Fields = append(format.Fields, strings.ReplaceAll(field, "`", ""))
+			}
+		}
+		formats[artifact.Name] = format
+	}
+	return formats
+}
+
+// CompareFormats lists the files generated by both runs whose sections or
+// fields differ, sorted by file. Files only one run generated are skipped,
+// since --only, --skip, and config options change which files are written.
+func CompareFormats(previous, current map[string]FileFormat) []FormatChange {
+	var changes []FormatChange
+	for name, format := range current {
+		old, ok := previous[name]
+		if !ok {
+			continue
+		}
+		change := FormatChange{
+			File:            name,
+			AddedSections:   missingFrom(format.Sections, old.Sections),
+			RemovedSections: missingFrom(old.Sections, format.Sections),
+			AddedFields:     missingFrom(format.Fields, old.Fields),
+			RemovedFields:   missingFrom(old.Fields, format.Fields),
+		}
+		if len(change.AddedSections)+len(change.RemovedSections)+len(change.AddedFields)+len(change.RemovedFields) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b FormatChange) int { return strings.Compare(a.File, b.File) })
+	return changes
+}
+
+// missingFrom returns the items of a that aren't in b, in a's order
+func missingFrom(a, b []string) []string {
+	var missing []string
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			missing = append(missing, item)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,53 @@
+package writer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFormats(t *testing.T) {
+	formats := Formats([]string{"tasks-by-status.md", "timeline-2024.md", "timeline-2025.md", "time-tracking.json", "notes.txt"})
+
+	if len(formats) != 3 {
+		t.Fatalf("Expected 3 documented formats, got %v", formats)
+	}
+	if _, ok := formats["timeline-*.md"]; !ok {
+		t.Error("Expected per-year timelines under their registry name")
+	}
+	if !slices.Contains(formats["tasks-by-status.md"].Sections, "Orphan Tasks") {
+		t.Errorf("Expected tasks-by-status.md sections, got %v", formats["tasks-by-status.md"])
+	}
+	if !slices.Contains(formats["time-tracking.json"].Fields, "total_time_logged (duration: {seconds, iso8601})") {
+		t.Errorf("Expected typed JSON fields without backticks, got %v", formats["time-tracking.json"].Fields)
+	}
+}
+
+func TestCompareFormats(t *testing.T) {
+	previous := map[string]FileFormat{
+		"dashboard.md":       {Sections: []string{"Quick Stats", "Old Section"}},
+		"time-tracking.json": {Fields: []string{"total (integer)", "weeks (array)"}},
+		"graph-health.md":    {Sections: []string{"Link Health"}},
+		"resurface.md":       {Sections: []string{"Resurface"}}, // Not generated this run
+	}
+	current := map[string]FileFormat{
+		"dashboard.md":       {Sections: []string{"Quick Stats", "New Section"}},
+		"time-tracking.json": {Fields: []string{"total (number)", "weeks (array)"}},
+		"graph-health.md":    {Sections: []string{"Link Health"}},
+		"tasks.ndjson":       {Fields: []string{"id (string)"}}, // New this run
+	}
+
+	changes := CompareFormats(previous, current)
+	if len(changes) != 2 {
+		t.Fatalf("Expected 2 changed files, got %+v", changes)
+	}
+	if got := changes[0].String(); got != "dashboard.md: +section New Section, -section Old Section" {
+		t.Errorf("Unexpected dashboard change %q", got)
+	}
+	if got := changes[1].String(); got != "time-tracking.json: +field total (number), -field total (integer)" {
+		t.Errorf("Unexpected time tracking change %q", got)
+	}
+
+	if changes := CompareFormats(nil, current); changes != nil {
+		t.Errorf("Expected no changes without a previous format, got %+v", changes)
+	}
+}
@@ -0,0 +1,92 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// GraphDiffFileName is the report written by the graph-diff command
+const GraphDiffFileName = "graph-diff.md"
+
+// WriteGraphDiff writes the comparison of two revisions to graph-diff.md
+func WriteGraphDiff(diff *indexer.GraphDiff, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, GraphDiffFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# Graph Diff\n\n")
+	fmt.Fprintf(f, "Generated: %s\n\n", diff.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "**From**: `%s` → **To**: `%s`\n\n", diff.From, diff.To)
+
+	fmt.Fprintf(f, "## Summary\n\n")
+	fmt.Fprintf(f, "- **New Pages**: %d\n", len(diff.NewPages))
+	fmt.Fprintf(f, "- **Removed Pages**: %d\n", len(diff.RemovedPages))
+	fmt.Fprintf(f, "- **New Journal Entries**: %d\n", diff.NewJournals)
+	fmt.Fprintf(f, "- **Pages With Reference Changes**: %d\n", len(diff.ReferenceChanges))
+	fmt.Fprintf(f, "\n---\n\n")
+
+	writePageList(f, "New Pages", diff.NewPages)
+	writePageList(f, "Removed Pages", diff.RemovedPages)
+
+	// Hub pages
+	fmt.Fprintf(f, "## Hub Pages\n\n")
+	if len(diff.NewHubPages) == 0 && len(diff.DroppedHubPages) == 0 {
+		fmt.Fprintf(f, "*No change in the top hub pages.*\n\n")
+	}
+	for _, page := range diff.NewHubPages {
+		fmt.Fprintf(f, "- %s[[%s]] became a hub\n", icon("⬆️"), page)
+	}
+	for _, page := range diff.DroppedHubPages {
+		fmt.Fprintf(f, "- %s[[%s]] is no longer a hub\n", icon("⬇️"), page)
+	}
+	if len(diff.NewHubPages) > 0 || len(diff.DroppedHubPages) > 0 {
+		fmt.Fprintf(f, "\n")
+	}
+	fmt.Fprintf(f, "---\n\n")
+
+	// Reference changes, capped to keep the report token-efficient
+	fmt.Fprintf(f, "## Reference Changes\n\n")
+	fmt.Fprintf(f, "*Change in the number of pages referencing each page.*\n\n")
+	if len(diff.ReferenceChanges) == 0 {
+		fmt.Fprintf(f, "*No reference changes.*\n\n")
+		return nil
+	}
+	limit := 25
+	if len(diff.ReferenceChanges) < limit {
+		limit = len(diff.ReferenceChanges)
+	}
+	for _, change := range diff.ReferenceChanges[:limit] {
+		fmt.Fprintf(f, "- [[%s]]: %d → %d (%+d)\n", change.Page, change.Before, change.After, change.Delta())
+	}
+	if len(diff.ReferenceChanges) > limit {
+		fmt.Fprintf(f, "\n*+%d more pages changed*\n", len(diff.ReferenceChanges)-limit)
+	}
+	fmt.Fprintf(f, "\n")
+
+	return nil
+}
+
+// writePageList writes a section listing pages, or a placeholder when empty
+func writePageList(f *os.File, title string, pages []string) {
+	fmt.Fprintf(f, "## %s\n\n", title)
+	if len(pages) == 0 {
+		fmt.Fprintf(f, "*None.*\n\n---\n\n")
+		return
+	}
+	for _, page := range pages {
+		fmt.Fprintf(f, "- [[%s]]\n", page)
+	}
+	fmt.Fprintf(f, "\n---\n\n")
+}
@@ -0,0 +1,53 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteGraphDiff(t *testing.T) {
+	tmpDir := t.TempDir()
+	diff := &indexer.GraphDiff{
+		GeneratedAt:     time.Now(),
+		From:            "HEAD~30",
+		To:              "HEAD",
+		NewPages:        []string{"Gamma"},
+		NewJournals:     4,
+		NewHubPages:     []string{"Gamma"},
+		DroppedHubPages: []string{"Old Hub"},
+		ReferenceChanges: []indexer.ReferenceChange{
+			{Page: "Gamma", Before: 0, After: 3},
+			{Page: "Beta", Before: 2, After: 1},
+		},
+	}
+
+	if err := WriteGraphDiff(diff, tmpDir); err != nil {
+		t.Fatalf("WriteGraphDiff failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, GraphDiffFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"**From**: `HEAD~30` → **To**: `HEAD`",
+		"- **New Journal Entries**: 4",
+		"## New Pages\n\n- [[Gamma]]",
+		"## Removed Pages\n\n*None.*",
+		"- ⬆️ [[Gamma]] became a hub",
+		"- ⬇️ [[Old Hub]] is no longer a hub",
+		"- [[Gamma]]: 0 → 3 (+3)",
+		"- [[Beta]]: 2 → 1 (-1)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
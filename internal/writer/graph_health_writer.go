@@ -0,0 +1,142 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// WriteGraphHealth writes graph navigability suggestions to graph-health.md
+func WriteGraphHealth(index *indexer.GraphHealthIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "graph-health.md")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Graph Health"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "---\n\n")
+
+	writeLinkBackSuggestions(f, index)
+	writeLinkHealth(f, index)
+	writeNamespaceHints(f, index)
+
+	return nil
+}
+
+// writeLinkBackSuggestions writes pages that should link back to their heavy referrers
+func writeLinkBackSuggestions(f *os.File, index *indexer.GraphHealthIndex) {
+	fmt.Fprintf(f, "## %s\n\n", tr("Consider Linking Back"))
+	fmt.Fprintf(f, "*"+tr("Pages referenced %d+ times by another page that never links back.")+"*\n\n", index.LinkBackThreshold)
+
+	if len(index.LinkBackSuggestions) == 0 {
+		fmt.Fprintf(f, "*%s*\n\n", tr("No one-way links found."))
+		fmt.Fprintf(f, "---\n\n")
+		return
+	}
+
+	// Cap the list to keep the report token-efficient
+	limit := 25
+	if len(index.LinkBackSuggestions) < limit {
+		limit = len(index.LinkBackSuggestions)
+	}
+	for _, s := range index.LinkBackSuggestions[:limit] {
+		fmt.Fprintf(f, "- "+tr("[[%s]] → [[%s]] (%d refs): add a link back from [[%s]]")+"\n",
+			s.From, s.To, s.Weight, s.To)
+	}
+	if len(index.LinkBackSuggestions) > limit {
+		fmt.Fprintf(f, "\n*"+tr("+%d more suggestions")+"*\n", len(index.LinkBackSuggestions)-limit)
+	}
+	fmt.Fprintf(f, "\n---\n\n")
+}
+
+// writeLinkHealth writes the pages with the largest share of links to missing pages
+func writeLinkHealth(f *os.File, index *indexer.GraphHealthIndex) {
+	fmt.Fprintf(f, "## %s\n\n", tr("Link Health"))
+	fmt.Fprintf(f, "*"+tr("Share of each page's links that lead to an existing page (pages linking %d+ pages). Create the stubs or prune the links.")+"*\n\n", index.MinOutboundLinks)
+
+	if len(index.LinkHealth) == 0 {
+		fmt.Fprintf(f, "*%s*\n\n", tr("Every scored page's links resolve."))
+		fmt.Fprintf(f, "---\n\n")
+		return
+	}
+
+	// Cap the list to keep the report token-efficient
+	limit := 25
+	if len(index.LinkHealth) < limit {
+		limit = len(index.LinkHealth)
+	}
+	for _, h := range index.LinkHealth[:limit] {
+		missing := make([]string, 0, len(h.Unresolved))
+		for i, page := range h.Unresolved {
+			if i == 5 {
+				missing = append(missing, fmt.Sprintf("+%d more", len(h.Unresolved)-i))
+				break
+			}
+			missing = append(missing, "[["+page+"]]")
+		}
+		fmt.Fprintf(f, "- "+tr("[[%s]]: %.0f%% (%d of %d links missing: %s)")+" `%s`\n",
+			h.Page, h.Score()*100, len(h.Unresolved), h.Outbound, strings.Join(missing, ", "), h.FilePath)
+	}
+	if len(index.LinkHealth) > limit {
+		fmt.Fprintf(f, "\n*"+tr("+%d more pages with missing links")+"*\n", len(index.LinkHealth)-limit)
+	}
+	fmt.Fprintf(f, "\n---\n\n")
+}
+
+// writeNamespaceHints writes namespaces holding a single page or lacking a parent page
+func writeNamespaceHints(f *os.File, index *indexer.GraphHealthIndex) {
+	fmt.Fprintf(f, "## %s\n\n", tr("Namespace Cleanup"))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Namespaces holding a single page, or several pages but no page of their own."))
+
+	if len(index.NamespaceHints) == 0 {
+		fmt.Fprintf(f, "*%s*\n\n", tr("No fragmented namespaces found."))
+		fmt.Fprintf(f, "---\n\n")
+		return
+	}
+
+	// Cap the list to keep the report token-efficient
+	limit := 25
+	if len(index.NamespaceHints) < limit {
+		limit = len(index.NamespaceHints)
+	}
+	for _, h := range index.NamespaceHints[:limit] {
+		pages := make([]string, 0, len(h.Pages))
+		for i, page := range h.Pages {
+			if i == 3 {
+				pages = append(pages, fmt.Sprintf("+%d more", len(h.Pages)-i))
+				break
+			}
+			pages = append(pages, "[["+page+"]]")
+		}
+
+		var action string
+		switch h.Action {
+		case indexer.NamespaceCreate:
+			action = fmt.Sprintf(tr("create [[%s]] as an overview"), h.Namespace)
+		case indexer.NamespaceFlatten:
+			action = fmt.Sprintf(tr("flatten to [[%s]]"), h.Target)
+		case indexer.NamespaceMerge:
+			action = fmt.Sprintf(tr("merge into [[%s]]"), h.Target)
+		}
+		fmt.Fprintf(f, "- [[%s]] ("+plural(len(h.Pages), "%d page", "%d pages")+": %s): %s\n",
+			h.Namespace, len(h.Pages), strings.Join(pages, ", "), action)
+	}
+	if len(index.NamespaceHints) > limit {
+		fmt.Fprintf(f, "\n*"+tr("+%d more namespaces")+"*\n", len(index.NamespaceHints)-limit)
+	}
+	fmt.Fprintf(f, "\n---\n\n")
+}
@@ -0,0 +1,59 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteGraphHealth(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.GraphHealthIndex{
+		GeneratedAt:       time.Now(),
+		LinkBackThreshold: 3,
+		LinkBackSuggestions: []indexer.LinkBackSuggestion{
+			{From: "Project", To: "Person", Weight: 4},
+		},
+		MinOutboundLinks: 3,
+		LinkHealth: []indexer.PageLinkHealth{
+			{Page: "Roadmap", FilePath: "pages/Roadmap.md", Outbound: 8, Unresolved: []string{"A", "B", "C", "D", "E", "F"}},
+		},
+		NamespaceHints: []indexer.NamespaceHint{
+			{Namespace: "Projects", Pages: []string{"Projects/A", "Projects/B", "Projects/C", "Projects/D"}, Action: indexer.NamespaceCreate},
+			{Namespace: "Areas", Pages: []string{"Areas/Health"}, Action: indexer.NamespaceFlatten, Target: "Health"},
+		},
+	}
+
+	if err := WriteGraphHealth(index, tmpDir); err != nil {
+		t.Fatalf("WriteGraphHealth failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "graph-health.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "## Consider Linking Back") {
+		t.Error("Expected link-back section")
+	}
+	if !strings.Contains(output, "- [[Project]] → [[Person]] (4 refs): add a link back from [[Person]]") {
+		t.Errorf("Expected suggestion line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "## Link Health") {
+		t.Error("Expected link health section")
+	}
+	if !strings.Contains(output, "- [[Roadmap]]: 25% (6 of 8 links missing: [[A]], [[B]], [[C]], [[D]], [[E]], +1 more) `pages/Roadmap.md`") {
+		t.Errorf("Expected link health line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- [[Projects]] (4 pages: [[Projects/A]], [[Projects/B]], [[Projects/C]], +1 more): create [[Projects]] as an overview") {
+		t.Errorf("Expected create hint, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- [[Areas]] (1 page: [[Areas/Health]]): flatten to [[Health]]") {
+		t.Errorf("Expected flatten hint, got:\n%s", output)
+	}
+}
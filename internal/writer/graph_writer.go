@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -25,29 +27,39 @@ func WriteReferenceGraph(graph *indexer.ReferenceGraph, outputDir string) error
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# Logseq Reference Graph\n\n")
-	fmt.Fprintf(f, "Generated: %s\n", graph.GeneratedAt.Format(time.RFC3339))
-	fmt.Fprintf(f, "Total Pages: %d\n", len(graph.Nodes))
+	fmt.Fprintf(f, "# %s\n\n", tr("Logseq Reference Graph"))
+	fmt.Fprintf(f, "%s: %s\n", tr("Generated"), graph.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "%s: %d\n", tr("Total Pages"), len(graph.Nodes))
 
 	// Count total references
 	totalRefs := 0
 	for _, node := range graph.Nodes {
 		totalRefs += len(node.OutboundRefs)
 	}
-	fmt.Fprintf(f, "Total References: %d\n\n", totalRefs)
+	fmt.Fprintf(f, "%s: %d\n\n", tr("Total References"), totalRefs)
 	fmt.Fprintf(f, "---\n\n")
 
 	// Write hub pages
 	if len(graph.HubPages) > 0 {
-		fmt.Fprintf(f, "## Hub Pages (Most Referenced)\n\n")
+		fmt.Fprintf(f, "## %s\n\n", tr("Hub Pages (Most Referenced)"))
 		for i, pageName := range graph.HubPages {
 			node := graph.Nodes[pageName]
-			fmt.Fprintf(f, "%d. **[[%s]]** - %d inbound references\n",
-				i+1, node.PageName, node.ReferenceCount)
+			pin := ""
+			if node.Pinned {
+				pin = emoji(" 📌", " (pinned)")
+			}
+			fmt.Fprintf(f, "%d. **[[%s]]**%s - %d %s\n",
+				i+1, node.PageName, pin, node.ReferenceCount, tr("inbound references"))
+			if node.InboundWeight > node.ReferenceCount {
+				fmt.Fprintf(f, "   - %d %s\n", node.InboundWeight, tr("total mentions"))
+			}
+			if node.RawInboundWeight > node.InboundWeight {
+				fmt.Fprintf(f, "   - "+tr("Unfiltered: %d references, %d mentions (with short or link-only lines)")+"\n", node.RawReferenceCount, node.RawInboundWeight)
+			}
 			if node.FilePath != "" {
-				fmt.Fprintf(f, "   - File: `%s`\n", node.FilePath)
+				fmt.Fprintf(f, "   - %s: `%s`\n", tr("File"), node.FilePath)
 			} else {
-				fmt.Fprintf(f, "   - *Page not yet created*\n")
+				fmt.Fprintf(f, "   - *%s*\n", tr("Page not yet created"))
 			}
 			fmt.Fprintf(f, "\n")
 		}
@@ -55,7 +67,7 @@ func WriteReferenceGraph(graph *indexer.ReferenceGraph, outputDir string) error
 	}
 
 	// Write detailed page information
-	fmt.Fprintf(f, "## Page Details\n\n")
+	fmt.Fprintf(f, "## %s\n\n", tr("Page Details"))
 
 	// Group by pages with most connections first
 	type pageEntry struct {
@@ -94,45 +106,91 @@ func WriteReferenceGraph(graph *indexer.ReferenceGraph, outputDir string) error
 		node := entry.node
 
 		fmt.Fprintf(f, "### [[%s]]\n", node.PageName)
-		fmt.Fprintf(f, "- **File**: `%s`\n", node.FilePath)
+		fmt.Fprintf(f, "- **%s**: `%s`\n", tr("File"), node.FilePath)
+		if len(node.Keywords) > 0 {
+			fmt.Fprintf(f, "- **%s**: %s\n", tr("Keywords"), strings.Join(node.Keywords, ", "))
+		}
+		if node.Language != "" {
+			fmt.Fprintf(f, "- **%s**: %s\n", tr("Language"), node.Language)
+		}
 
 		if len(node.OutboundRefs) > 0 {
-			fmt.Fprintf(f, "- **Outbound References** (%d):\n", len(node.OutboundRefs))
+			fmt.Fprintf(f, "- **%s** (%d):\n", tr("Outbound References"), len(node.OutboundRefs))
 			// Show first 10 references
 			displayLimit := 10
 			if len(node.OutboundRefs) < displayLimit {
 				displayLimit = len(node.OutboundRefs)
 			}
 			for j := 0; j < displayLimit; j++ {
-				fmt.Fprintf(f, "  - [[%s]]\n", node.OutboundRefs[j])
+				target := node.OutboundRefs[j]
+				fmt.Fprintf(f, "  - [[%s]]%s\n", target, formatWeight(node.OutboundWeights[target]))
 			}
 			if len(node.OutboundRefs) > displayLimit {
-				fmt.Fprintf(f, "  - *... and %d more*\n", len(node.OutboundRefs)-displayLimit)
+				fmt.Fprintf(f, "  - *"+tr("... and %d more")+"*\n", len(node.OutboundRefs)-displayLimit)
 			}
 		}
 
 		if len(node.InboundRefs) > 0 {
-			fmt.Fprintf(f, "- **Inbound References** (%d):\n", len(node.InboundRefs))
+			fmt.Fprintf(f, "- **%s** (%d):\n", tr("Inbound References"), len(node.InboundRefs))
 			// Show first 10 references
 			displayLimit := 10
 			if len(node.InboundRefs) < displayLimit {
 				displayLimit = len(node.InboundRefs)
 			}
 			for j := 0; j < displayLimit; j++ {
-				fmt.Fprintf(f, "  - [[%s]]\n", node.InboundRefs[j])
+				source := node.InboundRefs[j]
+				fmt.Fprintf(f, "  - [[%s]]%s\n", source, formatWeight(node.InboundWeights[source]))
 			}
 			if len(node.InboundRefs) > displayLimit {
-				fmt.Fprintf(f, "  - *... and %d more*\n", len(node.InboundRefs)-displayLimit)
+				fmt.Fprintf(f, "  - *"+tr("... and %d more")+"*\n", len(node.InboundRefs)-displayLimit)
 			}
 		}
 
+		if node.ProjectTasks > 0 {
+			fmt.Fprintf(f, "- **%s**: "+plural(node.ProjectTasks, "%d task", "%d tasks")+" (%s)\n", tr("Project of"),
+				node.ProjectTasks, formatProjectSources(node.ProjectWeights))
+		}
+
 		fmt.Fprintf(f, "\n")
 	}
 
 	// Note if there are more pages
 	if len(entries) > limit {
-		fmt.Fprintf(f, "*Showing top %d of %d pages. Pages with fewer connections are omitted.*\n\n", limit, len(entries))
+		fmt.Fprintf(f, "*"+tr("Showing top %d of %d pages. Pages with fewer connections are omitted.")+"*\n\n", limit, len(entries))
 	}
 
 	return nil
 }
+
+// formatProjectSources lists the pages holding a project's tasks, most tasks
+// first, capped at five
+func formatProjectSources(weights map[string]int) string {
+	sources := make([]string, 0, len(weights))
+	for source := range weights {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if weights[sources[i]] != weights[sources[j]] {
+			return weights[sources[i]] > weights[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+
+	var parts []string
+	for i, source := range sources {
+		if i == 5 {
+			parts = append(parts, fmt.Sprintf("+%d more", len(sources)-5))
+			break
+		}
+		parts = append(parts, "[["+source+"]]"+formatWeight(weights[source]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatWeight renders an edge weight suffix, omitted for single references
+func formatWeight(weight int) string {
+	if weight <= 1 {
+		return ""
+	}
+	return fmt.Sprintf(" (×%d)", weight)
+}
@@ -0,0 +1,70 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// InboxFileName lists quick-capture items waiting to be processed
+const InboxFileName = "inbox.md"
+
+// WriteInbox writes the unprocessed inbox items and suggested destinations to inbox.md
+func WriteInbox(index *indexer.InboxIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, InboxFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Inbox"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	var sources []string
+	if index.Page != "" {
+		sources = append(sources, tr("top-level blocks of")+" [["+index.Page+"]]")
+	}
+	if index.Tag != "" {
+		sources = append(sources, tr("blocks tagged")+" #"+index.Tag)
+	}
+	fmt.Fprintf(f, "*"+tr("Quick captures waiting to be filed: %s. Move each one to a destination, or mark it DONE.")+"*\n\n", strings.Join(sources, " "+tr("and")+" "))
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, "## %s (%d)\n\n", tr("Unprocessed"), len(index.Items))
+	if len(index.Items) == 0 {
+		fmt.Fprintf(f, "*%s*\n\n", tr("Inbox zero."))
+		return nil
+	}
+	for _, entry := range index.Items {
+		age := ""
+		if entry.AgeDays >= 0 {
+			age = fmt.Sprintf(" (%dd)", entry.AgeDays)
+		}
+		fmt.Fprintf(f, "- %s%s `%s:%d`\n", entry.Item.Text, age, entry.Item.SourceFile, entry.Item.LineNumber)
+
+		if len(entry.Destinations) == 0 {
+			continue
+		}
+		destinations := make([]string, len(entry.Destinations))
+		for i, d := range entry.Destinations {
+			if len(d.Matches) == 0 {
+				destinations[i] = fmt.Sprintf("[[%s]] (%s)", d.Page, tr("linked"))
+			} else {
+				destinations[i] = fmt.Sprintf("[[%s]] (%s)", d.Page, strings.Join(d.Matches, ", "))
+			}
+		}
+		fmt.Fprintf(f, "  - %s: %s\n", tr("Move to"), strings.Join(destinations, ", "))
+	}
+	fmt.Fprintf(f, "\n")
+
+	return nil
+}
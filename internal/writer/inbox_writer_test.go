@@ -0,0 +1,68 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteInbox(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.InboxIndex{
+		GeneratedAt: time.Now(),
+		Page:        "Inbox",
+		Tag:         "inbox",
+		Items: []indexer.InboxEntry{
+			{
+				Item:    models.InboxItem{SourceFile: "journals/2025_11_01.md", LineNumber: 2, Text: "Speed up the deploy pipeline"},
+				AgeDays: 9,
+				Destinations: []indexer.InboxDestination{
+					{Page: "Kubernetes"},
+					{Page: "Phoenix", Matches: []string{"deploy", "pipeline"}},
+				},
+			},
+			{Item: models.InboxItem{SourceFile: "pages/Inbox.md", LineNumber: 1, Text: "Book dentist"}, AgeDays: -1},
+		},
+	}
+
+	if err := WriteInbox(index, tmpDir); err != nil {
+		t.Fatalf("WriteInbox failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, InboxFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"top-level blocks of [[Inbox]] and blocks tagged #inbox",
+		"## Unprocessed (2)",
+		"- Speed up the deploy pipeline (9d) `journals/2025_11_01.md:2`\n  - Move to: [[Kubernetes]] (linked), [[Phoenix]] (deploy, pipeline)",
+		"- Book dentist `pages/Inbox.md:1`\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected inbox.md to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteInbox_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteInbox(&indexer.InboxIndex{GeneratedAt: time.Now(), Tag: "inbox"}, tmpDir); err != nil {
+		t.Fatalf("WriteInbox failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, InboxFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "*Inbox zero.*") {
+		t.Errorf("Expected an inbox zero note, got:\n%s", content)
+	}
+}
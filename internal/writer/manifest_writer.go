@@ -0,0 +1,102 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ManifestFileName is the machine-readable summary of a generation run
+const ManifestFileName = "manifest.json"
+
+// Manifest describes what a run generated, for scripts and tooling
+type Manifest struct {
+	ToolVersion string         `json:"tool_version"`
+	GeneratedAt time.Time      `json:"generated_at"`
+	Files       []string       `json:"files"`           // Generated file names, relative to the output directory
+	Counts      map[string]int `json:"counts"`          // Summary counts (tasks, pages, warnings, ...)
+	Stale       []string       `json:"stale,omitempty"` // Files earlier runs generated that this one didn't, left in place without --prune
+
+	Formats            map[string]FileFormat `json:"formats,omitempty"`              // Shape of each generated file, keyed by registry name
+	FormatChanges      []FormatChange        `json:"format_changes,omitempty"`       // Shapes that differ from the previous run's
+	FormatChangesSince string                `json:"format_changes_since,omitempty"` // Tool version of the previous run
+}
+
+// WriteManifest writes manifest.json to the output directory
+func WriteManifest(manifest *Manifest, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, ManifestFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ReadManifest reads manifest.json from the output directory
+func ReadManifest(outputDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// StaleFiles returns the files an earlier run generated that this run
+// didn't: those the previous manifest lists as generated or stale, minus
+// produced, that still exist in outputDir. When partial is set (--only or
+// --skip), files of writers that didn't run aren't stale, so only the
+// previous stale list is carried forward.
+func StaleFiles(previous *Manifest, produced []string, partial bool, outputDir string) []string {
+	if previous == nil {
+		return nil
+	}
+	candidates := previous.Stale
+	if !partial {
+		candidates = append(append([]string{}, previous.Files...), previous.Stale...)
+	}
+
+	current := make(map[string]bool, len(produced))
+	for _, name := range produced {
+		current[name] = true
+	}
+	var stale []string
+	for _, name := range candidates {
+		if current[name] || !filepath.IsLocal(filepath.FromSlash(name)) {
+			continue
+		}
+		current[name] = true // Listed once
+		if _, err := os.Stat(filepath.Join(outputDir, filepath.FromSlash(name))); err == nil {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+// PruneFiles removes stale outputs from outputDir. Names ending in "/" are
+// generated directories and are removed with their contents.
+func PruneFiles(stale []string, outputDir string) error {
+	for _, name := range stale {
+		if err := os.RemoveAll(filepath.Join(outputDir, filepath.FromSlash(name))); err != nil {
+			return fmt.Errorf("removing %s: %w", name, err)
+		}
+	}
+	return nil
+}
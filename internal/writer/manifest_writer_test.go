@@ -0,0 +1,58 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStaleFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"dashboard.md", "timeline-2023.md", "inbox.md", "backlinks/Phoenix.md", "notes.md"} {
+		path := filepath.Join(tmpDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	previous := &Manifest{
+		Files: []string{"dashboard.md", "timeline-2023.md", "backlinks/", "gone.md", "../outside.md"},
+		Stale: []string{"inbox.md"},
+	}
+	produced := []string{"dashboard.md", ManifestFileName}
+
+	stale := StaleFiles(previous, produced, false, tmpDir)
+	if got, want := strings.Join(stale, ","), "backlinks/,inbox.md,timeline-2023.md"; got != want {
+		t.Fatalf("Expected stale %s, got %s", want, got)
+	}
+
+	// A partial run only carries the previous stale list forward
+	if got := StaleFiles(previous, produced, true, tmpDir); strings.Join(got, ",") != "inbox.md" {
+		t.Errorf("Expected only inbox.md for a partial run, got %v", got)
+	}
+	if got := StaleFiles(nil, produced, false, tmpDir); len(got) != 0 {
+		t.Errorf("Expected nothing stale on the first run, got %v", got)
+	}
+
+	if err := PruneFiles(stale, tmpDir); err != nil {
+		t.Fatalf("PruneFiles failed: %v", err)
+	}
+	for _, name := range []string{"backlinks", "inbox.md", "timeline-2023.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed", name)
+		}
+	}
+	for _, name := range []string{"dashboard.md", "notes.md"} {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("Expected %s to be kept: %v", name, err)
+		}
+	}
+
+	// Pruning is idempotent: nothing is stale the second time
+	if got := StaleFiles(&Manifest{Files: produced}, produced, false, tmpDir); len(got) != 0 {
+		t.Errorf("Expected nothing stale after pruning, got %v", got)
+	}
+}
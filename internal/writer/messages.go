@@ -0,0 +1,506 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Locale selects the language of headings, labels, and dates in markdown outputs
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en" // Default
+	LocaleGerman  Locale = "de"
+)
+
+// locale is the configured report language (see SetLocale)
+var locale = LocaleEnglish
+
+// SetLocale configures the report language; empty restores the default
+func SetLocale(l Locale) error {
+	switch {
+	case l == "":
+		locale = LocaleEnglish
+	case l == LocaleEnglish || catalogs[l] != nil:
+		locale = l
+	default:
+		return fmt.Errorf("unknown locale %q (expected %s)", l, strings.Join(Locales(), ", "))
+	}
+	return nil
+}
+
+// Locales returns the supported locale codes, English first
+func Locales() []string {
+	return []string{string(LocaleEnglish), string(LocaleGerman)}
+}
+
+// catalogs maps English report strings to their translations. Strings are
+// keyed by their English text so writers stay readable (sentences with values
+// by their format string), and anything missing from a catalog falls back to
+// English. Date layouts are translated too, so
+// a locale can reorder day and month.
+var catalogs = map[Locale]map[string]string{
+	LocaleGerman: {
+		// Titles
+		"Knowledge Dashboard":        "Wissens-Dashboard",
+		"Tasks by Status":            "Aufgaben nach Status",
+		"High Priority Tasks [#A]":   "Aufgaben mit hoher Priorität [#A]",
+		"Time Tracking Analytics":    "Zeiterfassung",
+		"Recent Activity Timeline":   "Aktuelle Aktivitäten",
+		"Complete Activity Timeline": "Vollständiger Aktivitätsverlauf",
+		"Activity Timeline":          "Aktivitätsverlauf",
+
+		// Sections
+		"Quick Stats":             "Kurzübersicht",
+		"Pinned Pages":            "Angeheftete Seiten",
+		"Current Priorities [#A]": "Aktuelle Prioritäten [#A]",
+		"Quick Wins":              "Schnelle Erfolge",
+		"Waiting on Others":       "Wartet auf andere",
+		"Orphan Tasks":            "Verwaiste Aufgaben",
+		"Back from Snooze":        "Zurück aus dem Snooze",
+		"back from snooze":        "zurück aus dem Snooze",
+		"snoozed until":           "zurückgestellt bis",
+		"Recent Activity":         "Letzte Aktivitäten",
+		"Emerging Topics":         "Aufkommende Themen",
+		"Writing":                 "Schreiben",
+		"Writing Statistics":      "Schreibstatistik",
+		"Top Projects":            "Wichtigste Projekte",
+		"Time Budgets":            "Zeitbudgets",
+		"Time by Category":        "Zeit nach Kategorie",
+		"Week of":                 "Woche vom",
+		"Pages to Create":         "Anzulegende Seiten",
+		"Possibly Complete":       "Möglicherweise abgeschlossen",
+		"Since Last Run":          "Seit dem letzten Lauf",
+		"Detailed Reports":        "Detailberichte",
+		"Statistics":              "Statistik",
+		"Summary":                 "Zusammenfassung",
+		"Weekly Budgets":          "Wochenbudgets",
+		"Weekly Breakdown":        "Wochenübersicht",
+		"By Priority":             "Nach Priorität",
+		"By Location":             "Nach Ort",
+		"By Category":             "Nach Kategorie",
+		"By Status":               "Nach Status",
+		"By Year":                 "Nach Jahr",
+		"Records":                 "Rekorde",
+		"Time to First Start":     "Zeit bis zum Arbeitsbeginn",
+
+		// Labels
+		"Generated":                       "Erstellt",
+		"Total Tasks":                     "Aufgaben gesamt",
+		"Total High Priority":             "Hohe Priorität gesamt",
+		"Completion Rate":                 "Erledigungsquote",
+		"Time Tracking":                   "Zeiterfassung",
+		"Knowledge Graph":                 "Wissensgraph",
+		"Total Time Logged":               "Erfasste Zeit gesamt",
+		"Time Logged":                     "Erfasste Zeit",
+		"Tasks Tracked":                   "Erfasste Aufgaben",
+		"Tasks Added":                     "Neue Aufgaben",
+		"Tasks Completed":                 "Erledigte Aufgaben",
+		"New Pages":                       "Neue Seiten",
+		"Avg Time/Task":                   "Ø Zeit/Aufgabe",
+		"Current Streak":                  "Aktuelle Serie",
+		"Longest Streak":                  "Längste Serie",
+		"Most Time in a Day":              "Meiste Zeit an einem Tag",
+		"Most Tasks Completed in a Day":   "Meiste erledigte Aufgaben an einem Tag",
+		"Most Tasks Completed in a Week":  "Meiste erledigte Aufgaben in einer Woche",
+		"Most Productive Week":            "Produktivste Woche",
+		"Micro-sessions":                  "Kurzbuchungen",
+		"Inbox":                           "Eingang",
+		"Time":                            "Zeit",
+		"Page Namespaces":                 "Seiten-Namensräume",
+		"File":                            "Datei",
+		"References":                      "Verweise",
+		"Properties":                      "Eigenschaften",
+		"Last Activity":                   "Letzte Aktivität",
+		"Last 7 Days":                     "Letzte 7 Tage",
+		"Total Days":                      "Tage gesamt",
+		"Journal":                         "Journal",
+		"Activity":                        "Aktivität",
+		"Tasks":                           "Aufgaben",
+		"tasks":                           "Aufgaben",
+		"Page Tasks":                      "Seitenaufgaben",
+		"No high priority tasks found.":   "Keine Aufgaben mit hoher Priorität gefunden.",
+		"No activity in the last 7 days.": "Keine Aktivität in den letzten 7 Tagen.",
+		"No activity recorded.":           "Keine Aktivität erfasst.",
+		"No tasks":                        "Keine Aufgaben",
+		"logged":                          "erfasst",
+		"adoption":                        "Nutzung",
+		"%d day with activity":            "%d Tag mit Aktivität",
+		"%d days with activity":           "%d Tage mit Aktivität",
+		"via %s":                          "über %s",
+		"logbook":                         "Logbuch",
+		"completed":                       "erledigt",
+		"Words Written":                   "Geschriebene Wörter",
+		"words written":                   "Wörter geschrieben",
+		"Total Words":                     "Wörter gesamt",
+		"Avg Words/Day":                   "Ø Wörter/Tag",
+		"Most Words in a Day":             "Meiste Wörter an einem Tag",
+		"This Week":                       "Diese Woche",
+		"last week":                       "letzte Woche",
+		"longest":                         "längste",
+		"Writing Days":                    "Schreibtage",
+		"words":                           "Wörter",
+		"Words":                           "Wörter",
+		"Week":                            "Woche",
+		"Days":                            "Tage",
+		"Priority":                        "Priorität",
+		"Project":                         "Projekt",
+		"Median":                          "Median",
+		"Average":                         "Durchschnitt",
+		"Words exclude tasks, properties, and logbooks. Streaks count consecutive days with journal writing.": "Wörter ohne Aufgaben, Eigenschaften und Logbücher. Serien zählen aufeinanderfolgende Tage mit Journaleinträgen.",
+
+		// Reference graph, missing pages, and graph health
+		"Logseq Reference Graph":      "Logseq-Verweisgraph",
+		"Total Pages":                 "Seiten gesamt",
+		"Total References":            "Verweise gesamt",
+		"Hub Pages (Most Referenced)": "Zentrale Seiten (meistverwiesen)",
+		"inbound references":          "eingehende Verweise",
+		"total mentions":              "Erwähnungen gesamt",
+		"Unfiltered: %d references, %d mentions (with short or link-only lines)": "Ungefiltert: %d Verweise, %d Erwähnungen (mit kurzen oder reinen Link-Zeilen)",
+		"Page not yet created": "Seite noch nicht angelegt",
+		"Page Details":         "Seitendetails",
+		"Keywords":             "Schlüsselwörter",
+		"Language":             "Sprache",
+		"Outbound References":  "Ausgehende Verweise",
+		"Inbound References":   "Eingehende Verweise",
+		"... and %d more":      "... und %d weitere",
+		"Project of":           "Projekt von",
+		"Showing top %d of %d pages. Pages with fewer connections are omitted.": "Die %d meistverknüpften von %d Seiten. Seiten mit weniger Verbindungen sind ausgelassen.",
+		"Missing Pages to Create":                        "Fehlende Seiten",
+		"No missing pages with %d+ references found.":    "Keine fehlenden Seiten mit %d+ Verweisen gefunden.",
+		"Pages with %d+ references that don't exist yet": "Seiten mit %d+ Verweisen, die es noch nicht gibt",
+		"People":            "Personen",
+		"Projects":          "Projekte",
+		"Dates":             "Daten",
+		"Concepts":          "Begriffe",
+		"Unclassified":      "Nicht zugeordnet",
+		"Alias Suggestions": "Alias-Vorschläge",
+		"Missing pages that look like another name for an existing page. Add them as aliases there instead of creating new pages.": "Fehlende Seiten, die wie ein anderer Name einer vorhandenen Seite aussehen. Trage sie dort als Alias ein, statt neue Seiten anzulegen.",
+		"Add":                   "Hinzufügen",
+		"Variants":              "Varianten",
+		"Possible alias of":     "Möglicher Alias von",
+		"see Alias Suggestions": "siehe Alias-Vorschläge",
+		"Referenced from":       "Verwiesen von",
+		"Graph Health":          "Graph-Zustand",
+		"Consider Linking Back": "Rückverweise erwägen",
+		"Pages referenced %d+ times by another page that never links back.": "Seiten, die eine andere Seite %d+ Mal verweist, ohne dass sie zurückverweisen.",
+		"No one-way links found.":                                "Keine einseitigen Verweise gefunden.",
+		"[[%s]] → [[%s]] (%d refs): add a link back from [[%s]]": "[[%s]] → [[%s]] (%d Verweise): Rückverweis von [[%s]] hinzufügen",
+		"+%d more suggestions":                                   "+%d weitere Vorschläge",
+		"Link Health":                                            "Verweis-Zustand",
+		"Share of each page's links that lead to an existing page (pages linking %d+ pages). Create the stubs or prune the links.": "Anteil der Verweise jeder Seite, die zu einer vorhandenen Seite führen (Seiten mit %d+ verlinkten Seiten). Lege die Seiten an oder entferne die Verweise.",
+		"Every scored page's links resolve.":                                           "Alle Verweise der bewerteten Seiten führen zu vorhandenen Seiten.",
+		"[[%s]]: %.0f%% (%d of %d links missing: %s)":                                  "[[%s]]: %.0f%% (%d von %d Verweisen fehlen: %s)",
+		"+%d more pages with missing links":                                            "+%d weitere Seiten mit fehlenden Verweisen",
+		"Namespace Cleanup":                                                            "Namensräume aufräumen",
+		"Namespaces holding a single page, or several pages but no page of their own.": "Namensräume mit nur einer Seite, oder mit mehreren Seiten, aber ohne eigene Seite.",
+		"No fragmented namespaces found.":                                              "Keine zersplitterten Namensräume gefunden.",
+		"create [[%s]] as an overview":                                                 "[[%s]] als Übersicht anlegen",
+		"flatten to [[%s]]":                                                            "zu [[%s]] abflachen",
+		"merge into [[%s]]":                                                            "in [[%s]] zusammenführen",
+		"+%d more namespaces":                                                          "+%d weitere Namensräume",
+
+		// Backlinks
+		"Usually worked on":               "Meist bearbeitet",
+		"around %02d:%02d":                "gegen %02d:%02d",
+		"~%s sessions (%d logged)":        "~%s pro Sitzung (%d erfasst)",
+		"Last in journals":                "Zuletzt in Journalen",
+		"Recent Journals (%d of %d days)": "Letzte Journale (%d von %d Tagen)",
+		"Recent Journals (1 day)":         "Letzte Journale (1 Tag)",
+		"Recent Journals (%d days)":       "Letzte Journale (%d Tage)",
+		"mentions":                        "Erwähnungen",
+		"Backlinks":                       "Rückverweise",
+		"No pages link here.":             "Keine Seite verweist hierher.",
+		"+%d more backlinks":              "+%d weitere Rückverweise",
+		"Open Tasks":                      "Offene Aufgaben",
+		"Page":                            "Seite",
+		"Open":                            "Offen",
+		"Next Due":                        "Nächste Fälligkeit",
+		"This page":                       "Diese Seite",
+		"Tasks written on or linking each page. Neighbours are pages linked from or to this one.": "Aufgaben auf oder mit Verweis auf jede Seite. Nachbarn sind Seiten, die mit dieser verknüpft sind.",
+		"overdue":     "überfällig",
+		"today":       "heute",
+		"yesterday":   "gestern",
+		"%d days ago": "vor %d Tagen",
+
+		// Timeline page activity
+		"Created":             "Angelegt",
+		"Edited":              "Bearbeitet",
+		"%d page task in %s":  "%d Seitenaufgabe in %s",
+		"%d page tasks in %s": "%d Seitenaufgaben in %s",
+
+		// Prompts
+		"Daily Planning Prompt": "Prompt zur Tagesplanung",
+		"Paste everything below the line into a new conversation. It's refreshed on every run.":                                                                                                                                                              "Füge alles unterhalb der Linie in eine neue Unterhaltung ein. Wird bei jedem Lauf aktualisiert.",
+		"Help me plan my day for %s. Below are my agenda, unfinished work carried over from earlier days, and my top priorities, taken from my Logseq notes.":                                                                                                "Hilf mir, meinen Tag am %s zu planen. Unten stehen meine Termine, Unerledigtes aus den letzten Tagen und meine wichtigsten Prioritäten aus meinen Logseq-Notizen.",
+		"Suggest a realistic plan: what to tackle first, what fits around fixed commitments, and what to defer or drop. Point out anything that looks stuck (carried over for many days) and ask me about anything unclear before committing to a schedule.": "Schlage einen realistischen Plan vor: was ich zuerst angehen sollte, was um feste Termine herum passt und was ich verschieben oder streichen sollte. Weise auf alles hin, was feststeckt (seit vielen Tagen übertragen), und frage mich nach Unklarem, bevor du einen Zeitplan festlegst.",
+		"Agenda for today":              "Termine für heute",
+		"Nothing scheduled or due.":     "Nichts geplant oder fällig.",
+		"due today":                     "heute fällig",
+		"overdue by %d day":             "seit %d Tag überfällig",
+		"overdue by %d days":            "seit %d Tagen überfällig",
+		"from":                          "aus",
+		"Carried over":                  "Übertragen",
+		"Nothing carried over.":         "Nichts übertragen.",
+		"from %d day ago":               "von vor %d Tag",
+		"from %d days ago":              "von vor %d Tagen",
+		"Top priorities":                "Wichtigste Prioritäten",
+		"No other high priority tasks.": "Keine weiteren Aufgaben mit hoher Priorität.",
+		"Coming up":                     "Demnächst",
+		"due":                           "fällig",
+		"Retro Prompt":                  "Retro-Prompt",
+		"Every task referencing this project is DONE. Paste everything below the line into a new conversation to run a retrospective.": "Jede Aufgabe mit Verweis auf dieses Projekt ist DONE. Füge alles unterhalb der Linie in eine neue Unterhaltung ein, um eine Retrospektive durchzuführen.",
+		"Run a retrospective with me for my project \"%s\", which I've just finished. Below is its history from my Logseq notes":       "Führe mit mir eine Retrospektive für mein gerade abgeschlossenes Projekt \"%s\" durch. Unten steht sein Verlauf aus meinen Logseq-Notizen",
+		", from %s to %s": ", vom %s bis zum %s",
+		"Help me reflect on what went well, what didn't, and what I'd do differently. Compare how the time was spent with what turned out to matter, point out patterns in the timeline, and ask me questions one at a time before summarizing lessons I can apply to my next project.": "Hilf mir zu reflektieren, was gut lief, was nicht und was ich anders machen würde. Vergleiche, wofür die Zeit aufgewendet wurde, mit dem, was sich als wichtig herausstellte, weise auf Muster im Verlauf hin und stelle mir nacheinander Fragen, bevor du Lehren für mein nächstes Projekt zusammenfasst.",
+		"Overview":         "Überblick",
+		"Tasks completed":  "Erledigte Aufgaben",
+		"Time logged":      "Erfasste Zeit",
+		"Duration":         "Dauer",
+		"%d day":           "%d Tag",
+		"%d days":          "%d Tage",
+		"Timeline":         "Verlauf",
+		"%d more events":   "%d weitere Ereignisse",
+		"Notable tasks":    "Wichtige Aufgaben",
+		"Key linked pages": "Wichtige verknüpfte Seiten",
+		"and %d more":      "und %d weitere",
+
+		// Resurface, inbox, recently deleted, and time tree
+		"Resurface": "Wiederentdecken",
+		"Old pages worth a fresh look: reread them, link them to current work, or archive them.": "Alte Seiten, die einen neuen Blick lohnen: lies sie erneut, verknüpfe sie mit aktueller Arbeit oder archiviere sie.",
+		"Revisit Today":                   "Heute wiederentdecken",
+		"No pages are due for a revisit.": "Keine Seiten stehen zur Wiedervorlage an.",
+		"untouched %d days (since %s)":    "seit %d Tagen unverändert (seit %s)",
+		"pinned":                          "angeheftet",
+		"A new selection each day, favouring pages with more references and linked tasks.": "Jeden Tag eine neue Auswahl, bevorzugt Seiten mit mehr Verweisen und verknüpften Aufgaben.",
+		"Due for Revisit":     "Zur Wiedervorlage",
+		"Untouched":           "Unverändert",
+		"Pages":               "Seiten",
+		"days":                "Tage",
+		"Total":               "Gesamt",
+		"top-level blocks of": "Blöcke der obersten Ebene von",
+		"blocks tagged":       "Blöcke mit dem Tag",
+		"Quick captures waiting to be filed: %s. Move each one to a destination, or mark it DONE.": "Schnelle Notizen, die einsortiert werden wollen: %s. Verschiebe jede an ein Ziel oder markiere sie als DONE.",
+		"and":              "und",
+		"Unprocessed":      "Unbearbeitet",
+		"Inbox zero.":      "Eingang leer.",
+		"linked":           "verknüpft",
+		"Move to":          "Verschieben nach",
+		"Recently Deleted": "Kürzlich gelöscht",
+		"Pages deleted in the last %d days, from the copies Logseq keeps in logseq/.recycle and logseq/bak. None of them are part of the other indexes. To restore one, move its copy back to pages/ under the page's name.": "In den letzten %d Tagen gelöschte Seiten, aus den Kopien, die Logseq in logseq/.recycle und logseq/bak aufbewahrt. Keine davon ist Teil der anderen Indizes. Zum Wiederherstellen verschiebe die Kopie unter dem Seitennamen zurück nach pages/.",
+		"No pages deleted in the last %d days.": "Keine Seiten in den letzten %d Tagen gelöscht.",
+		"Still Linked":                          "Noch verknüpft",
+		"These links now point at a missing page. Restore the page, or update the links.": "Diese Verweise zeigen jetzt auf eine fehlende Seite. Stelle die Seite wieder her oder passe die Verweise an.",
+		"deleted":             "gelöscht",
+		"linked from":         "verknüpft von",
+		"Not Linked":          "Nicht verknüpft",
+		"newest of %d copies": "neueste von %d Kopien",
+		"Time Tree":           "Zeitbaum",
+		"Logged time rolled up through nested tasks: a task's total includes its subtasks', with its own time alongside. Projects are the first page reference of the top-level task. Only tasks with logged time are listed.": "Erfasste Zeit über verschachtelte Aufgaben summiert: die Summe einer Aufgabe enthält die ihrer Unteraufgaben, mit der eigenen Zeit daneben. Projekte sind der erste Seitenverweis der obersten Aufgabe. Nur Aufgaben mit erfasster Zeit sind aufgeführt.",
+		"No logged time.": "Keine erfasste Zeit.",
+		"%d more task":    "%d weitere Aufgabe",
+		"%d more tasks":   "%d weitere Aufgaben",
+		"own":             "eigene",
+
+		// Next week's plan
+		"Plan for Next Week": "Plan für nächste Woche",
+		"Open tasks that are overdue, due next week, or at the top priority, weighed against the time you usually track in a week. Delegated tasks are left out.": "Offene Aufgaben, die überfällig, nächste Woche fällig oder von höchster Priorität sind, verglichen mit der Zeit, die du üblicherweise pro Woche erfasst. Delegierte Aufgaben sind ausgenommen.",
+		"Capacity": "Kapazität",
+		"%s per week (average tracked over %d week with tracked time, of the last %d)":  "%s pro Woche (Durchschnitt über %d Woche mit erfasster Zeit, von den letzten %d)",
+		"%s per week (average tracked over %d weeks with tracked time, of the last %d)": "%s pro Woche (Durchschnitt über %d Wochen mit erfasster Zeit, von den letzten %d)",
+		"unknown (no time tracked in the last %d weeks)":                                "unbekannt (keine erfasste Zeit in den letzten %d Wochen)",
+		"Estimated":                       "Geschätzt",
+		"%s across %d task":               "%s für %d Aufgabe",
+		"%s across %d tasks":              "%s für %d Aufgaben",
+		"Unestimated":                     "Ohne Schätzung",
+		"counted at the average estimate": "mit der durchschnittlichen Schätzung gezählt",
+		"Projected":                       "Erwartet",
+		"%.0f%% of capacity":              "%.0f%% der Kapazität",
+		"Nothing lined up for next week.": "Nichts für nächste Woche geplant.",
+		"Track time on tasks to see whether the plan fits.":     "Erfasse Zeit auf Aufgaben, um zu sehen, ob der Plan passt.",
+		"Add estimate:: to tasks to see whether the plan fits.": "Ergänze estimate:: bei Aufgaben, um zu sehen, ob der Plan passt.",
+		"Overcommitted by %s.":                                  "Um %s überplant.",
+		"Defer or drop tasks before planning the week.":         "Verschiebe oder streiche Aufgaben, bevor du die Woche planst.",
+		"Fits, with %s to spare.":                               "Passt, mit %s Puffer.",
+		"Overdue":                                               "Überfällig",
+		"Due Next Week":                                         "Nächste Woche fällig",
+		"Top Priority":                                          "Höchste Priorität",
+		"left of the estimate":                                  "von der Schätzung übrig",
+		"based on similar tasks":                                "nach ähnlichen Aufgaben",
+		"no estimate":                                           "keine Schätzung",
+
+		// Counts
+		"%d task":       "%d Aufgabe",
+		"%d tasks":      "%d Aufgaben",
+		"%d page":       "%d Seite",
+		"%d pages":      "%d Seiten",
+		"%d ref":        "%d Verweis",
+		"%d refs":       "%d Verweise",
+		"%d reference":  "%d Verweis",
+		"%d references": "%d Verweise",
+		"%d link":       "%d Verweis",
+		"%d links":      "%d Verweise",
+		"+%d more":      "+%d weitere",
+		"same words":    "gleiche Wörter",
+		"plural":        "Plural",
+		"typo":          "Tippfehler",
+
+		// Dashboard
+		"%d open journal task referencing nothing, oldest %dd (see tasks-by-status.md)":  "%d offene Journalaufgabe ohne Verweis, älteste %d Tage (siehe tasks-by-status.md)",
+		"%d open journal tasks referencing nothing, oldest %dd (see tasks-by-status.md)": "%d offene Journalaufgaben ohne Verweis, älteste %d Tage (siehe tasks-by-status.md)",
+		", oldest %dd":                          ", älteste %d Tage",
+		"%d unprocessed item%s (see inbox.md)":  "%d unbearbeiteter Eintrag%s (siehe inbox.md)",
+		"%d unprocessed items%s (see inbox.md)": "%d unbearbeitete Einträge%s (siehe inbox.md)",
+		"%.1f%% adoption, %s logged":            "%.1f%% Nutzung, %s erfasst",
+		"%d pages, %d references":               "%d Seiten, %d Verweise",
+		"not yet created":                       "noch nicht angelegt",
+		"+%d more high priority tasks":          "+%d weitere Aufgaben mit hoher Priorität",
+		"Open tasks likely to take under %s":    "Offene Aufgaben, die wohl weniger als %s dauern",
+		"+%d more quick wins":                   "+%d weitere schnelle Erfolge",
+		"+%d more in [inbox.md](./inbox.md)":    "+%d weitere in [inbox.md](./inbox.md)",
+		"%d %s task":                            "%d %s-Aufgabe",
+		"%d %s tasks":                           "%d %s-Aufgaben",
+		"Journal days mentioning each topic: the %d before (%d entries) → last %d days (%d entries)": "Journaltage mit Erwähnung jedes Themas: die %d davor (%d Einträge) → letzte %d Tage (%d Einträge)",
+		"%d → %d days":           "%d → %d Tage",
+		"+%d more rising topics": "+%d weitere aufkommende Themen",
+		"%d active task":         "%d aktive Aufgabe",
+		"%d active tasks":        "%d aktive Aufgaben",
+		"Every task is DONE and nothing has happened for weeks: archive the page or write a retro": "Jede Aufgabe ist DONE und seit Wochen ist nichts passiert: archiviere die Seite oder schreibe eine Retro",
+		"%d task done":                                        "%d Aufgabe erledigt",
+		"%d tasks done":                                       "%d Aufgaben erledigt",
+		"last activity %s (%d weeks ago)":                     "letzte Aktivität %s (vor %d Wochen)",
+		"+%d more possibly complete projects":                 "+%d weitere möglicherweise abgeschlossene Projekte",
+		"%s this week / %s budget (%s)":                       "%s diese Woche / %s Budget (%s)",
+		"+%d more suggested pages":                            "+%d weitere vorgeschlagene Seiten",
+		"estimated":                                           "geschätzt",
+		"judging by the wording":                              "nach dem Wortlaut",
+		"Tasks by Priority":                                   "Aufgaben nach Priorität",
+		"Someday/Maybe":                                       "Irgendwann/Vielleicht",
+		"Timeline (Recent)":                                   "Verlauf (aktuell)",
+		"Timeline (Full)":                                     "Verlauf (vollständig)",
+		"Missing Pages":                                       "Fehlende Seiten",
+		"Reference Graph":                                     "Verweisgraph",
+		"All tasks organized by workflow stage":               "Alle Aufgaben nach Arbeitsschritt",
+		"High priority tasks requiring attention":             "Aufgaben mit hoher Priorität, die Aufmerksamkeit brauchen",
+		"Parked ideas excluded from active counts":            "Geparkte Ideen, nicht in den aktiven Zahlen",
+		"Activity from last 7 days":                           "Aktivität der letzten 7 Tage",
+		"Complete activity history, one file per year":        "Vollständiger Aktivitätsverlauf, eine Datei pro Jahr",
+		"Suggested pages to create":                           "Vorgeschlagene neue Seiten",
+		"Time allocation analytics":                           "Auswertung der Zeitverteilung",
+		"Page connections and relationships":                  "Verbindungen und Beziehungen der Seiten",
+		"Suggestions for a more navigable graph":              "Vorschläge für einen übersichtlicheren Graphen",
+		"Old pages to revisit today":                          "Alte Seiten zum Wiederentdecken",
+		"Quick captures to file, with suggested destinations": "Schnelle Notizen zum Einsortieren, mit vorgeschlagenen Zielen",
+
+		// Time tracking
+		"%d / %d (%.1f%% adoption)": "%d / %d (%.1f%% Nutzung)",
+		"%d entry under %s":         "%d Eintrag unter %s",
+		"%d entries under %s":       "%d Einträge unter %s",
+		"on %d task":                "bei %d Aufgabe",
+		"on %d tasks":               "bei %d Aufgaben",
+		"left out of the totals":    "nicht in den Summen",
+		"ended %s":                  "endete am %s",
+		"week of":                   "Woche vom",
+		"Streaks count consecutive days with logged time.":         "Serien zählen aufeinanderfolgende Tage mit erfasster Zeit.",
+		"%s/week budget, %s avg (last 4 weeks), %s this week — %s": "%s/Woche Budget, Ø %s (letzte 4 Wochen), %s diese Woche — %s",
+		"over":                              "überschritten",
+		"under":                             "unterschritten",
+		"on track":                          "im Plan",
+		"avg %s/task":                       "Ø %s/Aufgabe",
+		"Showing last %d weeks of %d total": "Die letzten %d von %d Wochen",
+		"Days from writing a task down (its journal's day, or its page's first commit) to first clocking in.": "Tage vom Aufschreiben einer Aufgabe (Tag ihres Journals oder erster Commit ihrer Seite) bis zur ersten Zeiterfassung.",
+		"Median %s across %d task.":  "Median %s über %d Aufgabe.",
+		"Median %s across %d tasks.": "Median %s über %d Aufgaben.",
+		"%d are page tasks dated by git, which overstates the wait for tasks added to older pages.": "%d davon sind Seitenaufgaben, nach git datiert, was die Wartezeit bei Aufgaben auf älteren Seiten überschätzt.",
+		"Where tracked tasks are written.": "Wo erfasste Aufgaben stehen.",
+		"journals":                         "Journale",
+		"pages":                            "Seiten",
+		"(no namespace)":                   "(kein Namensraum)",
+
+		// Someday/maybe backlog
+		"Someday/Maybe Backlog": "Irgendwann/Vielleicht-Backlog",
+		"Tasks tagged `#%s` or LATER tasks older than %d days. These are excluded from the active task indexes.": "Aufgaben mit dem Tag `#%s` oder LATER-Aufgaben, die älter als %d Tage sind. Sie sind von den aktiven Aufgabenindizes ausgenommen.",
+		"Tasks tagged `#%s`. These are excluded from the active task indexes.":                                   "Aufgaben mit dem Tag `#%s`. Sie sind von den aktiven Aufgabenindizes ausgenommen.",
+		"LATER tasks older than %d days. These are excluded from the active task indexes.":                       "LATER-Aufgaben, die älter als %d Tage sind. Sie sind von den aktiven Aufgabenindizes ausgenommen.",
+		"No someday/maybe tasks.": "Keine Irgendwann/Vielleicht-Aufgaben.",
+		"Total Parked":            "Geparkt gesamt",
+		"Tagged Someday":          "Als Irgendwann getaggt",
+		"Stale LATER":             "Veraltete LATER",
+		"%dd old":                 "%d Tage alt",
+
+		// Snoozed tasks
+		"Snoozed":  "Zurückgestellt",
+		"until %s": "bis %s",
+		"Tasks with a `snooze::` date stay here until that date, then return to the active indexes flagged as back from snooze.": "Aufgaben mit einem `snooze::`-Datum bleiben bis zu diesem Datum hier und kehren dann, als zurück aus dem Snooze markiert, in die aktiven Indizes zurück.",
+
+		// Token budget
+		"%d more section omitted to fit the token budget (output.token_budget).":  "%d weiterer Abschnitt weggelassen, um das Token-Budget einzuhalten (output.token_budget).",
+		"%d more sections omitted to fit the token budget (output.token_budget).": "%d weitere Abschnitte weggelassen, um das Token-Budget einzuhalten (output.token_budget).",
+
+		// Date layouts (Go reference time)
+		"Monday, Jan 2":           "Monday, 2. Jan",
+		"Monday, January 2, 2006": "Monday, 2. January 2006",
+		"January 2, 2006":         "2. January 2006",
+		"Mon Jan 2":               "Mon 2. Jan",
+
+		// Weekdays and months, full and abbreviated
+		"Monday": "Montag", "Tuesday": "Dienstag", "Wednesday": "Mittwoch", "Thursday": "Donnerstag",
+		"Friday": "Freitag", "Saturday": "Samstag", "Sunday": "Sonntag",
+		"Mon": "Mo", "Tue": "Di", "Wed": "Mi", "Thu": "Do", "Fri": "Fr", "Sat": "Sa", "Sun": "So",
+		"January": "Januar", "February": "Februar", "March": "März", "April": "April", "May": "Mai",
+		"June": "Juni", "July": "Juli", "August": "August", "September": "September",
+		"October": "Oktober", "November": "November", "December": "Dezember",
+		"Jan": "Jan", "Feb": "Feb", "Mar": "Mär", "Apr": "Apr", "Jun": "Jun", "Jul": "Jul",
+		"Aug": "Aug", "Sep": "Sep", "Oct": "Okt", "Nov": "Nov", "Dec": "Dez",
+	},
+}
+
+// tr translates a report string into the configured locale
+func tr(s string) string {
+	if translated, ok := catalogs[locale][s]; ok {
+		return translated
+	}
+	return s
+}
+
+// plural translates the singular or plural form of a message for n
+func plural(n int, one, other string) string {
+	if n == 1 {
+		return tr(one)
+	}
+	return tr(other)
+}
+
+// Placeholders for the name elements of a date layout. They contain no
+// layout tokens, so time.Format passes them through untouched.
+const (
+	weekdayPlaceholder      = "\x00W\x00"
+	shortWeekdayPlaceholder = "\x00w\x00"
+	monthPlaceholder        = "\x00M\x00"
+	shortMonthPlaceholder   = "\x00m\x00"
+)
+
+// formatDate formats t with a Go layout in the configured locale, translating
+// the layout itself and any weekday or month names it produces
+func formatDate(t time.Time, layout string) string {
+	layout = tr(layout)
+	if locale == LocaleEnglish {
+		return t.Format(layout)
+	}
+
+	// Longer tokens first, so "Monday" isn't read as "Mon" + "day"
+	layout = strings.NewReplacer(
+		"Monday", weekdayPlaceholder,
+		"Mon", shortWeekdayPlaceholder,
+		"January", monthPlaceholder,
+		"Jan", shortMonthPlaceholder,
+	).Replace(layout)
+
+	return strings.NewReplacer(
+		weekdayPlaceholder, tr(t.Weekday().String()),
+		shortWeekdayPlaceholder, tr(t.Weekday().String()[:3]),
+		monthPlaceholder, tr(t.Month().String()),
+		shortMonthPlaceholder, tr(t.Month().String()[:3]),
+	).Replace(t.Format(layout))
+}
@@ -0,0 +1,223 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestFormatDate(t *testing.T) {
+	defer SetLocale("")
+
+	date := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC) // A Monday in March
+	tests := []struct {
+		locale   Locale
+		layout   string
+		expected string
+	}{
+		{LocaleEnglish, "Monday, January 2, 2006", "Monday, March 3, 2025"},
+		{LocaleEnglish, "2006-01-02 (Mon)", "2025-03-03 (Mon)"},
+		{LocaleGerman, "Monday, January 2, 2006", "Montag, 3. März 2025"},
+		{LocaleGerman, "Monday, Jan 2", "Montag, 3. Mär"},
+		{LocaleGerman, "2006-01-02 (Mon)", "2025-03-03 (Mo)"},
+	}
+
+	for _, tt := range tests {
+		if err := SetLocale(tt.locale); err != nil {
+			t.Fatalf("SetLocale(%q) failed: %v", tt.locale, err)
+		}
+		if result := formatDate(date, tt.layout); result != tt.expected {
+			t.Errorf("%s %q: got %q, want %q", tt.locale, tt.layout, result, tt.expected)
+		}
+	}
+
+	if err := SetLocale("xx"); err == nil {
+		t.Error("Expected error for unknown locale")
+	}
+}
+
+func TestWriteTimelineRecent_German(t *testing.T) {
+	if err := SetLocale(LocaleGerman); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	tmpDir := t.TempDir()
+	day := time.Now().Truncate(24 * time.Hour)
+	index := &indexer.TimelineIndex{
+		GeneratedAt: time.Now(),
+		Entries: []indexer.TimelineDay{
+			{
+				Date:         day,
+				TimeLogged:   time.Hour,
+				TasksCreated: []models.Task{{Status: models.StatusTODO}, {Status: models.StatusTODO}},
+				PageTasks:    []indexer.BackfilledTask{{Task: models.Task{SourceFile: "pages/Mobile.md"}}},
+				PagesCreated: []string{"Phoenix"},
+			},
+		},
+	}
+
+	if err := WriteTimelineRecent(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimelineRecent failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "timeline-recent.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, expected := range []string{
+		"# Aktuelle Aktivitäten",
+		"**Letzte 7 Tage**: 1 Tag mit Aktivität",
+		"**Erfasste Zeit**: 1h",
+		"2 TODO-Aufgaben",
+		"1 Seitenaufgabe in [[Mobile]]",
+		"Angelegt [[Phoenix]]",
+		formatDate(day, "Monday, January 2, 2006"),
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestWriteGraphHealth_German(t *testing.T) {
+	if err := SetLocale(LocaleGerman); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	tmpDir := t.TempDir()
+	index := &indexer.GraphHealthIndex{
+		GeneratedAt:       time.Now(),
+		LinkBackThreshold: 3,
+		LinkBackSuggestions: []indexer.LinkBackSuggestion{
+			{From: "Project", To: "Person", Weight: 4},
+		},
+		NamespaceHints: []indexer.NamespaceHint{
+			{Namespace: "Areas", Pages: []string{"Areas/Health"}, Action: indexer.NamespaceFlatten, Target: "Health"},
+		},
+	}
+
+	if err := WriteGraphHealth(index, tmpDir); err != nil {
+		t.Fatalf("WriteGraphHealth failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "graph-health.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, expected := range []string{
+		"# Graph-Zustand",
+		"## Rückverweise erwägen",
+		"- [[Project]] → [[Person]] (4 Verweise): Rückverweis von [[Person]] hinzufügen",
+		"*Alle Verweise der bewerteten Seiten führen zu vorhandenen Seiten.*",
+		"zu [[Health]] abflachen",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestGermanReports_NoEnglish(t *testing.T) {
+	if err := SetLocale(LocaleGerman); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	tmpDir := t.TempDir()
+	now := time.Now()
+	week := now.AddDate(0, 0, -7)
+
+	timeTracking := &indexer.TimeTrackingIndex{
+		TotalTimeLogged: 8 * time.Hour,
+		ByPriority:      map[models.Priority]time.Duration{},
+		ByStatus:        map[models.TaskStatus]time.Duration{models.StatusDONE: 8 * time.Hour},
+		ByFileType:      map[string]time.Duration{"journal": 6 * time.Hour, "page": 2 * time.Hour},
+		ByNamespace:     map[string]time.Duration{"": 2 * time.Hour},
+		TopProjects:     []indexer.ProjectTime{{Project: "Phoenix", TimeLogged: 8 * time.Hour, TaskCount: 3, AvgTimePerTask: 2 * time.Hour}},
+		WeeklySummary:   []indexer.WeeklyTime{{WeekStart: week, TimeLogged: 8 * time.Hour, TaskCount: 1}},
+		Budgets:         []indexer.ProjectBudget{{Project: "Phoenix", WeeklyBudget: 4 * time.Hour, ThisWeek: 5 * time.Hour, RecentAvg: 6 * time.Hour, Status: "over"}},
+		Categories:      []indexer.CategoryTime{{Category: "Meetings", TimeLogged: 8 * time.Hour, TaskCount: 1}},
+		Records: &indexer.Records{
+			CurrentStreak: 0, LongestStreak: 3, LongestStreakEnd: week,
+			BestDay:           indexer.Record{Date: week, TimeLogged: 5 * time.Hour},
+			MostCompletedDay:  indexer.Record{Date: week, Count: 2},
+			MostCompletedWeek: indexer.Record{Date: week, Count: 3},
+		},
+		MicroSessions: indexer.MicroSessions{MinDuration: time.Minute, Entries: 2, Tasks: 1, TimeLogged: time.Minute},
+		StartDelays:   indexer.StartDelays{Tasks: 2, FromGit: 1, Median: 48 * time.Hour, ByPriority: []indexer.StartDelay{{Tasks: 2, Median: 48 * time.Hour, Average: 48 * time.Hour}}},
+		Statistics:    indexer.TimeStatistics{TotalTasks: 4, TasksWithTracking: 2, AdoptionRate: 50, AvgTimePerTask: 4 * time.Hour},
+	}
+	if err := WriteTimeTracking(timeTracking, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	task := models.Task{Status: models.StatusTODO, Description: "Plan Phoenix", SourceFile: "journals/2025_11_03.md", LineNumber: 1}
+	taskIndex := &indexer.TaskIndex{
+		TotalTasks: 2,
+		ByPriority: map[models.Priority][]models.Task{},
+		ByProject:  map[string][]models.Task{"Phoenix": {task}},
+		WaitingOn:  []indexer.DelegationGroup{{Person: "Anna", Tasks: []indexer.DelegatedTask{{Task: task, AgeDays: 3}}}},
+		Orphans:    []indexer.OrphanTask{{Task: task, AgeDays: 3}},
+		CompletionCandidates: []indexer.CompletionCandidate{
+			{Page: "Apollo", DoneTasks: 2, LastActivity: week, QuietWeeks: 6, FilePath: "pages/Apollo.md", Confidence: 1},
+		},
+		Statistics: indexer.TaskStatistics{StatusBreakdown: map[models.TaskStatus]int{}, PriorityBreakdown: map[models.Priority]int{}},
+	}
+	graph := &indexer.ReferenceGraph{
+		Nodes:  map[string]*indexer.GraphNode{"Phoenix": {ReferenceCount: 2}},
+		Pinned: []string{"Phoenix"},
+	}
+	timeline := &indexer.TimelineIndex{
+		Entries: []indexer.TimelineDay{{Date: now, TasksCreated: []models.Task{task, task}, TimeLogged: time.Hour}},
+		Writing: &indexer.WritingStats{WritingDays: 3, AvgWords: 120, LongestStreak: 3, Weeks: []indexer.WritingWeek{{Words: 300}, {Words: 200}}},
+	}
+	missing := &indexer.MissingPagesIndex{MissingPages: []indexer.MissingPage{{Name: "Anna", ReferenceCount: 6, PageType: "person", TypeConfidence: 1}}}
+	trends := &indexer.TrendsIndex{WindowDays: 14, PreviousDays: 2, RecentDays: 5, Emerging: []indexer.Trend{{Topic: "Phoenix", IsPage: true, Previous: 1, Recent: 4}}}
+	effort := &indexer.EffortIndex{QuickWins: []indexer.EffortEstimate{{Task: task, Duration: 15 * time.Minute}}}
+	inbox := &indexer.InboxIndex{Items: []indexer.InboxEntry{{Item: models.InboxItem{Text: "Buy milk"}, AgeDays: 2}}}
+	if err := WriteDashboard(taskIndex, graph, timeline, missing, timeTracking, trends, effort, &indexer.Changes{}, inbox, tmpDir); err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	someday := &indexer.SomedayIndex{
+		GeneratedAt: now,
+		Options:     indexer.SomedayOptions{Tag: "someday", LaterAfter: 90},
+		Tasks: []indexer.SomedayTask{
+			{Task: models.Task{Status: models.StatusLATER, Description: "Plan Phoenix"}, Reason: "stale", AgeDays: 120},
+			{Task: models.Task{Status: models.StatusTODO, Description: "Plan Phoenix"}, Reason: "tagged", AgeDays: -1},
+		},
+	}
+	if err := WriteSomedayBacklog(someday, tmpDir); err != nil {
+		t.Fatalf("WriteSomedayBacklog failed: %v", err)
+	}
+	if err := WriteTimelineRecent(timeline, tmpDir); err != nil {
+		t.Fatalf("WriteTimelineRecent failed: %v", err)
+	}
+
+	// File names, paths, and priorities are the same in every locale
+	paths := regexp.MustCompile("`[^`]*`|\\S+\\.md|/\\S*|\\[#\\w*\\]")
+	english := regexp.MustCompile(`(?i)\b(adoption|days?|tasks?|weeks?|avg|ended|refs?|references?|logged|entries|under|over|oldest|unprocessed|more|streaks?|parked|tagged|stale|old|these|none|not yet|words)\b`)
+	for _, name := range []string{"time-tracking.md", "dashboard.md", "backlog-someday.md", "timeline-recent.md"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		for i, line := range strings.Split(string(content), "\n") {
+			if match := english.FindString(paths.ReplaceAllString(line, "")); match != "" {
+				t.Errorf("%s:%d: English %q in %q", name, i+1, match, line)
+			}
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -25,15 +27,19 @@ func WriteMissingPages(index *indexer.MissingPagesIndex, outputDir string) error
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# Missing Pages to Create\n\n")
-	fmt.Fprintf(f, "Generated: %s\n\n", time.Now().Format(time.RFC3339))
+	fmt.Fprintf(f, "# %s\n\n", tr("Missing Pages to Create"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), time.Now().Format(time.RFC3339))
 
 	if len(index.MissingPages) == 0 {
-		fmt.Fprintf(f, "*No missing pages with %d+ references found.*\n", index.Threshold)
+		fmt.Fprintf(f, "*"+tr("No missing pages with %d+ references found.")+"*\n", index.Threshold)
+		if len(index.AliasSuggestions) > 0 {
+			fmt.Fprintf(f, "\n---\n\n")
+			writeAliasSuggestions(f, index.AliasSuggestions)
+		}
 		return nil
 	}
 
-	fmt.Fprintf(f, "**Pages with %d+ references that don't exist yet**: %d\n\n",
+	fmt.Fprintf(f, "**"+tr("Pages with %d+ references that don't exist yet")+"**: %d\n\n",
 		index.Threshold, len(index.MissingPages))
 
 	fmt.Fprintf(f, "---\n\n")
@@ -41,17 +47,31 @@ func WriteMissingPages(index *indexer.MissingPagesIndex, outputDir string) error
 	// Group by page type
 	byType := make(map[string][]indexer.MissingPage)
 	for _, page := range index.MissingPages {
-		byType[page.PageType] = append(byType[page.PageType], page)
+		pageType := shownPageType(page.PageType, page.TypeConfidence)
+		byType[pageType] = append(byType[pageType], page)
 	}
 
 	// Write pages by type in priority order
 	typeOrder := []string{"person", "project", "date", "concept"}
 	typeLabels := map[string]string{
-		"person":  "People",
-		"project": "Projects",
-		"date":    "Dates",
-		"concept": "Concepts",
+		"person":  tr("People"),
+		"project": tr("Projects"),
+		"date":    tr("Dates"),
+		"concept": tr("Concepts"),
+	}
+
+	// Custom types from classification rules follow, alphabetically, then
+	// pages whose type guess is below output.min_confidence
+	var custom []string
+	for pageType := range byType {
+		if _, builtin := typeLabels[pageType]; !builtin && pageType != unclassified {
+			custom = append(custom, pageType)
+			typeLabels[pageType] = customTypeLabel(pageType)
+		}
 	}
+	sort.Strings(custom)
+	typeOrder = append(append(typeOrder, custom...), unclassified)
+	typeLabels[unclassified] = tr("Unclassified")
 
 	for _, pageType := range typeOrder {
 		pages, exists := byType[pageType]
@@ -68,17 +88,74 @@ func WriteMissingPages(index *indexer.MissingPagesIndex, outputDir string) error
 		fmt.Fprintf(f, "---\n\n")
 	}
 
+	writeAliasSuggestions(f, index.AliasSuggestions)
+
 	return nil
 }
 
+// writeAliasSuggestions writes existing pages that missing pages look like
+// variants of, with the alias:: line to add
+func writeAliasSuggestions(f *os.File, suggestions []indexer.AliasSuggestion) {
+	if len(suggestions) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f, "## %s (%d)\n\n", tr("Alias Suggestions"), len(suggestions))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Missing pages that look like another name for an existing page. Add them as aliases there instead of creating new pages."))
+	for _, s := range suggestions {
+		names := make([]string, len(s.Variants))
+		variants := make([]string, len(s.Variants))
+		for i, v := range s.Variants {
+			names[i] = v.Name
+			variants[i] = fmt.Sprintf("[[%s]] ("+plural(v.ReferenceCount, "%d ref", "%d refs")+", %s)", v.Name, v.ReferenceCount, tr(v.Reason))
+		}
+		fmt.Fprintf(f, "### [[%s]]\n", s.Page)
+		fmt.Fprintf(f, "- **%s**: `%s`\n", tr("File"), s.FilePath)
+		fmt.Fprintf(f, "- **%s**: `alias:: %s`\n", tr("Add"), strings.Join(names, ", "))
+		fmt.Fprintf(f, "- **%s**: %s\n\n", tr("Variants"), strings.Join(variants, ", "))
+	}
+	fmt.Fprintf(f, "---\n\n")
+}
+
+// customTypeLabel turns a custom page type into a section heading,
+// e.g. "paper" into "Papers"
+func customTypeLabel(pageType string) string {
+	label := strings.ToUpper(pageType[:1]) + pageType[1:]
+	if !strings.HasSuffix(label, "s") {
+		label += "s"
+	}
+	return label
+}
+
 // writeMissingPage writes a single missing page entry
 func writeMissingPage(f *os.File, page indexer.MissingPage) {
 	fmt.Fprintf(f, "### [[%s]]\n", page.Name)
-	fmt.Fprintf(f, "- **References**: %d\n", page.ReferenceCount)
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("References"), page.ReferenceCount)
+	if page.AliasOf != "" {
+		fmt.Fprintf(f, "- **%s**: [[%s]] (%s)\n", tr("Possible alias of"), page.AliasOf, tr("see Alias Suggestions"))
+	}
+
+	// With context, show how each referencing page talks about this one
+	if len(page.Snippets) > 0 {
+		fmt.Fprintf(f, "- **%s**:\n", tr("Referenced from"))
+		snippets := make(map[string]string, len(page.Snippets))
+		for _, s := range page.Snippets {
+			snippets[s.Page] = s.Context
+		}
+		for _, sourcePage := range page.ReferencedFrom {
+			if context, ok := snippets[sourcePage]; ok {
+				fmt.Fprintf(f, "  - [[%s]]: %s\n", sourcePage, context)
+			} else {
+				fmt.Fprintf(f, "  - [[%s]]\n", sourcePage)
+			}
+		}
+		fmt.Fprintf(f, "\n")
+		return
+	}
 
 	// Show first few pages that reference this
 	if len(page.ReferencedFrom) > 0 {
-		fmt.Fprintf(f, "- **Referenced from**: ")
+		fmt.Fprintf(f, "- **%s**: ", tr("Referenced from"))
 		for i, sourcePage := range page.ReferencedFrom {
 			if i > 0 {
 				fmt.Fprintf(f, ", ")
@@ -176,6 +176,73 @@ func TestWriteMissingPages_GroupByType(t *testing.T) {
 	}
 }
 
+func TestWriteMissingPages_CustomTypes(t *testing.T) {
+	index := &indexer.MissingPagesIndex{
+		Threshold: 5,
+		MissingPages: []indexer.MissingPage{
+			{Name: "Acme GmbH", ReferenceCount: 9, PageType: "customer"},
+			{Name: "Alice Johnson", ReferenceCount: 8, PageType: "person"},
+			{Name: "Attention Is All You Need", ReferenceCount: 7, PageType: "paper"},
+			{Name: "Microservices", ReferenceCount: 5, PageType: "concept"},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteMissingPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteMissingPages failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "missing-pages.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	contentStr := string(content)
+
+	// Built-in types first, then custom types alphabetically
+	order := []string{"## People (1)", "## Concepts (1)", "## Customers (1)", "## Papers (1)"}
+	last := -1
+	for _, heading := range order {
+		idx := strings.Index(contentStr, heading)
+		if idx == -1 {
+			t.Fatalf("Missing section %q in:\n%s", heading, contentStr)
+		}
+		if idx < last {
+			t.Errorf("Section %q out of order", heading)
+		}
+		last = idx
+	}
+}
+
+func TestWriteMissingPages_MinConfidence(t *testing.T) {
+	if err := SetMinConfidence(0.5); err != nil {
+		t.Fatal(err)
+	}
+	defer SetMinConfidence(0)
+
+	index := &indexer.MissingPagesIndex{
+		Threshold: 5,
+		MissingPages: []indexer.MissingPage{
+			{Name: "Alice Johnson", ReferenceCount: 8, PageType: "person", TypeConfidence: 0.7},
+			{Name: "Bob Ray", ReferenceCount: 6, PageType: "person", TypeConfidence: 0.3},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteMissingPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteMissingPages failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "missing-pages.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	contentStr := string(content)
+
+	people := strings.Index(contentStr, "## People (1)")
+	unclassified := strings.Index(contentStr, "## Unclassified (1)\n\n### [[Bob Ray]]")
+	if people == -1 || unclassified == -1 || unclassified < people {
+		t.Errorf("Expected the weak guess listed as unclassified after People, got:\n%s", contentStr)
+	}
+}
+
 func TestWriteMissingPages_ReferencedFromList(t *testing.T) {
 	index := &indexer.MissingPagesIndex{
 		Threshold: 5,
@@ -267,3 +334,66 @@ func TestWriteMissingPage(t *testing.T) {
 		t.Error("Should show second source page")
 	}
 }
+
+func TestWriteMissingPages_Snippets(t *testing.T) {
+	index := &indexer.MissingPagesIndex{
+		Threshold: 5,
+		MissingPages: []indexer.MissingPage{
+			{
+				Name:           "Kafka",
+				ReferenceCount: 6,
+				PageType:       "concept",
+				ReferencedFrom: []string{"Standup", "Notes"},
+				Snippets:       []indexer.SourceSnippet{{Page: "Standup", Line: 3, Context: "- [[Kafka]] lag spiked again"}},
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteMissingPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteMissingPages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "missing-pages.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	want := "- **Referenced from**:\n  - [[Standup]]: - [[Kafka]] lag spiked again\n  - [[Notes]]\n"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected snippets list %q, got:\n%s", want, content)
+	}
+}
+
+func TestWriteMissingPages_AliasSuggestions(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.MissingPagesIndex{
+		Threshold: 5,
+		AliasSuggestions: []indexer.AliasSuggestion{{
+			Page:     "Project Phoenix",
+			FilePath: "pages/Project Phoenix.md",
+			Variants: []indexer.AliasVariant{
+				{Name: "Phoenix Project", ReferenceCount: 4, Reason: "same words"},
+				{Name: "Projct Phoenix", ReferenceCount: 1, Reason: "typo"},
+			},
+		}},
+	}
+
+	if err := WriteMissingPages(index, tmpDir); err != nil {
+		t.Fatalf("WriteMissingPages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "missing-pages.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	want := "## Alias Suggestions (1)"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected %q even without missing pages, got:\n%s", want, content)
+	}
+	want = "### [[Project Phoenix]]\n- **File**: `pages/Project Phoenix.md`\n- **Add**: `alias:: Phoenix Project, Projct Phoenix`\n" +
+		"- **Variants**: [[Phoenix Project]] (4 refs, same words), [[Projct Phoenix]] (1 ref, typo)\n"
+	if !strings.Contains(string(content), want) {
+		t.Errorf("Expected %q, got:\n%s", want, content)
+	}
+}
@@ -0,0 +1,203 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// Network analysis exports of the reference graph, for Gephi and Cytoscape
+const (
+	GEXFFileName    = "reference-graph.gexf"
+	GraphMLFileName = "reference-graph.graphml"
+)
+
+// networkNode is a page with the attributes exported for network analysis
+type networkNode struct {
+	id   string
+	name string
+	node *indexer.GraphNode
+}
+
+// networkEdge is a weighted link between two exported nodes
+type networkEdge struct {
+	source, target string // Node ids
+	weight         int
+	kind           string // "reference", or "project" for task project edges
+}
+
+// nodeType classifies a page as "page", "journal", or "missing"
+func nodeType(node *indexer.GraphNode) string {
+	switch {
+	case node.FilePath == "":
+		return "missing"
+	case strings.HasPrefix(filepath.ToSlash(node.FilePath), "journals/"):
+		return "journal"
+	}
+	return "page"
+}
+
+// networkElements lists the graph's nodes (sorted by name, with ids n0, n1, ...)
+// and its reference and project edges, for stable, diff-friendly exports
+func networkElements(graph *indexer.ReferenceGraph) ([]networkNode, []networkEdge) {
+	var names []string
+	for name := range graph.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ids := make(map[string]string, len(names))
+	nodes := make([]networkNode, len(names))
+	for i, name := range names {
+		ids[name] = fmt.Sprintf("n%d", i)
+		nodes[i] = networkNode{id: ids[name], name: name, node: graph.Nodes[name]}
+	}
+
+	var edges []networkEdge
+	for _, source := range names {
+		node := graph.Nodes[source]
+		targets := append([]string{}, node.OutboundRefs...)
+		sort.Strings(targets)
+		for _, target := range targets {
+			if _, exists := ids[target]; !exists {
+				continue
+			}
+			weight := node.OutboundWeights[target]
+			if weight < 1 {
+				weight = 1
+			}
+			edges = append(edges, networkEdge{ids[source], ids[target], weight, "reference"})
+		}
+	}
+
+	// Task project edges (see indexer.ApplyProjectRefs)
+	for _, target := range names {
+		node := graph.Nodes[target]
+		sources := make([]string, 0, len(node.ProjectWeights))
+		for source := range node.ProjectWeights {
+			if _, exists := ids[source]; exists {
+				sources = append(sources, source)
+			}
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			edges = append(edges, networkEdge{ids[source], ids[target], node.ProjectWeights[source], "project"})
+		}
+	}
+
+	return nodes, edges
+}
+
+// WriteReferenceGraphGEXF exports the reference graph as GEXF 1.3 to
+// reference-graph.gexf, with page attributes and weighted edges
+func WriteReferenceGraphGEXF(graph *indexer.ReferenceGraph, outputDir string) error {
+	return writeNetworkFile(outputDir, GEXFFileName, func(w io.Writer) {
+		nodes, edges := networkElements(graph)
+
+		fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+		fmt.Fprintf(w, "<gexf xmlns=\"http://gexf.net/1.3\" version=\"1.3\">\n")
+		fmt.Fprintf(w, "  <meta>\n    <creator>logseq-claude-indexer</creator>\n  </meta>\n")
+		fmt.Fprintf(w, "  <graph defaultedgetype=\"directed\" mode=\"static\">\n")
+		fmt.Fprintf(w, "    <attributes class=\"node\">\n")
+		fmt.Fprintf(w, "      <attribute id=\"type\" title=\"type\" type=\"string\"/>\n")
+		fmt.Fprintf(w, "      <attribute id=\"references\" title=\"references\" type=\"integer\"/>\n")
+		fmt.Fprintf(w, "      <attribute id=\"tasks\" title=\"tasks\" type=\"integer\"/>\n")
+		fmt.Fprintf(w, "      <attribute id=\"time_logged_hours\" title=\"time_logged_hours\" type=\"double\"/>\n")
+		fmt.Fprintf(w, "      <attribute id=\"pinned\" title=\"pinned\" type=\"boolean\"/>\n")
+		fmt.Fprintf(w, "    </attributes>\n")
+		fmt.Fprintf(w, "    <attributes class=\"edge\">\n")
+		fmt.Fprintf(w, "      <attribute id=\"kind\" title=\"kind\" type=\"string\"/>\n")
+		fmt.Fprintf(w, "    </attributes>\n")
+
+		fmt.Fprintf(w, "    <nodes>\n")
+		for _, n := range nodes {
+			fmt.Fprintf(w, "      <node id=\"%s\" label=\"%s\">\n", n.id, xmlEscape(n.name))
+			fmt.Fprintf(w, "        <attvalues>\n")
+			fmt.Fprintf(w, "          <attvalue for=\"type\" value=\"%s\"/>\n", nodeType(n.node))
+			fmt.Fprintf(w, "          <attvalue for=\"references\" value=\"%d\"/>\n", n.node.ReferenceCount)
+			fmt.Fprintf(w, "          <attvalue for=\"tasks\" value=\"%d\"/>\n", n.node.TaskCount)
+			fmt.Fprintf(w, "          <attvalue for=\"time_logged_hours\" value=\"%.2f\"/>\n", n.node.TimeLogged.Hours())
+			fmt.Fprintf(w, "          <attvalue for=\"pinned\" value=\"%t\"/>\n", n.node.Pinned)
+			fmt.Fprintf(w, "        </attvalues>\n")
+			fmt.Fprintf(w, "      </node>\n")
+		}
+		fmt.Fprintf(w, "    </nodes>\n")
+
+		fmt.Fprintf(w, "    <edges>\n")
+		for i, e := range edges {
+			fmt.Fprintf(w, "      <edge id=\"e%d\" source=\"%s\" target=\"%s\" weight=\"%d\">\n", i, e.source, e.target, e.weight)
+			fmt.Fprintf(w, "        <attvalues>\n          <attvalue for=\"kind\" value=\"%s\"/>\n        </attvalues>\n", e.kind)
+			fmt.Fprintf(w, "      </edge>\n")
+		}
+		fmt.Fprintf(w, "    </edges>\n")
+		fmt.Fprintf(w, "  </graph>\n")
+		fmt.Fprintf(w, "</gexf>\n")
+	})
+}
+
+// WriteReferenceGraphGraphML exports the reference graph as GraphML to
+// reference-graph.graphml, with page attributes and weighted edges
+func WriteReferenceGraphGraphML(graph *indexer.ReferenceGraph, outputDir string) error {
+	return writeNetworkFile(outputDir, GraphMLFileName, func(w io.Writer) {
+		nodes, edges := networkElements(graph)
+
+		fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+		fmt.Fprintf(w, "<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+		fmt.Fprintf(w, "  <key id=\"label\" for=\"node\" attr.name=\"label\" attr.type=\"string\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"type\" for=\"node\" attr.name=\"type\" attr.type=\"string\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"references\" for=\"node\" attr.name=\"references\" attr.type=\"int\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"tasks\" for=\"node\" attr.name=\"tasks\" attr.type=\"int\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"time_logged_hours\" for=\"node\" attr.name=\"time_logged_hours\" attr.type=\"double\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"pinned\" for=\"node\" attr.name=\"pinned\" attr.type=\"boolean\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"weight\" for=\"edge\" attr.name=\"weight\" attr.type=\"int\"/>\n")
+		fmt.Fprintf(w, "  <key id=\"kind\" for=\"edge\" attr.name=\"kind\" attr.type=\"string\"/>\n")
+		fmt.Fprintf(w, "  <graph id=\"logseq\" edgedefault=\"directed\">\n")
+
+		for _, n := range nodes {
+			fmt.Fprintf(w, "    <node id=\"%s\">\n", n.id)
+			fmt.Fprintf(w, "      <data key=\"label\">%s</data>\n", xmlEscape(n.name))
+			fmt.Fprintf(w, "      <data key=\"type\">%s</data>\n", nodeType(n.node))
+			fmt.Fprintf(w, "      <data key=\"references\">%d</data>\n", n.node.ReferenceCount)
+			fmt.Fprintf(w, "      <data key=\"tasks\">%d</data>\n", n.node.TaskCount)
+			fmt.Fprintf(w, "      <data key=\"time_logged_hours\">%.2f</data>\n", n.node.TimeLogged.Hours())
+			fmt.Fprintf(w, "      <data key=\"pinned\">%t</data>\n", n.node.Pinned)
+			fmt.Fprintf(w, "    </node>\n")
+		}
+		for _, e := range edges {
+			fmt.Fprintf(w, "    <edge source=\"%s\" target=\"%s\">\n", e.source, e.target)
+			fmt.Fprintf(w, "      <data key=\"weight\">%d</data>\n", e.weight)
+			fmt.Fprintf(w, "      <data key=\"kind\">%s</data>\n", e.kind)
+			fmt.Fprintf(w, "    </edge>\n")
+		}
+
+		fmt.Fprintf(w, "  </graph>\n")
+		fmt.Fprintf(w, "</graphml>\n")
+	})
+}
+
+// writeNetworkFile creates name in outputDir and fills it with write
+func writeNetworkFile(outputDir, name string, write func(w io.Writer)) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, name))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	write(f)
+	return nil
+}
+
+// xmlEscape escapes a page name for an XML attribute or text node
+func xmlEscape(s string) string {
+	return strings.NewReplacer(`&`, "&amp;", `<`, "&lt;", `>`, "&gt;", `"`, "&quot;", `'`, "&apos;").Replace(s)
+}
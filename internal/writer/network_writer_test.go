@@ -0,0 +1,121 @@
+package writer
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// networkTestGraph has a page, a journal, a missing page with an awkward name, and a project edge
+func networkTestGraph() *indexer.ReferenceGraph {
+	refs := []models.PageReference{
+		{SourcePage: "2025_11_03", TargetPage: "Alpha"},
+		{SourcePage: "2025_11_03", TargetPage: "Alpha"},
+		{SourcePage: "Alpha", TargetPage: `R&D "Lab"`},
+	}
+	graph := indexer.BuildReferenceGraph(refs, []models.File{
+		{Path: "pages/Alpha.md", Type: models.FileTypePage},
+		{Path: "journals/2025_11_03.md", Type: models.FileTypeJournal},
+	})
+	graph.ApplyProjectRefs([]models.PageReference{{SourcePage: "2025_11_03", TargetPage: "Alpha", Project: true}})
+	graph.ApplyTaskStats([]models.Task{
+		{PageRefs: []string{"Alpha"}, Logbook: []models.LogbookEntry{{Duration: 90 * time.Minute}}},
+	})
+	return graph
+}
+
+func TestWriteReferenceGraphGEXF(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteReferenceGraphGEXF(networkTestGraph(), tmpDir); err != nil {
+		t.Fatalf("WriteReferenceGraphGEXF failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, GEXFFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var doc struct {
+		Nodes []struct {
+			ID     string `xml:"id,attr"`
+			Label  string `xml:"label,attr"`
+			Values []struct {
+				For   string `xml:"for,attr"`
+				Value string `xml:"value,attr"`
+			} `xml:"attvalues>attvalue"`
+		} `xml:"graph>nodes>node"`
+		Edges []struct {
+			Source string `xml:"source,attr"`
+			Target string `xml:"target,attr"`
+			Weight string `xml:"weight,attr"`
+		} `xml:"graph>edges>edge"`
+	}
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("Output is not valid XML: %v", err)
+	}
+
+	attrs := make(map[string]map[string]string)
+	ids := make(map[string]string)
+	for _, n := range doc.Nodes {
+		attrs[n.Label] = make(map[string]string)
+		for _, v := range n.Values {
+			attrs[n.Label][v.For] = v.Value
+		}
+		ids[n.Label] = n.ID
+	}
+	if got := attrs["Alpha"]; got["type"] != "page" || got["tasks"] != "1" || got["time_logged_hours"] != "1.50" {
+		t.Errorf("Alpha attributes = %v", got)
+	}
+	if got := attrs["2025_11_03"]["type"]; got != "journal" {
+		t.Errorf("Journal type = %q", got)
+	}
+	if got := attrs[`R&D "Lab"`]["type"]; got != "missing" {
+		t.Errorf("Missing page type = %q (name should round-trip through escaping)", got)
+	}
+
+	if len(doc.Edges) != 3 {
+		t.Fatalf("Expected 2 reference edges and 1 project edge, got %+v", doc.Edges)
+	}
+	if e := doc.Edges[0]; e.Source != ids["2025_11_03"] || e.Target != ids["Alpha"] || e.Weight != "2" {
+		t.Errorf("First edge = %+v, want journal -> Alpha with weight 2", e)
+	}
+}
+
+func TestWriteReferenceGraphGraphML(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteReferenceGraphGraphML(networkTestGraph(), tmpDir); err != nil {
+		t.Fatalf("WriteReferenceGraphGraphML failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, GraphMLFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	var doc struct {
+		Nodes []struct{} `xml:"graph>node"`
+		Edges []struct{} `xml:"graph>edge"`
+	}
+	if err := xml.Unmarshal(content, &doc); err != nil {
+		t.Fatalf("Output is not valid XML: %v", err)
+	}
+	if len(doc.Nodes) != 3 || len(doc.Edges) != 3 {
+		t.Errorf("Expected 3 nodes and 3 edges, got %d and %d", len(doc.Nodes), len(doc.Edges))
+	}
+
+	output := string(content)
+	for _, want := range []string{
+		`<key id="time_logged_hours" for="node" attr.name="time_logged_hours" attr.type="double"/>`,
+		`<data key="label">R&amp;D &quot;Lab&quot;</data>`,
+		`<data key="kind">project</data>`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+}
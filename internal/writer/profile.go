@@ -0,0 +1,89 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Profile adapts the outputs to an AI coding assistant: the directory they
+// go to by default, and the entry file pointing the assistant at them
+type Profile string
+
+const (
+	ProfileClaude  Profile = "claude"  // Default: .claude/indexes, referenced from CLAUDE.md
+	ProfileCursor  Profile = "cursor"  // .cursor/rules/logseq, plus a Cursor rule describing the indexes
+	ProfileGeneric Profile = "generic" // docs/ai, plain markdown for any assistant
+)
+
+// Profiles returns the supported profile names, the default first
+func Profiles() []string {
+	return []string{string(ProfileClaude), string(ProfileCursor), string(ProfileGeneric)}
+}
+
+// ParseProfile checks a profile name; empty selects the default
+func ParseProfile(name string) (Profile, error) {
+	switch p := Profile(name); p {
+	case "":
+		return ProfileClaude, nil
+	case ProfileClaude, ProfileCursor, ProfileGeneric:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown profile %q (expected %s)", name, strings.Join(Profiles(), ", "))
+}
+
+// OutputDir returns the profile's output directory, relative to the repository
+func (p Profile) OutputDir() string {
+	switch p {
+	case ProfileCursor:
+		return filepath.Join(".cursor", "rules", "logseq")
+	case ProfileGeneric:
+		return filepath.Join("docs", "ai")
+	}
+	return filepath.Join(".claude", "indexes")
+}
+
+// CursorRuleFileName is the Cursor project rule describing the indexes
+const CursorRuleFileName = "logseq-indexes.mdc"
+
+// WriteCursorRule writes logseq-indexes.mdc, an agent-requested Cursor rule:
+// its description tells Cursor when to read the indexes, and its body lists
+// files (paths relative to outputDir) by their repository paths so only the
+// ones a question needs are opened. relDir is outputDir relative to the
+// repository.
+func WriteCursorRule(files []string, relDir, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, CursorRuleFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	relDir = filepath.ToSlash(relDir)
+	fmt.Fprintf(f, "---\n")
+	fmt.Fprintf(f, "description: Indexes of my Logseq notes (tasks, projects, timeline, time tracking, page links). Read them for questions about my tasks, plans, notes, or where my time goes.\n")
+	fmt.Fprintf(f, "globs:\n")
+	fmt.Fprintf(f, "alwaysApply: false\n")
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, "# Logseq Indexes\n\n")
+	fmt.Fprintf(f, "The files in `%s/` are generated from my Logseq graph by logseq-claude-indexer and overwritten on every run, so don't edit them. ", relDir)
+	fmt.Fprintf(f, "Start with `%s/dashboard.md` for an overview; `%s/%s` describes every file in detail. Read only the files a question needs.\n\n", relDir, relDir, IndexReadmeFileName)
+
+	documented := make(map[string]bool)
+	for _, name := range files {
+		artifact, ok := lookupArtifact(name)
+		if !ok || documented[artifact.Name] || artifact.Name == CursorRuleFileName {
+			continue
+		}
+		documented[artifact.Name] = true
+		fmt.Fprintf(f, "- `%s/%s` - %s\n", relDir, artifact.Name, artifact.Description)
+	}
+
+	return nil
+}
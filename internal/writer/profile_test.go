@@ -0,0 +1,67 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseProfile(t *testing.T) {
+	tests := []struct {
+		name      string
+		want      Profile
+		outputDir string
+	}{
+		{"", ProfileClaude, filepath.Join(".claude", "indexes")},
+		{"claude", ProfileClaude, filepath.Join(".claude", "indexes")},
+		{"cursor", ProfileCursor, filepath.Join(".cursor", "rules", "logseq")},
+		{"generic", ProfileGeneric, filepath.Join("docs", "ai")},
+	}
+	for _, tt := range tests {
+		got, err := ParseProfile(tt.name)
+		if err != nil {
+			t.Fatalf("ParseProfile(%q) failed: %v", tt.name, err)
+		}
+		if got != tt.want || got.OutputDir() != tt.outputDir {
+			t.Errorf("ParseProfile(%q) = %q (%s), want %q (%s)", tt.name, got, got.OutputDir(), tt.want, tt.outputDir)
+		}
+	}
+
+	if _, err := ParseProfile("copilot"); err == nil {
+		t.Error("Expected an error for an unknown profile")
+	}
+}
+
+func TestWriteCursorRule(t *testing.T) {
+	tmpDir := t.TempDir()
+	files := []string{"dashboard.md", "timeline-2025.md", "timeline-2024.md", "backlinks/", "not-documented.txt"}
+	if err := WriteCursorRule(files, filepath.Join(".cursor", "rules", "logseq"), tmpDir); err != nil {
+		t.Fatalf("WriteCursorRule failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, CursorRuleFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.HasPrefix(output, "---\ndescription: ") || !strings.Contains(output, "\nalwaysApply: false\n---\n") {
+		t.Errorf("Expected Cursor rule frontmatter, got:\n%s", output)
+	}
+	for _, want := range []string{
+		"- `.cursor/rules/logseq/dashboard.md` - ",
+		"- `.cursor/rules/logseq/timeline-*.md` - ",
+		"- `.cursor/rules/logseq/backlinks/` - ",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+	if n := strings.Count(output, "timeline-*.md"); n != 1 {
+		t.Errorf("Expected the timeline pattern listed once, got %d times", n)
+	}
+	if strings.Contains(output, "not-documented.txt") {
+		t.Errorf("Expected undocumented files to be left out, got:\n%s", output)
+	}
+}
@@ -0,0 +1,202 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// PromptsDir is the output subdirectory holding ready-to-paste prompts
+const PromptsDir = "prompts"
+
+// DailyPlanningFileName is the daily planning prompt, relative to the output directory
+const DailyPlanningFileName = PromptsDir + "/daily-planning.md"
+
+// RetroDir holds one retrospective prompt per recently finished project
+const RetroDir = PromptsDir + "/retro"
+
+// maxPromptTasks caps each task list in a prompt so it stays pasteable
+const maxPromptTasks = 10
+
+// WriteDailyPlanningPrompt writes prompts/daily-planning.md: a prompt for
+// planning the day that embeds the agenda, carried-over tasks, and top
+// priorities. Prompts are always English with plain [STATUS] markers, since
+// they're read by a model rather than rendered for people.
+func WriteDailyPlanningPrompt(plan *indexer.DailyPlan, outputDir string) error {
+	if err := os.MkdirAll(filepath.Join(outputDir, PromptsDir), 0755); err != nil {
+		return fmt.Errorf("creating prompts directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, DailyPlanningFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "# %s\n\n", tr("Daily Planning Prompt"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Paste everything below the line into a new conversation. It's refreshed on every run."))
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, tr("Help me plan my day for %s. Below are my agenda, unfinished work carried over from earlier days, and my top priorities, taken from my Logseq notes.")+"\n\n", formatDate(plan.Date, "Monday, January 2, 2006"))
+	fmt.Fprintf(f, "%s\n\n", tr("Suggest a realistic plan: what to tackle first, what fits around fixed commitments, and what to defer or drop. Point out anything that looks stuck (carried over for many days) and ask me about anything unclear before committing to a schedule."))
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Agenda for today"))
+	if len(plan.Agenda) == 0 {
+		fmt.Fprintf(f, "%s\n\n", tr("Nothing scheduled or due."))
+	} else {
+		for _, r := range plan.Agenda[:min(len(plan.Agenda), maxPromptTasks)] {
+			when := tr("due today")
+			if r.Overdue() {
+				when = fmt.Sprintf(plural(-r.DaysUntil, "overdue by %d day", "overdue by %d days"), -r.DaysUntil)
+			}
+			fmt.Fprintf(f, "- %s (%s, %s %s)\n", promptTask(r.Task), when, tr("from"), r.Page)
+		}
+		writeMore(f, len(plan.Agenda))
+		fmt.Fprintf(f, "\n")
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Carried over"))
+	if len(plan.CarriedOver) == 0 {
+		fmt.Fprintf(f, "%s\n\n", tr("Nothing carried over."))
+	} else {
+		for _, c := range plan.CarriedOver[:min(len(plan.CarriedOver), maxPromptTasks)] {
+			fmt.Fprintf(f, "- %s ("+plural(c.AgeDays, "from %d day ago", "from %d days ago")+")\n", promptTask(c.Task), c.AgeDays)
+		}
+		writeMore(f, len(plan.CarriedOver))
+		fmt.Fprintf(f, "\n")
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Top priorities"))
+	if len(plan.Priorities) == 0 {
+		fmt.Fprintf(f, "%s\n\n", tr("No other high priority tasks."))
+	} else {
+		for _, task := range plan.Priorities[:min(len(plan.Priorities), maxPromptTasks)] {
+			fmt.Fprintf(f, "- %s\n", promptTask(task))
+		}
+		writeMore(f, len(plan.Priorities))
+		fmt.Fprintf(f, "\n")
+	}
+
+	if len(plan.Upcoming) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("Coming up"))
+		for _, r := range plan.Upcoming[:min(len(plan.Upcoming), maxPromptTasks)] {
+			fmt.Fprintf(f, "- %s (%s %s)\n", promptTask(r.Task), tr("due"), formatDate(r.Due, "Mon Jan 2"))
+		}
+		writeMore(f, len(plan.Upcoming))
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
+
+// WriteRetroPrompts writes one prompts/retro/<Page>.md file per recently
+// finished project. The directory is rebuilt on every run, so a project that
+// reopens loses its prompt.
+func WriteRetroPrompts(retros []indexer.Retro, outputDir string) error {
+	dir := filepath.Join(outputDir, RetroDir)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("clearing retro prompts directory: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating retro prompts directory: %w", err)
+	}
+
+	for _, retro := range retros {
+		if err := writeRetroPrompt(retro, dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeRetroPrompt writes a single project's retro prompt
+func writeRetroPrompt(retro indexer.Retro, dir string) error {
+	f, err := os.Create(filepath.Join(dir, PageDetailFileName(retro.Page)))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "# %s: [[%s]]\n\n", tr("Retro Prompt"), retro.Page)
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Every task referencing this project is DONE. Paste everything below the line into a new conversation to run a retrospective."))
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, tr("Run a retrospective with me for my project \"%s\", which I've just finished. Below is its history from my Logseq notes"), retro.Page)
+	if !retro.Started.IsZero() {
+		fmt.Fprintf(f, tr(", from %s to %s"), formatDate(retro.Started, "January 2, 2006"), formatDate(retro.Finished, "January 2, 2006"))
+	}
+	fmt.Fprintf(f, ".\n\n")
+	fmt.Fprintf(f, "%s\n\n", tr("Help me reflect on what went well, what didn't, and what I'd do differently. Compare how the time was spent with what turned out to matter, point out patterns in the timeline, and ask me questions one at a time before summarizing lessons I can apply to my next project."))
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Overview"))
+	fmt.Fprintf(f, "- %s: `%s`\n", tr("Page"), retro.FilePath)
+	fmt.Fprintf(f, "- %s: %d\n", tr("Tasks completed"), len(retro.Tasks))
+	if retro.TotalTime > 0 {
+		fmt.Fprintf(f, "- %s: %s\n", tr("Time logged"), formatDuration(retro.TotalTime))
+	}
+	if !retro.Started.IsZero() {
+		days := int(retro.Finished.Sub(retro.Started).Hours()/24) + 1
+		fmt.Fprintf(f, "- %s: "+plural(days, "%d day", "%d days")+"\n", tr("Duration"), days)
+	}
+	fmt.Fprintf(f, "\n")
+
+	if len(retro.Timeline) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("Timeline"))
+		// Keep the start and the end when the history is long
+		events := retro.Timeline
+		if len(events) > 2*maxPromptTasks {
+			events = append(append([]indexer.RetroEvent(nil), events[:maxPromptTasks]...), events[len(events)-maxPromptTasks:]...)
+		}
+		for i, event := range events {
+			if i == maxPromptTasks && len(retro.Timeline) > 2*maxPromptTasks {
+				fmt.Fprintf(f, "- ..."+tr("%d more events")+"...\n", len(retro.Timeline)-2*maxPromptTasks)
+			}
+			fmt.Fprintf(f, "- %s: %s\n", event.Date.Format("2006-01-02"), strings.TrimSpace(event.Text))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Notable tasks"))
+	for _, task := range retro.Tasks[:min(len(retro.Tasks), maxPromptTasks)] {
+		fmt.Fprintf(f, "- %s", promptTask(task))
+		if d := task.TotalDuration(); d > 0 {
+			fmt.Fprintf(f, " (%s %s)", formatDuration(d), tr("logged"))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+	writeMore(f, len(retro.Tasks))
+	fmt.Fprintf(f, "\n")
+
+	if len(retro.LinkedPages) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("Key linked pages"))
+		for _, link := range retro.LinkedPages[:min(len(retro.LinkedPages), maxPromptTasks)] {
+			fmt.Fprintf(f, "- [[%s]] ("+plural(link.Count, "%d link", "%d links")+")\n", link.Page, link.Count)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
+
+// promptTask formats a task as "[STATUS] Description [#A]"
+func promptTask(task models.Task) string {
+	line := fmt.Sprintf("[%s] %s", task.Status, strings.TrimSpace(task.Description))
+	if task.Priority != models.PriorityNone {
+		line += fmt.Sprintf(" [#%s]", task.Priority)
+	}
+	return line
+}
+
+// writeMore notes how many of total items were left out of a capped list
+func writeMore(f *os.File, total int) {
+	if total > maxPromptTasks {
+		fmt.Fprintf(f, "- ..."+tr("and %d more")+"\n", total-maxPromptTasks)
+	}
+}
@@ -0,0 +1,112 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteDailyPlanningPrompt(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	var priorities []models.Task
+	for i := 0; i < 12; i++ {
+		priorities = append(priorities, models.Task{Status: models.StatusTODO, Priority: models.PriorityHigh, Description: fmt.Sprintf("Priority %d", i)})
+	}
+	plan := &indexer.DailyPlan{
+		Date: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC),
+		Agenda: []indexer.Reminder{
+			{Task: models.Task{Status: models.StatusTODO, Description: "File taxes"}, DaysUntil: -2, Page: "Admin"},
+			{Task: models.Task{Status: models.StatusLATER, Description: "Call bank"}, DaysUntil: 0, Page: "Nov 10th, 2025"},
+		},
+		CarriedOver: []indexer.CarriedTask{
+			{Task: models.Task{Status: models.StatusDOING, Description: "Refactor parser", Priority: models.PriorityMedium}, AgeDays: 1},
+		},
+		Priorities: priorities,
+	}
+
+	if err := WriteDailyPlanningPrompt(plan, tmpDir); err != nil {
+		t.Fatalf("WriteDailyPlanningPrompt failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "prompts", "daily-planning.md"))
+	if err != nil {
+		t.Fatalf("Failed to read prompt: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"Help me plan my day for Monday, November 10, 2025.",
+		"- [TODO] File taxes (overdue by 2 days, from Admin)",
+		"- [LATER] Call bank (due today, from Nov 10th, 2025)",
+		"- [DOING] Refactor parser [#B] (from 1 day ago)",
+		"- [TODO] Priority 9 [#A]\n- ...and 2 more",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Priority 10") {
+		t.Error("Expected priorities to be capped")
+	}
+	if strings.Contains(output, "## Coming up") {
+		t.Error("Expected no Coming up section without upcoming tasks")
+	}
+}
+
+func TestWriteRetroPrompts(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	// A prompt for a project that has since reopened should be removed
+	staleDir := filepath.Join(tmpDir, "prompts", "retro")
+	os.MkdirAll(staleDir, 0755)
+	os.WriteFile(filepath.Join(staleDir, "Reopened.md"), []byte("old"), 0644)
+
+	retros := []indexer.Retro{{
+		Page:      "Projects/Launch",
+		FilePath:  "pages/Projects___Launch.md",
+		Started:   time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC),
+		Finished:  time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+		TotalTime: 2 * time.Hour,
+		Tasks: []models.Task{
+			{Status: models.StatusDONE, Description: "Build site", Logbook: []models.LogbookEntry{{Duration: 2 * time.Hour}}},
+			{Status: models.StatusDONE, Description: "Write copy", Priority: models.PriorityHigh},
+		},
+		Timeline: []indexer.RetroEvent{
+			{Date: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), Text: "Kicked off [[Projects/Launch]]"},
+			{Date: time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC), Text: "Completed: Write copy"},
+		},
+		LinkedPages: []indexer.LinkedPage{{Page: "Design", Count: 3}},
+	}}
+
+	if err := WriteRetroPrompts(retros, tmpDir); err != nil {
+		t.Fatalf("WriteRetroPrompts failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(staleDir, "Reopened.md")); !os.IsNotExist(err) {
+		t.Error("Expected stale retro prompt to be removed")
+	}
+
+	content, err := os.ReadFile(filepath.Join(staleDir, "Projects___Launch.md"))
+	if err != nil {
+		t.Fatalf("Failed to read prompt: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"from November 1, 2025 to November 5, 2025.",
+		"- Time logged: 2h\n- Duration: 5 days",
+		"- 2025-11-01: Kicked off [[Projects/Launch]]",
+		"- [DONE] Build site (2h logged)\n- [DONE] Write copy [#A]\n",
+		"- [[Design]] (3 links)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+}
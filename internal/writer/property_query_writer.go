@@ -0,0 +1,59 @@
+package writer
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// propertyGroupJSON is one group of `query props --json` output
+type propertyGroupJSON struct {
+	Group  string              `json:"group,omitempty"` // Omitted without --group-by
+	Count  int                 `json:"count"`
+	Values []propertyValueJSON `json:"values"`
+}
+
+type propertyValueJSON struct {
+	Value  string              `json:"value"`
+	Count  int                 `json:"count"`
+	Blocks []propertyBlockJSON `json:"blocks,omitempty"` // Only with --list
+}
+
+type propertyBlockJSON struct {
+	File       string            `json:"file"`
+	Line       int               `json:"line"`
+	Page       bool              `json:"page,omitempty"` // Page properties rather than a block's
+	Text       string            `json:"text,omitempty"`
+	Properties map[string]string `json:"properties"`
+}
+
+// WritePropertyQueryJSON writes the results of a property query as JSON,
+// including the matching blocks when list is set
+func WritePropertyQueryJSON(w io.Writer, groups []indexer.PropertyGroup, list bool) error {
+	out := make([]propertyGroupJSON, 0, len(groups))
+	for _, group := range groups {
+		g := propertyGroupJSON{Group: group.Group, Count: group.Count()}
+		for _, value := range group.Values {
+			v := propertyValueJSON{Value: value.Value, Count: len(value.Blocks)}
+			if list {
+				for _, block := range value.Blocks {
+					v.Blocks = append(v.Blocks, propertyBlockJSON{
+						File:       filepath.ToSlash(block.SourceFile),
+						Line:       block.LineNumber,
+						Page:       block.Page,
+						Text:       block.Text,
+						Properties: block.Properties,
+					})
+				}
+			}
+			g.Values = append(g.Values, v)
+		}
+		out = append(out, g)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
@@ -0,0 +1,44 @@
+package writer
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWritePropertyQueryJSON(t *testing.T) {
+	groups := []indexer.PropertyGroup{{
+		Group: "Phoenix",
+		Values: []indexer.PropertyCount{{
+			Value:  "active",
+			Blocks: []models.PropertyBlock{{SourceFile: "pages/Phoenix.md", LineNumber: 4, Text: "Design review", Properties: map[string]string{"status": "active"}}},
+		}},
+	}}
+
+	var counts, listed bytes.Buffer
+	if err := WritePropertyQueryJSON(&counts, groups, false); err != nil {
+		t.Fatalf("WritePropertyQueryJSON failed: %v", err)
+	}
+	if err := WritePropertyQueryJSON(&listed, groups, true); err != nil {
+		t.Fatalf("WritePropertyQueryJSON failed: %v", err)
+	}
+
+	var out []propertyGroupJSON
+	if err := json.Unmarshal(counts.Bytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(out) != 1 || out[0].Group != "Phoenix" || out[0].Count != 1 || out[0].Values[0].Blocks != nil {
+		t.Errorf("Unexpected counts output %+v", out)
+	}
+
+	if err := json.Unmarshal(listed.Bytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	blocks := out[0].Values[0].Blocks
+	if len(blocks) != 1 || blocks[0].File != "pages/Phoenix.md" || blocks[0].Line != 4 || blocks[0].Text != "Design review" {
+		t.Errorf("Unexpected listed blocks %+v", blocks)
+	}
+}
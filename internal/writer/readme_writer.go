@@ -0,0 +1,419 @@
+package writer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/embeddings"
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// IndexReadmeFileName documents the generated files for collaborators
+const IndexReadmeFileName = "README.md"
+
+// Artifact documents one kind of generated file
+type Artifact struct {
+	Name        string      // File name, or a path.Match pattern such as "timeline-*.md"
+	Description string      // What the file is for
+	Sources     string      // What the file is built from
+	Caveats     string      // Limits and heuristics worth knowing before trusting it
+	Sections    []string    // Markdown sections, in order
+	Schema      interface{} // Zero value of the encoded type, for JSON files
+}
+
+// Artifacts is the registry of files the generate command can write.
+// Add an entry alongside any new writer so README.md documents it.
+var Artifacts = []Artifact{
+	{
+		Name:        "dashboard.md",
+		Description: "Overview of the whole graph. Read this first.",
+		Sources:     "Every other index, summarized; pinned pages from logseq/config.edn :favorites and the Contents page.",
+		Caveats:     "Each section is a short excerpt; follow the Detailed Reports links for the full lists. Since Last Run is omitted on the first run.",
+		Sections: []string{"Quick Stats", "Since Last Run", "Pinned Pages", "Current Priorities [#A]", "Waiting on Others", "Inbox", "Recent Activity",
+			"Writing", "Emerging Topics", "Top Projects", "Possibly Complete", "Time Budgets", "Time by Category", "Pages to Create", "Detailed Reports"},
+	},
+	{
+		Name:        "tasks-by-status.md",
+		Description: "Active tasks grouped by workflow status, with file locations and logged time.",
+		Sources:     "Task lines (TODO, DOING, NOW, LATER, DONE, and custom statuses in tasks.statuses) in journals and pages, with their LOGBOOK entries.",
+		Caveats:     "Someday and snoozed tasks are left out (see backlog-someday.md). Orphan tasks are journal tasks with no page, tag, person, or date, which may still belong somewhere.",
+		Sections:    []string{"Statistics", "Waiting on Others", "Orphan Tasks", "One section per status (NOW, DOING, TODO, LATER, DONE)"},
+	},
+	{
+		Name:        "tasks-by-priority.md",
+		Description: "High priority [#A] tasks with full details, plus any priorities added in tasks.priority_index.",
+		Sources:     "Task lines with a [#A] marker, or another priority added in tasks.priority_index.",
+		Caveats:     "Tasks without a priority marker never appear here. Capped priorities point to tasks-by-status.md for the rest.",
+		Sections:    []string{"One section per status (NOW, DOING, TODO, LATER, DONE) for [#A]", "One section per added priority, one line per task, capped"},
+	},
+	{
+		Name:        TasksNDJSONFileName,
+		Description: "Every task, one JSON object per line sorted by a stable ID, so git diffs show exactly which tasks changed.",
+		Sources:     "Every task line, active and someday.",
+		Caveats:     "A task without an id:: property is identified by a hash of its file and description, so moving or rewording it reads as a removal plus an addition.",
+		Schema:      taskLineJSON{},
+	},
+	{
+		Name:        "backlog-someday.md",
+		Description: "Parked someday/maybe tasks and snoozed tasks, excluded from the active task counts.",
+		Sources:     "Open tasks tagged #someday, with a future snooze:: date, or LATER tasks older than --someday-days.",
+		Caveats:     "Stale LATER tasks are only listed when --someday-days is set.",
+		Sections:    []string{"Snoozed", "Tagged Someday", "Stale LATER"},
+	},
+	{
+		Name:        "timeline-recent.md",
+		Description: "Day-by-day activity for the last 7 days.",
+		Sources:     "Journal pages from the last 7 days, page tasks by the day they were clocked or completed, and page edits from git history (file modification times outside git).",
+		Caveats:     "Word counts cover journal prose only. Page edits are only as precise as the commit history.",
+		Sections:    []string{"Writing Statistics", "One section per day: tasks created and completed, time logged, words written, key activity, page edits"},
+	},
+	{
+		Name:        "timeline-full.md",
+		Description: "Index of the complete activity history, linking one file per year.",
+		Sources:     "Every journal page and dated page task.",
+		Caveats:     "Only years with activity are listed.",
+		Sections:    []string{"By Year"},
+	},
+	{
+		Name:        "timeline-*.md",
+		Description: "Complete activity history for one year (e.g. timeline-2025.md).",
+		Sources:     "Journal pages and dated page tasks from one year.",
+		Caveats:     "Condensed: only highlighted activities are kept per day.",
+		Sections:    []string{"One section per day"},
+	},
+	{
+		Name:        "missing-pages.md",
+		Description: "Pages referenced often enough to be worth creating, classified by type.",
+		Sources:     "[[page]] references to pages that don't exist in pages/.",
+		Caveats:     "Page types are heuristic guesses (see classifications.json); missing_pages.rules override them. Only pages referenced 5+ times are suggested, though alias suggestions consider any.",
+		Sections:    []string{"One section per page type (person, project, concept, date, ..., unclassified)", "Alias Suggestions"},
+	},
+	{
+		Name:        ClassificationsFileName,
+		Description: "Heuristic guesses with a confidence from 0 to 1: the type of each missing page and the projects that look complete. Treat low scores as hints, not facts.",
+		Sources:     "The missing page and possibly complete project heuristics.",
+		Caveats:     "Scores are hand-tuned, not calibrated probabilities.",
+		Schema:      classificationsJSON{},
+	},
+	{
+		Name:        "time-tracking.md",
+		Description: "Where logged time (LOGBOOK entries) goes.",
+		Sources:     "CLOCK entries in task LOGBOOK drawers; projects are each task's first page reference.",
+		Caveats:     "Only clocked time counts, so untracked work is invisible. Suspicious entries are included unless --exclude-anomalies is given (see time-tracking-issues.md). A page task's time to first start dates from its page's first git commit, which overstates waits on older pages.",
+		Sections:    []string{"Summary", "Records", "Weekly Budgets", "By Category", "Top Projects", "Weekly Breakdown", "By Location", "By Priority", "Time to First Start", "By Status"},
+	},
+	{
+		Name:        "time-tracking.json",
+		Description: "Structured form of time-tracking.md for scripts.",
+		Sources:     "The same data as time-tracking.md.",
+		Caveats:     "Durations are given both as seconds and as ISO 8601.",
+		Schema:      timeTrackingJSON{},
+	},
+	{
+		Name:        TimeTrackingIssuesFileName,
+		Description: "Suspicious logbook entries that distort the time totals: future timestamps, clocks left running for days, and sessions over 12 hours.",
+		Sources:     "CLOCK entries in task LOGBOOK drawers.",
+		Caveats:     "Flagged entries still count towards the totals unless --exclude-anomalies is given.",
+		Sections:    []string{"Future Timestamps", "Sessions Spanning Several Days", "Long Sessions"},
+	},
+	{
+		Name:        TimeTreeFileName,
+		Description: "Logged time rolled up through nested tasks per project, so a parent task's total includes its subtasks'.",
+		Sources:     "CLOCK entries in task LOGBOOK drawers, and how tasks are nested in their blocks.",
+		Caveats:     "Only tasks nested in the same block count as subtasks; tasks linked by [[page]] elsewhere don't. Projects come from the top-level task's first page reference only. Up to 20 top-level tasks are listed per project.",
+		Sections:    []string{"One section per project, with each task tree"},
+	},
+	{
+		Name:        RemindersFileName,
+		Description: "Open tasks with a deadline or scheduled date coming up soon, including overdue ones.",
+		Sources:     "Open tasks with a DEADLINE: or SCHEDULED: date within --reminder-days, or overdue.",
+		Caveats:     "Snoozed tasks are left out until their snooze:: date, even when overdue.",
+		Schema:      remindersJSON{},
+	},
+	{
+		Name:        WeekPlanFileName,
+		Description: "Next week's overdue, due, and top priority tasks with their estimates, against the average time tracked per week.",
+		Sources:     "Open, undelegated tasks with dates or the top priority, their estimate:: or effort:: properties, and the last 8 weeks of logged time.",
+		Caveats:     "Capacity only counts clocked time. Tasks without an estimate are sized by similar completed tasks, or the average estimate.",
+		Sections:    []string{"Capacity", "Overdue", "Due Next Week", "Top Priority"},
+	},
+	{
+		Name:        DailyPlanningFileName,
+		Description: "Ready-to-paste prompt for planning the day, refreshed on every run.",
+		Sources:     "Open tasks scheduled, due, carried over from earlier journal days, or at the top priority.",
+		Caveats:     "Each list is capped at 10 tasks; someday tasks are left out.",
+		Sections:    []string{"Agenda for today", "Carried over", "Top priorities", "Coming up"},
+	},
+	{
+		Name:        RetroDir + "/",
+		Description: "One ready-to-paste retrospective prompt per project finished in the last 12 weeks.",
+		Sources:     "Project pages whose referencing tasks are all DONE, with their tasks, logbooks, and journal mentions.",
+		Caveats:     "Reopening a task removes the project's prompt on the next run.",
+		Sections:    []string{"Overview", "Timeline", "Notable tasks", "Key linked pages"},
+	},
+	{
+		Name:        "reference-graph.md",
+		Description: "Page connections: hub pages and each page's inbound and outbound references.",
+		Sources:     "[[page]] references in every page and journal, with TF-IDF keywords per page.",
+		Caveats:     "With graph.min_line_chars set, references on short or link-only lines don't count towards hub rankings. Language detection only knows English and German.",
+		Sections:    []string{"Hub Pages (Most Referenced)", "Page Details"},
+	},
+	{
+		Name:        "reference-graph.dot",
+		Description: "Graphviz export of the reference graph; edge thickness reflects reference counts.",
+		Sources:     "The reference graph.",
+	},
+	{
+		Name:        GEXFFileName,
+		Description: "GEXF export of the reference graph for Gephi: nodes carry type (page, journal, missing), references, tasks, time_logged_hours, and pinned; edges carry weight and kind (reference or project).",
+		Sources:     "The reference graph, with task and logbook totals per page.",
+	},
+	{
+		Name:        GraphMLFileName,
+		Description: "GraphML export of the reference graph for Cytoscape and other tools, with the same node and edge attributes as the GEXF file.",
+		Sources:     "The reference graph, with task and logbook totals per page.",
+	},
+	{
+		Name:        "graph-health.md",
+		Description: "Suggestions for a more navigable graph.",
+		Sources:     "The reference graph.",
+		Caveats:     "Suggestions are heuristics; not every one-way link needs a link back.",
+		Sections:    []string{"Consider Linking Back", "Link Health", "Namespace Cleanup"},
+	},
+	{
+		Name:        InboxFileName,
+		Description: "Quick captures waiting to be filed, oldest first, with suggested destinations. Only written when an inbox page or tag is configured.",
+		Sources:     "Top-level blocks of the inbox.page page and blocks tagged with inbox.tag.",
+		Caveats:     "An item stays until it's moved or marked DONE. Destinations are suggested from links and keyword matches.",
+		Sections:    []string{"Unprocessed"},
+	},
+	{
+		Name:        DeletedPagesFileName,
+		Description: "Pages deleted recently, from the copies Logseq keeps in logseq/.recycle and logseq/bak, those still linked from other pages first. Only written when scanner.deleted_days is set.",
+		Sources:     "Copies of deleted and overwritten pages in logseq/.recycle and logseq/bak.",
+		Caveats:     "A copy's modification time stands in for the deletion date. Not written with --export.",
+		Sections:    []string{"Still Linked", "Not Linked"},
+	},
+	{
+		Name:        ResurfaceFileName,
+		Description: "Five old pages to revisit today, from those untouched for 90, 180, or 365+ days.",
+		Sources:     "Each page's last git commit (file modification time outside git) and journal mentions.",
+		Caveats:     "The pick is stable for a day and rotates through the due pages over the following days.",
+		Sections:    []string{"Revisit Today", "Due for Revisit"},
+	},
+	{
+		Name:        "tag-suggestions.md",
+		Description: "Candidate tags for pages without a tags:: property.",
+		Sources:     "tags:: properties, #tags on tasks, and each page's keywords and links.",
+		Caveats:     "New tag ideas come from keywords and are never inserted by --apply-tags.",
+		Sections:    []string{"One section per untagged page"},
+	},
+	{
+		Name:        BacklinksDir + "/",
+		Description: "One file per page with its top keywords, open tasks around it, and every backlink in context.",
+		Sources:     "References to each existing page, nearby open tasks, and logbook sessions on its project tasks.",
+		Caveats:     "The directory is rebuilt on every run, so files for deleted pages disappear.",
+		Sections:    []string{"Open Tasks", "Recent Journals", "Backlinks"},
+	},
+	{
+		Name:        embeddings.FileName,
+		Description: "Optional: each page's top-level blocks with a vector from the configured embedding provider, for `search --semantic`.",
+		Sources:     "Top-level blocks of every page, embedded by the provider configured under embeddings.",
+		Caveats:     "Only written when embeddings are configured; vectors are only comparable within one model.",
+		Schema:      embeddings.Record{},
+	},
+	{
+		Name:        "diagnostics.md",
+		Description: "Problems found while indexing, such as unreadable files or invalid journal dates, and errors that appeared since the last run.",
+		Sources:     "Problems met while scanning and parsing, and error counts from the last 20 runs in run-state.json.",
+		Sections:    []string{"Regressions (files with more errors than in the last run)", "One section per severity and code (e.g. warning `invalid-journal-date`)"},
+	},
+	{
+		Name:        RunStateFileName,
+		Description: "This run's tasks and pages, compared against on the next run for the dashboard's Since Last Run section.",
+		Sources:     "This run's tasks, pages, and diagnostics.",
+		Caveats:     "Internal state for the next run; safe to delete, at the cost of one run without Since Last Run.",
+		Schema:      indexer.RunState{},
+	},
+	{
+		Name:        CursorRuleFileName,
+		Description: "Cursor project rule (with --profile cursor) telling Cursor when to read these indexes and what each file holds.",
+		Sources:     "The registry of generated files.",
+	},
+	{
+		Name:        IndexReadmeFileName,
+		Description: "This file.",
+		Sources:     "The registry of generated files and this run's options.",
+	},
+	{
+		Name:        ManifestFileName,
+		Description: "Machine-readable list of generated files, summary counts, and each file's sections or fields, with any that changed since the previous run, and files earlier runs generated that this one didn't.",
+		Sources:     "This run's outputs, compared with the previous manifest.",
+		Schema:      Manifest{},
+	},
+}
+
+// lookupArtifact finds the registry entry for a generated file name
+func lookupArtifact(name string) (Artifact, bool) {
+	for _, a := range Artifacts {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	for _, a := range Artifacts {
+		if matched, _ := path.Match(a.Name, name); matched {
+			return a, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// ReadmeOption is a setting that shaped the generated indexes
+type ReadmeOption struct {
+	Name  string
+	Value string
+}
+
+// WriteIndexReadme writes README.md describing each file in the manifest,
+// the options used, and when the indexes were generated. Files sharing a
+// registry entry (the per-year timelines) are documented once.
+func WriteIndexReadme(manifest *Manifest, options []ReadmeOption, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, IndexReadmeFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# Logseq Indexes\n\n")
+	fmt.Fprintf(f, "Generated: %s by logseq-claude-indexer %s\n\n", manifest.GeneratedAt.Format(time.RFC3339), manifest.ToolVersion)
+	fmt.Fprintf(f, "These files are generated from the Logseq graph and overwritten on every run; don't edit them. ")
+	fmt.Fprintf(f, "They reflect the graph as of the time above. Run `logseq-claude-indexer generate` (or commit, with the git hook installed) to refresh them.\n\n")
+	fmt.Fprintf(f, "---\n\n")
+
+	// Options
+	if len(options) > 0 {
+		fmt.Fprintf(f, "## Options Used\n\n")
+		for _, opt := range options {
+			fmt.Fprintf(f, "- **%s**: %s\n", opt.Name, opt.Value)
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	// Counts, sorted for stable output
+	if len(manifest.Counts) > 0 {
+		fmt.Fprintf(f, "## Counts\n\n")
+		keys := make([]string, 0, len(manifest.Counts))
+		for key := range manifest.Counts {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fmt.Fprintf(f, "- **%s**: %d\n", key, manifest.Counts[key])
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	// Files, in generation order
+	fmt.Fprintf(f, "## Files\n\n")
+	documented := make(map[string]bool)
+	files := append(append([]string{}, manifest.Files...), IndexReadmeFileName, ManifestFileName)
+	for _, name := range files {
+		artifact, ok := lookupArtifact(name)
+		if !ok {
+			fmt.Fprintf(f, "### `%s`\n\n*Not documented.*\n\n", name)
+			continue
+		}
+		if documented[artifact.Name] {
+			continue
+		}
+		documented[artifact.Name] = true
+
+		fmt.Fprintf(f, "### `%s`\n\n", artifact.Name)
+		fmt.Fprintf(f, "%s\n\n", artifact.Description)
+		writeArtifactLayout(f, artifact)
+	}
+
+	return nil
+}
+
+// writeArtifactLayout lists an artifact's sections, or fields for JSON files
+func writeArtifactLayout(w io.Writer, artifact Artifact) {
+	if len(artifact.Sections) > 0 {
+		fmt.Fprintf(w, "Sections:\n")
+		for _, section := range artifact.Sections {
+			fmt.Fprintf(w, "- %s\n", section)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+	if artifact.Schema != nil {
+		fmt.Fprintf(w, "Fields:\n")
+		for _, field := range jsonFields(reflect.TypeOf(artifact.Schema), "") {
+			fmt.Fprintf(w, "- %s\n", field)
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// jsonFields lists the JSON fields of a struct type as "`path` (type)",
+// descending into nested objects and arrays of objects
+func jsonFields(t reflect.Type, prefix string) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		name = prefix + name
+
+		ft := field.Type
+		switch {
+		case ft == reflect.TypeOf(jsonDuration(0)):
+			fields = append(fields, fmt.Sprintf("`%s` (duration: {seconds, iso8601})", name))
+		case ft == reflect.TypeOf(time.Time{}):
+			fields = append(fields, fmt.Sprintf("`%s` (RFC 3339 timestamp)", name))
+		case ft.Kind() == reflect.Slice && ft.Elem().Kind() == reflect.Struct:
+			fields = append(fields, fmt.Sprintf("`%s` (array)", name))
+			fields = append(fields, jsonFields(ft.Elem(), name+"[].")...)
+		case ft.Kind() == reflect.Slice:
+			fields = append(fields, fmt.Sprintf("`%s` (array: %s)", name, jsonTypeName(ft.Elem())))
+		case ft.Kind() == reflect.Map:
+			fields = append(fields, fmt.Sprintf("`%s` (object: %s → %s)", name, jsonTypeName(ft.Key()), jsonTypeName(ft.Elem())))
+		case ft.Kind() == reflect.Struct:
+			fields = append(fields, jsonFields(ft, name+".")...)
+		default:
+			fields = append(fields, fmt.Sprintf("`%s` (%s)", name, jsonTypeName(ft)))
+		}
+	}
+	return fields
+}
+
+// jsonTypeName names a Go type the way it appears in JSON
+func jsonTypeName(t reflect.Type) string {
+	switch {
+	case t == reflect.TypeOf(jsonDuration(0)):
+		return "duration"
+	case t.Kind() == reflect.String:
+		return "string"
+	case t.Kind() == reflect.Bool:
+		return "boolean"
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return "number"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return "integer"
+	}
+	return "object"
+}
@@ -0,0 +1,88 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteIndexReadme(t *testing.T) {
+	tmpDir := t.TempDir()
+	manifest := &Manifest{
+		ToolVersion: "1.2.3",
+		GeneratedAt: time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC),
+		Files:       []string{"dashboard.md", "timeline-2024.md", "timeline-2025.md", RemindersFileName, "custom.txt"},
+		Counts:      map[string]int{"tasks": 12, "files": 3},
+	}
+	options := []ReadmeOption{{Name: "Reminder window", Value: "7 days"}}
+
+	if err := WriteIndexReadme(manifest, options, tmpDir); err != nil {
+		t.Fatalf("WriteIndexReadme failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, IndexReadmeFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"Generated: 2025-11-06T12:00:00Z by logseq-claude-indexer 1.2.3",
+		"- **Reminder window**: 7 days",
+		"- **files**: 3\n- **tasks**: 12",
+		"### `dashboard.md`",
+		"- Emerging Topics",
+		"### `timeline-*.md`",
+		"### `reminders.json`",
+		"- `reminders[].due` (string)",
+		"- `reminders[].overdue` (boolean)",
+		"- `generated_at` (RFC 3339 timestamp)",
+		"### `custom.txt`\n\n*Not documented.*",
+		"### `README.md`",
+		"### `manifest.json`",
+		"- `counts` (object: string → integer)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in README, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Count(output, "### `timeline-*.md`") != 1 {
+		t.Error("Expected per-year timelines to be documented once")
+	}
+}
+
+func TestJSONFields_Durations(t *testing.T) {
+	fields := strings.Join(jsonFields(reflect.TypeOf(timeTrackingJSON{}), ""), "\n")
+
+	for _, want := range []string{
+		"`total_time_logged` (duration: {seconds, iso8601})",
+		"`projects` (array)",
+		"`projects[].avg_time_per_task` (duration: {seconds, iso8601})",
+		"`by_namespace` (object: string → duration)",
+		"`adoption_rate` (number)",
+	} {
+		if !strings.Contains(fields, want) {
+			t.Errorf("Expected field %q, got:\n%s", want, fields)
+		}
+	}
+}
+
+func TestArtifacts_UniqueNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, a := range Artifacts {
+		if seen[a.Name] {
+			t.Errorf("Duplicate artifact %q", a.Name)
+		}
+		seen[a.Name] = true
+		if a.Description == "" {
+			t.Errorf("Artifact %q has no description", a.Name)
+		}
+		if a.Sources == "" {
+			t.Errorf("Artifact %q has no sources", a.Name)
+		}
+	}
+}
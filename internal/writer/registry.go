@@ -0,0 +1,302 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// Indexes holds everything built in one generation, for writers to render
+type Indexes struct {
+	Tasks          *indexer.TaskIndex
+	Someday        *indexer.SomedayIndex
+	Timeline       *indexer.TimelineIndex
+	MissingPages   *indexer.MissingPagesIndex
+	TimeTracking   *indexer.TimeTrackingIndex
+	TimeTree       *indexer.TimeTreeIndex
+	Reminders      *indexer.RemindersIndex
+	DailyPlan      *indexer.DailyPlan
+	WeekPlan       *indexer.WeekPlan
+	Retros         []indexer.Retro
+	Graph          *indexer.ReferenceGraph
+	GraphHealth    *indexer.GraphHealthIndex
+	TagSuggestions *indexer.TagSuggestionsIndex
+	PageDetails    *indexer.PageDetailsIndex
+	Trends         *indexer.TrendsIndex
+	Effort         *indexer.EffortIndex
+	Diagnostics    *indexer.DiagnosticsIndex
+	Changes        *indexer.Changes // Since the previous run, nil on the first run
+	Resurface      *indexer.ResurfaceIndex
+	Inbox          *indexer.InboxIndex        // nil when no inbox is configured
+	DeletedPages   *indexer.DeletedPagesIndex // nil unless deleted pages are reported
+}
+
+// Options controls where and for which graph a writer writes
+type Options struct {
+	OutputDir string // Absolute output directory
+	GraphName string // Name of the Logseq graph, e.g. the repository directory
+}
+
+// Writer renders one group of output files from the built indexes.
+// Register a Writer to have generate run it after the built-in ones. This
+// is an in-repo extension point: Indexes holds internal/indexer types, so
+// custom writers live in this module, not in outside ones.
+type Writer interface {
+	// Name identifies the writer for --only and --skip
+	Name() string
+
+	// Write writes the files and returns their names relative to
+	// opts.OutputDir, in order; directories end in "/"
+	Write(ctx context.Context, indexes *Indexes, opts Options) ([]string, error)
+}
+
+// registry holds the writers generate runs, in registration order
+var registry []Writer
+
+// Register adds a writer to the registry. It panics if a writer with the
+// same name is already registered, like database/sql drivers.
+func Register(w Writer) {
+	for _, existing := range registry {
+		if existing.Name() == w.Name() {
+			panic(fmt.Sprintf("writer: Register called twice for %q", w.Name()))
+		}
+	}
+	registry = append(registry, w)
+}
+
+// Writers returns the registered writers in the order they run
+func Writers() []Writer {
+	return append([]Writer(nil), registry...)
+}
+
+// Select returns the registered writers named in only (all when only is
+// empty), minus those named in skip. Unknown names are an error so a typo
+// doesn't silently write everything.
+func Select(only, skip []string) ([]Writer, error) {
+	known := make(map[string]bool, len(registry))
+	for _, w := range registry {
+		known[w.Name()] = true
+	}
+	for _, name := range append(append([]string{}, only...), skip...) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown writer %q (available: %s)", name, strings.Join(writerNames(), ", "))
+		}
+	}
+
+	contains := func(names []string, name string) bool {
+		for _, n := range names {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	var selected []Writer
+	for _, w := range registry {
+		if len(only) > 0 && !contains(only, w.Name()) {
+			continue
+		}
+		if contains(skip, w.Name()) {
+			continue
+		}
+		selected = append(selected, w)
+	}
+	return selected, nil
+}
+
+// writerNames lists the registered writer names in order
+func writerNames() []string {
+	names := make([]string, len(registry))
+	for i, w := range registry {
+		names[i] = w.Name()
+	}
+	return names
+}
+
+// funcWriter adapts a write function to the Writer interface
+type funcWriter struct {
+	name  string
+	write func(indexes *Indexes, opts Options) ([]string, error)
+}
+
+func (w funcWriter) Name() string { return w.name }
+
+func (w funcWriter) Write(ctx context.Context, indexes *Indexes, opts Options) ([]string, error) {
+	return w.write(indexes, opts)
+}
+
+// Built-in writers, in the order their files are generated
+func init() {
+	Register(funcWriter{"tasks", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteTaskIndex(x.Tasks, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing task index: %w", err)
+		}
+		if err := WritePriorityIndex(x.Tasks, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing priority index: %w", err)
+		}
+		if err := WriteTasksNDJSON(x.Tasks, x.Someday, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing tasks NDJSON: %w", err)
+		}
+		return []string{"tasks-by-status.md", "tasks-by-priority.md", TasksNDJSONFileName}, nil
+	}})
+
+	Register(funcWriter{"someday", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteSomedayBacklog(x.Someday, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing someday backlog: %w", err)
+		}
+		return []string{"backlog-someday.md"}, nil
+	}})
+
+	Register(funcWriter{"timeline", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteTimelineRecent(x.Timeline, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing recent timeline: %w", err)
+		}
+		if err := WriteTimelineFull(x.Timeline, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing full timeline: %w", err)
+		}
+		return append([]string{"timeline-recent.md", "timeline-full.md"}, TimelineYearFiles(x.Timeline)...), nil
+	}})
+
+	Register(funcWriter{"missing-pages", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteMissingPages(x.MissingPages, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing missing pages: %w", err)
+		}
+		return []string{"missing-pages.md"}, nil
+	}})
+
+	Register(funcWriter{"classifications", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteClassifications(x.MissingPages, x.Tasks, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing classifications: %w", err)
+		}
+		return []string{ClassificationsFileName}, nil
+	}})
+
+	Register(funcWriter{"time-tracking", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteTimeTracking(x.TimeTracking, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing time tracking: %w", err)
+		}
+		if err := WriteTimeTrackingJSON(x.TimeTracking, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing time tracking JSON: %w", err)
+		}
+		if err := WriteTimeTrackingIssues(x.TimeTracking, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing time tracking issues: %w", err)
+		}
+		return []string{"time-tracking.md", "time-tracking.json", TimeTrackingIssuesFileName}, nil
+	}})
+
+	Register(funcWriter{"time-tree", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteTimeTree(x.TimeTree, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing time tree: %w", err)
+		}
+		return []string{TimeTreeFileName}, nil
+	}})
+
+	Register(funcWriter{"reminders", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteRemindersJSON(x.Reminders, opts.GraphName, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing reminders: %w", err)
+		}
+		return []string{RemindersFileName}, nil
+	}})
+
+	Register(funcWriter{"week-plan", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteWeekPlan(x.WeekPlan, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing week plan: %w", err)
+		}
+		return []string{WeekPlanFileName}, nil
+	}})
+
+	Register(funcWriter{"prompts", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteDailyPlanningPrompt(x.DailyPlan, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing daily planning prompt: %w", err)
+		}
+		if err := WriteRetroPrompts(x.Retros, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing retro prompts: %w", err)
+		}
+		return []string{DailyPlanningFileName, RetroDir + "/"}, nil
+	}})
+
+	Register(funcWriter{"graph", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteReferenceGraph(x.Graph, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing reference graph: %w", err)
+		}
+		if err := WriteReferenceGraphDOT(x.Graph, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing reference graph DOT: %w", err)
+		}
+		return []string{"reference-graph.md", "reference-graph.dot"}, nil
+	}})
+
+	Register(funcWriter{"graph-export", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteReferenceGraphGEXF(x.Graph, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing reference graph GEXF: %w", err)
+		}
+		if err := WriteReferenceGraphGraphML(x.Graph, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing reference graph GraphML: %w", err)
+		}
+		return []string{GEXFFileName, GraphMLFileName}, nil
+	}})
+
+	Register(funcWriter{"graph-health", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteGraphHealth(x.GraphHealth, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing graph health: %w", err)
+		}
+		return []string{"graph-health.md"}, nil
+	}})
+
+	Register(funcWriter{"resurface", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteResurface(x.Resurface, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing resurface queue: %w", err)
+		}
+		return []string{ResurfaceFileName}, nil
+	}})
+
+	Register(funcWriter{"inbox", func(x *Indexes, opts Options) ([]string, error) {
+		if x.Inbox == nil {
+			return nil, nil
+		}
+		if err := WriteInbox(x.Inbox, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing inbox: %w", err)
+		}
+		return []string{InboxFileName}, nil
+	}})
+
+	Register(funcWriter{"deleted", func(x *Indexes, opts Options) ([]string, error) {
+		if x.DeletedPages == nil {
+			return nil, nil
+		}
+		if err := WriteDeletedPages(x.DeletedPages, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing recently deleted pages: %w", err)
+		}
+		return []string{DeletedPagesFileName}, nil
+	}})
+
+	Register(funcWriter{"tag-suggestions", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteTagSuggestions(x.TagSuggestions, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing tag suggestions: %w", err)
+		}
+		return []string{"tag-suggestions.md"}, nil
+	}})
+
+	Register(funcWriter{"backlinks", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WritePageDetails(x.PageDetails, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing page details: %w", err)
+		}
+		return []string{BacklinksDir + "/"}, nil
+	}})
+
+	Register(funcWriter{"dashboard", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteDashboard(x.Tasks, x.Graph, x.Timeline, x.MissingPages, x.TimeTracking, x.Trends, x.Effort, x.Changes, x.Inbox, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing dashboard: %w", err)
+		}
+		return []string{"dashboard.md"}, nil
+	}})
+
+	Register(funcWriter{"diagnostics", func(x *Indexes, opts Options) ([]string, error) {
+		if err := WriteDiagnostics(x.Diagnostics, opts.OutputDir); err != nil {
+			return nil, fmt.Errorf("writing diagnostics: %w", err)
+		}
+		return []string{"diagnostics.md"}, nil
+	}})
+}
@@ -0,0 +1,83 @@
+package writer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestSelectWriters(t *testing.T) {
+	names := func(writers []Writer) []string {
+		var out []string
+		for _, w := range writers {
+			out = append(out, w.Name())
+		}
+		return out
+	}
+
+	all, err := Select(nil, nil)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if !reflect.DeepEqual(names(all), writerNames()) {
+		t.Errorf("Select(nil, nil) = %v, want every writer", names(all))
+	}
+
+	// --only keeps registration order, not flag order
+	only, err := Select([]string{"dashboard", "tasks"}, nil)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if got := names(only); !reflect.DeepEqual(got, []string{"tasks", "dashboard"}) {
+		t.Errorf("Select(only) = %v", got)
+	}
+
+	skipped, err := Select(nil, []string{"backlinks"})
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+	if len(skipped) != len(all)-1 || strings.Contains(strings.Join(names(skipped), ","), "backlinks") {
+		t.Errorf("Select(skip) = %v", names(skipped))
+	}
+
+	if _, err := Select([]string{"dashbaord"}, nil); err == nil || !strings.Contains(err.Error(), `unknown writer "dashbaord"`) {
+		t.Errorf("expected unknown writer error, got %v", err)
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("registering a duplicate name should panic")
+		}
+	}()
+	Register(funcWriter{name: "tasks"})
+}
+
+func TestBuiltinWriterReturnsFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	writers, err := Select([]string{"tasks"}, nil)
+	if err != nil {
+		t.Fatalf("Select failed: %v", err)
+	}
+
+	indexes := &Indexes{Tasks: indexer.BuildTaskIndex([]models.Task{{Status: models.StatusTODO, Description: "Write docs"}})}
+	files, err := writers[0].Write(context.Background(), indexes, Options{OutputDir: tmpDir})
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if !reflect.DeepEqual(files, []string{"tasks-by-status.md", "tasks-by-priority.md", TasksNDJSONFileName}) {
+		t.Errorf("files = %v", files)
+	}
+	for _, name := range files {
+		if _, err := os.Stat(filepath.Join(tmpDir, name)); err != nil {
+			t.Errorf("%s not written: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// RemindersFileName is the JSON feed of tasks due soon
+const RemindersFileName = "reminders.json"
+
+// remindersJSON is the reminders feed, meant for notification daemons and widgets
+type remindersJSON struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	WithinDays  int            `json:"within_days"`
+	Reminders   []reminderJSON `json:"reminders"`
+}
+
+type reminderJSON struct {
+	Task      string `json:"task"`
+	Status    string `json:"status"`
+	Due       string `json:"due"` // YYYY-MM-DD
+	DaysUntil int    `json:"days_until"`
+	Overdue   bool   `json:"overdue"`
+	Priority  string `json:"priority,omitempty"`
+	Page      string `json:"page"`
+	File      string `json:"file"`
+	Line      int    `json:"line"`
+	URL       string `json:"url"` // logseq:// link that opens the page
+}
+
+// WriteRemindersJSON writes reminders.json. graphName is the Logseq graph
+// (repository folder) name used in the logseq:// links.
+func WriteRemindersJSON(index *indexer.RemindersIndex, graphName, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	out := remindersJSON{
+		GeneratedAt: index.GeneratedAt.UTC(),
+		WithinDays:  index.WithinDays,
+		Reminders:   []reminderJSON{},
+	}
+	for _, r := range index.Reminders {
+		out.Reminders = append(out.Reminders, reminderJSON{
+			Task:      r.Task.Description,
+			Status:    string(r.Task.Status),
+			Due:       r.Due.Format("2006-01-02"),
+			DaysUntil: r.DaysUntil,
+			Overdue:   r.Overdue(),
+			Priority:  string(r.Task.Priority),
+			Page:      r.Page,
+			File:      r.Task.SourceFile,
+			Line:      r.Task.LineNumber,
+			URL:       logseqURL(graphName, r.Page),
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding reminders: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, RemindersFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing reminders: %w", err)
+	}
+
+	return nil
+}
+
+// logseqURL builds a link that opens a page in the Logseq desktop app
+func logseqURL(graphName, page string) string {
+	escape := func(s string) string {
+		return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+	}
+	return fmt.Sprintf("logseq://graph/%s?page=%s", escape(graphName), escape(page))
+}
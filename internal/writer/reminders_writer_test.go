@@ -0,0 +1,84 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteRemindersJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.RemindersIndex{
+		GeneratedAt: time.Date(2025, 11, 6, 12, 0, 0, 0, time.UTC),
+		WithinDays:  7,
+		Reminders: []indexer.Reminder{
+			{
+				Task: models.Task{
+					Description: "Submit report",
+					Status:      models.StatusTODO,
+					Priority:    models.PriorityHigh,
+					SourceFile:  "pages/Q4 & Planning.md",
+					LineNumber:  3,
+				},
+				Due:       time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+				DaysUntil: -1,
+				Page:      "Q4 & Planning",
+			},
+		},
+	}
+
+	if err := WriteRemindersJSON(index, "my notes", tmpDir); err != nil {
+		t.Fatalf("WriteRemindersJSON failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tmpDir, RemindersFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	var decoded struct {
+		WithinDays int `json:"within_days"`
+		Reminders  []struct {
+			Task     string `json:"task"`
+			Due      string `json:"due"`
+			Overdue  bool   `json:"overdue"`
+			Priority string `json:"priority"`
+			Line     int    `json:"line"`
+			URL      string `json:"url"`
+		} `json:"reminders"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+
+	if decoded.WithinDays != 7 || len(decoded.Reminders) != 1 {
+		t.Fatalf("Unexpected feed: %s", data)
+	}
+	r := decoded.Reminders[0]
+	if r.Task != "Submit report" || r.Due != "2025-11-05" || !r.Overdue || r.Priority != "A" || r.Line != 3 {
+		t.Errorf("Unexpected reminder: %+v", r)
+	}
+	if r.URL != "logseq://graph/my%20notes?page=Q4%20%26%20Planning" {
+		t.Errorf("Unexpected URL %q", r.URL)
+	}
+}
+
+func TestWriteRemindersJSON_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.RemindersIndex{GeneratedAt: time.Now(), WithinDays: 7}
+
+	if err := WriteRemindersJSON(index, "notes", tmpDir); err != nil {
+		t.Fatalf("WriteRemindersJSON failed: %v", err)
+	}
+	data, _ := os.ReadFile(filepath.Join(tmpDir, RemindersFileName))
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if reminders, ok := decoded["reminders"].([]interface{}); !ok || len(reminders) != 0 {
+		t.Errorf("Expected an empty reminders array, got %s", data)
+	}
+}
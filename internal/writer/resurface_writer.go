@@ -0,0 +1,64 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// ResurfaceFileName is the daily revisit queue of old notes
+const ResurfaceFileName = "resurface.md"
+
+// WriteResurface writes the pages to revisit today to resurface.md
+func WriteResurface(index *indexer.ResurfaceIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, ResurfaceFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Resurface"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Old pages worth a fresh look: reread them, link them to current work, or archive them."))
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Revisit Today"))
+	if len(index.Today) == 0 {
+		fmt.Fprintf(f, "*%s*\n\n", tr("No pages are due for a revisit."))
+	}
+	for i, page := range index.Today {
+		fmt.Fprintf(f, "%d. [[%s]] - "+tr("untouched %d days (since %s)")+", "+plural(page.References, "%d reference", "%d references"),
+			i+1, page.Page, page.DaysSince, page.LastTouched.Format("2006-01-02"), page.References)
+		if page.Tasks > 0 {
+			fmt.Fprintf(f, ", "+plural(page.Tasks, "%d task", "%d tasks"), page.Tasks)
+		}
+		if page.Pinned {
+			fmt.Fprintf(f, ", %s%s", emoji("📌 ", ""), tr("pinned"))
+		}
+		fmt.Fprintf(f, "\n")
+		fmt.Fprintf(f, "   - %s: `%s`\n", tr("File"), page.FilePath)
+	}
+	if len(index.Today) > 0 {
+		fmt.Fprintf(f, "\n*%s*\n\n", tr("A new selection each day, favouring pages with more references and linked tasks."))
+	}
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Due for Revisit"))
+	fmt.Fprintf(f, "| %s | %s |\n", tr("Untouched"), tr("Pages"))
+	fmt.Fprintf(f, "|-----------|-------|\n")
+	for _, tier := range index.Tiers {
+		fmt.Fprintf(f, "| %d+ %s | %d |\n", tier.Milestone, tr("days"), tier.Pages)
+	}
+	fmt.Fprintf(f, "\n**%s**: "+plural(index.Due, "%d page", "%d pages")+"\n", tr("Total"), index.Due)
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteResurface(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.ResurfaceIndex{
+		GeneratedAt: time.Now(),
+		Today: []indexer.ResurfacePage{
+			{Page: "Half", FilePath: "pages/Half.md", LastTouched: time.Date(2025, 4, 24, 0, 0, 0, 0, time.UTC),
+				DaysSince: 200, Milestone: 180, References: 1, Tasks: 2, Pinned: true, Importance: 8},
+		},
+		Tiers: []indexer.ResurfaceTier{{Milestone: 90, Pages: 3}, {Milestone: 180, Pages: 1}, {Milestone: 365, Pages: 0}},
+		Due:   4,
+	}
+
+	if err := WriteResurface(index, tmpDir); err != nil {
+		t.Fatalf("WriteResurface failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, ResurfaceFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"1. [[Half]] - untouched 200 days (since 2025-04-24), 1 reference, 2 tasks, 📌 pinned\n   - File: `pages/Half.md`",
+		"| 90+ days | 3 |",
+		"**Total**: 4 pages",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected resurface.md to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteResurface_NothingDue(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteResurface(&indexer.ResurfaceIndex{GeneratedAt: time.Now()}, tmpDir); err != nil {
+		t.Fatalf("WriteResurface failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, ResurfaceFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "*No pages are due for a revisit.*") {
+		t.Errorf("Expected an empty queue note, got:\n%s", content)
+	}
+}
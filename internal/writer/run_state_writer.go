@@ -0,0 +1,51 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// RunStateFileName remembers this run's tasks and pages for the next run's
+// "Since Last Run" dashboard section
+const RunStateFileName = "run-state.json"
+
+// WriteRunState writes run-state.json to the output directory
+func WriteRunState(state *indexer.RunState, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding run state: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, RunStateFileName), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing run state: %w", err)
+	}
+
+	return nil
+}
+
+// ReadRunState reads run-state.json from the output directory.
+// It returns nil without an error when there is no previous run.
+func ReadRunState(outputDir string) (*indexer.RunState, error) {
+	data, err := os.ReadFile(filepath.Join(outputDir, RunStateFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state indexer.RunState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("decoding run state: %w", err)
+	}
+	return &state, nil
+}
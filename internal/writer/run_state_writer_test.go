@@ -0,0 +1,44 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestRunStateRoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	state, err := ReadRunState(tmpDir)
+	if err != nil || state != nil {
+		t.Fatalf("Expected no state before the first run, got %+v, %v", state, err)
+	}
+
+	want := &indexer.RunState{
+		GeneratedAt: time.Date(2025, 11, 10, 12, 0, 0, 0, time.UTC),
+		OpenTasks:   []string{"pages/Alpha.md\x00Write spec"},
+		DoneTasks:   []string{"pages/Alpha.md\x00Kickoff"},
+		Pages:       []string{"Alpha"},
+	}
+	if err := WriteRunState(want, tmpDir); err != nil {
+		t.Fatalf("WriteRunState failed: %v", err)
+	}
+	got, err := ReadRunState(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadRunState failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadRunState = %+v, want %+v", got, want)
+	}
+
+	if err := os.WriteFile(filepath.Join(tmpDir, RunStateFileName), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadRunState(tmpDir); err == nil {
+		t.Error("Expected an error for a corrupt run state")
+	}
+}
@@ -0,0 +1,91 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// WriteSomedayBacklog writes parked someday/maybe tasks to backlog-someday.md
+func WriteSomedayBacklog(index *indexer.SomedayIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "backlog-someday.md")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Someday/Maybe Backlog"))
+	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
+
+	// Explain what counts as someday so Claude doesn't treat these as commitments
+	switch {
+	case index.Options.Tag != "" && index.Options.LaterAfter > 0:
+		fmt.Fprintf(f, tr("Tasks tagged `#%s` or LATER tasks older than %d days. These are excluded from the active task indexes.")+"\n\n",
+			index.Options.Tag, index.Options.LaterAfter)
+	case index.Options.Tag != "":
+		fmt.Fprintf(f, tr("Tasks tagged `#%s`. These are excluded from the active task indexes.")+"\n\n", index.Options.Tag)
+	case index.Options.LaterAfter > 0:
+		fmt.Fprintf(f, tr("LATER tasks older than %d days. These are excluded from the active task indexes.")+"\n\n",
+			index.Options.LaterAfter)
+	}
+	fmt.Fprintf(f, "%s\n\n", tr("Tasks with a `snooze::` date stay here until that date, then return to the active indexes flagged as back from snooze."))
+
+	if len(index.Tasks) == 0 {
+		fmt.Fprintf(f, "*%s*\n", tr("No someday/maybe tasks."))
+		return nil
+	}
+
+	fmt.Fprintf(f, "**%s**: "+plural(len(index.Tasks), "%d task", "%d tasks")+"\n\n", tr("Total Parked"), len(index.Tasks))
+	fmt.Fprintf(f, "---\n\n")
+
+	sections := []struct {
+		reason string
+		label  string
+	}{
+		{"snoozed", "Snoozed"},
+		{"tagged", "Tagged Someday"},
+		{"stale", "Stale LATER"},
+	}
+
+	for _, s := range sections {
+		var tasks []indexer.SomedayTask
+		for _, st := range index.Tasks {
+			if st.Reason == s.reason {
+				tasks = append(tasks, st)
+			}
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(f, "## %s (%d)\n\n", tr(s.label), len(tasks))
+		for _, st := range tasks {
+			description := st.Task.Description
+			if len(description) > 100 {
+				description = description[:97] + "..."
+			}
+			age := ""
+			if st.Reason == "snoozed" {
+				age = fmt.Sprintf(" ("+tr("until %s")+")", st.Task.Snooze.Format("2006-01-02"))
+			} else if st.AgeDays >= 0 {
+				age = fmt.Sprintf(" ("+tr("%dd old")+")", st.AgeDays)
+			}
+			fmt.Fprintf(f, "- %s%s%s `%s:%d`\n",
+				statusMarker(st.Task.Status), description, age, st.Task.SourceFile, st.Task.LineNumber)
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteSomedayBacklog(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.SomedayIndex{
+		GeneratedAt: time.Now(),
+		Options:     indexer.SomedayOptions{Tag: "someday", LaterAfter: 90},
+		Tasks: []indexer.SomedayTask{
+			{
+				Task:    models.Task{Status: models.StatusLATER, Description: "Old idea", SourceFile: "journals/2025_01_01.md", LineNumber: 3},
+				Reason:  "stale",
+				AgeDays: 120,
+			},
+			{
+				Task:    models.Task{Status: models.StatusTODO, Description: "Learn Rust", SourceFile: "pages/Ideas.md", LineNumber: 1},
+				Reason:  "tagged",
+				AgeDays: -1,
+			},
+			{
+				Task:    models.Task{Status: models.StatusTODO, Description: "Renew passport", SourceFile: "pages/Admin.md", LineNumber: 4, Snooze: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)},
+				Reason:  "snoozed",
+				AgeDays: -1,
+			},
+		},
+	}
+
+	if err := WriteSomedayBacklog(index, tmpDir); err != nil {
+		t.Fatalf("WriteSomedayBacklog failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "backlog-someday.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	expected := []string{
+		"# Someday/Maybe Backlog",
+		"Tasks tagged `#someday` or LATER tasks older than 90 days",
+		"**Total Parked**: 3 tasks",
+		"## Snoozed (1)",
+		"Renew passport (until 2025-11-20) `pages/Admin.md:4`",
+		"## Tagged Someday (1)",
+		"## Stale LATER (1)",
+		"Old idea (120d old) `journals/2025_01_01.md:3`",
+		"Learn Rust `pages/Ideas.md:1`",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q", exp)
+		}
+	}
+}
+
+func TestWriteSomedayBacklog_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.SomedayIndex{GeneratedAt: time.Now()}
+
+	if err := WriteSomedayBacklog(index, tmpDir); err != nil {
+		t.Fatalf("WriteSomedayBacklog failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "backlog-someday.md"))
+	if !strings.Contains(string(content), "*No someday/maybe tasks.*") {
+		t.Error("Expected empty message")
+	}
+}
+
+func TestWriteSomedayBacklog_GermanSnooze(t *testing.T) {
+	if err := SetLocale(LocaleGerman); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	tmpDir := t.TempDir()
+	index := &indexer.SomedayIndex{
+		GeneratedAt: time.Now(),
+		Tasks: []indexer.SomedayTask{
+			{
+				Task:    models.Task{Status: models.StatusTODO, Description: "Renew passport", SourceFile: "pages/Admin.md", LineNumber: 4, Snooze: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)},
+				Reason:  "snoozed",
+				AgeDays: -1,
+			},
+		},
+	}
+
+	if err := WriteSomedayBacklog(index, tmpDir); err != nil {
+		t.Fatalf("WriteSomedayBacklog failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(tmpDir, "backlog-someday.md"))
+	output := string(content)
+	for _, exp := range []string{
+		"Aufgaben mit einem `snooze::`-Datum bleiben bis zu diesem Datum hier",
+		"## Zurückgestellt (1)",
+		"Renew passport (bis 2025-11-20) `pages/Admin.md:4`",
+	} {
+		if !strings.Contains(output, exp) {
+			t.Errorf("Expected output to contain %q:\n%s", exp, output)
+		}
+	}
+}
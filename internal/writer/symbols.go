@@ -0,0 +1,110 @@
+package writer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// Symbols overrides the markers markdown outputs use for task statuses and
+// priorities, and can replace emoji with text for renderers that don't
+// display them. JSON outputs are unaffected.
+type Symbols struct {
+	Status   map[models.TaskStatus]string // Replaces "[NOW]", "[TODO]", ...; "" hides the marker
+	Priority map[models.Priority]string   // Replaces "[#A]", "[#B]", ...; "" hides the marker
+	NoEmoji  bool                         // Drop emoji from headings and use text indicators
+}
+
+// symbols is the configured symbol set (see SetSymbols)
+var symbols Symbols
+
+// SetSymbols configures status and priority markers and emoji use. The zero
+// value restores the defaults.
+func SetSymbols(s Symbols) error {
+	for status := range s.Status {
+		if !models.IsStatus(status) {
+			return fmt.Errorf("unknown task status %q", status)
+		}
+	}
+	for priority := range s.Priority {
+		if !models.IsPriority(priority) {
+			return fmt.Errorf("unknown priority %q", priority)
+		}
+	}
+	symbols = s
+	return nil
+}
+
+// statusMarker returns the bold status marker followed by a space, e.g.
+// "**[NOW]** ", or "" if the status's marker is configured empty
+func statusMarker(status models.TaskStatus) string {
+	marker, ok := symbols.Status[status]
+	if !ok {
+		marker = "[" + string(status) + "]"
+	}
+	if marker == "" {
+		return ""
+	}
+	return "**" + marker + "** "
+}
+
+// priorityMarker returns the priority marker preceded by a space, e.g.
+// " [#A]", or "" for tasks without a priority
+func priorityMarker(priority models.Priority) string {
+	if priority == models.PriorityNone {
+		return ""
+	}
+	marker, ok := symbols.Priority[priority]
+	if !ok {
+		marker = "[#" + string(priority) + "]"
+	}
+	if marker == "" {
+		return ""
+	}
+	return " " + marker
+}
+
+// emoji returns e, or plain when emoji are turned off
+func emoji(e, plain string) string {
+	if symbols.NoEmoji {
+		return plain
+	}
+	return e
+}
+
+// icon returns a heading's emoji followed by a space, or "" when emoji are turned off
+func icon(e string) string {
+	return emoji(e+" ", "")
+}
+
+// stripEmoji removes emoji from text built by the indexers, such as timeline
+// key activities, when emoji are turned off
+func stripEmoji(s string) string {
+	if !symbols.NoEmoji {
+		return s
+	}
+	s = strings.Map(func(r rune) rune {
+		if isEmoji(r) {
+			return -1
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// isEmoji reports whether r is a pictograph or an emoji modifier. Plain
+// symbols such as arrows and check marks are kept.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000: // Pictographs, emoticons, transport, ...
+		return true
+	case r >= 0x2600 && r <= 0x27BF && r != '✓' && r != '✗': // Miscellaneous symbols and dingbats
+		return true
+	case r >= 0x2B00 && r <= 0x2BFF, r >= 0x23E9 && r <= 0x23FA: // Arrows like ⬆, clocks like ⏱
+		return true
+	case r == 0xFE0F, r == 0x200D: // Emoji presentation selector and joiner
+		return true
+	}
+	return false
+}
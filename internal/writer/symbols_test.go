@@ -0,0 +1,95 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestSymbols_Markers(t *testing.T) {
+	defer SetSymbols(Symbols{})
+
+	if got := statusMarker(models.StatusNOW); got != "**[NOW]** " {
+		t.Errorf("Expected default status marker, got %q", got)
+	}
+	if got := priorityMarker(models.PriorityHigh) + priorityMarker(models.PriorityNone); got != " [#A]" {
+		t.Errorf("Expected default priority marker, got %q", got)
+	}
+
+	err := SetSymbols(Symbols{
+		Status:   map[models.TaskStatus]string{models.StatusNOW: "[!]", models.StatusDONE: ""},
+		Priority: map[models.Priority]string{models.PriorityHigh: "(!!)"},
+		NoEmoji:  true,
+	})
+	if err != nil {
+		t.Fatalf("SetSymbols failed: %v", err)
+	}
+	if got := statusMarker(models.StatusNOW); got != "**[!]** " {
+		t.Errorf("Expected custom status marker, got %q", got)
+	}
+	if got := statusMarker(models.StatusDONE); got != "" {
+		t.Errorf("Expected hidden status marker, got %q", got)
+	}
+	if got := statusMarker(models.StatusTODO); got != "**[TODO]** " {
+		t.Errorf("Expected unconfigured statuses to keep the default, got %q", got)
+	}
+	if got := priorityMarker(models.PriorityHigh); got != " (!!)" {
+		t.Errorf("Expected custom priority marker, got %q", got)
+	}
+	if got := stripEmoji("✏️ Edited [[A]] → [[B]] ✓"); got != "Edited [[A]] → [[B]] ✓" {
+		t.Errorf("Expected emoji stripped and plain symbols kept, got %q", got)
+	}
+
+	if err := SetSymbols(Symbols{Status: map[models.TaskStatus]string{"WAITING": "w"}}); err == nil {
+		t.Error("Expected error for unknown status")
+	}
+	if err := SetSymbols(Symbols{Priority: map[models.Priority]string{"Z": "z"}}); err == nil {
+		t.Error("Expected error for unknown priority")
+	}
+}
+
+func TestSymbols_Dashboard(t *testing.T) {
+	defer SetSymbols(Symbols{})
+	if err := SetSymbols(Symbols{Status: map[models.TaskStatus]string{models.StatusNOW: "[!]"}, NoEmoji: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+	taskIndex := &indexer.TaskIndex{
+		ByPriority: map[models.Priority][]models.Task{
+			models.PriorityHigh: {{Status: models.StatusNOW, Priority: models.PriorityHigh, Description: "Ship it", SourceFile: "journals/2025_11_06.md", LineNumber: 3}},
+		},
+		Statistics: indexer.TaskStatistics{StatusBreakdown: map[models.TaskStatus]int{}, PriorityBreakdown: map[models.Priority]int{}},
+	}
+	timeline := &indexer.TimelineIndex{Entries: []indexer.TimelineDay{
+		{Date: time.Now(), TimeLogged: time.Hour, TasksCreated: []models.Task{{Status: models.StatusNOW, Priority: models.PriorityHigh, Description: "Ship it"}}},
+	}}
+	err := WriteDashboard(taskIndex, &indexer.ReferenceGraph{Nodes: map[string]*indexer.GraphNode{}}, timeline,
+		&indexer.MissingPagesIndex{}, &indexer.TimeTrackingIndex{}, &indexer.TrendsIndex{}, &indexer.EffortIndex{}, nil, nil, tmpDir)
+	if err != nil {
+		t.Fatalf("WriteDashboard failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "dashboard.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "## Quick Stats") || !strings.Contains(output, "## Current Priorities [#A]") {
+		t.Errorf("Expected headings without emoji, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- **[!]** Ship it `journals/2025_11_06.md:3`") {
+		t.Errorf("Expected custom status marker, got:\n%s", output)
+	}
+	for _, e := range []string{"📊", "⏱", "🔥", "🔗"} {
+		if strings.Contains(output, e) {
+			t.Errorf("Expected no %s with emoji off, got:\n%s", e, output)
+		}
+	}
+}
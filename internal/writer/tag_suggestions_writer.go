@@ -0,0 +1,58 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// WriteTagSuggestions writes candidate tags for untagged pages to tag-suggestions.md
+func WriteTagSuggestions(index *indexer.TagSuggestionsIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	filePath := filepath.Join(outputDir, "tag-suggestions.md")
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# Tag Suggestions\n\n")
+	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "**Untagged Pages with Suggestions**: %d | **Tagged Pages**: %d | **Tags in Use**: %d\n\n",
+		len(index.Pages), index.TaggedPages, len(index.Vocabulary))
+	fmt.Fprintf(f, "*Existing tags (✓) can be inserted automatically with `generate --apply-tags`; new tags are ideas only.*\n\n")
+	fmt.Fprintf(f, "---\n\n")
+
+	if len(index.Pages) == 0 {
+		fmt.Fprintf(f, "*Every page is tagged or has no distinctive keywords.*\n")
+		return nil
+	}
+
+	for _, page := range index.Pages {
+		fmt.Fprintf(f, "## [[%s]]\n", page.Page)
+		fmt.Fprintf(f, "`%s`\n\n", page.FilePath)
+		for _, s := range page.Suggestions {
+			marker := "new"
+			if s.Existing {
+				marker = "✓"
+			}
+			fmt.Fprintf(f, "- %s `%s` (%s)\n", marker, s.Tag, s.Reason)
+		}
+		if tags := page.ExistingTags(); len(tags) > 0 {
+			fmt.Fprintf(f, "\n```\ntags:: %s\n```\n", strings.Join(tags, ", "))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
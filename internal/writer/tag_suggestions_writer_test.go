@@ -0,0 +1,52 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+func TestWriteTagSuggestions(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TagSuggestionsIndex{
+		GeneratedAt: time.Now(),
+		Vocabulary:  []string{"backend", "budget"},
+		TaggedPages: 2,
+		Pages: []*indexer.PageTagSuggestions{
+			{
+				Page:     "Phoenix",
+				FilePath: "pages/Phoenix.md",
+				Suggestions: []indexer.TagSuggestion{
+					{Tag: "backend", Reason: "links to [[Backend]]", Existing: true},
+					{Tag: "kubernetes", Reason: "keyword"},
+				},
+			},
+		},
+	}
+
+	if err := WriteTagSuggestions(index, tmpDir); err != nil {
+		t.Fatalf("WriteTagSuggestions failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tag-suggestions.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"**Untagged Pages with Suggestions**: 1 | **Tagged Pages**: 2 | **Tags in Use**: 2",
+		"## [[Phoenix]]",
+		"- ✓ `backend` (links to [[Backend]])",
+		"- new `kubernetes` (keyword)",
+		"tags:: backend\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -26,28 +28,23 @@ func WriteTaskIndex(index *indexer.TaskIndex, outputDir string) error {
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# Tasks by Status\n\n")
+	fmt.Fprintf(f, "# %s\n\n", tr("Tasks by Status"))
 	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
 
 	// Write statistics section
 	writeStatistics(f, index)
 
-	// Write tasks by status in priority order
-	statuses := []struct {
-		status models.TaskStatus
-		label  string
-	}{
-		{models.StatusNOW, "NOW"},
-		{models.StatusDOING, "DOING"},
-		{models.StatusTODO, "TODO"},
-		{models.StatusLATER, "LATER"},
-		{models.StatusDONE, "DONE"},
-	}
+	// Write delegated tasks
+	writeWaitingOn(f, index.WaitingOn)
+
+	// Write floating journal tasks
+	writeOrphans(f, index.Orphans)
 
-	for _, s := range statuses {
-		tasks := index.ByStatus[s.status]
+	// Write tasks by status in the configured order
+	for _, status := range models.Statuses() {
+		tasks := index.ByStatus[status]
 		if len(tasks) > 0 {
-			fmt.Fprintf(f, "## %s (%d)\n\n", s.label, len(tasks))
+			fmt.Fprintf(f, "## %s (%d)\n\n", status, len(tasks))
 			for _, task := range tasks {
 				writeLeanTask(f, task)
 			}
@@ -58,7 +55,28 @@ func WriteTaskIndex(index *indexer.TaskIndex, outputDir string) error {
 	return nil
 }
 
-// WritePriorityIndex writes high priority tasks to tasks-by-priority.md
+// priorityCaps are the priorities tasks-by-priority.md lists besides [#A],
+// with how many tasks to show of each (see SetPriorityIndex)
+var priorityCaps map[models.Priority]int
+
+// SetPriorityIndex adds priorities to tasks-by-priority.md, mapped to how
+// many of their tasks to list (0 for all). [#A] is always listed in full
+// detail; giving it a cap limits it too. Nil restores the [#A]-only index.
+func SetPriorityIndex(caps map[models.Priority]int) error {
+	for priority, limit := range caps {
+		if !models.IsPriority(priority) {
+			return fmt.Errorf("unknown priority %q", priority)
+		}
+		if limit < 0 {
+			return fmt.Errorf("invalid cap %d for priority %s (expected 0 or more)", limit, priority)
+		}
+	}
+	priorityCaps = caps
+	return nil
+}
+
+// WritePriorityIndex writes high priority tasks to tasks-by-priority.md,
+// followed by a shorter list for each priority added with SetPriorityIndex
 func WritePriorityIndex(index *indexer.TaskIndex, outputDir string) error {
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
@@ -74,49 +92,84 @@ func WritePriorityIndex(index *indexer.TaskIndex, outputDir string) error {
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# High Priority Tasks [#A]\n\n")
+	fmt.Fprintf(f, "# %s\n\n", tr("High Priority Tasks [#A]"))
 	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
 
 	// Get high priority tasks
 	highPriorityTasks := index.ByPriority[models.PriorityHigh]
-	fmt.Fprintf(f, "**Total High Priority**: %d tasks\n\n", len(highPriorityTasks))
+	fmt.Fprintf(f, "**%s**: %d %s\n\n", tr("Total High Priority"), len(highPriorityTasks), tr("tasks"))
 
 	if len(highPriorityTasks) == 0 {
-		fmt.Fprintf(f, "*No high priority tasks found.*\n")
-		return nil
-	}
-
-	fmt.Fprintf(f, "---\n\n")
-
-	// Group by status
-	statuses := []struct {
-		status models.TaskStatus
-		label  string
-	}{
-		{models.StatusNOW, "NOW"},
-		{models.StatusDOING, "DOING"},
-		{models.StatusTODO, "TODO"},
-		{models.StatusLATER, "LATER"},
-		{models.StatusDONE, "DONE"},
+		fmt.Fprintf(f, "*%s*\n\n", tr("No high priority tasks found."))
+	} else {
+		fmt.Fprintf(f, "---\n\n")
 	}
 
-	for _, s := range statuses {
+	// Group by status, up to the cap
+	limit := priorityCaps[models.PriorityHigh]
+	shown := 0
+	for _, status := range models.Statuses() {
 		// Filter high priority tasks by status
 		var statusTasks []models.Task
 		for _, task := range highPriorityTasks {
-			if task.Status == s.status {
+			if task.Status == status {
 				statusTasks = append(statusTasks, task)
 			}
 		}
+		if limit > 0 && len(statusTasks) > limit-shown {
+			statusTasks = statusTasks[:limit-shown]
+		}
 
 		if len(statusTasks) > 0 {
-			fmt.Fprintf(f, "## %s (%d)\n\n", s.label, len(statusTasks))
+			fmt.Fprintf(f, "## %s (%d)\n\n", status, len(statusTasks))
 			for _, task := range statusTasks {
 				writeFullTask(f, task)
 			}
 			fmt.Fprintf(f, "---\n\n")
+			shown += len(statusTasks)
 		}
 	}
+	if hidden := len(highPriorityTasks) - shown; hidden > 0 {
+		fmt.Fprintf(f, "*%d more [#A] tasks in tasks-by-status.md.*\n\n", hidden)
+	}
+
+	// Next-up priorities, one line per task in status order
+	for _, priority := range models.Priorities() {
+		limit, listed := priorityCaps[priority]
+		if !listed || priority == models.PriorityHigh {
+			continue
+		}
+		var tasks []models.Task
+		for _, status := range models.Statuses() {
+			for _, task := range index.ByPriority[priority] {
+				if task.Status == status {
+					tasks = append(tasks, task)
+				}
+			}
+		}
+
+		fmt.Fprintf(f, "## %s (%d)\n\n", priority.Label(), len(tasks))
+		if len(tasks) == 0 {
+			fmt.Fprintf(f, "*%s*\n\n", tr("No tasks"))
+			continue
+		}
+		hidden := 0
+		if limit > 0 && len(tasks) > limit {
+			hidden = len(tasks) - limit
+			tasks = tasks[:limit]
+		}
+		for _, task := range tasks {
+			description := task.Description
+			if len(description) > 100 {
+				description = description[:97] + "..."
+			}
+			fmt.Fprintf(f, "- %s%s `%s:%d`\n", statusMarker(task.Status), description, task.SourceFile, task.LineNumber)
+		}
+		if hidden > 0 {
+			fmt.Fprintf(f, "\n*%d more [#%s] tasks in tasks-by-status.md.*\n", hidden, priority)
+		}
+		fmt.Fprintf(f, "\n")
+	}
 
 	return nil
 }
@@ -125,41 +178,26 @@ func WritePriorityIndex(index *indexer.TaskIndex, outputDir string) error {
 func writeStatistics(f *os.File, index *indexer.TaskIndex) {
 	stats := index.Statistics
 
-	fmt.Fprintf(f, "## Statistics\n\n")
-	fmt.Fprintf(f, "- **Total Tasks**: %d\n", index.TotalTasks)
-	fmt.Fprintf(f, "- **Completion Rate**: %.1f%% (%d DONE)\n",
+	fmt.Fprintf(f, "## %s\n\n", tr("Statistics"))
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("Total Tasks"), index.TotalTasks)
+	fmt.Fprintf(f, "- **%s**: %.1f%% (%d DONE)\n", tr("Completion Rate"),
 		stats.CompletionRate, stats.StatusBreakdown[models.StatusDONE])
-	fmt.Fprintf(f, "- **Time Tracking**: %d tasks (%.1f%% adoption)\n",
-		stats.WithTimeTracking, stats.TrackingAdoption)
-	fmt.Fprintf(f, "- **Total Time Logged**: %s\n", formatDuration(stats.TotalTimeLogged))
+	fmt.Fprintf(f, "- **%s**: %d %s (%.1f%% %s)\n", tr("Time Tracking"),
+		stats.WithTimeTracking, tr("tasks"), stats.TrackingAdoption, tr("adoption"))
+	fmt.Fprintf(f, "- **%s**: %s\n", tr("Total Time Logged"), formatDuration(stats.TotalTimeLogged))
 
 	// Priority breakdown
-	fmt.Fprintf(f, "\n**By Priority**:\n")
-	priorityLabels := []struct {
-		priority models.Priority
-		label    string
-	}{
-		{models.PriorityHigh, "High [#A]"},
-		{models.PriorityMedium, "Medium [#B]"},
-		{models.PriorityLow, "Low [#C]"},
-		{models.PriorityNone, "None"},
-	}
-	for _, p := range priorityLabels {
-		count := stats.PriorityBreakdown[p.priority]
+	fmt.Fprintf(f, "\n**%s**:\n", tr("By Priority"))
+	for _, priority := range models.AllPriorities() {
+		count := stats.PriorityBreakdown[priority]
 		if count > 0 {
-			fmt.Fprintf(f, "- %s: %d\n", p.label, count)
+			fmt.Fprintf(f, "- %s: %d\n", priority.Label(), count)
 		}
 	}
 
 	// Status breakdown
-	fmt.Fprintf(f, "\n**By Status**:\n")
-	for _, s := range []models.TaskStatus{
-		models.StatusNOW,
-		models.StatusDOING,
-		models.StatusTODO,
-		models.StatusLATER,
-		models.StatusDONE,
-	} {
+	fmt.Fprintf(f, "\n**%s**:\n", tr("By Status"))
+	for _, s := range models.Statuses() {
 		count := stats.StatusBreakdown[s]
 		if count > 0 {
 			fmt.Fprintf(f, "- %s: %d\n", s, count)
@@ -169,6 +207,51 @@ func writeStatistics(f *os.File, index *indexer.TaskIndex) {
 	fmt.Fprintf(f, "\n---\n\n")
 }
 
+// writeWaitingOn writes open tasks delegated to other people, grouped by person
+func writeWaitingOn(f *os.File, groups []indexer.DelegationGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Waiting on Others"))
+	for _, group := range groups {
+		fmt.Fprintf(f, "### %s (%d)\n", group.Person, len(group.Tasks))
+		for _, dt := range group.Tasks {
+			description := dt.Task.Description
+			if len(description) > 100 {
+				description = description[:97] + "..."
+			}
+			age := ""
+			if dt.AgeDays >= 0 {
+				age = fmt.Sprintf(" (%dd)", dt.AgeDays)
+			}
+			fmt.Fprintf(f, "- %s%s%s `%s:%d`\n",
+				statusMarker(dt.Task.Status), description, age, dt.Task.SourceFile, dt.Task.LineNumber)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+	fmt.Fprintf(f, "---\n\n")
+}
+
+// writeOrphans writes open journal tasks that reference nothing, oldest first
+func writeOrphans(f *os.File, orphans []indexer.OrphanTask) {
+	if len(orphans) == 0 {
+		return
+	}
+
+	fmt.Fprintf(f, "## %s (%d)\n\n", tr("Orphan Tasks"), len(orphans))
+	fmt.Fprintf(f, "*Open journal tasks with no page, tag, person, or date: link them to a project, schedule them, or drop them.*\n\n")
+	for _, orphan := range orphans {
+		description := orphan.Task.Description
+		if len(description) > 100 {
+			description = description[:97] + "..."
+		}
+		fmt.Fprintf(f, "- %s%s (%dd) `%s:%d`\n",
+			statusMarker(orphan.Task.Status), description, orphan.AgeDays, orphan.Task.SourceFile, orphan.Task.LineNumber)
+	}
+	fmt.Fprintf(f, "\n---\n\n")
+}
+
 // writeLeanTask writes a task with truncated description (token-optimized)
 func writeLeanTask(f *os.File, task models.Task) {
 	description := task.Description
@@ -177,14 +260,14 @@ func writeLeanTask(f *os.File, task models.Task) {
 	}
 
 	// Single line format with priority indicator
-	priorityIndicator := ""
-	if task.Priority != models.PriorityNone {
-		priorityIndicator = fmt.Sprintf(" [#%s]", task.Priority)
-	}
+	priorityIndicator := priorityMarker(task.Priority)
 
 	timeInfo := ""
 	if len(task.Logbook) > 0 {
-		timeInfo = fmt.Sprintf(" ⏱ %s", formatDuration(task.TotalDuration()))
+		timeInfo = fmt.Sprintf(" %s%s", emoji("⏱ ", "time: "), formatDuration(task.TotalDuration()))
+	}
+	if indexer.BackFromSnooze(task, time.Now()) {
+		timeInfo += fmt.Sprintf(" %s*%s*", icon("⏰"), tr("back from snooze"))
 	}
 
 	fmt.Fprintf(f, "- **%s**%s%s `%s:%d`\n",
@@ -194,11 +277,11 @@ func writeLeanTask(f *os.File, task models.Task) {
 // writeFullTask writes a task with full details (for high priority tasks)
 func writeFullTask(f *os.File, task models.Task) {
 	fmt.Fprintf(f, "### %s\n", task.Description)
-	fmt.Fprintf(f, "- **File**: `%s:%d`\n", task.SourceFile, task.LineNumber)
+	fmt.Fprintf(f, "- **%s**: `%s:%d`\n", tr("File"), task.SourceFile, task.LineNumber)
 
 	// Write page references if present
 	if len(task.PageRefs) > 0 {
-		fmt.Fprintf(f, "- **References**: ")
+		fmt.Fprintf(f, "- **%s**: ", tr("References"))
 		for i, ref := range task.PageRefs {
 			if i > 0 {
 				fmt.Fprintf(f, ", ")
@@ -208,39 +291,36 @@ func writeFullTask(f *os.File, task models.Task) {
 		fmt.Fprintf(f, "\n")
 	}
 
+	// Write block properties, leaving out Logseq's internal ones
+	if props := displayProperties(task.Properties); len(props) > 0 {
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Properties"), strings.Join(props, ", "))
+	}
+
 	// Write time tracking info if present
 	if len(task.Logbook) > 0 {
 		totalDuration := task.TotalDuration()
-		fmt.Fprintf(f, "- **Time Logged**: %s (%d entries)\n",
+		fmt.Fprintf(f, "- **%s**: %s (%d entries)\n", tr("Time Logged"),
 			formatDuration(totalDuration), len(task.Logbook))
 
 		// Show most recent entry
 		mostRecent := task.Logbook[len(task.Logbook)-1]
-		fmt.Fprintf(f, "- **Last Activity**: %s\n", mostRecent.End.Format("2006-01-02 15:04"))
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Last Activity"), mostRecent.End.Format("2006-01-02 15:04"))
 	}
 
 	fmt.Fprintf(f, "\n")
 }
 
-// formatDuration converts a duration to human-readable format
-func formatDuration(d time.Duration) string {
-	hours := int(d.Hours())
-	minutes := int(d.Minutes()) % 60
-	seconds := int(d.Seconds()) % 60
-
-	if hours > 0 {
-		if minutes > 0 {
-			return fmt.Sprintf("%dh %dm", hours, minutes)
-		}
-		return fmt.Sprintf("%dh", hours)
-	}
+// hiddenProperties are block properties Logseq manages itself
+var hiddenProperties = map[string]bool{"id": true, "collapsed": true}
 
-	if minutes > 0 {
-		if seconds > 0 {
-			return fmt.Sprintf("%dm %ds", minutes, seconds)
+// displayProperties formats properties as sorted "key: value" pairs
+func displayProperties(properties map[string]string) []string {
+	var pairs []string
+	for key, value := range properties {
+		if !hiddenProperties[key] {
+			pairs = append(pairs, key+": "+value)
 		}
-		return fmt.Sprintf("%dm", minutes)
 	}
-
-	return fmt.Sprintf("%ds", seconds)
+	sort.Strings(pairs)
+	return pairs
 }
@@ -0,0 +1,146 @@
+package writer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+// TasksNDJSONFileName is the one-task-per-line form of the task index
+const TasksNDJSONFileName = "tasks.ndjson"
+
+// taskLineJSON is one line of tasks.ndjson. It has no generation time or
+// line numbers, so a task's line only changes when the task itself does.
+type taskLineJSON struct {
+	ID          string            `json:"id"` // Block id:: property, or a hash of file and description
+	Status      string            `json:"status"`
+	Priority    string            `json:"priority,omitempty"`
+	Description string            `json:"description"`
+	File        string            `json:"file"`
+	Pages       []string          `json:"pages,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	DelegatedTo string            `json:"delegated_to,omitempty"`
+	Scheduled   string            `json:"scheduled,omitempty"` // YYYY-MM-DD
+	Deadline    string            `json:"deadline,omitempty"`  // YYYY-MM-DD
+	Completed   string            `json:"completed,omitempty"` // YYYY-MM-DD
+	Estimate    *jsonDuration     `json:"estimate,omitempty"`
+	TimeLogged  *jsonDuration     `json:"time_logged,omitempty"`
+	Someday     string            `json:"someday,omitempty"` // "snoozed", "tagged", or "stale" when parked in the someday backlog
+	Properties  map[string]string `json:"properties,omitempty"`
+}
+
+// WriteTasksNDJSON writes every task, active and someday, to tasks.ndjson as
+// one JSON object per line sorted by ID, so git diffs of a committed index
+// show exactly which tasks were added, changed, or removed. Tasks sharing an
+// ID get "-2", "-3", ... suffixes in file order. someday may be nil.
+func WriteTasksNDJSON(tasks *indexer.TaskIndex, someday *indexer.SomedayIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	var lines []taskLineJSON
+	var order []int // Line number of each task, to number duplicate IDs in file order
+	for _, status := range models.Statuses() {
+		for _, task := range tasks.ByStatus[status] {
+			lines = append(lines, taskLine(task, ""))
+			order = append(order, task.LineNumber)
+		}
+	}
+	if someday != nil {
+		for _, st := range someday.Tasks {
+			lines = append(lines, taskLine(st.Task, st.Reason))
+			order = append(order, st.Task.LineNumber)
+		}
+	}
+
+	idx := make([]int, len(lines))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		la, lb := lines[idx[a]], lines[idx[b]]
+		if la.ID != lb.ID {
+			return la.ID < lb.ID
+		}
+		return order[idx[a]] < order[idx[b]]
+	})
+
+	f, err := os.Create(filepath.Join(outputDir, TasksNDJSONFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	seen := make(map[string]int)
+	for _, i := range idx {
+		line := lines[i]
+		seen[line.ID]++
+		if n := seen[line.ID]; n > 1 {
+			line.ID += "-" + strconv.Itoa(n)
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			return fmt.Errorf("encoding task %s: %w", line.ID, err)
+		}
+		w.Write(data)
+		w.WriteByte('\n')
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("writing %s: %w", TasksNDJSONFileName, err)
+	}
+	return nil
+}
+
+// taskLine converts a task to its tasks.ndjson form
+func taskLine(task models.Task, someday string) taskLineJSON {
+	line := taskLineJSON{
+		ID:          task.ID(),
+		Status:      string(task.Status),
+		Priority:    string(task.Priority),
+		Description: task.Description,
+		File:        filepath.ToSlash(task.SourceFile),
+		Pages:       task.PageRefs,
+		Tags:        task.Tags,
+		DelegatedTo: task.DelegatedTo,
+		Scheduled:   jsonDate(task.Scheduled),
+		Deadline:    jsonDate(task.Deadline),
+		Completed:   jsonDate(task.CompletedAt),
+		Someday:     someday,
+	}
+	if task.Estimate > 0 {
+		estimate := jsonDuration(task.Estimate)
+		line.Estimate = &estimate
+	}
+	if logged := task.TotalDuration(); logged > 0 {
+		timeLogged := jsonDuration(logged)
+		line.TimeLogged = &timeLogged
+	}
+	for key, value := range task.Properties {
+		if key == "id" {
+			continue // Already the task ID
+		}
+		if line.Properties == nil {
+			line.Properties = make(map[string]string)
+		}
+		line.Properties[key] = value
+	}
+	return line
+}
+
+// jsonDate formats a date as YYYY-MM-DD, or "" when it's zero
+func jsonDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
@@ -0,0 +1,96 @@
+package writer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteTasksNDJSON(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Priority: models.PriorityHigh, Description: "Ship [[Phoenix]]", PageRefs: []string{"Phoenix"},
+			SourceFile: "journals/2025_11_03.md", LineNumber: 7, Deadline: time.Date(2025, 11, 20, 0, 0, 0, 0, time.UTC)},
+		{Status: models.StatusDONE, Description: "Water plants", SourceFile: "journals/2025_11_03.md", LineNumber: 2,
+			Logbook: []models.LogbookEntry{{Duration: 30 * time.Minute}}},
+		{Status: models.StatusTODO, Description: "Water plants", SourceFile: "journals/2025_11_03.md", LineNumber: 9},
+		{Status: models.StatusDOING, Description: "Review", SourceFile: "pages/Inbox.md", LineNumber: 1,
+			Properties: map[string]string{"id": "6551e3c2", "effort": "1h"}},
+	}
+	someday := &indexer.SomedayIndex{Tasks: []indexer.SomedayTask{
+		{Task: models.Task{Status: models.StatusLATER, Description: "Learn Rust", SourceFile: "journals/2024_01_01.md"}, Reason: "stale"},
+	}}
+
+	tmpDir := t.TempDir()
+	if err := WriteTasksNDJSON(indexer.BuildTaskIndex(tasks), someday, tmpDir); err != nil {
+		t.Fatalf("WriteTasksNDJSON failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, TasksNDJSONFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	rawLines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(rawLines) != 5 {
+		t.Fatalf("Expected 5 lines, got %d:\n%s", len(rawLines), content)
+	}
+
+	// The fields checked below; durations are covered by the raw output
+	type taskLine struct {
+		ID          string            `json:"id"`
+		Status      string            `json:"status"`
+		Priority    string            `json:"priority"`
+		Description string            `json:"description"`
+		Deadline    string            `json:"deadline"`
+		Someday     string            `json:"someday"`
+		Properties  map[string]string `json:"properties"`
+	}
+	byDescription := make(map[string][]taskLine)
+	var ids []string
+	for _, raw := range rawLines {
+		var line taskLine
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", raw, err)
+		}
+		byDescription[line.Description] = append(byDescription[line.Description], line)
+		ids = append(ids, line.ID)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i-1] >= ids[i] {
+			t.Errorf("Expected lines sorted by unique ID, got %v", ids)
+		}
+	}
+
+	if review := byDescription["Review"][0]; review.ID != "6551e3c2" || review.Properties["effort"] != "1h" || review.Properties["id"] != "" {
+		t.Errorf("Expected the id:: property as ID and left out of properties, got %+v", review)
+	}
+	if ship := byDescription["Ship [[Phoenix]]"][0]; ship.Deadline != "2025-11-20" || ship.Priority != "A" || !strings.HasPrefix(ship.ID, "t-") {
+		t.Errorf("Unexpected line for Ship: %+v", ship)
+	}
+	if rust := byDescription["Learn Rust"][0]; rust.Someday != "stale" {
+		t.Errorf("Expected someday reason, got %+v", rust)
+	}
+
+	// The same task text in one file: the earlier line keeps the plain ID
+	plants := byDescription["Water plants"]
+	if len(plants) != 2 || plants[0].Status != "DONE" || plants[1].ID != plants[0].ID+"-2" {
+		t.Errorf("Expected duplicate IDs numbered in file order, got %+v", plants)
+	}
+	if !strings.Contains(string(content), `"time_logged":{"seconds":1800`) {
+		t.Errorf("Expected logged time in seconds, got:\n%s", content)
+	}
+}
+
+func TestTaskID_StableAcrossStatusChanges(t *testing.T) {
+	todo := models.Task{Status: models.StatusTODO, Description: "Call the bank", SourceFile: "journals/2025_11_03.md", LineNumber: 3}
+	done := models.Task{Status: models.StatusDONE, Priority: models.PriorityHigh, Description: "Call the bank", SourceFile: "journals/2025_11_03.md", LineNumber: 8}
+
+	if todo.ID() != done.ID() {
+		t.Errorf("Expected the same ID after status, priority, and line changes, got %s and %s", todo.ID(), done.ID())
+	}
+}
@@ -0,0 +1,84 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// TimeTrackingIssuesFileName lists suspicious logbook entries
+const TimeTrackingIssuesFileName = "time-tracking-issues.md"
+
+// anomalyLabels describe each anomaly kind as a section heading
+var anomalyLabels = []struct {
+	kind  indexer.AnomalyKind
+	label string
+	hint  string
+}{
+	{indexer.AnomalyFuture, "Future Timestamps", "Clock entries ending after this run, often a typo in the date"},
+	{indexer.AnomalyMultiDay, "Sessions Spanning Several Days", fmt.Sprintf("Clocks touching %d or more days, usually left running", indexer.MaxSessionDays)},
+	{indexer.AnomalyLongSession, "Long Sessions", fmt.Sprintf("Single sessions over %s", formatDuration(indexer.MaxSessionLength))},
+}
+
+// WriteTimeTrackingIssues writes time-tracking-issues.md listing logbook
+// entries that look wrong, grouped by kind, so they can be fixed at the source
+func WriteTimeTrackingIssues(index *indexer.TimeTrackingIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, TimeTrackingIssuesFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# Time Tracking Issues\n\n")
+	fmt.Fprintf(f, "Generated: %s\n\n", time.Now().UTC().Format(time.RFC3339))
+
+	if len(index.Anomalies) == 0 {
+		fmt.Fprintf(f, "*No suspicious logbook entries found.*\n")
+		return nil
+	}
+
+	var total time.Duration
+	for _, a := range index.Anomalies {
+		total += a.Entry.Duration
+	}
+	fmt.Fprintf(f, "**Suspicious entries**: %d (%s)\n\n", len(index.Anomalies), formatDuration(total))
+	if index.AnomaliesExcluded {
+		fmt.Fprintf(f, "*These entries are excluded from time-tracking.md and the timeline (--exclude-anomalies).*\n\n")
+	} else {
+		fmt.Fprintf(f, "*These entries are counted in time-tracking.md; fix them, or run with --exclude-anomalies to leave them out.*\n\n")
+	}
+	fmt.Fprintf(f, "---\n\n")
+
+	for _, group := range anomalyLabels {
+		var entries []indexer.LogbookAnomaly
+		for _, a := range index.Anomalies {
+			if a.Kind == group.kind {
+				entries = append(entries, a)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(f, "## %s (%d)\n\n", group.label, len(entries))
+		fmt.Fprintf(f, "*%s*\n\n", group.hint)
+		for _, a := range entries {
+			fmt.Fprintf(f, "- `%s:%d` %s: %s → %s (%s)\n",
+				a.Task.SourceFile, a.Task.LineNumber, a.Task.Description,
+				a.Entry.Start.Format("2006-01-02 15:04"), a.Entry.End.Format("2006-01-02 15:04"),
+				formatDuration(a.Entry.Duration))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteTimeTrackingIssues(t *testing.T) {
+	tmpDir := t.TempDir()
+	start := time.Date(2025, 11, 3, 17, 0, 0, 0, time.UTC)
+
+	index := &indexer.TimeTrackingIndex{
+		AnomaliesExcluded: true,
+		Anomalies: []indexer.LogbookAnomaly{
+			{
+				Task:  models.Task{Description: "Forgot to stop", SourceFile: "journals/2025_11_03.md", LineNumber: 4},
+				Entry: models.LogbookEntry{Start: start, End: start.Add(40 * time.Hour), Duration: 40 * time.Hour},
+				Kind:  indexer.AnomalyMultiDay,
+			},
+		},
+	}
+	if err := WriteTimeTrackingIssues(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTrackingIssues failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, TimeTrackingIssuesFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"**Suspicious entries**: 1 (40h)",
+		"excluded from time-tracking.md",
+		"## Sessions Spanning Several Days (1)",
+		"- `journals/2025_11_03.md:4` Forgot to stop: 2025-11-03 17:00 → 2025-11-05 09:00 (40h)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "## Long Sessions") {
+		t.Error("Empty kinds should be omitted")
+	}
+}
+
+func TestWriteTimeTrackingIssues_None(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteTimeTrackingIssues(&indexer.TimeTrackingIndex{}, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTrackingIssues failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, TimeTrackingIssuesFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "No suspicious logbook entries found") {
+		t.Error("Should say there are no issues")
+	}
+}
@@ -0,0 +1,184 @@
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// timeTrackingJSON is the structured form of time-tracking.md
+type timeTrackingJSON struct {
+	GeneratedAt       time.Time               `json:"generated_at"`
+	TotalTimeLogged   jsonDuration            `json:"total_time_logged"`
+	TasksWithTracking int                     `json:"tasks_with_tracking"`
+	TotalTasks        int                     `json:"total_tasks"`
+	AdoptionRate      float64                 `json:"adoption_rate"`
+	Projects          []projectTimeJSON       `json:"projects"`
+	Weeks             []weeklyTimeJSON        `json:"weeks"`
+	Budgets           []projectBudgetJSON     `json:"budgets,omitempty"`
+	Categories        []categoryTimeJSON      `json:"categories,omitempty"`
+	ByFileType        map[string]jsonDuration `json:"by_file_type"`
+	ByNamespace       map[string]jsonDuration `json:"by_namespace"`             // "" for pages outside a namespace
+	MicroSessions     *microSessionsJSON      `json:"micro_sessions,omitempty"` // When time_tracking.min_entry_seconds is set
+	StartDelays       *startDelaysJSON        `json:"start_delays,omitempty"`   // When any task is clocked
+}
+
+type startDelaysJSON struct {
+	Tasks      int              `json:"tasks"`
+	FromGit    int              `json:"from_git"` // Page tasks dated by their page's first commit
+	Median     jsonDuration     `json:"median"`
+	ByPriority []startDelayJSON `json:"by_priority"` // Priority "" is no priority
+	ByProject  []startDelayJSON `json:"by_project"`
+}
+
+type startDelayJSON struct {
+	Priority *string      `json:"priority,omitempty"`
+	Project  string       `json:"project,omitempty"`
+	Tasks    int          `json:"tasks"`
+	Median   jsonDuration `json:"median"`
+	Average  jsonDuration `json:"average"`
+}
+
+type microSessionsJSON struct {
+	MinDuration jsonDuration `json:"min_duration"`
+	Entries     int          `json:"entries"`
+	Tasks       int          `json:"tasks"`
+	TimeLogged  jsonDuration `json:"time_logged"`
+}
+
+type projectTimeJSON struct {
+	Project        string       `json:"project"`
+	TimeLogged     jsonDuration `json:"time_logged"`
+	TaskCount      int          `json:"task_count"`
+	AvgTimePerTask jsonDuration `json:"avg_time_per_task"`
+}
+
+type weeklyTimeJSON struct {
+	WeekStart  string       `json:"week_start"` // Monday, YYYY-MM-DD
+	TimeLogged jsonDuration `json:"time_logged"`
+	TaskCount  int          `json:"task_count"`
+
+	Categories map[string]jsonDuration `json:"categories,omitempty"` // Category -> time, when categories are configured
+}
+
+type categoryTimeJSON struct {
+	Category   string       `json:"category"`
+	TimeLogged jsonDuration `json:"time_logged"`
+	TaskCount  int          `json:"task_count"`
+}
+
+type projectBudgetJSON struct {
+	Project      string       `json:"project"`
+	WeeklyBudget jsonDuration `json:"weekly_budget"`
+	ThisWeek     jsonDuration `json:"this_week"`
+	RecentAvg    jsonDuration `json:"recent_avg"`
+	Status       string       `json:"status"`
+}
+
+// WriteTimeTrackingJSON writes time-tracking.json. Durations are encoded as
+// seconds plus an ISO 8601 string rather than the markdown display format.
+func WriteTimeTrackingJSON(index *indexer.TimeTrackingIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	out := timeTrackingJSON{
+		GeneratedAt:       time.Now().UTC(),
+		TotalTimeLogged:   jsonDuration(index.TotalTimeLogged),
+		TasksWithTracking: index.Statistics.TasksWithTracking,
+		TotalTasks:        index.Statistics.TotalTasks,
+		AdoptionRate:      index.Statistics.AdoptionRate,
+		Projects:          []projectTimeJSON{},
+		Weeks:             []weeklyTimeJSON{},
+		ByFileType:        make(map[string]jsonDuration),
+		ByNamespace:       make(map[string]jsonDuration),
+	}
+	for fileType, d := range index.ByFileType {
+		out.ByFileType[fileType] = jsonDuration(d)
+	}
+	for namespace, d := range index.ByNamespace {
+		out.ByNamespace[namespace] = jsonDuration(d)
+	}
+	for _, p := range index.TopProjects {
+		out.Projects = append(out.Projects, projectTimeJSON{
+			Project:        p.Project,
+			TimeLogged:     jsonDuration(p.TimeLogged),
+			TaskCount:      p.TaskCount,
+			AvgTimePerTask: jsonDuration(p.AvgTimePerTask),
+		})
+	}
+	for _, w := range index.WeeklySummary {
+		week := weeklyTimeJSON{
+			WeekStart:  w.WeekStart.Format("2006-01-02"),
+			TimeLogged: jsonDuration(w.TimeLogged),
+			TaskCount:  w.TaskCount,
+		}
+		for _, c := range index.CategoriesInWeek(week.WeekStart) {
+			if week.Categories == nil {
+				week.Categories = make(map[string]jsonDuration)
+			}
+			week.Categories[c.Category] = jsonDuration(c.TimeLogged)
+		}
+		out.Weeks = append(out.Weeks, week)
+	}
+	if ms := index.MicroSessions; ms.MinDuration > 0 {
+		out.MicroSessions = &microSessionsJSON{
+			MinDuration: jsonDuration(ms.MinDuration),
+			Entries:     ms.Entries,
+			Tasks:       ms.Tasks,
+			TimeLogged:  jsonDuration(ms.TimeLogged),
+		}
+	}
+	if delays := index.StartDelays; delays.Tasks > 0 {
+		out.StartDelays = &startDelaysJSON{
+			Tasks:      delays.Tasks,
+			FromGit:    delays.FromGit,
+			Median:     jsonDuration(delays.Median),
+			ByPriority: []startDelayJSON{},
+			ByProject:  []startDelayJSON{},
+		}
+		for _, d := range delays.ByPriority {
+			priority := d.Group
+			out.StartDelays.ByPriority = append(out.StartDelays.ByPriority, startDelayJSON{
+				Priority: &priority, Tasks: d.Tasks, Median: jsonDuration(d.Median), Average: jsonDuration(d.Average),
+			})
+		}
+		for _, d := range delays.ByProject {
+			out.StartDelays.ByProject = append(out.StartDelays.ByProject, startDelayJSON{
+				Project: d.Group, Tasks: d.Tasks, Median: jsonDuration(d.Median), Average: jsonDuration(d.Average),
+			})
+		}
+	}
+	for _, c := range index.Categories {
+		out.Categories = append(out.Categories, categoryTimeJSON{
+			Category:   c.Category,
+			TimeLogged: jsonDuration(c.TimeLogged),
+			TaskCount:  c.TaskCount,
+		})
+	}
+	for _, b := range index.Budgets {
+		out.Budgets = append(out.Budgets, projectBudgetJSON{
+			Project:      b.Project,
+			WeeklyBudget: jsonDuration(b.WeeklyBudget),
+			ThisWeek:     jsonDuration(b.ThisWeek),
+			RecentAvg:    jsonDuration(b.RecentAvg),
+			Status:       b.Status,
+		})
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding time tracking: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputDir, "time-tracking.json"), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing time tracking JSON: %w", err)
+	}
+
+	return nil
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -20,29 +22,80 @@ func WriteTimeTracking(index *indexer.TimeTrackingIndex, outputDir string) error
 	defer f.Close()
 
 	// Header
-	fmt.Fprintf(f, "# Time Tracking Analytics\n\n")
+	fmt.Fprintf(f, "# %s\n\n", tr("Time Tracking Analytics"))
 	fmt.Fprintf(f, "Generated: %s\n\n", time.Now().UTC().Format(time.RFC3339))
 
 	// Overall Statistics
-	fmt.Fprintf(f, "## Summary\n\n")
-	fmt.Fprintf(f, "- **Total Time Logged**: %s\n", formatDuration(index.TotalTimeLogged))
-	fmt.Fprintf(f, "- **Tasks Tracked**: %d / %d (%.1f%% adoption)\n",
+	fmt.Fprintf(f, "## %s\n\n", tr("Summary"))
+	fmt.Fprintf(f, "- **%s**: %s\n", tr("Total Time Logged"), formatDuration(index.TotalTimeLogged))
+	fmt.Fprintf(f, "- **%s**: "+tr("%d / %d (%.1f%% adoption)")+"\n", tr("Tasks Tracked"),
 		index.Statistics.TasksWithTracking,
 		index.Statistics.TotalTasks,
 		index.Statistics.AdoptionRate)
 	if index.Statistics.AvgTimePerTask > 0 {
-		fmt.Fprintf(f, "- **Avg Time/Task**: %s\n", formatDuration(index.Statistics.AvgTimePerTask))
+		fmt.Fprintf(f, "- **%s**: %s\n", tr("Avg Time/Task"), formatDuration(index.Statistics.AvgTimePerTask))
 	}
 	if index.Statistics.MostProductiveWeek.TimeLogged > 0 {
-		fmt.Fprintf(f, "- **Most Productive Week**: %s (%s)\n",
+		fmt.Fprintf(f, "- **%s**: %s (%s)\n", tr("Most Productive Week"),
 			index.Statistics.MostProductiveWeek.WeekStart.Format("2006-01-02"),
 			formatDuration(index.Statistics.MostProductiveWeek.TimeLogged))
 	}
+	if ms := index.MicroSessions; ms.MinDuration > 0 {
+		entries := fmt.Sprintf(plural(ms.Entries, "%d entry under %s", "%d entries under %s"), ms.Entries, formatShortDuration(ms.MinDuration))
+		tasks := fmt.Sprintf(plural(ms.Tasks, "on %d task", "on %d tasks"), ms.Tasks)
+		fmt.Fprintf(f, "- **%s**: %s %s (%s, %s)\n", tr("Micro-sessions"),
+			entries, tasks, formatDuration(ms.TimeLogged), tr("left out of the totals"))
+	}
 	fmt.Fprintf(f, "\n---\n\n")
 
+	// Streaks and personal bests
+	if r := index.Records; r != nil && (r.LongestStreak > 0 || r.MostCompletedDay.Count > 0) {
+		fmt.Fprintf(f, "## %s\n\n", tr("Records"))
+		if r.LongestStreak > 0 {
+			fmt.Fprintf(f, "- **%s**: "+plural(r.CurrentStreak, "%d day", "%d days")+"\n", tr("Current Streak"), r.CurrentStreak)
+			fmt.Fprintf(f, "- **%s**: "+plural(r.LongestStreak, "%d day", "%d days")+" ("+tr("ended %s")+")\n", tr("Longest Streak"),
+				r.LongestStreak, r.LongestStreakEnd.Format("2006-01-02"))
+			fmt.Fprintf(f, "- **%s**: %s (%s)\n", tr("Most Time in a Day"),
+				formatDuration(r.BestDay.TimeLogged), r.BestDay.Date.Format("2006-01-02"))
+		}
+		if r.MostCompletedDay.Count > 0 {
+			fmt.Fprintf(f, "- **%s**: %d (%s)\n", tr("Most Tasks Completed in a Day"),
+				r.MostCompletedDay.Count, r.MostCompletedDay.Date.Format("2006-01-02"))
+			fmt.Fprintf(f, "- **%s**: %d (%s %s)\n", tr("Most Tasks Completed in a Week"),
+				r.MostCompletedWeek.Count, tr("week of"), r.MostCompletedWeek.Date.Format("2006-01-02"))
+		}
+		fmt.Fprintf(f, "\n*%s*\n", tr("Streaks count consecutive days with logged time."))
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	// Weekly Budgets
+	if len(index.Budgets) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("Weekly Budgets"))
+		for _, b := range index.Budgets {
+			fmt.Fprintf(f, "- %s **%s**: "+tr("%s/week budget, %s avg (last 4 weeks), %s this week — %s")+"\n",
+				budgetIndicator(b.Status),
+				b.Project,
+				formatDuration(b.WeeklyBudget),
+				formatDuration(b.RecentAvg),
+				formatDuration(b.ThisWeek),
+				tr(b.Status))
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	// By Category
+	if len(index.Categories) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("By Category"))
+		for _, c := range index.Categories {
+			fmt.Fprintf(f, "- **%s**: %s (%.0f%%, %s)\n", c.Category, formatDuration(c.TimeLogged),
+				float64(c.TimeLogged)/float64(index.TotalTimeLogged)*100, fmt.Sprintf(plural(c.TaskCount, "%d task", "%d tasks"), c.TaskCount))
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
 	// Top Projects
 	if len(index.TopProjects) > 0 {
-		fmt.Fprintf(f, "## Top Projects\n\n")
+		fmt.Fprintf(f, "## %s\n\n", tr("Top Projects"))
 		// Show top 10 projects
 		limit := 10
 		if len(index.TopProjects) < limit {
@@ -51,9 +104,9 @@ func WriteTimeTracking(index *indexer.TimeTrackingIndex, outputDir string) error
 		for i := 0; i < limit; i++ {
 			proj := index.TopProjects[i]
 			fmt.Fprintf(f, "### %s\n", proj.Project)
-			fmt.Fprintf(f, "- **Time**: %s (%d tasks, avg %s/task)\n",
+			fmt.Fprintf(f, "- **%s**: %s (%s, "+tr("avg %s/task")+")\n", tr("Time"),
 				formatDuration(proj.TimeLogged),
-				proj.TaskCount,
+				fmt.Sprintf(plural(proj.TaskCount, "%d task", "%d tasks"), proj.TaskCount),
 				formatDuration(proj.AvgTimePerTask))
 		}
 		fmt.Fprintf(f, "\n---\n\n")
@@ -61,29 +114,38 @@ func WriteTimeTracking(index *indexer.TimeTrackingIndex, outputDir string) error
 
 	// Weekly Breakdown (last 8 weeks only for token efficiency)
 	if len(index.WeeklySummary) > 0 {
-		fmt.Fprintf(f, "## Weekly Breakdown\n\n")
+		fmt.Fprintf(f, "## %s\n\n", tr("Weekly Breakdown"))
 		limit := 8
 		if len(index.WeeklySummary) < limit {
 			limit = len(index.WeeklySummary)
 		}
 		for i := 0; i < limit; i++ {
 			week := index.WeeklySummary[i]
-			fmt.Fprintf(f, "- **Week of %s**: %s (%d tasks)\n",
-				week.WeekStart.Format("2006-01-02"),
+			weekKey := week.WeekStart.Format("2006-01-02")
+			fmt.Fprintf(f, "- **%s %s**: %s (%s)\n",
+				tr("Week of"),
+				weekKey,
 				formatDuration(week.TimeLogged),
-				week.TaskCount)
+				fmt.Sprintf(plural(week.TaskCount, "%d task", "%d tasks"), week.TaskCount))
+			if categories := index.CategoriesInWeek(weekKey); len(categories) > 0 {
+				parts := make([]string, len(categories))
+				for j, c := range categories {
+					parts[j] = fmt.Sprintf("%s %s (%.0f%%)", c.Category, formatDuration(c.TimeLogged),
+						float64(c.TimeLogged)/float64(week.TimeLogged)*100)
+				}
+				fmt.Fprintf(f, "  - %s\n", strings.Join(parts, ", "))
+			}
 		}
 		if len(index.WeeklySummary) > limit {
-			fmt.Fprintf(f, "\n*Showing last %d weeks of %d total*\n", limit, len(index.WeeklySummary))
+			fmt.Fprintf(f, "\n*"+tr("Showing last %d weeks of %d total")+"*\n", limit, len(index.WeeklySummary))
 		}
 		fmt.Fprintf(f, "\n---\n\n")
 	}
 
 	// By Priority
 	if len(index.ByPriority) > 0 {
-		fmt.Fprintf(f, "## By Priority\n\n")
-		priorities := []models.Priority{models.PriorityHigh, models.PriorityMedium, models.PriorityLow, models.PriorityNone}
-		for _, priority := range priorities {
+		fmt.Fprintf(f, "## %s\n\n", tr("By Priority"))
+		for _, priority := range models.AllPriorities() {
 			if duration, exists := index.ByPriority[priority]; exists && duration > 0 {
 				label := string(priority)
 				if label == "" {
@@ -95,17 +157,78 @@ func WriteTimeTracking(index *indexer.TimeTrackingIndex, outputDir string) error
 		fmt.Fprintf(f, "\n---\n\n")
 	}
 
+	// Time to First Start
+	if delays := index.StartDelays; delays.Tasks > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("Time to First Start"))
+		fmt.Fprintf(f, "*%s "+plural(delays.Tasks, "Median %s across %d task.", "Median %s across %d tasks."),
+			tr("Days from writing a task down (its journal's day, or its page's first commit) to first clocking in."),
+			formatDays(delays.Median), delays.Tasks)
+		if delays.FromGit > 0 {
+			fmt.Fprintf(f, " "+tr("%d are page tasks dated by git, which overstates the wait for tasks added to older pages."), delays.FromGit)
+		}
+		fmt.Fprintf(f, "*\n\n")
+
+		fmt.Fprintf(f, "| %s | %s | %s | %s |\n", tr("Priority"), tr("Tasks"), tr("Median"), tr("Average"))
+		fmt.Fprintf(f, "|---|---|---|---|\n")
+		for _, d := range delays.ByPriority {
+			label := "[#" + d.Group + "]"
+			if d.Group == "" {
+				label = "[#None]"
+			}
+			fmt.Fprintf(f, "| %s | %d | %s | %s |\n", label, d.Tasks, formatDays(d.Median), formatDays(d.Average))
+		}
+		if len(delays.ByProject) > 0 {
+			fmt.Fprintf(f, "\n| %s | %s | %s | %s |\n", tr("Project"), tr("Tasks"), tr("Median"), tr("Average"))
+			fmt.Fprintf(f, "|---|---|---|---|\n")
+			for _, d := range delays.ByProject {
+				fmt.Fprintf(f, "| [[%s]] | %d | %s | %s |\n", d.Group, d.Tasks, formatDays(d.Median), formatDays(d.Average))
+			}
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
+	// By Location
+	if len(index.ByFileType) > 0 {
+		fmt.Fprintf(f, "## %s\n\n", tr("By Location"))
+		fmt.Fprintf(f, "*%s*\n\n", tr("Where tracked tasks are written."))
+		for _, location := range []struct {
+			fileType models.FileType
+			label    string
+		}{{models.FileTypeJournal, "journals"}, {models.FileTypePage, "pages"}} {
+			if duration := index.ByFileType[location.fileType.String()]; duration > 0 {
+				fmt.Fprintf(f, "- **%s**: %s (%.0f%%)\n", tr(location.label), formatDuration(duration),
+					float64(duration)/float64(index.TotalTimeLogged)*100)
+			}
+		}
+
+		if len(index.ByNamespace) > 0 {
+			fmt.Fprintf(f, "\n**%s**:\n", tr("Page Namespaces"))
+			namespaces := make([]string, 0, len(index.ByNamespace))
+			for namespace := range index.ByNamespace {
+				namespaces = append(namespaces, namespace)
+			}
+			sort.Slice(namespaces, func(i, j int) bool {
+				a, b := index.ByNamespace[namespaces[i]], index.ByNamespace[namespaces[j]]
+				if a != b {
+					return a > b
+				}
+				return namespaces[i] < namespaces[j]
+			})
+			for _, namespace := range namespaces {
+				label := namespace + "/"
+				if namespace == "" {
+					label = tr("(no namespace)")
+				}
+				fmt.Fprintf(f, "- %s: %s\n", label, formatDuration(index.ByNamespace[namespace]))
+			}
+		}
+		fmt.Fprintf(f, "\n---\n\n")
+	}
+
 	// By Status
 	if len(index.ByStatus) > 0 {
-		fmt.Fprintf(f, "## By Status\n\n")
-		statuses := []models.TaskStatus{
-			models.StatusDONE,
-			models.StatusNOW,
-			models.StatusDOING,
-			models.StatusTODO,
-			models.StatusLATER,
-		}
-		for _, status := range statuses {
+		fmt.Fprintf(f, "## %s\n\n", tr("By Status"))
+		for _, status := range models.Statuses() {
 			if duration, exists := index.ByStatus[status]; exists && duration > 0 {
 				fmt.Fprintf(f, "- **%s**: %s\n", status, formatDuration(duration))
 			}
@@ -115,3 +238,24 @@ func WriteTimeTracking(index *indexer.TimeTrackingIndex, outputDir string) error
 
 	return nil
 }
+
+// formatDays formats a wait of a day or more in days ("2.5d"), and shorter
+// ones as durations
+func formatDays(d time.Duration) string {
+	if d < 24*time.Hour {
+		return formatDuration(d)
+	}
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
+
+// budgetIndicator returns a marker for a budget status
+func budgetIndicator(status string) string {
+	switch status {
+	case "over":
+		return emoji("🔺", "[over]")
+	case "under":
+		return emoji("🔻", "[under]")
+	default:
+		return emoji("✅", "[ok]")
+	}
+}
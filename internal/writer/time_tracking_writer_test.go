@@ -246,3 +246,197 @@ func TestWriteTimeTracking_ManyWeeks(t *testing.T) {
 		t.Error("Expected note about showing 8 of 12 weeks")
 	}
 }
+
+func TestWriteTimeTracking_Budgets(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		Budgets: []indexer.ProjectBudget{
+			{Project: "Phoenix", WeeklyBudget: 10 * time.Hour, ThisWeek: 3 * time.Hour, RecentAvg: 12 * time.Hour, Status: "over"},
+			{Project: "Research", WeeklyBudget: 5 * time.Hour, Status: "under"},
+		},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "## Weekly Budgets") {
+		t.Error("Expected Weekly Budgets section")
+	}
+	if !strings.Contains(output, "🔺 **Phoenix**: 10h/week budget, 12h avg (last 4 weeks), 3h this week — over") {
+		t.Errorf("Expected Phoenix over-budget line, got:\n%s", output)
+	}
+	if !strings.Contains(output, "🔻 **Research**") {
+		t.Error("Expected Research under-budget marker")
+	}
+}
+
+func TestWriteTimeTracking_MicroSessions(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		MicroSessions: indexer.MicroSessions{MinDuration: 30 * time.Second, Entries: 12, Tasks: 1, TimeLogged: 50 * time.Second},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "- **Micro-sessions**: 12 entries under 30s on 1 task (50s, left out of the totals)") {
+		t.Errorf("Expected micro-sessions line, got:\n%s", content)
+	}
+}
+
+func TestWriteTimeTracking_Categories(t *testing.T) {
+	tmpDir := t.TempDir()
+	monday := time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)
+	index := &indexer.TimeTrackingIndex{
+		TotalTimeLogged: 8 * time.Hour,
+		WeeklySummary:   []indexer.WeeklyTime{{WeekStart: monday, TimeLogged: 4 * time.Hour, TaskCount: 3}},
+		Categories: []indexer.CategoryTime{
+			{Category: "Meetings", TimeLogged: 6 * time.Hour, TaskCount: 4},
+			{Category: indexer.Uncategorized, TimeLogged: 2 * time.Hour, TaskCount: 1},
+		},
+		ByCategoryWeek: map[string]map[string]time.Duration{
+			"2025-11-10": {"Meetings": 3 * time.Hour, indexer.Uncategorized: time.Hour},
+		},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## By Category",
+		"- **Meetings**: 6h (75%, 4 tasks)\n- **Uncategorized**: 2h (25%, 1 task)",
+		"- **Week of 2025-11-10**: 4h (3 tasks)\n  - Meetings 3h (75%), Uncategorized 1h (25%)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteTimeTracking_ByLocation(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		TotalTimeLogged: 4 * time.Hour,
+		ByFileType: map[string]time.Duration{
+			"journal": time.Hour,
+			"page":    3 * time.Hour,
+		},
+		ByNamespace: map[string]time.Duration{
+			"Projects": 2 * time.Hour,
+			"":         time.Hour,
+		},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## By Location",
+		"- **journals**: 1h (25%)",
+		"- **pages**: 3h (75%)",
+		"- Projects/: 2h\n- (no namespace): 1h",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteTimeTracking_Records(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		Records: &indexer.Records{
+			CurrentStreak:     1,
+			LongestStreak:     5,
+			LongestStreakEnd:  time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
+			BestDay:           indexer.Record{Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), TimeLogged: 3 * time.Hour},
+			MostCompletedDay:  indexer.Record{Date: time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC), Count: 2},
+			MostCompletedWeek: indexer.Record{Date: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), Count: 3},
+		},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, expected := range []string{
+		"## Records",
+		"- **Current Streak**: 1 day\n",
+		"- **Longest Streak**: 5 days (ended 2025-11-05)",
+		"- **Most Time in a Day**: 3h (2025-11-03)",
+		"- **Most Tasks Completed in a Day**: 2 (2025-11-04)",
+		"- **Most Tasks Completed in a Week**: 3 (week of 2025-11-03)",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected %q in output:\n%s", expected, output)
+		}
+	}
+}
+
+func TestWriteTimeTracking_StartDelays(t *testing.T) {
+	tmpDir := t.TempDir()
+	index := &indexer.TimeTrackingIndex{
+		StartDelays: indexer.StartDelays{
+			Tasks:   7,
+			FromGit: 2,
+			Median:  36 * time.Hour,
+			ByPriority: []indexer.StartDelay{
+				{Group: "A", Tasks: 3, Median: 4 * time.Hour, Average: 30 * time.Hour},
+				{Group: "", Tasks: 4, Median: 60 * time.Hour, Average: 72 * time.Hour},
+			},
+			ByProject: []indexer.StartDelay{{Group: "Phoenix", Tasks: 3, Median: 48 * time.Hour, Average: 48 * time.Hour}},
+		},
+	}
+
+	if err := WriteTimeTracking(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimeTracking failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "time-tracking.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	for _, want := range []string{
+		"## Time to First Start",
+		"Median 1.5d across 7 tasks. 2 are page tasks dated by git",
+		"| [#A] | 3 | 4h | 1.2d |",
+		"| [#None] | 4 | 2.5d | 3.0d |",
+		"| [[Phoenix]] | 3 | 2.0d | 2.0d |",
+	} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q, got:\n%s", want, content)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// TimeTreeFileName holds logged time rolled up through nested tasks
+const TimeTreeFileName = "time-tree.md"
+
+// maxTimeTreeRoots caps the top-level tasks listed per project
+const maxTimeTreeRoots = 20
+
+// WriteTimeTree writes time-tree.md: per project, each task tree with its
+// total time, subtasks indented under their parents
+func WriteTimeTree(index *indexer.TimeTreeIndex, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, TimeTreeFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Time Tree"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "*%s*\n\n", tr("Logged time rolled up through nested tasks: a task's total includes its subtasks', with its own time alongside. Projects are the first page reference of the top-level task. Only tasks with logged time are listed."))
+	fmt.Fprintf(f, "---\n\n")
+
+	if len(index.Projects) == 0 {
+		fmt.Fprintf(f, "*%s*\n", tr("No logged time."))
+		return nil
+	}
+
+	for _, project := range index.Projects {
+		fmt.Fprintf(f, "## %s (%s)\n\n", project.Name, formatDuration(project.Total))
+		roots := project.Roots
+		if len(roots) > maxTimeTreeRoots {
+			roots = roots[:maxTimeTreeRoots]
+		}
+		for _, root := range roots {
+			writeTimeTreeNode(f, root, 0)
+		}
+		if hidden := project.Roots[len(roots):]; len(hidden) > 0 {
+			var rest time.Duration
+			for _, root := range hidden {
+				rest += root.Total
+			}
+			fmt.Fprintf(f, "- *"+plural(len(hidden), "%d more task", "%d more tasks")+" (%s)*\n", len(hidden), formatDuration(rest))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
+
+// writeTimeTreeNode writes a task and, indented below it, its subtasks
+func writeTimeTreeNode(f *os.File, node *indexer.TimeTreeNode, depth int) {
+	description := node.Task.Description
+	if len(description) > 100 {
+		description = description[:97] + "..."
+	}
+	own := ""
+	if len(node.Children) > 0 {
+		own = fmt.Sprintf(" (%s %s)", formatDuration(node.Own), tr("own"))
+	}
+	fmt.Fprintf(f, "%s- %s%s - **%s**%s `%s:%d`\n", strings.Repeat("  ", depth), statusMarker(node.Task.Status),
+		description, formatDuration(node.Total), own, node.Task.SourceFile, node.Task.LineNumber)
+	for _, child := range node.Children {
+		writeTimeTreeNode(f, child, depth+1)
+	}
+}
@@ -0,0 +1,60 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteTimeTree(t *testing.T) {
+	start := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	logged := func(d time.Duration) []models.LogbookEntry {
+		return []models.LogbookEntry{{Start: start, End: start.Add(d), Duration: d}}
+	}
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Launch", PageRefs: []string{"Phoenix"}, SourceFile: "pages/Phoenix.md", LineNumber: 1, Logbook: logged(time.Hour)},
+		{Status: models.StatusDONE, Description: "Docs", SourceFile: "pages/Phoenix.md", LineNumber: 2, ParentLine: 1, Logbook: logged(3 * time.Hour)},
+	}
+	for i := range maxTimeTreeRoots + 2 {
+		tasks = append(tasks, models.Task{Status: models.StatusDONE, Description: "Chore", SourceFile: "pages/Chores.md", LineNumber: i + 1, Logbook: logged(time.Minute)})
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteTimeTree(indexer.BuildTimeTreeIndex(tasks, start), tmpDir); err != nil {
+		t.Fatalf("WriteTimeTree failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, TimeTreeFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## Phoenix (4h)\n\n- **[TODO]** Launch - **4h** (1h own) `pages/Phoenix.md:1`\n  - **[DONE]** Docs - **3h** `pages/Phoenix.md:2`\n",
+		"## No Project (22m)",
+		"- *2 more tasks (2m)*",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteTimeTree_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := WriteTimeTree(indexer.BuildTimeTreeIndex(nil, time.Now()), tmpDir); err != nil {
+		t.Fatalf("WriteTimeTree failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, TimeTreeFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	if !strings.Contains(string(content), "*No logged time.*") {
+		t.Errorf("Expected a note about no logged time, got:\n%s", content)
+	}
+}
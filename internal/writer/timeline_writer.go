@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
@@ -26,8 +27,8 @@ func WriteTimelineRecent(index *indexer.TimelineIndex, outputDir string) error {
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# Recent Activity Timeline\n\n")
-	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "# %s\n\n", tr("Recent Activity Timeline"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
 
 	// Get last 7 days
 	sevenDaysAgo := time.Now().AddDate(0, 0, -7)
@@ -39,12 +40,14 @@ func WriteTimelineRecent(index *indexer.TimelineIndex, outputDir string) error {
 	}
 
 	if len(recentDays) == 0 {
-		fmt.Fprintf(f, "*No activity in the last 7 days.*\n")
-		return nil
+		fmt.Fprintf(f, "*%s*\n\n", tr("No activity in the last 7 days."))
+	} else {
+		fmt.Fprintf(f, "**%s**: "+plural(len(recentDays), "%d day with activity", "%d days with activity")+"\n\n", tr("Last 7 Days"), len(recentDays))
+		fmt.Fprintf(f, "---\n\n")
 	}
 
-	fmt.Fprintf(f, "**Last 7 Days**: %d days with activity\n\n", len(recentDays))
-	fmt.Fprintf(f, "---\n\n")
+	// Writing stats cover the whole graph, so they're written even without recent activity
+	writeWritingStats(f, index.Writing)
 
 	// Write each day in detail
 	for _, day := range recentDays {
@@ -54,13 +57,32 @@ func WriteTimelineRecent(index *indexer.TimelineIndex, outputDir string) error {
 	return nil
 }
 
-// WriteTimelineFull writes the complete timeline history
+// WriteTimelineFull writes the complete timeline history, split into one file per
+// year (timeline-2025.md, ...) so each stays loadable within a context window.
+// timeline-full.md becomes a small index linking to the yearly files.
 func WriteTimelineFull(index *indexer.TimelineIndex, outputDir string) error {
 	// Ensure output directory exists
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return fmt.Errorf("creating output directory: %w", err)
 	}
 
+	// Group days by year (entries are already sorted newest first)
+	var years []int
+	byYear := make(map[int][]indexer.TimelineDay)
+	for _, day := range index.Entries {
+		year := day.Date.Year()
+		if _, exists := byYear[year]; !exists {
+			years = append(years, year)
+		}
+		byYear[year] = append(byYear[year], day)
+	}
+
+	for _, year := range years {
+		if err := writeTimelineYear(index, year, byYear[year], outputDir); err != nil {
+			return err
+		}
+	}
+
 	filePath := filepath.Join(outputDir, "timeline-full.md")
 
 	f, err := os.Create(filePath)
@@ -70,78 +92,251 @@ func WriteTimelineFull(index *indexer.TimelineIndex, outputDir string) error {
 	defer f.Close()
 
 	// Write header
-	fmt.Fprintf(f, "# Complete Activity Timeline\n\n")
-	fmt.Fprintf(f, "Generated: %s\n\n", index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "# %s\n\n", tr("Complete Activity Timeline"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
 
 	if len(index.Entries) == 0 {
-		fmt.Fprintf(f, "*No activity recorded.*\n")
+		fmt.Fprintf(f, "*%s*\n", tr("No activity recorded."))
 		return nil
 	}
 
-	fmt.Fprintf(f, "**Total Days**: %d days with activity\n\n", len(index.Entries))
+	fmt.Fprintf(f, "**%s**: "+plural(len(index.Entries), "%d day with activity", "%d days with activity")+"\n\n", tr("Total Days"), len(index.Entries))
+	fmt.Fprintf(f, "---\n\n")
+
+	// Write one line per year
+	fmt.Fprintf(f, "## %s\n\n", tr("By Year"))
+	for _, year := range years {
+		days := byYear[year]
+		taskCount := 0
+		var timeLogged time.Duration
+		for _, day := range days {
+			taskCount += len(day.TasksCreated)
+			timeLogged += day.TimeLogged
+		}
+
+		fmt.Fprintf(f, "- [%d](./%s) - "+plural(len(days), "%d day", "%d days")+", "+plural(taskCount, "%d task", "%d tasks"),
+			year, timelineYearFile(year), len(days), taskCount)
+		if timeLogged > 0 {
+			fmt.Fprintf(f, ", %s%s %s", emoji("⏱ ", ""), formatDuration(timeLogged), tr("logged"))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+	fmt.Fprintf(f, "\n")
+
+	return nil
+}
+
+// writeTimelineYear writes the condensed history for a single year
+func writeTimelineYear(index *indexer.TimelineIndex, year int, days []indexer.TimelineDay, outputDir string) error {
+	filePath := filepath.Join(outputDir, timelineYearFile(year))
+
+	f, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s %d\n\n", tr("Activity Timeline"), year)
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), index.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "**%s**: "+plural(len(days), "%d day with activity", "%d days with activity")+"\n\n", tr("Total Days"), len(days))
 	fmt.Fprintf(f, "---\n\n")
 
 	// Write each day with condensed format
-	for _, day := range index.Entries {
+	for _, day := range days {
 		writeDayCondensed(f, day)
 	}
 
 	return nil
 }
 
+// TimelineYearFiles lists the per-year files WriteTimelineFull produces for an index
+func TimelineYearFiles(index *indexer.TimelineIndex) []string {
+	var files []string
+	seen := make(map[int]bool)
+	for _, day := range index.Entries {
+		year := day.Date.Year()
+		if !seen[year] {
+			seen[year] = true
+			files = append(files, timelineYearFile(year))
+		}
+	}
+	return files
+}
+
+// timelineYearFile returns the file name for a year's timeline
+func timelineYearFile(year int) string {
+	return fmt.Sprintf("timeline-%d.md", year)
+}
+
+// dayActivity returns a day's summary bullets: task counts by status, up to
+// two high priority tasks, the page tasks, and the pages created and edited
+func dayActivity(day indexer.TimelineDay) []string {
+	var activity []string
+
+	statusCounts := make(map[models.TaskStatus]int)
+	var highPriorityTasks []models.Task
+	for _, task := range day.TasksCreated {
+		statusCounts[task.Status]++
+		if task.Priority == models.PriorityHigh {
+			highPriorityTasks = append(highPriorityTasks, task)
+		}
+	}
+	for _, status := range models.Statuses() {
+		if n := statusCounts[status]; n > 0 {
+			activity = append(activity, fmt.Sprintf(plural(n, "%d %s task", "%d %s tasks"), n, status))
+		}
+	}
+	for i, task := range highPriorityTasks {
+		if i >= 2 {
+			break
+		}
+		desc := task.Description
+		if len(desc) > 60 {
+			desc = desc[:57] + "..."
+		}
+		activity = append(activity, "🔥 "+desc)
+	}
+
+	if len(day.PageTasks) > 0 {
+		var pages []string
+		seen := make(map[string]bool)
+		for _, task := range day.PageTasks {
+			page := models.PageName(task.SourceFile)
+			if !seen[page] {
+				seen[page] = true
+				pages = append(pages, page)
+			}
+		}
+		activity = append(activity, fmt.Sprintf(plural(len(day.PageTasks), "%d page task in %s", "%d page tasks in %s"),
+			len(day.PageTasks), formatPageList(pages)))
+	}
+
+	if len(day.PagesCreated) > 0 {
+		activity = append(activity, "📝 "+tr("Created")+" "+formatPageList(day.PagesCreated))
+	}
+	if len(day.PagesEdited) > 0 {
+		activity = append(activity, "✏️ "+tr("Edited")+" "+formatPageList(day.PagesEdited))
+	}
+	return activity
+}
+
+// formatPageList links up to 3 pages, summarising the rest
+func formatPageList(pages []string) string {
+	limit := 3
+	if len(pages) < limit {
+		limit = len(pages)
+	}
+	links := make([]string, limit)
+	for i, page := range pages[:limit] {
+		links[i] = "[[" + page + "]]"
+	}
+	list := strings.Join(links, ", ")
+	if len(pages) > limit {
+		list += " " + fmt.Sprintf(tr("+%d more"), len(pages)-limit)
+	}
+	return list
+}
+
 // writeDayDetail writes a single day with full task details
 func writeDayDetail(f *os.File, day indexer.TimelineDay) {
 	// Date header
-	fmt.Fprintf(f, "## %s\n\n", day.Date.Format("Monday, January 2, 2006"))
-	fmt.Fprintf(f, "**Journal**: `%s`\n\n", day.JournalPath)
+	fmt.Fprintf(f, "## %s\n\n", formatDate(day.Date, "Monday, January 2, 2006"))
+	if day.JournalPath != "" {
+		fmt.Fprintf(f, "**%s**: `%s`\n\n", tr("Journal"), day.JournalPath)
+	}
 
 	// Key activity summary
-	if len(day.KeyActivity) > 0 {
-		fmt.Fprintf(f, "**Activity**:\n")
-		for _, activity := range day.KeyActivity {
-			fmt.Fprintf(f, "- %s\n", activity)
+	if activity := dayActivity(day); len(activity) > 0 {
+		fmt.Fprintf(f, "**%s**:\n", tr("Activity"))
+		for _, activity := range activity {
+			fmt.Fprintf(f, "- %s\n", stripEmoji(activity))
 		}
 		fmt.Fprintf(f, "\n")
 	}
 
 	// Time logged
 	if day.TimeLogged > 0 {
-		fmt.Fprintf(f, "**Time Logged**: %s\n\n", formatDuration(day.TimeLogged))
+		fmt.Fprintf(f, "**%s**: %s\n\n", tr("Time Logged"), formatDuration(day.TimeLogged))
+	}
+
+	if day.WordsWritten > 0 {
+		fmt.Fprintf(f, "**%s**: %d\n\n", tr("Words Written"), day.WordsWritten)
 	}
 
 	// List tasks
 	if len(day.TasksCreated) > 0 {
-		fmt.Fprintf(f, "**Tasks** (%d):\n", len(day.TasksCreated))
+		fmt.Fprintf(f, "**%s** (%d):\n", tr("Tasks"), len(day.TasksCreated))
 		for _, task := range day.TasksCreated {
 			writeTimelineTask(f, task)
 		}
 	}
 
+	// Page tasks attributed to this day
+	if len(day.PageTasks) > 0 {
+		if len(day.TasksCreated) > 0 {
+			fmt.Fprintf(f, "\n")
+		}
+		fmt.Fprintf(f, "**%s** (%d):\n", tr("Page Tasks"), len(day.PageTasks))
+		for _, task := range day.PageTasks {
+			writeTimelineTask(f, task.Task)
+			fmt.Fprintf(f, "  - `%s:%d` ("+tr("via %s")+")\n", task.SourceFile, task.LineNumber, tr(task.Source))
+		}
+	}
+
 	fmt.Fprintf(f, "---\n\n")
 }
 
 // writeDayCondensed writes a single day in condensed format
 func writeDayCondensed(f *os.File, day indexer.TimelineDay) {
 	// Date header (shorter format)
-	fmt.Fprintf(f, "## %s\n\n", day.Date.Format("2006-01-02 (Mon)"))
+	fmt.Fprintf(f, "## %s\n\n", formatDate(day.Date, "2006-01-02 (Mon)"))
 
 	// Key activity only
-	if len(day.KeyActivity) > 0 {
-		for _, activity := range day.KeyActivity {
-			fmt.Fprintf(f, "- %s\n", activity)
+	if activity := dayActivity(day); len(activity) > 0 {
+		for _, activity := range activity {
+			fmt.Fprintf(f, "- %s\n", stripEmoji(activity))
 		}
 	} else {
-		fmt.Fprintf(f, "- *No tasks*\n")
+		fmt.Fprintf(f, "- *%s*\n", tr("No tasks"))
 	}
 
 	// Time logged (inline)
 	if day.TimeLogged > 0 {
-		fmt.Fprintf(f, "- ⏱ %s logged\n", formatDuration(day.TimeLogged))
+		fmt.Fprintf(f, "- %s%s %s\n", emoji("⏱ ", ""), formatDuration(day.TimeLogged), tr("logged"))
+	}
+	if day.WordsWritten > 0 {
+		fmt.Fprintf(f, "- %s%d %s\n", emoji("✍ ", ""), day.WordsWritten, tr("words written"))
 	}
 
 	fmt.Fprintf(f, "\n")
 }
 
+// writeWritingStats writes the journaling statistics section, if anything was written
+func writeWritingStats(f *os.File, stats *indexer.WritingStats) {
+	if stats == nil || stats.WritingDays == 0 {
+		return
+	}
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Writing Statistics"))
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("Total Words"), stats.TotalWords)
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("Writing Days"), stats.WritingDays)
+	fmt.Fprintf(f, "- **%s**: %d\n", tr("Avg Words/Day"), stats.AvgWords)
+	fmt.Fprintf(f, "- **%s**: %d (%s)\n", tr("Most Words in a Day"), stats.BestDay.Words, stats.BestDay.Date.Format("2006-01-02"))
+	fmt.Fprintf(f, "- **%s**: "+plural(stats.CurrentStreak, "%d day", "%d days")+"\n", tr("Current Streak"), stats.CurrentStreak)
+	fmt.Fprintf(f, "- **%s**: "+plural(stats.LongestStreak, "%d day", "%d days")+" ("+tr("ended %s")+")\n", tr("Longest Streak"),
+		stats.LongestStreak, stats.LongestStreakEnd.Format("2006-01-02"))
+	fmt.Fprintf(f, "\n")
+
+	fmt.Fprintf(f, "| %s | %s | %s |\n", tr("Week"), tr("Words"), tr("Days"))
+	fmt.Fprintf(f, "|------|-------|------|\n")
+	for _, week := range stats.Weeks {
+		fmt.Fprintf(f, "| %s | %d | %d |\n", week.WeekStart.Format("2006-01-02"), week.Words, week.Days)
+	}
+	fmt.Fprintf(f, "\n*%s*\n\n", tr("Words exclude tasks, properties, and logbooks. Streaks count consecutive days with journal writing."))
+	fmt.Fprintf(f, "---\n\n")
+}
+
 // writeTimelineTask writes a task in lean timeline format
 func writeTimelineTask(f *os.File, task models.Task) {
 	description := task.Description
@@ -150,14 +345,10 @@ func writeTimelineTask(f *os.File, task models.Task) {
 	}
 
 	// Format: - [STATUS] Description [#A] ⏱ 2h
-	line := fmt.Sprintf("- **[%s]** %s", task.Status, description)
-
-	if task.Priority != models.PriorityNone {
-		line += fmt.Sprintf(" [#%s]", task.Priority)
-	}
+	line := "- " + statusMarker(task.Status) + description + priorityMarker(task.Priority)
 
 	if len(task.Logbook) > 0 {
-		line += fmt.Sprintf(" ⏱ %s", formatDuration(task.TotalDuration()))
+		line += fmt.Sprintf(" %s%s", emoji("⏱ ", "time: "), formatDuration(task.TotalDuration()))
 	}
 
 	fmt.Fprintf(f, "%s\n", line)
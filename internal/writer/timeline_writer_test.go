@@ -28,17 +28,12 @@ func TestWriteTimelineRecent(t *testing.T) {
 					},
 				},
 				TimeLogged: 2 * time.Hour,
-				KeyActivity: []string{
-					"1 NOW task",
-					"🔥 Urgent task from yesterday",
-				},
 			},
 			{
 				Date:         now.AddDate(0, 0, -3), // 3 days ago
 				JournalPath:  "journals/three-days-ago.md",
 				TasksCreated: []models.Task{},
 				TimeLogged:   0,
-				KeyActivity:  []string{},
 			},
 			{
 				Date:        now.AddDate(0, 0, -10), // 10 days ago (should not appear)
@@ -101,6 +96,52 @@ func TestWriteTimelineRecent(t *testing.T) {
 	}
 }
 
+func TestWriteTimelineRecent_Writing(t *testing.T) {
+	now := time.Now()
+	index := &indexer.TimelineIndex{
+		GeneratedAt: now,
+		Entries: []indexer.TimelineDay{
+			{Date: now.AddDate(0, 0, -1), JournalPath: "journals/yesterday.md", WordsWritten: 240},
+		},
+		Writing: &indexer.WritingStats{
+			TotalWords:       240,
+			WritingDays:      1,
+			AvgWords:         240,
+			BestDay:          indexer.WritingDay{Date: time.Date(2025, 11, 9, 0, 0, 0, 0, time.UTC), Words: 240},
+			CurrentStreak:    1,
+			LongestStreak:    1,
+			LongestStreakEnd: time.Date(2025, 11, 9, 0, 0, 0, 0, time.UTC),
+			Weeks: []indexer.WritingWeek{
+				{WeekStart: time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC), Words: 240, Days: 1},
+			},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteTimelineRecent(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimelineRecent failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "timeline-recent.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"## Writing Statistics",
+		"- **Total Words**: 240\n- **Writing Days**: 1",
+		"- **Most Words in a Day**: 240 (2025-11-09)",
+		"- **Longest Streak**: 1 day (ended 2025-11-09)",
+		"| 2025-11-03 | 240 | 1 |",
+		"**Words Written**: 240",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected timeline to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
 func TestWriteTimelineRecent_NoRecentActivity(t *testing.T) {
 	// Create timeline with only old dates
 	index := &indexer.TimelineIndex{
@@ -130,6 +171,31 @@ func TestWriteTimelineRecent_NoRecentActivity(t *testing.T) {
 	}
 }
 
+func TestWriteTimelineRecent_WritingWithoutRecentActivity(t *testing.T) {
+	index := &indexer.TimelineIndex{
+		GeneratedAt: time.Now(),
+		Entries: []indexer.TimelineDay{
+			{Date: time.Now().AddDate(0, 0, -10), JournalPath: "journals/old.md", WordsWritten: 80},
+		},
+		Writing: &indexer.WritingStats{TotalWords: 80, WritingDays: 1, AvgWords: 80, LongestStreak: 1},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteTimelineRecent(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimelineRecent failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "timeline-recent.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "No activity in the last 7 days") || !strings.Contains(output, "- **Total Words**: 80") {
+		t.Errorf("Expected writing stats alongside the no-activity note, got:\n%s", output)
+	}
+}
+
 func TestWriteTimelineFull(t *testing.T) {
 	// Create test timeline
 	index := &indexer.TimelineIndex{
@@ -145,9 +211,6 @@ func TestWriteTimelineFull(t *testing.T) {
 					},
 				},
 				TimeLogged: 1 * time.Hour,
-				KeyActivity: []string{
-					"1 NOW task",
-				},
 			},
 			{
 				Date:        time.Date(2025, 11, 5, 0, 0, 0, 0, time.UTC),
@@ -158,9 +221,6 @@ func TestWriteTimelineFull(t *testing.T) {
 						Description: "Task 2",
 					},
 				},
-				KeyActivity: []string{
-					"1 DONE task",
-				},
 			},
 		},
 	}
@@ -195,6 +255,22 @@ func TestWriteTimelineFull(t *testing.T) {
 		t.Error("Missing total days summary")
 	}
 
+	// Index links to the yearly file
+	if !strings.Contains(contentStr, "- [2025](./timeline-2025.md) - 2 days, 2 tasks, ⏱ 1h logged") {
+		t.Errorf("Missing year link, got:\n%s", contentStr)
+	}
+
+	// Day details live in the yearly file
+	content, err = os.ReadFile(filepath.Join(tmpDir, "timeline-2025.md"))
+	if err != nil {
+		t.Fatalf("Failed to read yearly file: %v", err)
+	}
+	contentStr = string(content)
+
+	if !strings.Contains(contentStr, "# Activity Timeline 2025") {
+		t.Error("Missing yearly header")
+	}
+
 	// Should contain both dates
 	if !strings.Contains(contentStr, "2025-11-06") {
 		t.Error("Missing Nov 6 entry")
@@ -214,6 +290,42 @@ func TestWriteTimelineFull(t *testing.T) {
 	}
 }
 
+func TestWriteTimelineFull_SplitsByYear(t *testing.T) {
+	index := &indexer.TimelineIndex{
+		GeneratedAt: time.Now(),
+		Entries: []indexer.TimelineDay{
+			{Date: time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC), PagesCreated: []string{"new year"}},
+			{Date: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), PagesCreated: []string{"old year"}},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteTimelineFull(index, tmpDir); err != nil {
+		t.Fatalf("WriteTimelineFull failed: %v", err)
+	}
+
+	y2025, err := os.ReadFile(filepath.Join(tmpDir, "timeline-2025.md"))
+	if err != nil {
+		t.Fatalf("Missing timeline-2025.md: %v", err)
+	}
+	y2024, err := os.ReadFile(filepath.Join(tmpDir, "timeline-2024.md"))
+	if err != nil {
+		t.Fatalf("Missing timeline-2024.md: %v", err)
+	}
+
+	if !strings.Contains(string(y2025), "new year") || strings.Contains(string(y2025), "old year") {
+		t.Error("timeline-2025.md should only contain 2025 days")
+	}
+	if !strings.Contains(string(y2024), "old year") || strings.Contains(string(y2024), "new year") {
+		t.Error("timeline-2024.md should only contain 2024 days")
+	}
+
+	full, _ := os.ReadFile(filepath.Join(tmpDir, "timeline-full.md"))
+	if strings.Index(string(full), "[2025]") > strings.Index(string(full), "[2024]") {
+		t.Error("Years should be listed newest first")
+	}
+}
+
 func TestWriteTimelineFull_EmptyTimeline(t *testing.T) {
 	index := &indexer.TimelineIndex{
 		GeneratedAt: time.Now(),
@@ -333,9 +445,6 @@ func TestWriteDayDetail(t *testing.T) {
 			},
 		},
 		TimeLogged: 2 * time.Hour,
-		KeyActivity: []string{
-			"1 NOW task",
-		},
 	}
 
 	writeDayDetail(tmpFile, day)
@@ -368,6 +477,43 @@ func TestWriteDayDetail(t *testing.T) {
 	}
 }
 
+func TestWriteDayDetail_PageTasks(t *testing.T) {
+	tmpFile, err := os.CreateTemp("", "test-*.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	day := indexer.TimelineDay{
+		Date: time.Date(2025, 11, 4, 0, 0, 0, 0, time.UTC),
+		PageTasks: []indexer.BackfilledTask{
+			{
+				Task:   models.Task{Status: models.StatusDONE, Description: "Ship release", SourceFile: "pages/Phoenix.md", LineNumber: 12},
+				Source: indexer.DateSourceCompleted,
+			},
+		},
+	}
+
+	writeDayDetail(tmpFile, day)
+	tmpFile.Sync()
+
+	content, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	contentStr := string(content)
+
+	if strings.Contains(contentStr, "**Journal**") {
+		t.Error("Expected no journal line for a day without a journal")
+	}
+	for _, expected := range []string{"**Page Tasks** (1):", "Ship release", "`pages/Phoenix.md:12` (via completed)"} {
+		if !strings.Contains(contentStr, expected) {
+			t.Errorf("Expected content to contain %q, got:\n%s", expected, contentStr)
+		}
+	}
+}
+
 func TestWriteDayCondensed(t *testing.T) {
 	tmpFile, err := os.CreateTemp("", "test-*.md")
 	if err != nil {
@@ -383,9 +529,6 @@ func TestWriteDayCondensed(t *testing.T) {
 			{Status: models.StatusNOW},
 		},
 		TimeLogged: 1 * time.Hour,
-		KeyActivity: []string{
-			"1 NOW task",
-		},
 	}
 
 	writeDayCondensed(tmpFile, day)
@@ -424,7 +567,6 @@ func TestWriteDayCondensed_NoActivity(t *testing.T) {
 		Date:         time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
 		JournalPath:  "journals/2025_11_06.md",
 		TasksCreated: []models.Task{},
-		KeyActivity:  []string{},
 	}
 
 	writeDayCondensed(tmpFile, day)
@@ -440,3 +582,36 @@ func TestWriteDayCondensed_NoActivity(t *testing.T) {
 		t.Error("Should indicate no tasks")
 	}
 }
+
+func TestDayActivity(t *testing.T) {
+	day := indexer.TimelineDay{
+		Date: time.Date(2025, 11, 6, 0, 0, 0, 0, time.UTC),
+		TasksCreated: []models.Task{
+			{Status: models.StatusNOW, Priority: models.PriorityHigh, Description: "High priority urgent task"},
+			{Status: models.StatusTODO},
+			{Status: models.StatusTODO},
+			{Status: models.StatusDONE, Priority: models.PriorityHigh, Description: "This is a very long task description that should be truncated to 60 characters"},
+		},
+		PageTasks: []indexer.BackfilledTask{
+			{Task: models.Task{SourceFile: "pages/Phoenix.md"}, Source: indexer.DateSourceLogbook},
+		},
+		PagesEdited: []string{"A", "B", "C", "D"},
+	}
+
+	expected := []string{
+		"1 NOW task",
+		"2 TODO tasks",
+		"1 DONE task",
+		"🔥 High priority urgent task",
+		"🔥 This is a very long task description that should be trunc...",
+		"1 page task in [[Phoenix]]",
+		"✏️ Edited [[A]], [[B]], [[C]] +1 more",
+	}
+	if got := dayActivity(day); strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("dayActivity() = %q, want %q", got, expected)
+	}
+
+	if got := dayActivity(indexer.TimelineDay{Date: day.Date}); len(got) != 0 {
+		t.Errorf("Expected no activity for an empty day, got %q", got)
+	}
+}
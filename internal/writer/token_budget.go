@@ -0,0 +1,176 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// BudgetGroups maps each budget priority name to the markdown files it
+// covers, most important first. JSON, DOT, the backlinks directory, and
+// README.md are reference material loaded on demand, so they're outside
+// the budget. Every markdown file in Artifacts must belong to a group.
+var BudgetGroups = map[string][]string{
+	"dashboard": {"dashboard.md"},
+	"tasks": {"tasks-by-priority.md", "tasks-by-status.md", WeekPlanFileName, DailyPlanningFileName, InboxFileName,
+		"backlog-someday.md", RetroDir + "/*.md"},
+	"timeline":    {"timeline-recent.md", "timeline-full.md", "timeline-*.md"},
+	"graph":       {"graph-health.md", "missing-pages.md", "reference-graph.md", ResurfaceFileName, "tag-suggestions.md", DeletedPagesFileName},
+	"time":        {"time-tracking.md", TimeTreeFileName, TimeTrackingIssuesFileName},
+	"diagnostics": {"diagnostics.md"},
+}
+
+// DefaultBudgetPriority is the order groups receive the token budget in.
+// Groups missing from a configured order follow it in this order.
+var DefaultBudgetPriority = []string{"dashboard", "tasks", "timeline", "graph", "time", "diagnostics"}
+
+// BudgetResult records what the token budget left of one file
+type BudgetResult struct {
+	File    string
+	Tokens  int // Estimated tokens after trimming
+	Omitted int // Trailing "## " sections removed
+}
+
+// EstimateTokens approximates a text's token count at four characters per token
+func EstimateTokens(text string) int {
+	return (len([]rune(text)) + 3) / 4
+}
+
+// ApplyTokenBudget trims the generated markdown files in outputDir so their
+// combined size fits within budget tokens. Groups are served in priority
+// order and, within a group, files in BudgetGroups order. Each file keeps
+// its header and as many of its leading "## " sections as fit what's left;
+// the section that doesn't fit and everything after it is replaced by a
+// note. Sections are dropped from the end because writers put the most
+// important section first. Files are never removed, so links between them
+// stay valid, even once the budget is spent and only headers remain.
+func ApplyTokenBudget(outputDir string, generated []string, budget int, priority []string) ([]BudgetResult, error) {
+	var results []BudgetResult
+	remaining := budget
+
+	files, err := expandDirs(outputDir, generated)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range budgetOrder(files, priority) {
+		filePath := filepath.Join(outputDir, name)
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+
+		trimmed, omitted := trimToBudget(string(content), remaining)
+		if omitted > 0 {
+			if err := os.WriteFile(filePath, []byte(trimmed), 0644); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", name, err)
+			}
+		}
+
+		tokens := EstimateTokens(trimmed)
+		remaining = max(remaining-tokens, 0)
+		results = append(results, BudgetResult{File: name, Tokens: tokens, Omitted: omitted})
+	}
+
+	return results, nil
+}
+
+// expandDirs replaces the directories among generated names (ending in "/")
+// with the files inside them, so groups can cover files like prompts/retro/*.md
+func expandDirs(outputDir string, generated []string) ([]string, error) {
+	var files []string
+	for _, name := range generated {
+		if !strings.HasSuffix(name, "/") {
+			files = append(files, name)
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(outputDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, name+entry.Name())
+			}
+		}
+	}
+	return files, nil
+}
+
+// budgetOrder lists the generated files covered by the budget in the order
+// they're served: groups in priority order, then the remaining default groups
+func budgetOrder(generated []string, priority []string) []string {
+	var order []string
+	seenGroup := make(map[string]bool)
+	seenFile := make(map[string]bool)
+
+	for _, group := range append(append([]string(nil), priority...), DefaultBudgetPriority...) {
+		if seenGroup[group] {
+			continue
+		}
+		seenGroup[group] = true
+
+		for _, pattern := range BudgetGroups[group] {
+			for _, name := range generated {
+				if matched, _ := path.Match(pattern, name); matched && !seenFile[name] {
+					seenFile[name] = true
+					order = append(order, name)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// trimToBudget keeps the header and leading "## " sections of a markdown
+// file that fit within budget tokens, returning the result and how many
+// sections were omitted
+func trimToBudget(content string, budget int) (string, int) {
+	if EstimateTokens(content) <= budget {
+		return content, 0
+	}
+
+	// Split before each level-two heading
+	parts := strings.SplitAfter(content, "\n")
+	var sections []string
+	var current strings.Builder
+	for _, line := range parts {
+		if strings.HasPrefix(line, "## ") && current.Len() > 0 {
+			sections = append(sections, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	sections = append(sections, current.String())
+
+	header, body := sections[0], sections[1:]
+	if len(body) == 0 {
+		return content, 0 // A single block can't be trimmed at section boundaries
+	}
+
+	kept := header
+	omitted := len(body)
+	for _, section := range body {
+		note := budgetNote(omitted - 1)
+		if omitted == 1 {
+			note = ""
+		}
+		if EstimateTokens(kept+section+note) > budget {
+			break
+		}
+		kept += section
+		omitted--
+	}
+
+	if omitted == 0 {
+		return kept, 0
+	}
+	return strings.TrimRight(kept, "\n") + "\n\n" + budgetNote(omitted), omitted
+}
+
+// budgetNote marks where trimmed sections were removed
+func budgetNote(omitted int) string {
+	return "*" + fmt.Sprintf(plural(omitted, "%d more section omitted to fit the token budget (output.token_budget).",
+		"%d more sections omitted to fit the token budget (output.token_budget)."), omitted) + "*\n"
+}
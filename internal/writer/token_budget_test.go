@@ -0,0 +1,135 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTrimToBudget(t *testing.T) {
+	header := "# Report\n\nGenerated: now\n\n"
+	first := "## First\n\n" + strings.Repeat("a", 200) + "\n\n"
+	second := "## Second\n\n" + strings.Repeat("b", 200) + "\n\n"
+	third := "## Third\n\n" + strings.Repeat("c", 200) + "\n"
+	content := header + first + second + third
+
+	if result, omitted := trimToBudget(content, EstimateTokens(content)); result != content || omitted != 0 {
+		t.Errorf("Expected content within budget to be unchanged, omitted %d", omitted)
+	}
+
+	result, omitted := trimToBudget(content, EstimateTokens(header+first)+40)
+	if omitted != 2 {
+		t.Errorf("Expected 2 sections omitted, got %d", omitted)
+	}
+	if !strings.Contains(result, "## First") || strings.Contains(result, "## Second") {
+		t.Errorf("Expected only the first section kept, got:\n%s", result)
+	}
+	if !strings.HasSuffix(result, "*2 more sections omitted to fit the token budget (output.token_budget).*\n") {
+		t.Errorf("Expected omission note, got:\n%s", result)
+	}
+
+	// The header is always kept
+	result, omitted = trimToBudget(content, 0)
+	if omitted != 3 || !strings.HasPrefix(result, header) {
+		t.Errorf("Expected header only, got (%d omitted):\n%s", omitted, result)
+	}
+}
+
+func TestTrimToBudget_German(t *testing.T) {
+	if err := SetLocale(LocaleGerman); err != nil {
+		t.Fatal(err)
+	}
+	defer SetLocale("")
+
+	content := "# Bericht\n\n## Eins\n\n" + strings.Repeat("a", 200) + "\n"
+	result, _ := trimToBudget(content, 0)
+	if !strings.HasSuffix(result, "*1 weiterer Abschnitt weggelassen, um das Token-Budget einzuhalten (output.token_budget).*\n") {
+		t.Errorf("Expected a German omission note, got:\n%s", result)
+	}
+}
+
+func TestBudgetOrder(t *testing.T) {
+	generated := []string{"tasks-by-status.md", "timeline-recent.md", "timeline-2025.md", "timeline-2024.md",
+		"reference-graph.md", "reference-graph.dot", "time-tracking.json", "dashboard.md", "README.md"}
+
+	expected := []string{"dashboard.md", "tasks-by-status.md", "timeline-recent.md", "timeline-2025.md", "timeline-2024.md", "reference-graph.md"}
+	if order := budgetOrder(generated, nil); !reflect.DeepEqual(order, expected) {
+		t.Errorf("Default order: got %v, want %v", order, expected)
+	}
+
+	expected = []string{"reference-graph.md", "timeline-recent.md", "timeline-2025.md", "timeline-2024.md", "dashboard.md", "tasks-by-status.md"}
+	if order := budgetOrder(generated, []string{"graph", "timeline"}); !reflect.DeepEqual(order, expected) {
+		t.Errorf("Configured order: got %v, want %v", order, expected)
+	}
+}
+
+func TestApplyTokenBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	dashboard := "# Dashboard\n\n## Stats\n\n" + strings.Repeat("x", 400) + "\n"
+	tasks := "# Tasks\n\n## NOW\n\n" + strings.Repeat("y", 400) + "\n\n## LATER\n\n" + strings.Repeat("z", 400) + "\n"
+	files := map[string]string{"dashboard.md": dashboard, "tasks-by-status.md": tasks}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Room for the dashboard and about half the tasks file
+	budget := EstimateTokens(dashboard) + 150
+	results, err := ApplyTokenBudget(tmpDir, []string{"tasks-by-status.md", "dashboard.md"}, budget, nil)
+	if err != nil {
+		t.Fatalf("ApplyTokenBudget failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].File != "dashboard.md" || results[0].Omitted != 0 || results[1].Omitted != 1 {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "## LATER") || !strings.Contains(string(content), "## NOW") {
+		t.Errorf("Expected the trailing section trimmed, got:\n%s", content)
+	}
+}
+
+func TestApplyTokenBudget_Directory(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, RetroDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	retro := "# Retro\n\n## Overview\n\n" + strings.Repeat("x", 400) + "\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, RetroDir, "Phoenix.md"), []byte(retro), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := ApplyTokenBudget(tmpDir, []string{RetroDir + "/"}, 10, nil)
+	if err != nil {
+		t.Fatalf("ApplyTokenBudget failed: %v", err)
+	}
+	if len(results) != 1 || results[0].File != RetroDir+"/Phoenix.md" || results[0].Omitted != 1 {
+		t.Errorf("Expected the retro prompt trimmed, got %+v", results)
+	}
+}
+
+func TestBudgetGroups_CoverArtifacts(t *testing.T) {
+	for _, a := range Artifacts {
+		name := a.Name
+		switch {
+		case name == IndexReadmeFileName || name == BacklinksDir+"/":
+			continue // Reference material, outside the budget
+		case strings.HasSuffix(name, "/"):
+			name += "example.md"
+		case !strings.HasSuffix(name, ".md"):
+			continue
+		}
+		name = strings.ReplaceAll(name, "*", "2025")
+
+		if len(budgetOrder([]string{name}, nil)) == 0 {
+			t.Errorf("%s isn't in any of BudgetGroups", a.Name)
+		}
+	}
+}
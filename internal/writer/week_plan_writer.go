@@ -0,0 +1,124 @@
+package writer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+)
+
+// WeekPlanFileName weighs next week's work against the usual weekly capacity
+const WeekPlanFileName = "plan-next-week.md"
+
+// WriteWeekPlan writes plan-next-week.md: the capacity math first, then the
+// tasks it adds up, grouped by why they're in the plan
+func WriteWeekPlan(plan *indexer.WeekPlan, outputDir string) error {
+	// Ensure output directory exists
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	f, err := os.Create(filepath.Join(outputDir, WeekPlanFileName))
+	if err != nil {
+		return fmt.Errorf("creating file: %w", err)
+	}
+	defer f.Close()
+
+	// Write header
+	fmt.Fprintf(f, "# %s\n\n", tr("Plan for Next Week"))
+	fmt.Fprintf(f, "%s: %s\n\n", tr("Generated"), plan.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(f, "*%s %s. %s*\n\n", tr("Week of"), formatDate(plan.WeekStart, "Monday, January 2, 2006"),
+		tr("Open tasks that are overdue, due next week, or at the top priority, weighed against the time you usually track in a week. Delegated tasks are left out."))
+	fmt.Fprintf(f, "---\n\n")
+
+	fmt.Fprintf(f, "## %s\n\n", tr("Capacity"))
+	if plan.Capacity > 0 {
+		fmt.Fprintf(f, "- **%s**: "+plural(plan.CapacityWeeks,
+			"%s per week (average tracked over %d week with tracked time, of the last %d)",
+			"%s per week (average tracked over %d weeks with tracked time, of the last %d)")+"\n",
+			tr("Capacity"), formatDuration(plan.Capacity), plan.CapacityWeeks, indexer.CapacityWeeks)
+	} else {
+		fmt.Fprintf(f, "- **%s**: "+tr("unknown (no time tracked in the last %d weeks)")+"\n", tr("Capacity"), indexer.CapacityWeeks)
+	}
+	estimated := len(plan.Tasks) - plan.Unestimated
+	if len(plan.Tasks) > 0 {
+		fmt.Fprintf(f, "- **%s**: "+plural(estimated, "%s across %d task", "%s across %d tasks")+"\n", tr("Estimated"), formatDuration(plan.Estimated), estimated)
+	}
+	if plan.Unestimated > 0 {
+		fmt.Fprintf(f, "- **%s**: "+plural(plan.Unestimated, "%d task", "%d tasks"), tr("Unestimated"), plan.Unestimated)
+		if estimated > 0 {
+			fmt.Fprintf(f, ", %s", tr("counted at the average estimate"))
+		}
+		fmt.Fprintf(f, "\n")
+	}
+	if projected := plan.Projected(); projected > 0 {
+		fmt.Fprintf(f, "- **%s**: %s", tr("Projected"), formatDuration(projected))
+		if plan.Capacity > 0 {
+			fmt.Fprintf(f, " ("+tr("%.0f%% of capacity")+")", plan.Load()*100)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+	fmt.Fprintf(f, "\n")
+
+	switch projected := plan.Projected(); {
+	case len(plan.Tasks) == 0:
+		fmt.Fprintf(f, "*%s*\n\n", tr("Nothing lined up for next week."))
+		return nil
+	case plan.Capacity == 0:
+		fmt.Fprintf(f, "*%s*\n\n", tr("Track time on tasks to see whether the plan fits."))
+	case estimated == 0:
+		fmt.Fprintf(f, "*%s*\n\n", tr("Add estimate:: to tasks to see whether the plan fits."))
+	case projected > plan.Capacity:
+		fmt.Fprintf(f, "**"+tr("Overcommitted by %s.")+"** "+tr("Defer or drop tasks before planning the week.")+"\n\n", formatDuration(projected-plan.Capacity))
+	default:
+		fmt.Fprintf(f, "**"+tr("Fits, with %s to spare.")+"**\n\n", formatDuration(plan.Capacity-projected))
+	}
+
+	sections := []struct {
+		reason string
+		label  string
+	}{
+		{indexer.PlanOverdue, "Overdue"},
+		{indexer.PlanDue, "Due Next Week"},
+		{indexer.PlanPriority, "Top Priority"},
+	}
+	for _, s := range sections {
+		var tasks []indexer.PlannedTask
+		for _, pt := range plan.Tasks {
+			if pt.Reason == s.reason {
+				tasks = append(tasks, pt)
+			}
+		}
+		if len(tasks) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(f, "## %s (%d)\n\n", tr(s.label), len(tasks))
+		for _, pt := range tasks {
+			desc := pt.Task.Description
+			if len(desc) > 100 {
+				desc = desc[:97] + "..."
+			}
+			var details []string
+			if due := pt.Task.DueDate(); !due.IsZero() {
+				details = append(details, tr("due")+" "+formatDate(due, "Mon Jan 2"))
+			}
+			switch pt.Basis {
+			case indexer.BasisEstimate:
+				details = append(details, formatDuration(pt.Effort)+" "+tr("left of the estimate"))
+			case indexer.BasisHistory:
+				details = append(details, "~"+formatDuration(pt.Effort)+", "+tr("based on similar tasks"))
+			default:
+				details = append(details, tr("no estimate"))
+			}
+			fmt.Fprintf(f, "- %s%s%s (%s) `%s:%d`\n", statusMarker(pt.Task.Status), desc, priorityMarker(pt.Task.Priority),
+				strings.Join(details, ", "), pt.Task.SourceFile, pt.Task.LineNumber)
+		}
+		fmt.Fprintf(f, "\n")
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package writer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyluth/logseq-claude-indexer/internal/indexer"
+	"github.com/dyluth/logseq-claude-indexer/pkg/models"
+)
+
+func TestWriteWeekPlan(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &indexer.WeekPlan{
+		GeneratedAt: time.Now(),
+		WeekStart:   time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC),
+		Tasks: []indexer.PlannedTask{
+			{
+				Task:   models.Task{Status: models.StatusTODO, Description: "File taxes", SourceFile: "pages/Admin.md", LineNumber: 1, Deadline: time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)},
+				Reason: indexer.PlanOverdue, Effort: 90 * time.Minute, Basis: indexer.BasisEstimate,
+			},
+			{
+				Task:   models.Task{Status: models.StatusLATER, Description: "Book dentist", SourceFile: "pages/Health.md", LineNumber: 2, Scheduled: time.Date(2025, 11, 12, 0, 0, 0, 0, time.UTC)},
+				Reason: indexer.PlanDue,
+			},
+			{
+				Task:   models.Task{Status: models.StatusTODO, Description: "Write the monthly report", SourceFile: "pages/Work.md", LineNumber: 6, Priority: models.PriorityHigh},
+				Reason: indexer.PlanPriority, Effort: 4 * time.Hour, Basis: indexer.BasisHistory,
+			},
+		},
+		Capacity:      4 * time.Hour,
+		CapacityWeeks: 3,
+		Estimated:     330 * time.Minute,
+		Unestimated:   1,
+	}
+
+	if err := WriteWeekPlan(plan, tmpDir); err != nil {
+		t.Fatalf("WriteWeekPlan failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, WeekPlanFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"Week of Monday, November 10, 2025",
+		"- **Capacity**: 4h per week (average tracked over 3 weeks with tracked time, of the last 8)",
+		"- **Estimated**: 5h 30m across 2 tasks",
+		"- **Unestimated**: 1 task, counted at the average estimate",
+		"- **Projected**: 8h 15m (206% of capacity)",
+		"**Overcommitted by 4h 15m.**",
+		"## Overdue (1)\n\n- **[TODO]** File taxes (due Sat Nov 1, 1h 30m left of the estimate) `pages/Admin.md:1`",
+		"## Due Next Week (1)\n\n- **[LATER]** Book dentist (due Wed Nov 12, no estimate) `pages/Health.md:2`",
+		"## Top Priority (1)\n\n- **[TODO]** Write the monthly report [#A] (~4h, based on similar tasks) `pages/Work.md:6`",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected plan-next-week.md to contain %q, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteWeekPlan_Empty(t *testing.T) {
+	tmpDir := t.TempDir()
+	plan := &indexer.WeekPlan{GeneratedAt: time.Now(), WeekStart: time.Date(2025, 11, 10, 0, 0, 0, 0, time.UTC)}
+	if err := WriteWeekPlan(plan, tmpDir); err != nil {
+		t.Fatalf("WriteWeekPlan failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, WeekPlanFileName))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	for _, want := range []string{"- **Capacity**: unknown (no time tracked in the last 8 weeks)", "*Nothing lined up for next week.*"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Expected %q, got:\n%s", want, content)
+		}
+	}
+}
@@ -139,6 +139,32 @@ func TestWriteReferenceGraph(t *testing.T) {
 	}
 }
 
+func TestWriteReferenceGraph_RawCounts(t *testing.T) {
+	refs := []models.PageReference{
+		{SourcePage: "Notes", TargetPage: "Topic", Context: "- Wrote up how [[Topic]] affects the launch"},
+		{SourcePage: "Index", TargetPage: "Topic", Context: "related:: [[Topic]] [[Other]]"},
+	}
+	graph := indexer.BuildReferenceGraph(refs, nil)
+	graph.ApplyNoiseFilter(refs, 10)
+
+	tmpDir := t.TempDir()
+	if err := WriteReferenceGraph(graph, tmpDir); err != nil {
+		t.Fatalf("WriteReferenceGraph failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "reference-graph.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+
+	contentStr := string(content)
+	if !strings.Contains(contentStr, "[[Topic]]** - 1 inbound references") {
+		t.Errorf("Expected the filtered count in the hub ranking, got:\n%s", contentStr)
+	}
+	if !strings.Contains(contentStr, "Unfiltered: 2 references, 2 mentions (with short or link-only lines)") {
+		t.Errorf("Expected the raw counts, got:\n%s", contentStr)
+	}
+}
+
 func TestFormatDuration(t *testing.T) {
 	tests := []struct {
 		duration time.Duration
@@ -265,6 +291,7 @@ func TestWritePriorityIndex(t *testing.T) {
 			Description: "Another high priority task",
 			SourceFile:  "pages/tasks.md",
 			LineNumber:  20,
+			Properties:  map[string]string{"id": "6543a1b2", "effort": "0:30", "category": "admin"},
 		},
 		{
 			Status:      models.StatusTODO,
@@ -313,6 +340,7 @@ func TestWritePriorityIndex(t *testing.T) {
 		"Another high priority task",
 		"journals/2025-11-06.md:10",
 		"pages/tasks.md:20",
+		"- **Properties**: category: admin, effort: 0:30\n",
 	}
 
 	for _, expected := range expectedStrings {
@@ -369,3 +397,167 @@ func TestWritePriorityIndex_NoHighPriority(t *testing.T) {
 		t.Error("Expected message about no high priority tasks")
 	}
 }
+
+func TestWritePriorityIndex_NextUpPriorities(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Priority: models.PriorityHigh, Description: "First urgent", SourceFile: "a.md", LineNumber: 1},
+		{Status: models.StatusNOW, Priority: models.PriorityHigh, Description: "Second urgent", SourceFile: "a.md", LineNumber: 2},
+		{Status: models.StatusTODO, Priority: models.PriorityMedium, Description: "Next up later", SourceFile: "b.md", LineNumber: 1},
+		{Status: models.StatusDOING, Priority: models.PriorityMedium, Description: "Next up now", SourceFile: "b.md", LineNumber: 2},
+		{Status: models.StatusTODO, Priority: models.PriorityMedium, Description: "Next up last", SourceFile: "b.md", LineNumber: 3},
+		{Status: models.StatusTODO, Priority: models.PriorityLow, Description: "Someday maybe", SourceFile: "c.md", LineNumber: 1},
+	}
+	index := indexer.BuildTaskIndex(tasks)
+
+	if err := SetPriorityIndex(map[models.Priority]int{models.PriorityHigh: 1, models.PriorityMedium: 2}); err != nil {
+		t.Fatal(err)
+	}
+	defer SetPriorityIndex(nil)
+
+	tmpDir := t.TempDir()
+	if err := WritePriorityIndex(index, tmpDir); err != nil {
+		t.Fatalf("WritePriorityIndex failed: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-priority.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	for _, want := range []string{
+		"### Second urgent",
+		"*1 more [#A] tasks in tasks-by-status.md.*",
+		"## Medium [#B] (3)",
+		"Next up now",
+		"Next up later",
+		"*1 more [#B] tasks in tasks-by-status.md.*",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q, got:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"First urgent", "Next up last", "Someday maybe"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("Expected %q to be left out, got:\n%s", unwanted, output)
+		}
+	}
+	if strings.Index(output, "Next up now") > strings.Index(output, "Next up later") {
+		t.Error("Expected [#B] tasks in status order")
+	}
+
+	if err := SetPriorityIndex(map[models.Priority]int{"Q": 1}); err == nil {
+		t.Error("Expected an error for an unknown priority")
+	}
+}
+
+func TestWriteTaskIndex_WaitingOn(t *testing.T) {
+	tasks := []models.Task{
+		{Status: models.StatusTODO, Description: "Send contract @Mike", DelegatedTo: "Mike", SourceFile: "journals/2025_01_01.md", LineNumber: 4},
+	}
+	index := indexer.BuildTaskIndex(tasks)
+
+	tmpDir := t.TempDir()
+	if err := WriteTaskIndex(index, tmpDir); err != nil {
+		t.Fatalf("WriteTaskIndex failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "## Waiting on Others") {
+		t.Error("Expected Waiting on Others section")
+	}
+	if !strings.Contains(output, "### Mike (1)") {
+		t.Error("Expected Mike group")
+	}
+	if !strings.Contains(output, "`journals/2025_01_01.md:4`") {
+		t.Error("Expected delegated task location")
+	}
+}
+
+func TestWriteTaskIndex_Orphans(t *testing.T) {
+	index := &indexer.TaskIndex{
+		ByStatus: map[models.TaskStatus][]models.Task{},
+		Orphans: []indexer.OrphanTask{
+			{Task: models.Task{Status: models.StatusTODO, Description: "Call the bank", SourceFile: "journals/2025_01_01.md", LineNumber: 3}, AgeDays: 40},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	if err := WriteTaskIndex(index, tmpDir); err != nil {
+		t.Fatalf("WriteTaskIndex failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "## Orphan Tasks (1)") {
+		t.Error("Expected Orphan Tasks section")
+	}
+	if !strings.Contains(output, "Call the bank (40d) `journals/2025_01_01.md:3`") {
+		t.Errorf("Expected orphan task with age and location, got:\n%s", output)
+	}
+}
+
+func TestWriteTaskIndex_ConfiguredPriorities(t *testing.T) {
+	models.SetPriorities([]models.Priority{"A", "B", "C", "D", "E"})
+	defer models.SetPriorities(nil)
+
+	index := indexer.BuildTaskIndex([]models.Task{
+		{Status: models.StatusTODO, Priority: "E", Description: "Nice to have", SourceFile: "test.md", LineNumber: 1},
+		{Status: models.StatusTODO, Priority: models.PriorityHigh, Description: "Urgent", SourceFile: "test.md", LineNumber: 2},
+	})
+
+	tmpDir := t.TempDir()
+	if err := WriteTaskIndex(index, tmpDir); err != nil {
+		t.Fatalf("WriteTaskIndex failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "- High [#A]: 1\n- [#E]: 1\n") {
+		t.Errorf("Expected configured priorities in breakdown order, got:\n%s", output)
+	}
+	if !strings.Contains(output, "- **Nice to have** [#E]") {
+		t.Errorf("Expected [#E] marker on task line, got:\n%s", output)
+	}
+}
+
+func TestWriteTaskIndex_ConfiguredStatuses(t *testing.T) {
+	models.SetStatuses([]models.TaskStatus{"WAITING", "NOW", "DOING", "TODO", "LATER", "DONE"})
+	defer models.SetStatuses(nil)
+
+	index := indexer.BuildTaskIndex([]models.Task{
+		{Status: models.StatusTODO, Description: "Write report", SourceFile: "test.md", LineNumber: 1},
+		{Status: "WAITING", Description: "Vendor quote", SourceFile: "test.md", LineNumber: 2},
+	})
+
+	tmpDir := t.TempDir()
+	if err := WriteTaskIndex(index, tmpDir); err != nil {
+		t.Fatalf("WriteTaskIndex failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "tasks-by-status.md"))
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	output := string(content)
+
+	if !strings.Contains(output, "- WAITING: 1\n- TODO: 1\n") {
+		t.Errorf("Expected the configured order in the status breakdown, got:\n%s", output)
+	}
+	waiting, todo := strings.Index(output, "## WAITING (1)"), strings.Index(output, "## TODO (1)")
+	if waiting < 0 || todo < 0 || waiting > todo {
+		t.Errorf("Expected a WAITING section before TODO, got:\n%s", output)
+	}
+}
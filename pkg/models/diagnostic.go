@@ -0,0 +1,19 @@
+package models
+
+// Severity indicates how serious a diagnostic is
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Diagnostic is a structured problem found while indexing, surfaced in
+// diagnostics.md instead of being silently skipped
+type Diagnostic struct {
+	Severity Severity // warning or error
+	Code     string   // Stable machine-readable identifier (e.g. "invalid-journal-date")
+	File     string   // Relative path of the affected file
+	Line     int      // Line number (1-indexed, 0 if not line-specific)
+	Message  string   // Human-readable explanation
+}
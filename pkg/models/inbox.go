@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// InboxItem is a quick-capture block waiting to be processed: a top-level
+// block of the inbox page, or a block tagged with the inbox tag
+type InboxItem struct {
+	SourceFile string    // File path containing the block
+	LineNumber int       // Line of the block's bullet (1-indexed)
+	Text       string    // The block's first line without its bullet or the inbox tag
+	Links      []string  // Pages the block links or tags, other than the inbox tag and dates
+	Date       time.Time // First date the block links, e.g. [[Nov 6th, 2025]], zero if none
+	Words      []string  // Content words, for suggesting destinations
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
 
 // FileType distinguishes between journal entries and regular pages
 type FileType int
@@ -28,3 +32,18 @@ type File struct {
 	Type         FileType  // Journal or Page
 	ModTime      time.Time // Last modified timestamp
 }
+
+// PageName returns the page name a file path stands for, without its
+// directory or extension, e.g. "Project" for pages/Project.md or
+// pages/Project.markdown. Both slash styles are accepted.
+func PageName(path string) string {
+	base := path[strings.LastIndexAny(path, `/\`)+1:]
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// DeletedFile is a copy Logseq kept of a page it deleted or overwrote, under
+// logseq/.recycle or logseq/bak. It is never part of the graph.
+type DeletedFile struct {
+	File        // The copy
+	Page string // Name of the page it was a copy of, e.g. "Project"
+}
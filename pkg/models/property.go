@@ -0,0 +1,11 @@
+package models
+
+// PropertyBlock is a block, or a page's leading property lines, carrying
+// key:: value properties
+type PropertyBlock struct {
+	SourceFile string            // File path containing the block
+	LineNumber int               // Line of the block's bullet, or of the first page property (1-indexed)
+	Page       bool              // Page properties rather than a block's
+	Text       string            // The block's first line without its bullet, "" for page properties
+	Properties map[string]string // Lowercase keys, trimmed values
+}
@@ -7,4 +7,5 @@ type PageReference struct {
 	TargetPage string // Referenced page name (content inside [[...]])
 	LineNumber int    // Line number where reference appears (1-indexed)
 	Context    string // Surrounding text for context (truncated to reasonable length)
+	Project    bool   // First reference on a task line, i.e. the task's project
 }
@@ -1,6 +1,12 @@
 package models
 
-import "time"
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"time"
+)
 
 // TaskStatus represents the state of a task in Logseq
 type TaskStatus string
@@ -13,6 +19,57 @@ const (
 	StatusDONE  TaskStatus = "DONE"
 )
 
+// DefaultStatuses is the order statuses are shown in when none is
+// configured: work in progress first, DONE last
+var DefaultStatuses = []TaskStatus{StatusNOW, StatusDOING, StatusTODO, StatusLATER, StatusDONE}
+
+// statuses is the configured status order (see SetStatuses)
+var statuses = DefaultStatuses
+
+// SetStatuses configures the order statuses are shown in, which may add custom
+// markers such as WAITING. An empty list restores the default.
+func SetStatuses(order []TaskStatus) {
+	if len(order) == 0 {
+		order = DefaultStatuses
+	}
+	statuses = order
+}
+
+// Statuses returns the configured statuses in display order
+func Statuses() []TaskStatus {
+	return statuses
+}
+
+// IsStatus reports whether s is one of the configured statuses
+func IsStatus(s TaskStatus) bool {
+	for _, status := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+// closedStatuses are the statuses that finish a task (see SetClosedStatuses)
+var closedStatuses = []TaskStatus{StatusDONE}
+
+// SetClosedStatuses configures the statuses that finish a task besides DONE,
+// such as CANCELED, so they don't count as open work. DONE is always closed.
+func SetClosedStatuses(closed []TaskStatus) {
+	closedStatuses = append([]TaskStatus{StatusDONE}, closed...)
+}
+
+// IsClosed reports whether a task with this status is finished: DONE, or a
+// status configured with SetClosedStatuses
+func IsClosed(s TaskStatus) bool {
+	for _, status := range closedStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
 // Priority represents the urgency level of a task in Logseq
 // Extracted from markers like [#A], [#B], [#C]
 type Priority string
@@ -24,6 +81,57 @@ const (
 	PriorityNone   Priority = ""
 )
 
+// DefaultPriorities is Logseq's built-in priority range, highest first
+var DefaultPriorities = []Priority{PriorityHigh, PriorityMedium, PriorityLow}
+
+// priorities is the configured priority range (see SetPriorities)
+var priorities = DefaultPriorities
+
+// SetPriorities configures the priority range, highest first, for graphs that
+// extend Logseq's A–C default (e.g. A–E). An empty list restores the default.
+func SetPriorities(levels []Priority) {
+	if len(levels) == 0 {
+		levels = DefaultPriorities
+	}
+	priorities = levels
+}
+
+// Priorities returns the configured priority levels, highest first
+func Priorities() []Priority {
+	return priorities
+}
+
+// AllPriorities returns the configured priority levels followed by PriorityNone,
+// the order used for grouping and breakdowns
+func AllPriorities() []Priority {
+	return append(append([]Priority(nil), priorities...), PriorityNone)
+}
+
+// IsPriority reports whether p is one of the configured priority levels
+func IsPriority(p Priority) bool {
+	for _, level := range priorities {
+		if level == p {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns a display label such as "High [#A]" or "[#D]"
+func (p Priority) Label() string {
+	switch p {
+	case PriorityHigh:
+		return "High [#A]"
+	case PriorityMedium:
+		return "Medium [#B]"
+	case PriorityLow:
+		return "Low [#C]"
+	case PriorityNone:
+		return "None"
+	}
+	return fmt.Sprintf("[#%s]", string(p))
+}
+
 // Task represents a task extracted from Logseq markdown
 // Example: - NOW [#A] [[Project Name]] - Task description
 type Task struct {
@@ -31,11 +139,29 @@ type Task struct {
 	Priority    Priority        // The task's priority level ([#A], [#B], [#C])
 	Description string          // Full task text (without status/priority markers)
 	PageRefs    []string        // [[Page Name]] references found in the task
+	Tags        []string        // #tag references found in the task (without the leading #)
+	DelegatedTo string          // Person the task waits on (@Name, @[[Name]], or "[[Name]] to:")
 	SourceFile  string          // Relative path to file containing this task
 	LineNumber  int             // Line number where task appears (1-indexed)
+	ParentLine  int             // Line number of the task this one is nested under, 0 for a top-level task
+	CompletedAt time.Time       // From a completed:: property, zero if not recorded
+	Scheduled   time.Time       // From a SCHEDULED: <date> line, zero if not set
+	Deadline    time.Time       // From a DEADLINE: <date> line, zero if not set
+	Estimate    time.Duration   // From an estimate:: or effort:: property, zero if not set
+	Snooze      time.Time       // From a snooze:: property: hidden from active lists until this date, zero if not set
+	Properties  map[string]string // Block properties (key:: value lines and :PROPERTIES: drawers), keys lowercased
 	Logbook     []LogbookEntry  // Time tracking entries (if :LOGBOOK: present)
 }
 
+// DueDate returns the task's deadline, falling back to its scheduled date.
+// It's zero if neither is set.
+func (t *Task) DueDate() time.Time {
+	if !t.Deadline.IsZero() {
+		return t.Deadline
+	}
+	return t.Scheduled
+}
+
 // TotalDuration calculates the sum of all logbook entry durations
 func (t *Task) TotalDuration() time.Duration {
 	var total time.Duration
@@ -44,3 +170,15 @@ func (t *Task) TotalDuration() time.Duration {
 	}
 	return total
 }
+
+// ID identifies a task across runs: its block's id:: property when it has
+// one, otherwise a hash of its file and description, so status, priority,
+// and logbook changes or lines moving within the file keep the same ID.
+// Two tasks with the same description in one file share an ID.
+func (t *Task) ID() string {
+	if id := t.Properties["id"]; id != "" {
+		return id
+	}
+	sum := sha1.Sum([]byte(filepath.ToSlash(t.SourceFile) + "\x00" + t.Description))
+	return "t-" + hex.EncodeToString(sum[:8])
+}